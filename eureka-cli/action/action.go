@@ -1,15 +1,19 @@
 package action
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/url"
 	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/spf13/viper"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -19,6 +23,7 @@ import (
 type Action struct {
 	Name                               string
 	GatewayURLTemplate                 string
+	ConfigFileUsed                     string
 	ReservedPorts                      []int
 	Param                              *Param
 	Caser                              cases.Caser
@@ -30,6 +35,9 @@ type Action struct {
 	ConfigFarURL                       string
 	ConfigRegistryURL                  string
 	ConfigPortStart                    int
+	ConfigGatewayPort                  string
+	ConfigGatewayAdminPort             string
+	ConfigVaultPort                    string
 	ConfigPortEnd                      int
 	ConfigManagementTopicSharing       bool
 	ConfigTopicSharingTenant           string
@@ -43,16 +51,30 @@ type Action struct {
 	ConfigApplicationDependencies      map[string]any
 	ConfigApplicationStripesBranch     string
 	ConfigApplicationGatewayHostname   string
+	ConfigApplicationPlatform          string
+	ConfigDefaultDescription           string
+	ConfigApplicationAllowedPlatforms  []string
+	ConfigApplicationIncludeModules    []string
+	ConfigApplicationExcludeModules    []string
+	ConfigApplicationEntitlements      []string
 	ConfigNamespacePlatformCompleteUI  string
 	ConfigGlobalEnv                    map[string]string
 	ConfigEnvFolio                     string
+	ConfigKafkaConsumerGroupSuffix     string
+	ConfigKeycloakURL                  string
 	ConfigSidecarModule                map[string]any
 	ConfigSidecarModuleResources       map[string]any
 	ConfigSidecarModuleNativeBinaryCmd []string
+	ConfigSystemContainers             map[string]any
 	ConfigBackendModules               map[string]any
 	ConfigFrontendModules              map[string]any
 	ConfigCustomFrontendModules        map[string]any
 	ConfigTenants                      map[string]any
+	ConfigTenantHeaderName             string
+	ConfigTokenHeaderName              string
+	ConfigModuleNameStripPrefixes      []string
+	ConfigModuleNameSeparators         []string
+	ConfigPhaseWaits                   map[string]string
 	ConfigRoles                        map[string]any
 	ConfigUsers                        map[string]any
 	ConfigRolesCapabilitySets          map[string]any
@@ -66,6 +88,7 @@ func New(name string, gatewayURL string, actionParam *Param) *Action {
 	return &Action{
 		Name:                               name,
 		GatewayURLTemplate:                 gatewayURL,
+		ConfigFileUsed:                     viper.ConfigFileUsed(),
 		ReservedPorts:                      []int{},
 		Param:                              actionParam,
 		Caser:                              cases.Lower(language.English),
@@ -73,6 +96,9 @@ func New(name string, gatewayURL string, actionParam *Param) *Action {
 		ConfigLspURL:                       viper.GetString(field.LspURL),
 		ConfigFarURL:                       viper.GetString(field.FarURL),
 		ConfigRegistryURL:                  viper.GetString(field.RegistryURL),
+		ConfigGatewayPort:                  viper.GetString(field.PortsGateway),
+		ConfigGatewayAdminPort:             viper.GetString(field.PortsGatewayAdmin),
+		ConfigVaultPort:                    viper.GetString(field.PortsVault),
 		ConfigManagementTopicSharing:       viper.GetBool(field.BackendModulesManagementTopicSharing),
 		ConfigTopicSharingTenant:           viper.GetString(field.EnvTopicSharingTenant),
 		ConfigApplication:                  viper.GetStringMap(field.Application),
@@ -85,16 +111,30 @@ func New(name string, gatewayURL string, actionParam *Param) *Action {
 		ConfigApplicationDependencies:      viper.GetStringMap(field.ApplicationDependencies),
 		ConfigApplicationStripesBranch:     viper.GetString(field.ApplicationStripesBranch),
 		ConfigApplicationGatewayHostname:   viper.GetString(field.ApplicationGatewayHostname),
+		ConfigApplicationPlatform:          viper.GetString(field.ApplicationPlatform),
+		ConfigApplicationAllowedPlatforms:  viper.GetStringSlice(field.ApplicationAllowedPlatforms),
+		ConfigApplicationIncludeModules:    viper.GetStringSlice(field.ApplicationIncludeModules),
+		ConfigApplicationExcludeModules:    viper.GetStringSlice(field.ApplicationExcludeModules),
+		ConfigApplicationEntitlements:      viper.GetStringSlice(field.ApplicationEntitlements),
+		ConfigDefaultDescription:           viper.GetString(field.DefaultDescription),
 		ConfigNamespacePlatformCompleteUI:  viper.GetString(field.NamespacesPlatformCompleteUI),
 		ConfigGlobalEnv:                    viper.GetStringMapString(field.Env),
 		ConfigEnvFolio:                     viper.GetString(field.EnvFolio),
+		ConfigKafkaConsumerGroupSuffix:     viper.GetString(field.KafkaConsumerGroupSuffix),
+		ConfigKeycloakURL:                  viper.GetString(field.KeycloakURL),
 		ConfigSidecarModule:                viper.GetStringMap(field.SidecarModule),
 		ConfigSidecarModuleResources:       viper.GetStringMap(field.SidecarModuleResources),
 		ConfigSidecarModuleNativeBinaryCmd: GetSidecarModuleCmd(),
+		ConfigSystemContainers:             viper.GetStringMap(field.SystemContainers),
 		ConfigBackendModules:               viper.GetStringMap(field.BackendModules),
 		ConfigFrontendModules:              viper.GetStringMap(field.FrontendModules),
 		ConfigCustomFrontendModules:        viper.GetStringMap(field.CustomFrontendModules),
 		ConfigTenants:                      viper.GetStringMap(field.Tenants),
+		ConfigTenantHeaderName:             viper.GetString(field.HeaderNamesTenant),
+		ConfigTokenHeaderName:              viper.GetString(field.HeaderNamesToken),
+		ConfigModuleNameStripPrefixes:      viper.GetStringSlice(field.ModuleNameStripPrefixes),
+		ConfigModuleNameSeparators:         viper.GetStringSlice(field.ModuleNameSeparators),
+		ConfigPhaseWaits:                   viper.GetStringMapString(field.PhaseWaits),
 		ConfigRoles:                        viper.GetStringMap(field.Roles),
 		ConfigUsers:                        viper.GetStringMap(field.Users),
 		ConfigRolesCapabilitySets:          viper.GetStringMap(field.RolesCapabilitySetsEntry),
@@ -109,12 +149,164 @@ func (a *Action) GetRequestURL(port string, route string) string {
 	return fmt.Sprintf(a.GatewayURLTemplate, port) + route
 }
 
+// SetBaseURL points the gateway, gateway admin and Keycloak requests at a single base URL, e.g. of a mock server
+func (a *Action) SetBaseURL(baseURL string) error {
+	parsedURL, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Port() == "" {
+		return apperrors.InvalidBaseURL(baseURL)
+	}
+	a.GatewayURLTemplate = fmt.Sprintf("%s://%s", parsedURL.Scheme, parsedURL.Hostname()) + ":%s"
+	a.ConfigGatewayPort = parsedURL.Port()
+	a.ConfigGatewayAdminPort = parsedURL.Port()
+	a.ConfigKeycloakURL = parsedURL.String()
+
+	return nil
+}
+
+// GetKeycloakURL returns the Keycloak base URL used for token and admin calls, falling back to the in-network URL
+func (a *Action) GetKeycloakURL() string {
+	if a.ConfigKeycloakURL != "" {
+		return strings.TrimRight(a.ConfigKeycloakURL, "/")
+	}
+
+	return constant.KeycloakHTTP
+}
+
+// ==================== Ports ====================
+
+func (a *Action) GetGatewayPort() string {
+	return getPortOrDefault(a.ConfigGatewayPort, constant.KongPort)
+}
+
+func (a *Action) GetGatewayAdminPort() string {
+	return getPortOrDefault(a.ConfigGatewayAdminPort, constant.KongAdminPort)
+}
+
+func (a *Action) GetVaultPort() string {
+	return getPortOrDefault(a.ConfigVaultPort, constant.VaultServerPort)
+}
+
+func getPortOrDefault(port string, defaultPort string) string {
+	if port == "" {
+		return defaultPort
+	}
+
+	return port
+}
+
 // ==================== Application ====================
 
 func (a *Action) IsChildApp() bool {
 	return len(a.ConfigApplicationDependencies) > 0
 }
 
+// GetEntitlementApplicationIDs returns the configured application followed by the other applications
+// the tenants are entitled to together with it, blank and duplicate ids are dropped
+func (a *Action) GetEntitlementApplicationIDs() []string {
+	applicationIDs := []string{a.ConfigApplicationID}
+	for _, applicationID := range a.ConfigApplicationEntitlements {
+		if applicationID == "" || slices.Contains(applicationIDs, applicationID) {
+			continue
+		}
+		applicationIDs = append(applicationIDs, applicationID)
+	}
+
+	return applicationIDs
+}
+
+// IsModuleRegistrable reports whether a module passes the include and exclude lists of the application,
+// the lists accept module names or globs and the flags take precedence over the config
+func (a *Action) IsModuleRegistrable(moduleName string) (bool, string) {
+	includeModules, excludeModules := a.ConfigApplicationIncludeModules, a.ConfigApplicationExcludeModules
+	if a.Param != nil && len(a.Param.IncludeModules) > 0 {
+		includeModules = a.Param.IncludeModules
+	}
+	if a.Param != nil && len(a.Param.ExcludeModules) > 0 {
+		excludeModules = a.Param.ExcludeModules
+	}
+
+	if pattern, ok := helpers.MatchAnyGlob(moduleName, excludeModules); ok {
+		return false, fmt.Sprintf("matches exclude pattern %s", pattern)
+	}
+	if len(includeModules) > 0 {
+		if _, ok := helpers.MatchAnyGlob(moduleName, includeModules); !ok {
+			return false, "matches no include pattern"
+		}
+	}
+
+	return true, ""
+}
+
+// ==================== Tenant Headers ====================
+
+// BuildTenantHeaders returns the headers of a tenant-scoped call merged with the extra headers
+// configured for the tenant, the extra headers never override the content type, tenant and token
+func (a *Action) BuildTenantHeaders(tenantName string, accessToken string) (map[string]string, error) {
+	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantEntry := helpers.GetMap(a.ConfigTenants, tenantName)
+	for name, value := range helpers.GetMap(tenantEntry, field.TenantsHeadersEntry) {
+		if hasHeader(headers, name) {
+			continue
+		}
+		headers[name] = fmt.Sprint(value)
+	}
+
+	return headers, nil
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for existing := range headers {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsIgnorableConflict reports whether a create call failed only because the resource already exists
+// and --ignoreExisting allows to treat it as a non-fatal outcome
+func (a *Action) IsIgnorableConflict(err error) bool {
+	return a.Param != nil && a.Param.IgnoreExisting && errors.Is(err, apperrors.ErrHTTP409Conflict)
+}
+
+// ==================== Description ====================
+
+// GetDescription returns the description of a config entry, entries without one fall back
+// to the default-description config key and then to the built-in default, a tag passed with --tag is appended
+func (a *Action) GetDescription(entry map[string]any) string {
+	description := a.getConfigDescription(entry)
+	if tag := a.GetTag(); tag != "" {
+		return fmt.Sprintf("%s [%s]", description, tag)
+	}
+
+	return description
+}
+
+// GetTag returns the tag passed with --tag, it is empty when no tag is set
+func (a *Action) GetTag() string {
+	if a.Param == nil {
+		return ""
+	}
+
+	return a.Param.Tag
+}
+
+func (a *Action) getConfigDescription(entry map[string]any) string {
+	if description := helpers.GetString(entry, field.DescriptionEntry); description != "" {
+		return description
+	}
+	if a.ConfigDefaultDescription != "" {
+		return a.ConfigDefaultDescription
+	}
+
+	return constant.DefaultDescription
+}
+
 // ==================== Environment ====================
 
 func GetSidecarModuleCmd() []string {
@@ -168,7 +360,7 @@ func (a *Action) GetPreReservedPort() (int, error) {
 		}
 	}
 	if freePort == 0 {
-		return 0, errors.NoFreeTCPPort(a.ConfigApplicationPortStart, a.ConfigApplicationPortEnd)
+		return 0, apperrors.NoFreeTCPPort(a.ConfigApplicationPortStart, a.ConfigApplicationPortEnd)
 	}
 	a.ReservedPorts = append(a.ReservedPorts, freePort)
 
@@ -1,10 +1,19 @@
 package action
 
 const (
+	AddModule                   = "Add Module"
+	AppDependencies             = "App Dependencies"
 	AttachCapabilitySets        = "Attach Capability Sets"
+	Bench                       = "Bench"
 	BuildAndPushUi              = "Build and push UI"
 	BuildSystem                 = "Build System"
+	CacheDescriptors            = "Cache Descriptors"
+	CheckCompatibility          = "Check Compatibility"
+	CheckMgrServices            = "Check Mgr Services"
+	CheckModuleVersions         = "Check Module Versions"
 	CheckPorts                  = "Check Ports"
+	CheckSidecars               = "Check Sidecars"
+	CompareApplications         = "Compare Applications"
 	CreateConsortiums           = "Create Consortiums"
 	CreatePortProxy             = "Create Port Proxy"
 	CreateRoles                 = "Create Roles"
@@ -16,23 +25,43 @@ const (
 	DeployManagement            = "Deploy Management"
 	DeployModule                = "Deploy Module"
 	DeployModules               = "Deploy Modules"
+	DeployPlan                  = "Deploy Plan"
 	DeploySystem                = "Deploy System"
 	DeployUi                    = "Deploy UI"
+	DescribeRole                = "Describe Role"
+	DetachAllUserRoles          = "Detach All User Roles"
 	DetachCapabilitySets        = "Detach Capability Sets"
+	EntitleAll                  = "Entitle All"
+	ExportRoleMappings          = "Export Role Mappings"
 	GetEdgeApiKey               = "Get Edge Api Key"          //nolint:gosec // G101: Not a hardcoded credential, just an action name
 	GetKeycloakAccessToken      = "Get Keycloak Access Token" //nolint:gosec // G101: Not a hardcoded credential, just an action name
 	GetVaultRootToken           = "Get Vault Root Token"      //nolint:gosec // G101: Not a hardcoded credential, just an action name
+	Init                        = "Init"
 	InterceptModule             = "Intercept Module"
+	KafkaStatus                 = "Kafka Status"
+	KeycloakReport              = "Keycloak Report"
+	ListCapabilitySets          = "List Capability Sets"
+	ListModuleDiscovery         = "List Module Discovery"
 	ListModules                 = "List Modules"
 	ListModuleVersions          = "List Module Versions"
 	ListSystem                  = "List System"
+	ListTenants                 = "List Tenants"
+	LoadDiscovery               = "Load Discovery"
+	MissingDiscovery            = "Missing Discovery"
+	Nuke                        = "Nuke"
 	PurgeTenants                = "Purge Tenants"
+	RecreateRole                = "Recreate Role"
 	ReindexIndices              = "Reindex Indices"
 	RemoveRoles                 = "Remove Roles"
 	RemoveTenantEntitlements    = "Remove Tenant Entitlements"
 	RemoveTenants               = "Remove Tenants"
 	RemoveUsers                 = "Remove Users"
+	ResetTenant                 = "Reset Tenant"
+	RestoreModuleDiscovery      = "Restore Module Discovery"
+	RewriteDiscovery            = "Rewrite Discovery"
 	Root                        = "Root"
+	SaveDiscovery               = "Save Discovery"
+	StartupReport               = "Startup Report"
 	UndeployAdditionalSystem    = "Undeploy Additional System"
 	UndeployApplication         = "Undeploy Application"
 	UndeployManagement          = "Undeploy Management"
@@ -40,7 +69,11 @@ const (
 	UndeployModules             = "Undeploy Modules"
 	UndeploySystem              = "Undeploy System"
 	UndeployUi                  = "Undeploy UI"
+	UnusedCapabilitySets        = "Unused Capability Sets"
 	UpdateKeycloakPublicClients = "Update Keycloak Public Clients"
 	UpdateModuleDiscovery       = "Update Module Discovery"
 	UpgradeModule               = "Upgrade Module"
+	ValidateKongRoutes          = "Validate Kong Routes"
+	VerifyLogins                = "Verify Logins"
+	WaitForEntitlements         = "Wait For Entitlements"
 )
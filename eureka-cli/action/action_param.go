@@ -1,50 +1,106 @@
 package action
 
+import "time"
+
 // Param is a central container of all parameters
 // passed to the program by the user from the shell instance
 type Param struct {
-	All                   bool
-	ApplicationNames      []string
-	BuildImages           bool
-	Cleanup               bool
-	ConfigFile            string
-	DefaultGateway        bool
-	EnableDebug           bool
-	EnableECSRequests     bool
-	GatewayHostname       string
-	GatewayURL            string
-	ID                    string
-	Length                int
-	ModuleName            string
-	ModulePath            string
-	ModuleType            string
-	ModuleURL             string
-	ModuleVersion         string
-	Namespace             string
-	OnlyRequired          bool
-	OverwriteFiles        bool
-	PlatformCompleteURL   string
-	PrivatePort           int
-	Profile               string
-	PurgeSchemas          bool
-	RemoveApplication     bool
-	Restore               bool
-	SidecarURL            string
-	SingleTenant          bool
-	SkipApplication       bool
-	SkipModuleArtifact    bool
-	SkipModuleImage       bool
-	SkipCapabilitySets    bool
-	SkipModuleDeployment  bool
-	SkipModuleDiscovery   bool
-	SkipRegistry          bool
-	SkipTenantEntitlement bool
-	Tenant                string
-	TenantIDs             []string
-	TokenType             string
-	UpdateCloned          bool
-	User                  string
-	Versions              int
+	AccessTokenEnv         string
+	All                    bool
+	ApplicationA           string
+	ApplicationB           string
+	ApplicationID          string
+	ApplicationIDs         []string
+	ApplicationNames       []string
+	AttachRetries          int
+	AttachRetryWait        time.Duration
+	BaseURL                string
+	BuildImages            bool
+	CapabilityConcurrency  int
+	CapabilitySetNames     []string
+	Concurrency            int
+	Cleanup                bool
+	ClearCache             bool
+	ConfigFile             string
+	Confirm                bool
+	DefaultGateway         bool
+	DryRun                 bool
+	Duration               time.Duration
+	EnableDebug            bool
+	EnableECSRequests      bool
+	Endpoint               string
+	ExcludeModules         []string
+	Force                  bool
+	Format                 string
+	From                   string
+	GatewayHostname        string
+	GatewayURL             string
+	Group                  string
+	HARFile                string
+	HTTPRetries            int
+	HealthcheckConcurrency int
+	HealthcheckInterval    time.Duration
+	HealthcheckMinInterval time.Duration
+	HealthcheckMaxAttempts int
+	ID                     string
+	IgnoreExisting         bool
+	IncludeModules         []string
+	Invalidate             bool
+	JSON                   bool
+	KongAdminURL           string
+	Length                 int
+	Limit                  int
+	MaxConcurrentRequests  int
+	MaxTotalRetries        int
+	ModuleName             string
+	ModulePath             string
+	ModuleType             string
+	ModuleURL              string
+	ModuleVersion          string
+	Namespace              string
+	Native                 bool
+	NoCache                bool
+	Offset                 int
+	OnlyOutdated           bool
+	OnlyRequired           bool
+	Output                 string
+	OverwriteFiles         bool
+	PhaseWait              time.Duration
+	PlatformCompleteURL    string
+	PrivatePort            int
+	Profile                string
+	PurgeKafkaTopics       bool
+	PurgeSchemas           bool
+	RemoveApplication      bool
+	RequestTimeout         time.Duration
+	Restore                bool
+	Resume                 bool
+	RoleName               string
+	ServeReadiness         string
+	SidecarURL             string
+	SingleTenant           bool
+	SkipApplication        bool
+	SkipModuleArtifact     bool
+	SkipModuleImage        bool
+	SkipCapabilitySets     bool
+	SkipModuleDeployment   bool
+	SkipModuleDiscovery    bool
+	SkipRegistry           bool
+	SkipTenantEntitlement  bool
+	StatusCodes            bool
+	Strict                 bool
+	Tag                    string
+	Tenant                 string
+	TenantIDs              []string
+	Timeout                time.Duration
+	To                     string
+	TokenType              string
+	UpdateCloned           bool
+	UpdateExisting         bool
+	User                   string
+	Verbose                bool
+	Versions               int
+	WithUsers              bool
 }
 
 // Flag holds the metadata for a CLI flag
@@ -61,46 +117,100 @@ func (f Flag) GetName() string {
 
 // Flag definitions
 var (
-	All                   = Flag{"all", "a", "All modules for all profiles"}
-	ApplicationNames      = Flag{"apps", "", "Application names"}
-	BuildImages           = Flag{"buildImages", "b", "Build Docker images"}
-	Cleanup               = Flag{"cleanup", "", "Perform a cleanup operation"}
-	ConfigFile            = Flag{"configFile", "c", "Use a specific config file"}
-	DefaultGateway        = Flag{"defaultGateway", "g", "Use default gateway in URLs, .e.g. http://host.docker.internal:{{port}} will be set automatically"}
-	EnableDebug           = Flag{"enableDebug", "d", "Enable debug"}
-	EnableECSRequests     = Flag{"enableEcsRequests", "", "Enable ECS requests"}
-	GatewayHostname       = Flag{"gatewayHostname", "", "Gateway hostname"}
-	GatewayURL            = Flag{"gatewayURL", "", "Gateway URL"}
-	ID                    = Flag{"id", "i", "Module id, e.g. mod-orders:13.1.0-SNAPSHOT.1021"}
-	Length                = Flag{"length", "l", "Salt length"}
-	ModuleName            = Flag{"moduleName", "n", "Module name, e.g. mod-orders"}
-	ModulePath            = Flag{"modulePath", "", "Module path, e.g. the path of your module in IntelliJ"}
-	ModuleType            = Flag{"moduleType", "y", "Module type, e.g. management"}
-	ModuleURL             = Flag{"moduleUrl", "m", "Module URL, e.g. http://host.docker.internal:36002 or 36002 (if -g is used)"}
-	ModuleVersion         = Flag{"moduleVersion", "", "Module version, e.g. 13.1.0-SNAPSHOT.1093"}
-	Namespace             = Flag{"namespace", "", "DockerHub namespace"}
-	OnlyRequired          = Flag{"onlyRequired", "q", "Use only required system containers"}
-	OverwriteFiles        = Flag{"overwriteFiles", "o", "Overwrite files in %s home directory"}
-	PlatformCompleteURL   = Flag{"platformCompleteURL", "", "Platform Complete UI url"}
-	PrivatePort           = Flag{"privatePort", "", "Private port e.g. 8081"}
-	Profile               = Flag{"profile", "p", "Use a specific profile, options: %s"}
-	PurgeSchemas          = Flag{"purgeSchemas", "", "Purge schemas in PostgreSQL on uninstallation"}
-	RemoveApplication     = Flag{"removeApplication", "", "Remove application from the DB"}
-	Restore               = Flag{"restore", "r", "Restore module & sidecar"}
-	SidecarURL            = Flag{"sidecarUrl", "s", "Sidecar URL e.g. http://host.docker.internal:37002 or 37002 (if -g is used)"}
-	SingleTenant          = Flag{"singleTenant", "", "Use for Single Tenant workflow"}
-	SkipApplication       = Flag{"skipApplication", "", "Skip application operations"}
-	SkipModuleArtifact    = Flag{"skipModuleArtifact", "", "Skip building module artifact, i.e. the jar and its module descriptor"}
-	SkipModuleImage       = Flag{"skipModuleImage", "", "Skip building module image, i.e. the Docker image from a prebuilt jar artifact"}
-	SkipCapabilitySets    = Flag{"skipCapabilitySets", "", "Skip refreshing capability sets"}
-	SkipModuleDeployment  = Flag{"skipModuleDeployment", "", "Skip module & sidecar deployment"}
-	SkipModuleDiscovery   = Flag{"skipModuleDiscovery", "", "Skip module discovery update"}
-	SkipRegistry          = Flag{"skipRegistry", "", "Skip retrieving module registry versions"}
-	SkipTenantEntitlement = Flag{"skipTenantEntitlement", "", "Skip tenant entitlement operations"}
-	Tenant                = Flag{"tenant", "t", "Tenant"}
-	TenantIDs             = Flag{"ids", "", "Tenant ids"}
-	TokenType             = Flag{"tokenType", "", "Token type"}
-	UpdateCloned          = Flag{"updateCloned", "u", "Update Git cloned projects"}
-	User                  = Flag{"user", "x", "User"}
-	Versions              = Flag{"versions", "v", "Number of versions, e.g. 5"}
+	AccessTokenEnv         = Flag{"accessTokenEnv", "", "Environment variable holding a tenant access token, %s is replaced by the upper-cased tenant name"}
+	All                    = Flag{"all", "a", "All modules for all profiles"}
+	ApplicationA           = Flag{"a", "", "Application id to compare from, e.g. app-platform-minimal-1.0.0"}
+	ApplicationB           = Flag{"b", "", "Application id to compare to, e.g. app-platform-minimal-1.0.1"}
+	ApplicationID          = Flag{"application", "", "Application id, e.g. app-platform-minimal-1.0.0"}
+	ApplicationIDs         = Flag{"application", "", "Application ids to undeploy instead of the configured one, repeat the flag for several"}
+	ApplicationNames       = Flag{"apps", "", "Application names"}
+	AttachRetries          = Flag{"attachRetries", "", "Attempts to repeat attaching capability sets to a role that resolved none, 0 disables the retries"}
+	AttachRetryWait        = Flag{"attachRetryWait", "", "Wait between the attempts of --attachRetries"}
+	BaseURL                = Flag{"baseURL", "", "Send all gateway and Keycloak requests to a single base URL, e.g. http://localhost:9130 of a mock server"}
+	BuildImages            = Flag{"buildImages", "b", "Build Docker images"}
+	CapabilityConcurrency  = Flag{"capabilityConcurrency", "", "Maximum number of concurrent capability set queries across applications"}
+	CapabilitySetNames     = Flag{"capabilitySet", "", "Capability set name, repeat the flag to select several"}
+	Concurrency            = Flag{"concurrency", "", "Number of concurrent workers"}
+	Cleanup                = Flag{"cleanup", "", "Perform a cleanup operation"}
+	ClearCache             = Flag{"clearCache", "", "Remove all cached module descriptors before fetching them"}
+	ConfigFile             = Flag{"configFile", "c", "Use a specific config file"}
+	Confirm                = Flag{"confirm", "", "Confirm a destructive operation"}
+	DefaultGateway         = Flag{"defaultGateway", "g", "Use default gateway in URLs, .e.g. http://host.docker.internal:{{port}} will be set automatically"}
+	DryRun                 = Flag{"dryRun", "", "Print what would be registered or rewritten without applying it"}
+	Duration               = Flag{"duration", "", "Duration, e.g. 30s"}
+	EnableDebug            = Flag{"enableDebug", "d", "Enable debug"}
+	EnableECSRequests      = Flag{"enableEcsRequests", "", "Enable ECS requests"}
+	Endpoint               = Flag{"endpoint", "", "Gateway endpoint path, e.g. /users"}
+	ExcludeModules         = Flag{"excludeModules", "", "Module names or globs to skip at module deployment and application registration"}
+	Force                  = Flag{"force", "", "Force an update even when the current state already matches"}
+	Format                 = Flag{"format", "", "Output format, options: %s"}
+	From                   = Flag{"from", "", "Network suffix of the discovery locations to rewrite, e.g. eureka"}
+	GatewayHostname        = Flag{"gatewayHostname", "", "Gateway hostname"}
+	GatewayURL             = Flag{"gatewayURL", "", "Gateway URL"}
+	Group                  = Flag{"group", "", "Kafka consumer group, defaults to the capability consumer group"}
+	HARFile                = Flag{"harFile", "", "Record all HTTP traffic of the run into a HAR file, secrets are redacted"}
+	HTTPRetries            = Flag{"httpRetries", "", "Retries of idempotent HTTP requests failing with 5xx or connection errors, 0 disables the retries"}
+	HealthcheckConcurrency = Flag{"healthcheckConcurrency", "", "Maximum number of modules healthchecked at once during a deploy, 0 is unlimited"}
+	HealthcheckInterval    = Flag{"healthcheckInterval", "", "Maximum interval between module healthchecks of a deploy, 0 uses the default"}
+	HealthcheckMinInterval = Flag{"healthcheckMinInterval", "", "Interval after the first module healthcheck of a deploy, doubled after every attempt up to --healthcheckInterval, 0 uses the default"}
+	HealthcheckMaxAttempts = Flag{"healthcheckMaxAttempts", "", "Maximum number of module healthchecks of a deploy, 0 uses the default"}
+	ID                     = Flag{"id", "i", "Module id, e.g. mod-orders:13.1.0-SNAPSHOT.1021"}
+	IgnoreExisting         = Flag{"ignoreExisting", "", "Treat 409 Conflict of created tenants, roles and users as already existing"}
+	IncludeModules         = Flag{"includeModules", "", "Module names or globs to deploy and register exclusively in the application"}
+	Invalidate             = Flag{"invalidate", "", "Remove cached module descriptors, all of them or only the one of --id"}
+	JSON                   = Flag{"json", "", "Print output as JSON"}
+	KongAdminURL           = Flag{"kongAdminURL", "", "Kong admin API URL, e.g. http://localhost:8001, defaults to the gateway-admin port"}
+	Length                 = Flag{"length", "l", "Salt length"}
+	Limit                  = Flag{"limit", "", "Maximum number of records to return"}
+	MaxConcurrentRequests  = Flag{"maxConcurrentRequests", "", "Maximum number of HTTP requests in flight across a command run, 0 is unlimited"}
+	MaxTotalRetries        = Flag{"maxTotalRetries", "", "Maximum number of HTTP retries shared across a command run, 0 is unlimited"}
+	ModuleName             = Flag{"moduleName", "n", "Module name, e.g. mod-orders"}
+	ModulePath             = Flag{"modulePath", "", "Module path, e.g. the path of your module in IntelliJ"}
+	ModuleType             = Flag{"moduleType", "y", "Module type, e.g. management"}
+	ModuleURL              = Flag{"moduleUrl", "m", "Module URL, e.g. http://host.docker.internal:36002 or 36002 (if -g is used)"}
+	ModuleVersion          = Flag{"moduleVersion", "", "Module version, e.g. 13.1.0-SNAPSHOT.1093"}
+	Namespace              = Flag{"namespace", "", "DockerHub namespace"}
+	Native                 = Flag{"native", "", "Use the Kafka CLI installed on the host instead of the kafka-tools container"}
+	NoCache                = Flag{"noCache", "", "Fetch module descriptors from the registry without reading or writing the descriptor cache"}
+	Offset                 = Flag{"offset", "", "Number of records to skip"}
+	OnlyOutdated           = Flag{"onlyOutdated", "", "Only show outdated modules"}
+	OnlyRequired           = Flag{"onlyRequired", "q", "Use only required system containers"}
+	Output                 = Flag{"output", "", "Output file path"}
+	OverwriteFiles         = Flag{"overwriteFiles", "o", "Overwrite files in %s home directory"}
+	PhaseWait              = Flag{"phaseWait", "", "Wait between deploy phases, 0 keeps the default of every phase, phase-waits in the config override it per phase"}
+	PlatformCompleteURL    = Flag{"platformCompleteURL", "", "Platform Complete UI url"}
+	PrivatePort            = Flag{"privatePort", "", "Private port e.g. 8081"}
+	Profile                = Flag{"profile", "p", "Use a specific profile, options: %s"}
+	PurgeKafkaTopics       = Flag{"purgeKafkaTopics", "", "Purge the Kafka topics of removed tenants, disable with =false to keep them"}
+	PurgeSchemas           = Flag{"purgeSchemas", "", "Purge schemas in PostgreSQL on uninstallation"}
+	RemoveApplication      = Flag{"removeApplication", "", "Remove application from the DB"}
+	RequestTimeout         = Flag{"requestTimeout", "", "Maximum duration of every HTTP request attempt, 0 keeps the default of 10m"}
+	Restore                = Flag{"restore", "r", "Restore module & sidecar"}
+	Resume                 = Flag{"resume", "", "Resume a deploy by skipping the phases completed by a previous run of the same profile and application"}
+	RoleName               = Flag{"name", "", "Role name"}
+	ServeReadiness         = Flag{"serveReadiness", "", "Serve /ready on an address, e.g. :8090, after the deploy until interrupted"}
+	SidecarURL             = Flag{"sidecarUrl", "s", "Sidecar URL e.g. http://host.docker.internal:37002 or 37002 (if -g is used)"}
+	SingleTenant           = Flag{"singleTenant", "", "Use for Single Tenant workflow"}
+	SkipApplication        = Flag{"skipApplication", "", "Skip application operations"}
+	SkipModuleArtifact     = Flag{"skipModuleArtifact", "", "Skip building module artifact, i.e. the jar and its module descriptor"}
+	SkipModuleImage        = Flag{"skipModuleImage", "", "Skip building module image, i.e. the Docker image from a prebuilt jar artifact"}
+	SkipCapabilitySets     = Flag{"skipCapabilitySets", "", "Skip refreshing capability sets"}
+	SkipModuleDeployment   = Flag{"skipModuleDeployment", "", "Skip module & sidecar deployment"}
+	SkipModuleDiscovery    = Flag{"skipModuleDiscovery", "", "Skip creating or updating the module discovery"}
+	SkipRegistry           = Flag{"skipRegistry", "", "Skip retrieving module registry versions"}
+	SkipTenantEntitlement  = Flag{"skipTenantEntitlement", "", "Skip tenant entitlement operations"}
+	StatusCodes            = Flag{"statusCodes", "", "Print the number of responses per status code"}
+	Strict                 = Flag{"strict", "", "Remove capability sets attached to roles but missing from the config"}
+	Tag                    = Flag{"tag", "", "Tag added to created tenants, users, roles and applications, e.g. a run id"}
+	Tenant                 = Flag{"tenant", "t", "Tenant"}
+	TenantIDs              = Flag{"ids", "", "Tenant ids"}
+	Timeout                = Flag{"timeout", "", "Maximum time to wait, e.g. 10m"}
+	To                     = Flag{"to", "", "Network suffix that replaces --from in the discovery locations"}
+	TokenType              = Flag{"tokenType", "", "Token type"}
+	UpdateCloned           = Flag{"updateCloned", "u", "Update Git cloned projects"}
+	UpdateExisting         = Flag{"updateExisting", "", "Update the personal fields of users that already exist"}
+	User                   = Flag{"user", "x", "User"}
+	Verbose                = Flag{"verbose", "", "Print a one-line summary of every HTTP call to stderr"}
+	Versions               = Flag{"versions", "v", "Number of versions, e.g. 5"}
+	WithUsers              = Flag{"withUsers", "", "Also show the number of users of every tenant"}
 )
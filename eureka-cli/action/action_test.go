@@ -1,10 +1,13 @@
 package action_test
 
 import (
+	"net/http"
 	"runtime"
 	"testing"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/internal/testhelpers"
 	"github.com/spf13/viper"
@@ -404,6 +407,269 @@ func TestGetRequestURL(t *testing.T) {
 	})
 }
 
+func TestGetKeycloakURL(t *testing.T) {
+	t.Run("TestGetKeycloakURL_Default", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{}
+
+		// Act
+		result := act.GetKeycloakURL()
+
+		// Assert
+		assert.Equal(t, constant.KeycloakHTTP, result)
+	})
+
+	t.Run("TestGetKeycloakURL_Configured", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{ConfigKeycloakURL: "http://localhost:8080/"}
+
+		// Act
+		result := act.GetKeycloakURL()
+
+		// Assert
+		assert.Equal(t, "http://localhost:8080", result)
+	})
+}
+
+func TestSetBaseURL(t *testing.T) {
+	t.Run("TestSetBaseURL_Success", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{GatewayURLTemplate: "http://host.docker.internal:%s"}
+
+		// Act
+		err := act.SetBaseURL("http://127.0.0.1:9130/")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "http://127.0.0.1:9130/tenants", act.GetRequestURL(act.GetGatewayPort(), "/tenants"))
+		assert.Equal(t, "http://127.0.0.1:9130/status", act.GetRequestURL(act.GetGatewayAdminPort(), "/status"))
+		assert.Equal(t, "http://127.0.0.1:9130", act.GetKeycloakURL())
+	})
+
+	t.Run("TestSetBaseURL_MissingPort", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{}
+
+		// Act
+		err := act.SetBaseURL("http://localhost")
+
+		// Assert
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	})
+}
+
+// ==================== Port Tests ====================
+
+func TestGetPorts(t *testing.T) {
+	t.Run("TestGetPorts_Defaults", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{}
+
+		// Act & Assert
+		assert.Equal(t, "8000", act.GetGatewayPort())
+		assert.Equal(t, "8001", act.GetGatewayAdminPort())
+		assert.Equal(t, "8200", act.GetVaultPort())
+	})
+
+	t.Run("TestGetPorts_FromConfig", func(t *testing.T) {
+		// Arrange
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set(field.PortsGateway, 18000)
+		viper.Set(field.PortsGatewayAdmin, "18001")
+		viper.Set(field.PortsVault, 18200)
+		act := action.New("test", "http://localhost:%s", &action.Param{})
+
+		// Act
+		result := act.GetRequestURL(act.GetGatewayPort(), "/tenants")
+
+		// Assert
+		assert.Equal(t, "http://localhost:18000/tenants", result)
+		assert.Equal(t, "18001", act.GetGatewayAdminPort())
+		assert.Equal(t, "18200", act.GetVaultPort())
+	})
+}
+
+// ==================== Description Tests ====================
+
+func TestIsModuleRegistrable(t *testing.T) {
+	tests := []struct {
+		name           string
+		configInclude  []string
+		configExclude  []string
+		paramInclude   []string
+		paramExclude   []string
+		moduleName     string
+		expected       bool
+		expectedReason string
+	}{
+		{
+			name:       "TestIsModuleRegistrable_NoLists",
+			moduleName: "mod-users",
+			expected:   true,
+		},
+		{
+			name:           "TestIsModuleRegistrable_ExcludedByGlob",
+			configExclude:  []string{"mod-search*"},
+			moduleName:     "mod-search",
+			expected:       false,
+			expectedReason: "matches exclude pattern mod-search*",
+		},
+		{
+			name:           "TestIsModuleRegistrable_NotIncluded",
+			configInclude:  []string{"mod-users", "mod-login*"},
+			moduleName:     "mod-notes",
+			expected:       false,
+			expectedReason: "matches no include pattern",
+		},
+		{
+			name:          "TestIsModuleRegistrable_IncludedByGlob",
+			configInclude: []string{"mod-users", "mod-login*"},
+			moduleName:    "mod-login-keycloak",
+			expected:      true,
+		},
+		{
+			name:           "TestIsModuleRegistrable_ExcludeWinsOverInclude",
+			configInclude:  []string{"mod-*"},
+			configExclude:  []string{"mod-notes"},
+			moduleName:     "mod-notes",
+			expected:       false,
+			expectedReason: "matches exclude pattern mod-notes",
+		},
+		{
+			name:          "TestIsModuleRegistrable_FlagsOverrideConfig",
+			configInclude: []string{"mod-users"},
+			paramInclude:  []string{"mod-notes"},
+			moduleName:    "mod-notes",
+			expected:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			act := &action.Action{
+				ConfigApplicationIncludeModules: tt.configInclude,
+				ConfigApplicationExcludeModules: tt.configExclude,
+				Param:                           &action.Param{IncludeModules: tt.paramInclude, ExcludeModules: tt.paramExclude},
+			}
+
+			// Act
+			result, reason := act.IsModuleRegistrable(tt.moduleName)
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedReason, reason)
+		})
+	}
+}
+
+func TestBuildTenantHeaders(t *testing.T) {
+	t.Run("TestBuildTenantHeaders_MergesExtraHeaders", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{ConfigTenants: map[string]any{
+			"diku": map[string]any{
+				field.TenantsHeadersEntry: map[string]any{
+					"x-api-key":       "secret-key",
+					"x-okapi-tenant":  "other",
+					"x-request-trace": 1,
+				},
+			},
+		}}
+
+		// Act
+		headers, err := act.BuildTenantHeaders("diku", "token")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "secret-key", headers["x-api-key"])
+		assert.Equal(t, "1", headers["x-request-trace"])
+		assert.Equal(t, "diku", headers[constant.OkapiTenantHeader])
+		assert.NotContains(t, headers, "x-okapi-tenant")
+	})
+
+	t.Run("TestBuildTenantHeaders_NoExtraHeaders", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{ConfigTenants: map[string]any{"diku": nil}}
+
+		// Act
+		headers, err := act.BuildTenantHeaders("diku", "token")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, headers, 3)
+	})
+
+	t.Run("TestBuildTenantHeaders_BlankToken", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{}
+
+		// Act
+		headers, err := act.BuildTenantHeaders("diku", "")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, headers)
+	})
+}
+
+func TestIsIgnorableConflict(t *testing.T) {
+	conflict := apperrors.RequestFailed(http.StatusConflict, http.MethodPost, "/roles")
+
+	t.Run("TestIsIgnorableConflict_Enabled", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{Param: &action.Param{IgnoreExisting: true}}
+
+		// Act & Assert
+		assert.True(t, act.IsIgnorableConflict(conflict))
+		assert.False(t, act.IsIgnorableConflict(apperrors.ErrHTTP404NotFound))
+		assert.False(t, act.IsIgnorableConflict(nil))
+	})
+
+	t.Run("TestIsIgnorableConflict_Disabled", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{Param: &action.Param{}}
+
+		// Act & Assert
+		assert.False(t, act.IsIgnorableConflict(conflict))
+	})
+}
+
+func TestGetDescription(t *testing.T) {
+	t.Run("TestGetDescription_BuiltInDefault", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{}
+
+		// Act & Assert
+		assert.Equal(t, "Default", act.GetDescription(nil))
+	})
+
+	t.Run("TestGetDescription_ConfiguredDefault", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{ConfigDefaultDescription: "Local dev"}
+
+		// Act & Assert
+		assert.Equal(t, "Local dev", act.GetDescription(map[string]any{"tenant": "diku"}))
+	})
+
+	t.Run("TestGetDescription_PerItemTakesPrecedence", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{ConfigDefaultDescription: "Local dev"}
+
+		// Act & Assert
+		assert.Equal(t, "Admin role", act.GetDescription(map[string]any{field.DescriptionEntry: "Admin role"}))
+	})
+
+	t.Run("TestGetDescription_TagAppended", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{ConfigDefaultDescription: "Local dev", Param: &action.Param{Tag: "run-42"}}
+
+		// Act & Assert
+		assert.Equal(t, "Admin role [run-42]", act.GetDescription(map[string]any{field.DescriptionEntry: "Admin role"}))
+		assert.Equal(t, "Local dev [run-42]", act.GetDescription(nil))
+	})
+}
+
 // ==================== Environment Variable Tests ====================
 
 func TestGetConfigEnvVars(t *testing.T) {
@@ -815,6 +1081,33 @@ func TestIsChildApp(t *testing.T) {
 	}
 }
 
+func TestGetEntitlementApplicationIDs(t *testing.T) {
+	t.Run("TestGetEntitlementApplicationIDs_ConfiguredApplicationFirst", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{
+			ConfigApplicationID:           "app-combined-1.0.0",
+			ConfigApplicationEntitlements: []string{"app-edge-1.0.0", "", "app-combined-1.0.0", "app-edge-1.0.0", "app-erm-2.0.0"},
+		}
+
+		// Act
+		result := act.GetEntitlementApplicationIDs()
+
+		// Assert
+		assert.Equal(t, []string{"app-combined-1.0.0", "app-edge-1.0.0", "app-erm-2.0.0"}, result)
+	})
+
+	t.Run("TestGetEntitlementApplicationIDs_NoEntitlements", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{ConfigApplicationID: "app-combined-1.0.0"}
+
+		// Act
+		result := act.GetEntitlementApplicationIDs()
+
+		// Assert
+		assert.Equal(t, []string{"app-combined-1.0.0"}, result)
+	})
+}
+
 // ==================== GetTemplateEnvVars Tests ====================
 
 func TestGetTemplateEnvVars(t *testing.T) {
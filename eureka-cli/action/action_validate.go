@@ -0,0 +1,189 @@
+package action
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"go.yaml.in/yaml/v3"
+)
+
+// ValidateConfig checks config values that would otherwise be rejected by the gateway with an opaque error
+func (a *Action) ValidateConfig() error {
+	if err := a.ValidateApplicationPlatform(); err != nil {
+		return err
+	}
+
+	if err := a.ValidateUserPreferredContactTypes(); err != nil {
+		return err
+	}
+	if err := a.ValidateUserTenants(); err != nil {
+		return err
+	}
+	if err := a.ValidateRoleTenants(); err != nil {
+		return err
+	}
+	if err := helpers.ValidateResources(field.SidecarModuleResources, a.ConfigSidecarModuleResources); err != nil {
+		return err
+	}
+	if err := helpers.ValidateSystemContainerResources(a.ConfigSystemContainers); err != nil {
+		return err
+	}
+	if err := a.ValidatePhaseWaits(); err != nil {
+		return err
+	}
+
+	return a.ValidateUniqueNames()
+}
+
+// ValidateApplicationPlatform checks the optional application platform against the allowed set,
+// the set defaults to the known platforms and can be overridden with application.allowed-platforms
+func (a *Action) ValidateApplicationPlatform() error {
+	if a.ConfigApplicationPlatform == "" {
+		return nil
+	}
+
+	allowedPlatforms := a.ConfigApplicationAllowedPlatforms
+	if len(allowedPlatforms) == 0 {
+		allowedPlatforms = constant.GetApplicationPlatforms()
+	}
+	if !slices.Contains(allowedPlatforms, a.ConfigApplicationPlatform) {
+		return errors.InvalidApplicationPlatform(a.ConfigApplicationPlatform, allowedPlatforms)
+	}
+
+	return nil
+}
+
+// ValidateUserPreferredContactTypes checks the optional preferred-contact-type-id of every configured user
+// against the known contact type ids, users without one fall back to the email contact type
+func (a *Action) ValidateUserPreferredContactTypes() error {
+	allowedContactTypeIDs := constant.GetPreferredContactTypeIDs()
+	for _, username := range helpers.SortedMapKeys(a.ConfigUsers) {
+		entry, ok := a.ConfigUsers[username].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		contactTypeID := helpers.GetString(entry, field.UsersPreferredContactTypeIDEntry)
+		if contactTypeID != "" && !slices.Contains(allowedContactTypeIDs, contactTypeID) {
+			return errors.InvalidPreferredContactTypeID(username, contactTypeID, allowedContactTypeIDs)
+		}
+	}
+
+	return nil
+}
+
+// ValidatePhaseWaits checks that every phase-waits entry names a known phase and holds a non-negative duration
+func (a *Action) ValidatePhaseWaits() error {
+	phaseWaits := constant.GetPhaseWaits()
+	for _, phase := range slices.Sorted(maps.Keys(a.ConfigPhaseWaits)) {
+		if _, exists := phaseWaits[phase]; !exists {
+			return errors.UnknownPhaseWait(phase, slices.Sorted(maps.Keys(phaseWaits)))
+		}
+
+		wait, err := time.ParseDuration(a.ConfigPhaseWaits[phase])
+		if err != nil || wait < 0 {
+			return errors.InvalidPhaseWait(phase, a.ConfigPhaseWaits[phase])
+		}
+	}
+
+	return nil
+}
+
+// ValidateUserTenants checks that the tenant of every configured user exists in the tenants section
+func (a *Action) ValidateUserTenants() error {
+	return a.validateEntryTenants(field.Users, a.ConfigUsers, field.UsersTenantEntry)
+}
+
+// ValidateRoleTenants checks that the tenant of every configured role exists in the tenants section
+func (a *Action) ValidateRoleTenants() error {
+	return a.validateEntryTenants(field.Roles, a.ConfigRoles, field.RolesTenantEntry)
+}
+
+func (a *Action) validateEntryTenants(section string, entries map[string]any, tenantKey string) error {
+	var offenders []string
+	for _, name := range helpers.SortedMapKeys(entries) {
+		entry, ok := entries[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		tenantName := helpers.GetString(entry, tenantKey)
+		if _, exists := a.ConfigTenants[tenantName]; !exists {
+			offenders = append(offenders, fmt.Sprintf("%s (tenant %q)", name, tenantName))
+		}
+	}
+	if len(offenders) > 0 {
+		return errors.UnknownConfigTenants(section, offenders)
+	}
+
+	return nil
+}
+
+// ValidateUniqueNames fails on role names and per-tenant usernames that only differ in case, the config file
+// is read as written because viper folds map keys to lower case and would silently keep only one of them
+func (a *Action) ValidateUniqueNames() error {
+	if a.ConfigFileUsed == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(a.ConfigFileUsed)
+	if err != nil {
+		return err
+	}
+	var rawConfig map[string]any
+	if err := yaml.Unmarshal(data, &rawConfig); err != nil {
+		return err
+	}
+
+	var collisions []string
+	roles := helpers.GetMap(rawConfig, field.Roles)
+	for _, names := range findCaseInsensitiveDuplicates(helpers.SortedMapKeys(roles)) {
+		collisions = append(collisions, fmt.Sprintf("roles %s", strings.Join(names, ", ")))
+	}
+
+	users := helpers.GetMap(rawConfig, field.Users)
+	usernamesByTenant := map[string][]string{}
+	for _, username := range helpers.SortedMapKeys(users) {
+		tenantName := helpers.GetString(helpers.GetMap(users, username), field.UsersTenantEntry)
+		usernamesByTenant[tenantName] = append(usernamesByTenant[tenantName], username)
+	}
+	for _, tenantName := range slices.Sorted(maps.Keys(usernamesByTenant)) {
+		for _, names := range findCaseInsensitiveDuplicates(usernamesByTenant[tenantName]) {
+			collisions = append(collisions, fmt.Sprintf("users %s in tenant %s", strings.Join(names, ", "), tenantName))
+		}
+	}
+	if len(collisions) > 0 {
+		return errors.DuplicateConfigNames(collisions)
+	}
+
+	return nil
+}
+
+func findCaseInsensitiveDuplicates(names []string) [][]string {
+	groups := map[string][]string{}
+	var keys []string
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], name)
+	}
+
+	var duplicates [][]string
+	for _, key := range keys {
+		if len(groups[key]) > 1 {
+			duplicates = append(duplicates, groups[key])
+		}
+	}
+
+	return duplicates
+}
@@ -0,0 +1,296 @@
+package action_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== ValidateApplicationPlatform Tests ====================
+
+func TestValidateApplicationPlatform_Empty(t *testing.T) {
+	// Arrange
+	act := &action.Action{}
+
+	// Act
+	err := act.ValidateApplicationPlatform()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestValidateApplicationPlatform_DefaultAllowedSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+	}{
+		{
+			name:     "TestValidateApplicationPlatform_DefaultAllowedSet_Base",
+			platform: "base",
+		},
+		{
+			name:     "TestValidateApplicationPlatform_DefaultAllowedSet_Complete",
+			platform: "complete",
+		},
+		{
+			name:     "TestValidateApplicationPlatform_DefaultAllowedSet_Custom",
+			platform: "custom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			act := &action.Action{ConfigApplicationPlatform: tt.platform}
+
+			// Act
+			err := act.ValidateApplicationPlatform()
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateApplicationPlatform_Invalid(t *testing.T) {
+	// Arrange
+	act := &action.Action{ConfigApplicationPlatform: "compelte"}
+
+	// Act
+	err := act.ValidateApplicationPlatform()
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "compelte")
+}
+
+func TestValidateApplicationPlatform_OverriddenAllowedSet(t *testing.T) {
+	// Arrange
+	act := &action.Action{
+		ConfigApplicationPlatform:         "minimal",
+		ConfigApplicationAllowedPlatforms: []string{"minimal"},
+	}
+
+	// Act
+	err := act.ValidateApplicationPlatform()
+
+	// Assert
+	assert.NoError(t, err)
+	act.ConfigApplicationPlatform = "base"
+	assert.Error(t, act.ValidateApplicationPlatform())
+}
+
+// ==================== ValidateUserPreferredContactTypes Tests ====================
+
+func TestValidateUserPreferredContactTypes_DefaultAndKnown(t *testing.T) {
+	// Arrange
+	act := &action.Action{
+		ConfigUsers: map[string]any{
+			"diku_admin": map[string]any{"tenant": "diku"},
+			"diku_user":  map[string]any{"tenant": "diku", "preferred-contact-type-id": "005"},
+		},
+	}
+
+	// Act
+	err := act.ValidateUserPreferredContactTypes()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestValidateUserPreferredContactTypes_Invalid(t *testing.T) {
+	// Arrange
+	act := &action.Action{
+		ConfigUsers: map[string]any{
+			"diku_admin": map[string]any{"tenant": "diku", "preferred-contact-type-id": "email"},
+		},
+	}
+
+	// Act
+	err := act.ValidateConfig()
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "diku_admin")
+	assert.Contains(t, err.Error(), "email")
+}
+
+// ==================== ValidateUserTenants / ValidateRoleTenants Tests ====================
+
+func TestValidateUserTenants_AllKnown(t *testing.T) {
+	// Arrange
+	act := &action.Action{
+		ConfigTenants: map[string]any{"diku": nil},
+		ConfigUsers: map[string]any{
+			"diku_admin": map[string]any{"tenant": "diku"},
+		},
+	}
+
+	// Act
+	err := act.ValidateUserTenants()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestValidateUserTenants_ReportsAllOffenders(t *testing.T) {
+	// Arrange
+	act := &action.Action{
+		ConfigTenants: map[string]any{"diku": nil},
+		ConfigUsers: map[string]any{
+			"diku_admin":  map[string]any{"tenant": "diku"},
+			"other_admin": map[string]any{"tenant": "other"},
+			"typo_admin":  map[string]any{"tenant": "dikuu"},
+		},
+	}
+
+	// Act
+	err := act.ValidateConfig()
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), `other_admin (tenant "other")`)
+	assert.Contains(t, err.Error(), `typo_admin (tenant "dikuu")`)
+	assert.NotContains(t, err.Error(), "diku_admin")
+}
+
+func TestValidateRoleTenants_Invalid(t *testing.T) {
+	// Arrange
+	act := &action.Action{
+		ConfigTenants: map[string]any{"diku": nil},
+		ConfigRoles: map[string]any{
+			"adm-role": map[string]any{"tenant": "diku"},
+			"usr-role": map[string]any{"tenant": "missing"},
+		},
+	}
+
+	// Act
+	err := act.ValidateRoleTenants()
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "roles")
+	assert.Contains(t, err.Error(), `usr-role (tenant "missing")`)
+}
+
+// ==================== ValidatePhaseWaits Tests ====================
+
+func TestValidatePhaseWaits_Valid(t *testing.T) {
+	// Arrange
+	act := &action.Action{ConfigPhaseWaits: map[string]string{"system": "30s", "partition": "0s"}}
+
+	// Act
+	err := act.ValidatePhaseWaits()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestValidatePhaseWaits_UnknownPhase(t *testing.T) {
+	// Arrange
+	act := &action.Action{ConfigPhaseWaits: map[string]string{"tenants": "5s"}}
+
+	// Act
+	err := act.ValidatePhaseWaits()
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "tenants")
+}
+
+func TestValidatePhaseWaits_InvalidDuration(t *testing.T) {
+	for _, value := range []string{"soon", "-5s"} {
+		t.Run(value, func(t *testing.T) {
+			// Arrange
+			act := &action.Action{ConfigPhaseWaits: map[string]string{"modules": value}}
+
+			// Act
+			err := act.ValidatePhaseWaits()
+
+			// Assert
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+			assert.Contains(t, err.Error(), value)
+		})
+	}
+}
+
+// ==================== ValidateUniqueNames Tests ====================
+
+func writeValidateConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return configFile
+}
+
+func TestValidateUniqueNames_NoConfigFile(t *testing.T) {
+	// Arrange
+	act := &action.Action{}
+
+	// Act
+	err := act.ValidateUniqueNames()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestValidateUniqueNames_Unique(t *testing.T) {
+	// Arrange
+	act := &action.Action{ConfigFileUsed: writeValidateConfigFile(t, `
+roles:
+  adm-role:
+    tenant: diku
+  usr-role:
+    tenant: diku
+users:
+  diku_admin:
+    tenant: diku
+  DIKU_ADMIN:
+    tenant: other
+`)}
+
+	// Act
+	err := act.ValidateUniqueNames()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestValidateUniqueNames_ReportsAllCollisions(t *testing.T) {
+	// Arrange
+	act := &action.Action{ConfigFileUsed: writeValidateConfigFile(t, `
+roles:
+  admin:
+    tenant: diku
+  Admin:
+    tenant: diku
+  ADMIN:
+    tenant: other
+  viewer:
+    tenant: diku
+users:
+  diku_admin:
+    tenant: diku
+  Diku_Admin:
+    tenant: diku
+  diku_user:
+    tenant: diku
+`)}
+
+	// Act
+	err := act.ValidateUniqueNames()
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "roles ADMIN, Admin, admin")
+	assert.Contains(t, err.Error(), "users Diku_Admin, diku_admin in tenant diku")
+	assert.NotContains(t, err.Error(), "viewer")
+	assert.NotContains(t, err.Error(), "diku_user")
+}
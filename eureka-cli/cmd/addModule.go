@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// addModuleCmd represents the addModule command
+var addModuleCmd = &cobra.Command{
+	Use:   "addModule",
+	Short: "Add module",
+	Long:  `Add a single backend module to a running application without redeploying the other modules.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.AddModule)
+		if err != nil {
+			return err
+		}
+
+		return run.AddModule(params.ApplicationID)
+	},
+}
+
+// AddModule deploys a module and sidecar pair and adds the module to a new patch version of the application,
+// the module must be configured in the backend modules of the current profile
+func (run *Run) AddModule(applicationID string) error {
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	var (
+		moduleName    = params.ModuleName
+		moduleVersion = params.ModuleVersion
+	)
+	params.ID = fmt.Sprintf("%s-%s", moduleName, moduleVersion)
+
+	app, err := run.getApplicationOrLatest(applicationID)
+	if err != nil {
+		return err
+	}
+	newBackendModules, newDiscoveryModules, err := run.Config.UpgradeModuleSvc.AddBackendModule(moduleName, moduleVersion, false, helpers.GetAnySlice(app, "modules"))
+	if err != nil {
+		return err
+	}
+	newFrontendModules := run.Config.UpgradeModuleSvc.UpdateFrontendModules(false, helpers.GetAnySlice(app, "uiModules"))
+
+	oldAppVersion := helpers.GetString(app, "version")
+	appVersion, err := semver.NewVersion(oldAppVersion)
+	if err != nil {
+		return err
+	}
+	var (
+		appName       = helpers.GetString(app, "name")
+		newAppVersion = appVersion.IncPatch().String()
+		newAppID      = fmt.Sprintf("%s-%s", appName, newAppVersion)
+	)
+
+	slog.Info(run.Config.Action.Name, "text", "ADDING MODULE", "module", moduleName, "version", moduleVersion, "application", helpers.GetString(app, "id"))
+	if err := run.deployNewModuleAndSidecarPair(); err != nil {
+		return err
+	}
+	if err := run.Config.ManagementSvc.CreateNewApplication(&models.ApplicationUpgradeRequest{
+		ApplicationName:       appName,
+		NewApplicationID:      newAppID,
+		NewApplicationVersion: newAppVersion,
+		NewDependencies:       helpers.GetMapOrDefault(app, "dependencies", nil),
+		NewBackendModules:     newBackendModules,
+		NewFrontendModules:    newFrontendModules,
+		ShouldBuild:           false,
+	}); err != nil {
+		return err
+	}
+	if err := run.Config.ManagementSvc.CreateNewModuleDiscovery(newDiscoveryModules); err != nil {
+		if downstreamErr := run.cleanupApplicationsOnFailure(constant.NoneConsortium, constant.All, appName, err); downstreamErr != nil {
+			return downstreamErr
+		}
+
+		return err
+	}
+
+	slog.Info(run.Config.Action.Name, "text", "UPGRADING TENANT ENTITLEMENT", "from", oldAppVersion, "to", newAppVersion)
+	if err := run.Config.ManagementSvc.UpgradeTenantEntitlement(constant.NoneConsortium, constant.All, newAppID); err != nil {
+		if downstreamErr := run.cleanupApplicationsOnFailure(constant.NoneConsortium, constant.All, appName, err); downstreamErr != nil {
+			return downstreamErr
+		}
+
+		return err
+	}
+	slog.Info(run.Config.Action.Name, "text", "REMOVING APPLICATIONS", "name", appName)
+	_, err = run.Config.ManagementSvc.RemoveApplications(appName, newAppID)
+
+	return err
+}
+
+func (run *Run) getApplicationOrLatest(applicationID string) (map[string]any, error) {
+	if applicationID == "" {
+		return run.Config.ManagementSvc.GetLatestApplication()
+	}
+
+	return run.Config.ManagementSvc.GetApplication(applicationID)
+}
+
+func init() {
+	rootCmd.AddCommand(addModuleCmd)
+	addModuleCmd.PersistentFlags().StringVarP(&params.ModuleName, action.ModuleName.Long, action.ModuleName.Short, "", action.ModuleName.Description)
+	addModuleCmd.PersistentFlags().StringVarP(&params.ModuleVersion, action.ModuleVersion.Long, action.ModuleVersion.Short, "", action.ModuleVersion.Description)
+	addModuleCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+
+	if err := addModuleCmd.MarkPersistentFlagRequired(action.ModuleName.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.ModuleName, err).Error())
+		os.Exit(1)
+	}
+	if err := addModuleCmd.MarkPersistentFlagRequired(action.ModuleVersion.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.ModuleVersion, err).Error())
+		os.Exit(1)
+	}
+
+	if err := addModuleCmd.RegisterFlagCompletionFunc(action.ModuleName.Long, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return helpers.GetBackendModuleNames(viper.GetStringMap(field.BackendModules)), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		slog.Error(errors.RegisterFlagCompletionFailed(err).Error())
+		os.Exit(1)
+	}
+}
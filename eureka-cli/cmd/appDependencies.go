@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// appDependenciesCmd represents the appDependencies command
+var appDependenciesCmd = &cobra.Command{
+	Use:   "appDependencies",
+	Short: "Show application dependencies",
+	Long:  `Show the dependency graph of an application as a text tree or in DOT format for graphviz.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.AppDependencies)
+		if err != nil {
+			return err
+		}
+
+		return run.AppDependencies(params.ApplicationID, params.Format, os.Stdout)
+	},
+}
+
+func (run *Run) AppDependencies(applicationID string, format string, writer io.Writer) error {
+	if !slices.Contains(constant.GetDependencyGraphFormats(), format) {
+		return errors.UnsupportedOutputFormat(format, constant.GetDependencyGraphFormats())
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	graph, err := run.Config.ManagementSvc.GetApplicationDependencies(applicationID)
+	if err != nil {
+		return err
+	}
+	if format == constant.DotFormat {
+		return writeDependencyGraphDOT(writer, graph)
+	}
+
+	return writeDependencyGraphTree(writer, graph)
+}
+
+func writeDependencyGraphTree(writer io.Writer, graph *models.ApplicationDependencyGraph) error {
+	var sb strings.Builder
+	sb.WriteString(graph.ApplicationID + "\n")
+
+	total := len(graph.Dependencies) + len(graph.Modules)
+	index := 0
+	for _, dependency := range graph.Dependencies {
+		index++
+		branch, _ := getTreeBranch(index == total)
+		_, _ = fmt.Fprintf(&sb, "%sdepends on %s %s\n", branch, dependency.Name, dependency.Version)
+	}
+	for _, module := range graph.Modules {
+		index++
+		branch, indent := getTreeBranch(index == total)
+		sb.WriteString(branch + module.ModuleID + "\n")
+		for i, required := range module.Requires {
+			requiredBranch, _ := getTreeBranch(i == len(module.Requires)-1)
+			_, _ = fmt.Fprintf(&sb, "%s%s%s %s %s -> %s\n", indent, requiredBranch, getRequirementKind(required), required.InterfaceID, required.Version, getProvidersLabel(required))
+		}
+	}
+	_, err := io.WriteString(writer, sb.String())
+
+	return err
+}
+
+func getTreeBranch(isLast bool) (branch string, indent string) {
+	if isLast {
+		return "└── ", "    "
+	}
+
+	return "├── ", "│   "
+}
+
+func getRequirementKind(required models.InterfaceDependency) string {
+	if required.Optional {
+		return "optional"
+	}
+
+	return "requires"
+}
+
+func getProvidersLabel(required models.InterfaceDependency) string {
+	if len(required.ProviderIDs) == 0 {
+		return "(not provided by the application)"
+	}
+
+	return strings.Join(required.ProviderIDs, ", ")
+}
+
+func writeDependencyGraphDOT(writer io.Writer, graph *models.ApplicationDependencyGraph) error {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "digraph %q {\n", graph.ApplicationID)
+	_, _ = fmt.Fprintf(&sb, "  %q [shape=box];\n", graph.ApplicationID)
+	for _, dependency := range graph.Dependencies {
+		_, _ = fmt.Fprintf(&sb, "  %q -> %q [style=dashed];\n", graph.ApplicationID, fmt.Sprintf("%s %s", dependency.Name, dependency.Version))
+	}
+	for _, module := range graph.Modules {
+		_, _ = fmt.Fprintf(&sb, "  %q -> %q;\n", graph.ApplicationID, module.ModuleID)
+		for _, required := range module.Requires {
+			style := "solid"
+			if required.Optional {
+				style = "dotted"
+			}
+			label := fmt.Sprintf("%s %s", required.InterfaceID, required.Version)
+			if len(required.ProviderIDs) == 0 {
+				_, _ = fmt.Fprintf(&sb, "  %q -> %q [label=%q, style=%s, color=red];\n", module.ModuleID, required.InterfaceID+" (unmet)", label, style)
+				continue
+			}
+			for _, providerID := range required.ProviderIDs {
+				_, _ = fmt.Fprintf(&sb, "  %q -> %q [label=%q, style=%s];\n", module.ModuleID, providerID, label, style)
+			}
+		}
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(writer, sb.String())
+
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(appDependenciesCmd)
+	appDependenciesCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+	appDependenciesCmd.PersistentFlags().StringVarP(&params.Format, action.Format.Long, action.Format.Short, constant.TextFormat, fmt.Sprintf(action.Format.Description, constant.GetDependencyGraphFormats()))
+	if err := appDependenciesCmd.MarkPersistentFlagRequired(action.ApplicationID.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.ApplicationID, err).Error())
+		os.Exit(1)
+	}
+}
@@ -16,7 +16,9 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -25,6 +27,7 @@ import (
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
 	"github.com/spf13/cobra"
 )
 
@@ -44,13 +47,28 @@ var attachCapabilitySetsCmd = &cobra.Command{
 			return err
 		}
 
-		return run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
-			return run.AttachCapabilitySets(consortiumName, tenantType, time.Duration(0*time.Second), false)
-		})
+		report := &models.CapabilitySetsAttachReport{}
+		if err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
+			return run.AttachCapabilitySetsWithReport(consortiumName, tenantType, time.Duration(0*time.Second), false, report)
+		}); err != nil {
+			return err
+		}
+		if run.Config.Action.Param.JSON {
+			return writeCapabilitySetsAttachReportJSON(os.Stdout, report)
+		}
+
+		return nil
 	},
 }
 
 func (run *Run) AttachCapabilitySets(consortiumName string, tenantType constant.TenantType, initialWait time.Duration, forceRefresh bool) error {
+	return run.AttachCapabilitySetsWithReport(consortiumName, tenantType, initialWait, forceRefresh, nil)
+}
+
+// AttachCapabilitySetsWithReport attaches capability sets to the roles of every tenant of a partition and adds
+// the result of every tenant to the report, a nil report only logs the results
+func (run *Run) AttachCapabilitySetsWithReport(consortiumName string, tenantType constant.TenantType, initialWait time.Duration, forceRefresh bool,
+	report *models.CapabilitySetsAttachReport) error {
 	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.Password); err != nil {
 		return err
 	}
@@ -100,15 +118,20 @@ func (run *Run) AttachCapabilitySets(consortiumName string, tenantType constant.
 				return err
 			}
 		}
-		if err := run.Config.KeycloakSvc.AttachCapabilitySetsToRoles(configTenant); err != nil {
+		results, err := run.Config.KeycloakSvc.AttachCapabilitySetsToRoles(configTenant)
+		if err != nil {
 			return err
 		}
+		tenantResult := run.summarizeAttachCapabilitySetsResults(configTenant, results)
 
 		count, err := run.Config.KeycloakSvc.CountCapabilitySets(configTenant)
 		if err != nil {
 			slog.Warn(run.Config.Action.Name, "text", "Could not count capability sets, skipping persistence", "tenant", configTenant, "error", err)
+			addTenantCapabilitySetsAttachResult(report, tenantResult)
 			return nil
 		}
+		tenantResult.CapabilitySets = count
+		addTenantCapabilitySetsAttachResult(report, tenantResult)
 		if err := helpers.WriteJSONToFile(filePath, capabilitySetsRecord{Tenant: configTenant, Total: count}); err != nil {
 			slog.Warn(run.Config.Action.Name, "text", "Could not write capability sets file", "tenant", configTenant, "error", err)
 		} else {
@@ -119,6 +142,39 @@ func (run *Run) AttachCapabilitySets(consortiumName string, tenantType constant.
 	})
 }
 
+func (run *Run) summarizeAttachCapabilitySetsResults(configTenant string, results []models.RoleCapabilitySetsAttachResult) models.TenantCapabilitySetsAttachResult {
+	tenantResult := models.TenantCapabilitySetsAttachResult{Tenant: configTenant, Roles: len(results)}
+	for _, result := range results {
+		tenantResult.Attached += result.Attached
+		if len(result.Unresolved) > 0 {
+			slog.Warn(run.Config.Action.Name, "text", "Capability sets could not be resolved", "role", result.RoleName, "tenant", configTenant, "names", result.Unresolved)
+			if tenantResult.Unresolved == nil {
+				tenantResult.Unresolved = make(map[string][]string)
+			}
+			tenantResult.Unresolved[result.RoleName] = result.Unresolved
+		}
+	}
+	slog.Info(run.Config.Action.Name, "text", "Attached capability sets to roles", "tenant", configTenant, "roles", tenantResult.Roles, "attached", tenantResult.Attached)
+
+	return tenantResult
+}
+
+func addTenantCapabilitySetsAttachResult(report *models.CapabilitySetsAttachReport, tenantResult models.TenantCapabilitySetsAttachResult) {
+	if report != nil {
+		report.AddTenant(tenantResult)
+	}
+}
+
+func writeCapabilitySetsAttachReportJSON(writer io.Writer, report *models.CapabilitySetsAttachReport) error {
+	if report.Results == nil {
+		report.Results = []models.TenantCapabilitySetsAttachResult{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
 func (run *Run) updateRealmAccessTokenSettingsAndRelogin(configTenant string) error {
 	if err := run.Config.KeycloakSvc.UpdateRealmAccessTokenSettings(configTenant, constant.KeycloakTenantRealmAccessTokenLifespan); err != nil {
 		return err
@@ -133,4 +189,8 @@ func (run *Run) updateRealmAccessTokenSettingsAndRelogin(configTenant string) er
 
 func init() {
 	rootCmd.AddCommand(attachCapabilitySetsCmd)
+	attachCapabilitySetsCmd.PersistentFlags().BoolVarP(&params.Strict, action.Strict.Long, action.Strict.Short, false, action.Strict.Description)
+	attachCapabilitySetsCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+	attachCapabilitySetsCmd.PersistentFlags().IntVarP(&params.AttachRetries, action.AttachRetries.Long, action.AttachRetries.Short, 0, action.AttachRetries.Description)
+	attachCapabilitySetsCmd.PersistentFlags().DurationVarP(&params.AttachRetryWait, action.AttachRetryWait.Long, action.AttachRetryWait.Short, constant.AttachCapabilitySetsRetryWait, action.AttachRetryWait.Description)
 }
@@ -0,0 +1,237 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark gateway throughput",
+	Long:  `Issue concurrent authenticated GET requests against a gateway endpoint and report throughput, latency percentiles and error rate.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.Bench)
+		if err != nil {
+			return err
+		}
+
+		return run.Bench(params.Endpoint, params.Tenant, params.Concurrency, params.Duration, os.Stdout)
+	},
+}
+
+// benchSample is the outcome of a single benchmark request
+type benchSample struct {
+	latency time.Duration
+	status  string
+}
+
+func (run *Run) Bench(endpoint string, tenantName string, concurrency int, duration time.Duration, writer io.Writer) error {
+	if endpoint == "" {
+		return apperrors.RequiredParameterMissing(action.Endpoint.Long)
+	}
+	if concurrency <= 0 {
+		return apperrors.NonPositiveParameter(action.Concurrency.Long, concurrency)
+	}
+	if duration <= 0 {
+		return apperrors.NonPositiveParameter(action.Duration.Long, duration)
+	}
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		return err
+	}
+
+	headers, err := run.Config.Action.BuildTenantHeaders(tenantName, run.Config.Action.KeycloakAccessToken)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	requestURL := run.Config.Action.GetRequestURL(run.Config.Action.GetGatewayPort(), endpoint)
+
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		samples []benchSample
+		wg      sync.WaitGroup
+	)
+	startedAt := time.Now()
+	for range concurrency {
+		wg.Go(func() {
+			for ctx.Err() == nil {
+				requestStartedAt := time.Now()
+				_, err := run.Config.HTTPClient.GetReturnRawBytes(requestURL, headers)
+				sample := benchSample{latency: time.Since(requestStartedAt), status: getBenchStatus(err)}
+
+				mu.Lock()
+				samples = append(samples, sample)
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	summary := summarizeBench(samples, time.Since(startedAt))
+	summary.Endpoint = endpoint
+	summary.Tenant = tenantName
+	summary.Concurrency = concurrency
+	if !run.Config.Action.Param.StatusCodes {
+		summary.StatusCodes = nil
+	}
+	if run.Config.Action.Param.JSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(summary)
+	}
+
+	return writeBenchSummary(writer, summary)
+}
+
+// getBenchStatus returns the status of a request, the gateway responses other than 2xx
+// surface as an HTTP error carrying the status code
+func getBenchStatus(err error) string {
+	if err == nil {
+		return "2xx"
+	}
+
+	var httpErr *apperrors.HTTPError
+	if errors.As(err, &httpErr) {
+		return strconv.Itoa(httpErr.StatusCode)
+	}
+
+	return "error"
+}
+
+func summarizeBench(samples []benchSample, elapsed time.Duration) models.BenchSummary {
+	summary := models.BenchSummary{
+		DurationMs:  elapsed.Milliseconds(),
+		Requests:    len(samples),
+		StatusCodes: make(map[string]int),
+	}
+	if len(samples) == 0 {
+		return summary
+	}
+
+	latencies := make([]time.Duration, 0, len(samples))
+	for _, sample := range samples {
+		latencies = append(latencies, sample.latency)
+		summary.StatusCodes[sample.status]++
+		if sample.status != "2xx" {
+			summary.Errors++
+		}
+	}
+	slices.Sort(latencies)
+
+	summary.ErrorRate = float64(summary.Errors) / float64(summary.Requests)
+	summary.Throughput = float64(summary.Requests) / elapsed.Seconds()
+	summary.Latency = models.BenchLatency{
+		P50: getLatencyPercentile(latencies, 50),
+		P90: getLatencyPercentile(latencies, 90),
+		P99: getLatencyPercentile(latencies, 99),
+		Max: toMilliseconds(latencies[len(latencies)-1]),
+	}
+
+	return summary
+}
+
+// getLatencyPercentile returns the nearest-rank percentile of the sorted latencies in milliseconds
+func getLatencyPercentile(sorted []time.Duration, percentile int) float64 {
+	rank := (percentile*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return toMilliseconds(sorted[rank-1])
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func writeBenchSummary(writer io.Writer, summary models.BenchSummary) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Endpoint:\t%s\n", summary.Endpoint)
+	_, _ = fmt.Fprintf(tw, "Tenant:\t%s\n", summary.Tenant)
+	_, _ = fmt.Fprintf(tw, "Concurrency:\t%d\n", summary.Concurrency)
+	_, _ = fmt.Fprintf(tw, "Duration:\t%s\n", (time.Duration(summary.DurationMs) * time.Millisecond).String())
+	_, _ = fmt.Fprintf(tw, "Requests:\t%d\n", summary.Requests)
+	_, _ = fmt.Fprintf(tw, "Throughput:\t%.2f req/s\n", summary.Throughput)
+	_, _ = fmt.Fprintf(tw, "Errors:\t%d (%.2f%%)\n", summary.Errors, summary.ErrorRate*100)
+	_, _ = fmt.Fprintf(tw, "Latency p50:\t%.2f ms\n", summary.Latency.P50)
+	_, _ = fmt.Fprintf(tw, "Latency p90:\t%.2f ms\n", summary.Latency.P90)
+	_, _ = fmt.Fprintf(tw, "Latency p99:\t%.2f ms\n", summary.Latency.P99)
+	_, _ = fmt.Fprintf(tw, "Latency max:\t%.2f ms\n", summary.Latency.Max)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(summary.StatusCodes) == 0 {
+		return nil
+	}
+
+	tw = tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer)
+	_, _ = fmt.Fprintln(tw, "STATUS\tCOUNT")
+	statuses := make([]string, 0, len(summary.StatusCodes))
+	for status := range summary.StatusCodes {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+	for _, status := range statuses {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\n", status, summary.StatusCodes[status])
+	}
+
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+	benchCmd.PersistentFlags().StringVarP(&params.Endpoint, action.Endpoint.Long, action.Endpoint.Short, "", action.Endpoint.Description)
+	benchCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	benchCmd.PersistentFlags().IntVarP(&params.Concurrency, action.Concurrency.Long, action.Concurrency.Short, constant.BenchConcurrency, action.Concurrency.Description)
+	benchCmd.PersistentFlags().DurationVarP(&params.Duration, action.Duration.Long, action.Duration.Short, constant.BenchDuration, action.Duration.Description)
+	benchCmd.PersistentFlags().BoolVarP(&params.StatusCodes, action.StatusCodes.Long, action.StatusCodes.Short, false, action.StatusCodes.Description)
+	benchCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+	if err := benchCmd.MarkPersistentFlagRequired(action.Endpoint.Long); err != nil {
+		slog.Error(apperrors.MarkFlagRequiredFailed(action.Endpoint, err).Error())
+		os.Exit(1)
+	}
+	if err := benchCmd.MarkPersistentFlagRequired(action.Tenant.Long); err != nil {
+		slog.Error(apperrors.MarkFlagRequiredFailed(action.Tenant, err).Error())
+		os.Exit(1)
+	}
+}
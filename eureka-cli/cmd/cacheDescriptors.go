@@ -0,0 +1,185 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/spf13/cobra"
+)
+
+// cacheDescriptorsCmd represents the cacheDescriptors command
+var cacheDescriptorsCmd = &cobra.Command{
+	Use:   "cacheDescriptors",
+	Short: "Cache module descriptors",
+	Long:  `Fetch the descriptors of all configured modules from the registry into the local cache that deployments read before fetching a descriptor.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.CacheDescriptors)
+		if err != nil {
+			return err
+		}
+		if params.Invalidate {
+			return run.InvalidateDescriptorCache(params.ID)
+		}
+
+		return run.CacheDescriptors(params.ID)
+	},
+}
+
+// CacheDescriptors writes the registry descriptors of the configured modules into the descriptor cache,
+// a module id limits the refresh to that module
+func (run *Run) CacheDescriptors(moduleID string) error {
+	moduleIDs, err := run.getConfiguredModuleIDs()
+	if err != nil {
+		return err
+	}
+	if moduleID != "" {
+		moduleID, err = normalizeModuleID(moduleID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(moduleIDs, moduleID) {
+			return errors.ModuleNotConfigured(moduleID)
+		}
+		moduleIDs = []string{moduleID}
+	}
+
+	for _, id := range moduleIDs {
+		var descriptor any
+		if err := run.Config.HTTPClient.GetRetryReturnStruct(run.Config.Action.GetModuleURL(id), map[string]string{}, &descriptor); err != nil {
+			return err
+		}
+		cachePath, err := helpers.GetDescriptorCacheFilePath(id)
+		if err != nil {
+			return err
+		}
+		if err := helpers.WriteJSONToFile(cachePath, descriptor); err != nil {
+			return err
+		}
+		slog.Info(run.Config.Action.Name, "text", "Cached module descriptor", "module", id)
+	}
+	slog.Info(run.Config.Action.Name, "text", "Cached module descriptors", "count", len(moduleIDs))
+
+	return nil
+}
+
+// InvalidateDescriptorCache removes the cached descriptor of a module id or all cached descriptors
+func (run *Run) InvalidateDescriptorCache(moduleID string) error {
+	cacheDir, err := helpers.GetDescriptorCacheDirPath()
+	if err != nil {
+		return err
+	}
+	if moduleID != "" {
+		moduleID, err = normalizeModuleID(moduleID)
+		if err != nil {
+			return err
+		}
+		cachePath := filepath.Join(cacheDir, moduleID+".json")
+		if err := os.Remove(cachePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		slog.Info(run.Config.Action.Name, "text", "Invalidated cached module descriptor", "module", moduleID)
+		return nil
+	}
+
+	cachePaths, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, cachePath := range cachePaths {
+		if err := os.Remove(cachePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	slog.Info(run.Config.Action.Name, "text", "Invalidated cached module descriptors", "count", len(cachePaths))
+
+	return nil
+}
+
+// getConfiguredModuleIDs returns the ids of the deployable modules resolved against the registry,
+// applying pinned versions and leaving out management modules and modules with local descriptors
+func (run *Run) getConfiguredModuleIDs() ([]string, error) {
+	backendModules, err := run.Config.ModuleProps.ReadBackendModules(false, false)
+	if err != nil {
+		return nil, err
+	}
+	frontendModules, err := run.Config.ModuleProps.ReadFrontendModules(false)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := run.Config.RegistrySvc.GetModules(false, false)
+	if err != nil {
+		return nil, err
+	}
+	run.Config.RegistrySvc.ResolveModuleMetadata(modules)
+
+	var moduleIDs []string
+	for _, module := range append(modules.FolioModules, modules.EurekaModules...) {
+		name := module.Metadata.Name
+		if name == "" || strings.HasPrefix(name, constant.ManagementModulePattern) {
+			continue
+		}
+
+		var version *string
+		if backendModule, ok := backendModules[name]; ok && backendModule.DeployModule {
+			if backendModule.LocalDescriptorPath != "" || backendModule.DescriptorFile != "" {
+				continue
+			}
+			version = backendModule.ModuleVersion
+		} else if frontendModule, ok := frontendModules[name]; ok && frontendModule.DeployModule {
+			if frontendModule.LocalDescriptorPath != "" || frontendModule.DescriptorFile != "" {
+				continue
+			}
+			version = frontendModule.ModuleVersion
+		} else {
+			continue
+		}
+
+		moduleID := module.ID
+		if version != nil {
+			moduleID = name + "-" + *version
+		}
+		moduleIDs = append(moduleIDs, moduleID)
+	}
+	sort.Strings(moduleIDs)
+
+	return moduleIDs, nil
+}
+
+// normalizeModuleID accepts both name:version and name-version ids and rejects anything else, as the id names a cache file
+func normalizeModuleID(moduleID string) (string, error) {
+	normalized := strings.Replace(moduleID, ":", "-", 1)
+	if !helpers.IsModuleID(normalized) || strings.ContainsAny(normalized, `/\`) {
+		return "", errors.InvalidModuleID(moduleID)
+	}
+
+	return normalized, nil
+}
+
+func init() {
+	rootCmd.AddCommand(cacheDescriptorsCmd)
+	cacheDescriptorsCmd.PersistentFlags().StringVarP(&params.ID, action.ID.Long, action.ID.Short, "", action.ID.Description)
+	cacheDescriptorsCmd.PersistentFlags().BoolVarP(&params.Invalidate, action.Invalidate.Long, action.Invalidate.Short, false, action.Invalidate.Description)
+}
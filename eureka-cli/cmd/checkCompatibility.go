@@ -0,0 +1,125 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// checkCompatibilityCmd represents the checkCompatibility command
+var checkCompatibilityCmd = &cobra.Command{
+	Use:   "checkCompatibility",
+	Short: "Check interface compatibility",
+	Long:  `Check the interface versions required by the modules of an application against the versions provided within the application.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.CheckCompatibility)
+		if err != nil {
+			return err
+		}
+
+		return run.CheckCompatibility(params.ApplicationID, os.Stdout)
+	},
+}
+
+// CheckCompatibility reports the required interfaces of an application that are not provided in a compatible version,
+// interfaces missing from an application with application dependencies are reported as unresolved and do not fail the check.
+// The configured application is checked from the registry descriptors so that it can be checked before it is registered
+func (run *Run) CheckCompatibility(applicationID string, writer io.Writer) error {
+	graph, err := run.getApplicationDependencyGraph(applicationID)
+	if err != nil {
+		return err
+	}
+
+	incompatibilities := graph.Incompatibilities()
+	var failed int
+	for _, incompatibility := range incompatibilities {
+		if len(incompatibility.Provided) > 0 || len(graph.Dependencies) == 0 {
+			failed++
+		}
+	}
+	if run.Config.Action.Param.JSON {
+		err = writeIncompatibilitiesJSON(writer, incompatibilities)
+	} else {
+		err = writeIncompatibilities(writer, graph, incompatibilities)
+	}
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.InterfaceIncompatibilitiesFound(graph.ApplicationID, failed)
+	}
+
+	return nil
+}
+
+func writeIncompatibilitiesJSON(writer io.Writer, incompatibilities []models.InterfaceIncompatibility) error {
+	if incompatibilities == nil {
+		incompatibilities = []models.InterfaceIncompatibility{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(incompatibilities)
+}
+
+func writeIncompatibilities(writer io.Writer, graph *models.ApplicationDependencyGraph, incompatibilities []models.InterfaceIncompatibility) error {
+	if len(incompatibilities) == 0 {
+		_, err := fmt.Fprintf(writer, "%s: all interfaces of %d modules are compatible\n", graph.ApplicationID, len(graph.Modules))
+		return err
+	}
+
+	var unresolved bool
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MODULE\tINTERFACE\tREQUIRED\tPROVIDED\tSTATUS")
+	for _, incompatibility := range incompatibilities {
+		required := incompatibility.Required
+		if incompatibility.Optional {
+			required += " (optional)"
+		}
+		provided, status := strings.Join(incompatibility.Provided, ", "), "incompatible"
+		if provided == "" {
+			provided, status = "-", "missing"
+			if len(graph.Dependencies) > 0 {
+				status, unresolved = "unresolved", true
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", incompatibility.ModuleID, incompatibility.InterfaceID, required, provided, status)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if unresolved {
+		_, _ = fmt.Fprintln(writer, "\nUnresolved interfaces may be provided by the application dependencies")
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(checkCompatibilityCmd)
+	checkCompatibilityCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+	checkCompatibilityCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/spf13/cobra"
+)
+
+// checkMgrServicesCmd represents the checkMgrServices command
+var checkMgrServicesCmd = &cobra.Command{
+	Use:   "checkMgrServices",
+	Short: "Check mgr services",
+	Long:  `Check that every management service is healthy on its published port and reachable through the gateway.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.CheckMgrServices)
+		if err != nil {
+			return err
+		}
+
+		return run.CheckMgrServices(os.Stdout)
+	},
+}
+
+var statusHTTPOK = fmt.Sprintf("HTTP %d", http.StatusOK)
+
+type mgrServiceReachability struct {
+	module        string
+	directURL     string
+	directStatus  string
+	gatewayURL    string
+	gatewayStatus string
+}
+
+func (r mgrServiceReachability) getPath() string {
+	direct := r.directStatus == statusHTTPOK
+	gateway := r.gatewayStatus == statusHTTPOK
+	switch {
+	case direct && gateway:
+		return "both"
+	case direct:
+		return "direct only"
+	case gateway:
+		return "gateway only"
+	default:
+		return "none"
+	}
+}
+
+// CheckMgrServices pings /admin/health of every configured management module on its published port and one of
+// its routes through the gateway, to tell gateway routing issues apart from unhealthy or unreachable services
+func (run *Run) CheckMgrServices(writer io.Writer) error {
+	slog.Info(run.Config.Action.Name, "text", "CHECKING MGR SERVICES")
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+	headers, err := helpers.SecureApplicationJSONHeaders(run.Config.Action.KeycloakMasterAccessToken)
+	if err != nil {
+		return err
+	}
+
+	gatewayRoutes := constant.GetManagementGatewayRoutes()
+	var results []mgrServiceReachability
+	var unreachable []string
+	for _, moduleName := range helpers.SortedMapKeys(run.Config.Action.ConfigBackendModules) {
+		if !strings.HasPrefix(moduleName, constant.ManagementModulePattern) {
+			continue
+		}
+
+		result := mgrServiceReachability{module: moduleName, directStatus: "no port", gatewayStatus: "no route"}
+		entry, _ := run.Config.Action.ConfigBackendModules[moduleName].(map[string]any)
+		if port := helpers.GetInt(entry, field.ModulePortEntry); port > 0 {
+			result.directURL = run.Config.Action.GetRequestURL(strconv.Itoa(port), "/admin/health")
+			result.directStatus = run.pingMgrService(result.directURL)
+		}
+		if route, ok := gatewayRoutes[moduleName]; ok {
+			result.gatewayURL = run.Config.Action.GetRequestURL(run.Config.Action.GetGatewayPort(), route)
+			result.gatewayStatus = run.getMgrServiceThroughGateway(result.gatewayURL, headers)
+		}
+		if result.getPath() != "both" {
+			unreachable = append(unreachable, moduleName)
+		}
+		results = append(results, result)
+	}
+	if err := writeMgrServiceReachability(writer, results); err != nil {
+		return err
+	}
+	if len(unreachable) > 0 {
+		return apperrors.ManagementServicesUnreachable(unreachable)
+	}
+
+	return nil
+}
+
+func (run *Run) pingMgrService(requestURL string) string {
+	statusCode, err := run.Config.HTTPClient.Ping(requestURL)
+	if err != nil {
+		slog.Warn(run.Config.Action.Name, "text", "Management service is unreachable", "url", requestURL, "error", err)
+		return "unreachable"
+	}
+
+	return fmt.Sprintf("HTTP %d", statusCode)
+}
+
+func (run *Run) getMgrServiceThroughGateway(requestURL string, headers map[string]string) string {
+	if _, err := run.Config.HTTPClient.GetReturnRawBytes(requestURL, headers); err != nil {
+		var httpErr *apperrors.HTTPError
+		if errors.As(err, &httpErr) {
+			return fmt.Sprintf("HTTP %d", httpErr.StatusCode)
+		}
+		slog.Warn(run.Config.Action.Name, "text", "Gateway is unreachable", "url", requestURL, "error", err)
+		return "unreachable"
+	}
+
+	return statusHTTPOK
+}
+
+func writeMgrServiceReachability(writer io.Writer, results []mgrServiceReachability) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MODULE\tDIRECT\tGATEWAY\tPATH\tDIRECT-URL\tGATEWAY-URL")
+	for _, result := range results {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", result.module, result.directStatus, result.gatewayStatus, result.getPath(),
+			valueOrDash(result.directURL), valueOrDash(result.gatewayURL))
+	}
+
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(checkMgrServicesCmd)
+}
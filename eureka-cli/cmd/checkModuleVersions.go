@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+const (
+	moduleVersionOutdated      = "outdated"
+	moduleVersionCurrent       = "current"
+	moduleVersionUnpinned      = "unpinned"
+	moduleVersionNotInRegistry = "not in registry"
+)
+
+// checkModuleVersionsCmd represents the checkModuleVersions command
+var checkModuleVersionsCmd = &cobra.Command{
+	Use:   "checkModuleVersions",
+	Short: "Check module versions",
+	Long:  `Compare pinned versions of configured backend modules against the latest versions available in the registry.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.CheckModuleVersions)
+		if err != nil {
+			return err
+		}
+
+		return run.CheckModuleVersions(os.Stdout)
+	},
+}
+
+type moduleVersionStatus struct {
+	name       string
+	configured string
+	latest     string
+	status     string
+}
+
+func (run *Run) CheckModuleVersions(writer io.Writer) error {
+	backendModules, err := run.Config.ModuleProps.ReadBackendModules(false, false)
+	if err != nil {
+		return err
+	}
+
+	latestVersions, err := run.getLatestRegistryModuleVersions()
+	if err != nil {
+		return err
+	}
+
+	statuses := getModuleVersionStatuses(backendModules, latestVersions)
+	if run.Config.Action.Param.OnlyOutdated {
+		var outdated []moduleVersionStatus
+		for _, status := range statuses {
+			if status.status == moduleVersionOutdated {
+				outdated = append(outdated, status)
+			}
+		}
+		statuses = outdated
+	}
+
+	return writeModuleVersionStatuses(writer, statuses)
+}
+
+func (run *Run) getLatestRegistryModuleVersions() (map[string]string, error) {
+	requestURL := fmt.Sprintf("%s/_/proxy/modules", run.Config.Action.ConfigRegistryURL)
+
+	var decodedResponse models.ProxyModulesResponse
+	if err := run.Config.HTTPClient.GetRetryReturnStruct(requestURL, map[string]string{}, &decodedResponse); err != nil {
+		return nil, err
+	}
+
+	latestVersions := make(map[string]string)
+	for _, module := range decodedResponse {
+		name := helpers.GetModuleNameFromID(module.ID)
+		version := helpers.GetModuleVersionFromID(module.ID)
+		if name == "" || version == "" || version == module.ID {
+			continue
+		}
+		if latest, ok := latestVersions[name]; !ok || helpers.IsVersionGreater(version, latest) {
+			latestVersions[name] = version
+		}
+	}
+
+	return latestVersions, nil
+}
+
+func getModuleVersionStatuses(backendModules map[string]models.BackendModule, latestVersions map[string]string) []moduleVersionStatus {
+	statuses := make([]moduleVersionStatus, 0, len(backendModules))
+	for name, module := range backendModules {
+		status := moduleVersionStatus{name: name, latest: latestVersions[name]}
+		if module.ModuleVersion != nil {
+			status.configured = *module.ModuleVersion
+		}
+
+		switch {
+		case status.latest == "":
+			status.status = moduleVersionNotInRegistry
+		case status.configured == "":
+			status.status = moduleVersionUnpinned
+		case helpers.IsVersionGreater(status.latest, status.configured):
+			status.status = moduleVersionOutdated
+		default:
+			status.status = moduleVersionCurrent
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].name < statuses[j].name
+	})
+
+	return statuses
+}
+
+func writeModuleVersionStatuses(writer io.Writer, statuses []moduleVersionStatus) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MODULE\tCONFIGURED\tLATEST\tSTATUS")
+	for _, status := range statuses {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", status.name, valueOrDash(status.configured), valueOrDash(status.latest), status.status)
+	}
+
+	return tw.Flush()
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(checkModuleVersionsCmd)
+	checkModuleVersionsCmd.PersistentFlags().BoolVarP(&params.OnlyOutdated, action.OnlyOutdated.Long, action.OnlyOutdated.Short, false, action.OnlyOutdated.Description)
+}
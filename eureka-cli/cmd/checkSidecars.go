@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/spf13/cobra"
+)
+
+// checkSidecarsCmd represents the checkSidecars command
+var checkSidecarsCmd = &cobra.Command{
+	Use:   "checkSidecars",
+	Short: "Check sidecars",
+	Long:  `Check that the sidecar of every deployed module is reachable from the gateway network using its discovery location.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.CheckSidecars)
+		if err != nil {
+			return err
+		}
+
+		return run.CheckSidecars(os.Stdout)
+	},
+}
+
+type sidecarReachability struct {
+	module    string
+	location  string
+	reachable bool
+	status    string
+	latency   time.Duration
+}
+
+func (run *Run) CheckSidecars(writer io.Writer) error {
+	slog.Info(run.Config.Action.Name, "text", "CHECKING SIDECAR REACHABILITY")
+	if err := run.deployNetcatContainer(); err != nil {
+		return err
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	moduleNames, err := run.getDeployedSidecarModuleNames()
+	if err != nil {
+		return err
+	}
+
+	results := make([]sidecarReachability, 0, len(moduleNames))
+	for _, moduleName := range moduleNames {
+		results = append(results, run.checkSidecarReachability(moduleName))
+	}
+
+	return writeSidecarReachability(writer, results)
+}
+
+func (run *Run) getDeployedSidecarModuleNames() ([]string, error) {
+	client, err := run.Config.DockerClient.Create()
+	if err != nil {
+		return nil, err
+	}
+	defer run.Config.DockerClient.Close(client)
+
+	filters := filters.NewArgs(filters.KeyValuePair{
+		Key:   "name",
+		Value: fmt.Sprintf(constant.SidecarContainerPattern, run.Config.Action.ConfigProfileName),
+	})
+	containers, err := run.Config.ModuleSvc.GetDeployedModules(client, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("eureka-%s-", run.Config.Action.ConfigProfileName)
+	moduleNames := make([]string, 0, len(containers))
+	for _, container := range containers {
+		if len(container.Names) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(container.Names[0], "/")
+		moduleNames = append(moduleNames, strings.TrimSuffix(strings.TrimPrefix(name, prefix), "-sc"))
+	}
+	sort.Strings(moduleNames)
+
+	return moduleNames, nil
+}
+
+func (run *Run) checkSidecarReachability(moduleName string) sidecarReachability {
+	result := sidecarReachability{module: moduleName}
+	discovery, err := run.Config.ManagementSvc.GetModuleDiscovery(moduleName)
+	if err != nil || len(discovery.Discovery) == 0 || discovery.Discovery[0].Location == "" {
+		slog.Warn(run.Config.Action.Name, "text", "Module has no discovery location", "module", moduleName, "error", err)
+		result.status = "no discovery"
+		return result
+	}
+	result.location = discovery.Discovery[0].Location
+
+	requestURL := strings.TrimRight(result.location, "/") + "/admin/health"
+	maxTime := strconv.Itoa(int(constant.SidecarHealthTimeout.Seconds()))
+	stdout, _, err := run.Config.ExecSvc.ExecReturnOutput(exec.Command("docker", "exec", "-i", "netcat",
+		"curl", "-s", "-o", "/dev/null", "-w", "%{http_code} %{time_total}", "--max-time", maxTime, requestURL))
+	fields := strings.Fields(stdout.String())
+	if err != nil || len(fields) != 2 {
+		result.status = "unreachable"
+		return result
+	}
+
+	seconds, _ := strconv.ParseFloat(fields[1], 64)
+	result.latency = time.Duration(seconds * float64(time.Second)).Round(time.Millisecond)
+	result.reachable = fields[0] == strconv.Itoa(http.StatusOK)
+	result.status = fmt.Sprintf("HTTP %s", fields[0])
+
+	return result
+}
+
+func writeSidecarReachability(writer io.Writer, results []sidecarReachability) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MODULE\tLOCATION\tREACHABLE\tSTATUS\tLATENCY")
+	for _, result := range results {
+		reachable := "unreachable"
+		if result.reachable {
+			reachable = "reachable"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", result.module, valueOrDash(result.location), reachable, result.status, result.latency)
+	}
+
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(checkSidecarsCmd)
+}
@@ -1,15 +1,27 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/internal/testhelpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
 	"github.com/folio-org/eureka-setup/eureka-cli/modulesvc"
 	"github.com/folio-org/eureka-setup/eureka-cli/runconfig"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -38,6 +50,11 @@ func (m *MockUpgradeModuleSvc) BuildModuleImage(namespace, moduleName, moduleVer
 	return args.Error(0)
 }
 
+func (m *MockUpgradeModuleSvc) CleanModuleArtifact(moduleName, modulePath string) error {
+	args := m.Called(moduleName, modulePath)
+	return args.Error(0)
+}
+
 func (m *MockUpgradeModuleSvc) ReadModuleDescriptor(moduleName, moduleVersion, modulePath string) (map[string]any, error) {
 	args := m.Called(moduleName, moduleVersion, modulePath)
 	if args.Get(0) == nil {
@@ -70,6 +87,14 @@ func (m *MockUpgradeModuleSvc) UpdateBackendModuleDescriptors(moduleName, oldMod
 	return args.Get(0).([]any)
 }
 
+func (m *MockUpgradeModuleSvc) AddBackendModule(moduleName, moduleVersion string, shouldBuild bool, oldBackendModules []any) ([]map[string]any, []map[string]string, error) {
+	args := m.Called(moduleName, moduleVersion, shouldBuild, oldBackendModules)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]map[string]any), args.Get(1).([]map[string]string), args.Error(2)
+}
+
 func (m *MockUpgradeModuleSvc) DeployModuleAndSidecarPair(client *client.Client, pair *modulesvc.ModulePair) error {
 	args := m.Called(client, pair)
 	return args.Error(0)
@@ -106,6 +131,14 @@ func (m *MockKongSvc) ListAllRoutes() ([]models.KongRoute, error) {
 	return args.Get(0).([]models.KongRoute), args.Error(1)
 }
 
+func (m *MockKongSvc) ListAllServices() ([]models.KongService, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.KongService), args.Error(1)
+}
+
 // ==================== UpgradeModule Tests ====================
 
 func TestValidateModulePath_EmptyPath(t *testing.T) {
@@ -215,7 +248,7 @@ func TestDeployManagement_Success(t *testing.T) {
 	mockDocker.On("Create").Return(nil, nil)
 	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
 	mockModule.On("DeployModules", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(map[string]int{"test-module": 8080}, 1, nil)
+		Return(map[string]models.BackendModule{"test-module": {ModuleExposedServerPort: 8080}}, 1, nil)
 	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
 	mockKongSvc.On("CheckRouteReadiness").Return(nil)
 	mockKeycloak.On("GetMasterAccessToken", mock.Anything).Return("access-token", nil)
@@ -269,7 +302,7 @@ func TestDeployManagement_NoModulesDeployed(t *testing.T) {
 	mockDocker.On("Create").Return(nil, nil)
 	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
 	mockModule.On("DeployModules", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(map[string]int{}, 0, nil)
+		Return(map[string]models.BackendModule{}, 0, nil)
 	mockDocker.On("Close", mock.Anything).Return(nil)
 
 	// Act
@@ -295,7 +328,7 @@ func TestDeployManagement_AllAlreadyDeployed_SkipsHealthcheck(t *testing.T) {
 	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
 	// newlyDeployed=empty (all already existed), totalMatched=1
 	mockModule.On("DeployModules", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(map[string]int{}, 1, nil)
+		Return(map[string]models.BackendModule{}, 1, nil)
 	mockKeycloak.On("GetMasterAccessToken", mock.Anything).Return("access-token", nil)
 	mockKeycloak.On("UpdateRealmAccessTokenSettings", constant.KeycloakMasterRealm, mock.Anything).Return(nil)
 	mockDocker.On("Close", mock.Anything).Return(nil)
@@ -359,6 +392,73 @@ func TestUndeployApplication_Success(t *testing.T) {
 // require mocking every dependency of all sub-commands. The individual commands
 // (DeploySystem, DeployManagement, etc.) have their own comprehensive unit tests.
 
+func TestRunDeployPhase_ResumeSkipsCompletedPhases(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, _ := newTestRun(action.DeployApplication)
+	state, err := run.LoadDeployState()
+	assert.NoError(t, err)
+
+	assert.NoError(t, run.RunDeployPhase(state, constant.SystemPhase, func() error { return nil }))
+	assert.Error(t, run.RunDeployPhase(state, constant.TenantsPhase, func() error { return apperrors.New("tenants failed") }))
+
+	run.Config.Action.Param.Resume = true
+	var ranPhases []string
+
+	// Act
+	resumedState, err := run.LoadDeployState()
+	assert.NoError(t, err)
+	for _, phase := range []string{constant.SystemPhase, constant.TenantsPhase} {
+		err = run.RunDeployPhase(resumedState, phase, func() error {
+			ranPhases = append(ranPhases, phase)
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+
+	// Assert
+	assert.Equal(t, []string{constant.TenantsPhase}, ranPhases)
+	assert.Equal(t, []string{constant.SystemPhase, constant.TenantsPhase}, resumedState.CompletedPhases)
+}
+
+func TestLoadDeployState_WithoutResumeStartsOver(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, _ := newTestRun(action.DeployApplication)
+	state, err := run.LoadDeployState()
+	assert.NoError(t, err)
+	assert.NoError(t, run.RunDeployPhase(state, constant.SystemPhase, func() error { return nil }))
+
+	// Act
+	freshState, err := run.LoadDeployState()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, freshState.CompletedPhases)
+}
+
+func TestLoadDeployState_ResumeIgnoresOtherProfiles(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, _ := newTestRun(action.DeployApplication)
+	run.Config.Action.ConfigProfileName = "combined"
+	run.Config.Action.ConfigApplicationID = "app-combined-1.0.0"
+	state, err := run.LoadDeployState()
+	assert.NoError(t, err)
+	assert.NoError(t, run.RunDeployPhase(state, constant.SystemPhase, func() error { return nil }))
+
+	run.Config.Action.Param.Resume = true
+	run.Config.Action.ConfigProfileName = "export"
+	run.Config.Action.ConfigApplicationID = "app-export-1.0.0"
+
+	// Act
+	otherState, err := run.LoadDeployState()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, otherState.CompletedPhases)
+}
+
 // ==================== DeployAdditionalSystem Tests ====================
 
 func TestDeployAdditionalSystem_NoContainers(t *testing.T) {
@@ -465,12 +565,40 @@ func TestConsortiumPartition_FunctionError(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 }
 
+// ==================== TenantPartition Tests ====================
+
+func TestTenantPartition_WithAccessTokenEnv(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, _ := newTestRun(action.DetachAllUserRoles)
+	run.Config.Action.Param.AccessTokenEnv = "EUREKA_ACCESS_TOKEN_%s"
+	run.Config.Action.ConfigTenants = map[string]any{"test-tenant": map[string]any{}}
+	t.Setenv("EUREKA_ACCESS_TOKEN_TEST-TENANT", "injected-token")
+	mockManagement.On("GetConfigTenants", constant.NoneConsortium, mock.Anything).
+		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}})
+
+	var calledWith []string
+	fn := func(configTenant string, tenantType string) error {
+		calledWith = append(calledWith, configTenant+":"+run.Config.Action.KeycloakAccessToken)
+		return nil
+	}
+
+	// Act
+	err := run.TenantPartition(constant.NoneConsortium, constant.Default, fn)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test-tenant:injected-token"}, calledWith)
+	mockDocker.AssertNotCalled(t, "Create")
+	mockKeycloak.AssertNotCalled(t, "GetMasterAccessToken", mock.Anything)
+	mockManagement.AssertNotCalled(t, "GetTenants", mock.Anything, mock.Anything)
+}
+
 // ==================== CheckDeployedModuleReadiness Tests ====================
 
 func TestCheckDeployedModuleReadiness_NoModules(t *testing.T) {
 	// Arrange
 	run, _, _, _, _, _ := newTestRun(action.DeployModules)
-	modules := map[string]int{}
+	modules := map[string]models.BackendModule{}
 
 	// Act
 	err := run.CheckDeployedModuleReadiness("backend", modules)
@@ -481,10 +609,11 @@ func TestCheckDeployedModuleReadiness_NoModules(t *testing.T) {
 
 func TestCheckDeployedModuleReadiness_WithModules(t *testing.T) {
 	// Arrange
+	t.Setenv("HOME", t.TempDir())
 	run, _, _, _, _, mockModule := newTestRun(action.DeployModules)
-	modules := map[string]int{
-		"mod-test-1": 8081,
-		"mod-test-2": 8082,
+	modules := map[string]models.BackendModule{
+		"mod-test-1": {ModuleExposedServerPort: 8081},
+		"mod-test-2": {ModuleExposedServerPort: 8082},
 	}
 
 	// CheckModuleReadiness is called once per module in a goroutine with WaitGroup
@@ -498,3 +627,1740 @@ func TestCheckDeployedModuleReadiness_WithModules(t *testing.T) {
 	assert.NoError(t, err)
 	mockModule.AssertExpectations(t)
 }
+
+func TestCheckDeployedModuleReadiness_UsesHealthPort(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, mockModule := newTestRun(action.DeployModules)
+	modules := map[string]models.BackendModule{
+		"mod-test-1": {ModuleExposedServerPort: 8081, HealthPort: 9091},
+	}
+
+	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, "mod-test-1", 9091).Return()
+
+	// Act
+	err := run.CheckDeployedModuleReadiness("backend", modules)
+
+	// Assert
+	assert.NoError(t, err)
+	mockModule.AssertExpectations(t)
+}
+
+func TestCheckDeployedModuleReadiness_ReportsAllUnhealthyModules(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, mockModule := newTestRun(action.DeployModules)
+	modules := map[string]models.BackendModule{
+		"mod-test-1": {ModuleExposedServerPort: 8081},
+		"mod-test-2": {ModuleExposedServerPort: 8082},
+		"mod-test-3": {ModuleExposedServerPort: 8083},
+	}
+
+	notReady := func(moduleName string) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			args.Get(1).(chan<- error) <- apperrors.ModuleNotReady(moduleName)
+		}
+	}
+	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, "mod-test-1", 8081).Run(notReady("mod-test-1")).Return()
+	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, "mod-test-2", 8082).Return()
+	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, "mod-test-3", 8083).Run(notReady("mod-test-3")).Return()
+
+	// Act
+	err := run.CheckDeployedModuleReadiness("backend", modules)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotReady)
+	assert.Contains(t, err.Error(), "2 of 3 modules")
+	assert.Contains(t, err.Error(), "mod-test-1")
+	assert.Contains(t, err.Error(), "mod-test-3")
+	assert.NotContains(t, err.Error(), "mod-test-2")
+	mockModule.AssertExpectations(t)
+}
+
+func TestCheckDeployedModuleReadiness_BoundedByHealthcheckConcurrency(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, mockModule := newTestRun(action.DeployModules)
+	run.Config.Action.Param.HealthcheckConcurrency = 2
+	modules := map[string]models.BackendModule{"mod-test-1": {ModuleExposedServerPort: 8081}, "mod-test-2": {ModuleExposedServerPort: 8082}, "mod-test-3": {ModuleExposedServerPort: 8083}, "mod-test-4": {ModuleExposedServerPort: 8084}, "mod-test-5": {ModuleExposedServerPort: 8085}}
+
+	var mu sync.Mutex
+	var running, maxRunning int
+	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}).Return()
+
+	// Act
+	err := run.CheckDeployedModuleReadiness("backend", modules)
+
+	// Assert
+	assert.NoError(t, err)
+	mockModule.AssertNumberOfCalls(t, "CheckModuleReadiness", 5)
+	assert.Equal(t, 2, maxRunning)
+}
+
+func TestCheckDeployedModuleReadiness_StartupTimesExcludeQueueing(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, mockModule := newTestRun(action.DeployModules)
+	run.Config.Action.Param.HealthcheckConcurrency = 1
+	modules := map[string]models.BackendModule{"mod-test-1": {ModuleExposedServerPort: 8081}, "mod-test-2": {ModuleExposedServerPort: 8082}, "mod-test-3": {ModuleExposedServerPort: 8083}}
+
+	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { time.Sleep(20 * time.Millisecond) }).Return()
+
+	// Act
+	err := run.CheckDeployedModuleReadiness(constant.Module, modules)
+
+	// Assert
+	assert.NoError(t, err)
+	startupTimes, err := loadModuleStartupTimes()
+	assert.NoError(t, err)
+	assert.Len(t, startupTimes.Modules, 3)
+	for _, startupTime := range startupTimes.Modules {
+		assert.Less(t, startupTime.DurationMs, int64(40), startupTime.Module)
+	}
+}
+
+func TestCheckDeployedModuleReadiness_RecordsStartupTimes(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, mockModule := newTestRun(action.DeployModules)
+	modules := map[string]models.BackendModule{"mod-test-1": {ModuleExposedServerPort: 8081}, "mod-test-2": {ModuleExposedServerPort: 8082}}
+
+	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, "mod-test-1", 8081).
+		Run(func(args mock.Arguments) { time.Sleep(20 * time.Millisecond) }).Return()
+	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, "mod-test-2", 8082).Return()
+	var output bytes.Buffer
+
+	// Act
+	err := run.CheckDeployedModuleReadiness(constant.Module, modules)
+	reportErr := run.StartupReport(&output)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, reportErr)
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if !assert.Len(t, lines, 3) {
+		return
+	}
+	assert.Contains(t, lines[0], "MODULE")
+	assert.Contains(t, lines[1], "mod-test-1")
+	assert.Contains(t, lines[2], "mod-test-2")
+}
+
+// ==================== StartupReport Tests ====================
+
+func TestStartupReport_JSONWithLimit(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, _ := newTestRun(action.StartupReport)
+	run.Config.Action.Param.JSON = true
+	run.Config.Action.Param.Limit = 1
+	err := saveModuleStartupTimes([]models.ModuleStartupTime{
+		{Module: "mod-users", Type: constant.Module, Healthy: true, DurationMs: 1000},
+		{Module: "mod-orders", Type: constant.Module, Healthy: true, DurationMs: 4000},
+	})
+	assert.NoError(t, err)
+	var output bytes.Buffer
+
+	// Act
+	err = run.StartupReport(&output)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded []models.ModuleStartupTime
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	if !assert.Len(t, decoded, 1) {
+		return
+	}
+	assert.Equal(t, "mod-orders", decoded[0].Module)
+}
+
+func TestStartupReport_NoStartupTimes(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, _ := newTestRun(action.StartupReport)
+
+	// Act
+	err := run.StartupReport(&bytes.Buffer{})
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+// ==================== CacheDescriptors Tests ====================
+
+func newCacheDescriptorsTestRun() *Run {
+	run, _, _, _, _, _ := newTestRun(action.CacheDescriptors)
+	mockModuleProps := &MockModuleProps{}
+	mockRegistrySvc := &MockRegistrySvc{}
+	run.Config.ModuleProps = mockModuleProps
+	run.Config.RegistrySvc = mockRegistrySvc
+
+	pinnedVersion := "2.0.0"
+	mockModuleProps.On("ReadBackendModules", false, false).Return(map[string]models.BackendModule{
+		"mod-orders":   {DeployModule: true},
+		"mod-users":    {DeployModule: true, ModuleVersion: &pinnedVersion},
+		"mod-local":    {DeployModule: true, LocalDescriptorPath: "/tmp/mod-local.json"},
+		"mod-disabled": {DeployModule: false},
+		"mgr-tenants":  {DeployModule: true},
+	}, nil)
+	mockModuleProps.On("ReadFrontendModules", false).Return(map[string]models.FrontendModule{}, nil)
+	mockRegistrySvc.On("GetModules", false, false).Return(&models.ProxyModulesByRegistry{
+		FolioModules: []*models.ProxyModule{
+			{ID: "mod-orders-1.0.0", Metadata: models.ProxyModuleMetadata{Name: "mod-orders"}},
+			{ID: "mod-users-1.5.0", Metadata: models.ProxyModuleMetadata{Name: "mod-users"}},
+			{ID: "mod-local-1.0.0", Metadata: models.ProxyModuleMetadata{Name: "mod-local"}},
+			{ID: "mod-disabled-1.0.0", Metadata: models.ProxyModuleMetadata{Name: "mod-disabled"}},
+		},
+		EurekaModules: []*models.ProxyModule{
+			{ID: "mgr-tenants-1.0.0", Metadata: models.ProxyModuleMetadata{Name: "mgr-tenants"}},
+		},
+	}, nil)
+	mockRegistrySvc.On("ResolveModuleMetadata", mock.Anything).Return()
+
+	return run
+}
+
+func TestCacheDescriptors_WritesConfiguredModules(t *testing.T) {
+	// Arrange
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	run := newCacheDescriptorsTestRun()
+	mockHTTP := run.Config.HTTPClient.(*testhelpers.MockHTTPClient)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*any)
+			*target = map[string]any{"id": filepath.Base(args.String(0))}
+		}).
+		Return(nil)
+
+	// Act
+	err := run.CacheDescriptors("")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertNumberOfCalls(t, "GetRetryReturnStruct", 2)
+	cachePaths, _ := filepath.Glob(filepath.Join(homeDir, constant.ConfigDir, constant.DockerComposeWorkDir, constant.DescriptorCacheDir, "*.json"))
+	if !assert.Len(t, cachePaths, 2) {
+		return
+	}
+	assert.Equal(t, "mod-orders-1.0.0.json", filepath.Base(cachePaths[0]))
+	assert.Equal(t, "mod-users-2.0.0.json", filepath.Base(cachePaths[1]))
+}
+
+func TestCacheDescriptors_SingleModule(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run := newCacheDescriptorsTestRun()
+	mockHTTP := run.Config.HTTPClient.(*testhelpers.MockHTTPClient)
+	mockHTTP.On("GetRetryReturnStruct", run.Config.Action.GetModuleURL("mod-orders-1.0.0"), mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	err := run.CacheDescriptors("mod-orders:1.0.0")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCacheDescriptors_UnknownModule(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run := newCacheDescriptorsTestRun()
+
+	// Act
+	err := run.CacheDescriptors("mod-unknown-1.0.0")
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestInvalidateDescriptorCache(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, _, _, _, _, _ := newTestRun(action.CacheDescriptors)
+	for _, moduleID := range []string{"mod-orders-1.0.0", "mod-users-2.0.0"} {
+		cachePath, err := helpers.GetDescriptorCacheFilePath(moduleID)
+		assert.NoError(t, err)
+		assert.NoError(t, helpers.WriteJSONToFile(cachePath, map[string]any{"id": moduleID}))
+	}
+	cacheDir, _ := helpers.GetDescriptorCacheDirPath()
+
+	// Act
+	errSingle := run.InvalidateDescriptorCache("mod-orders:1.0.0")
+	remaining, _ := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	errAll := run.InvalidateDescriptorCache("")
+	remainingAfterAll, _ := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+
+	// Assert
+	assert.NoError(t, errSingle)
+	assert.NoError(t, errAll)
+	assert.Equal(t, []string{filepath.Join(cacheDir, "mod-users-2.0.0.json")}, remaining)
+	assert.Empty(t, remainingAfterAll)
+}
+
+func TestInvalidateDescriptorCache_RejectsInvalidModuleID(t *testing.T) {
+	for _, moduleID := range []string{"../../config-1.0.0", "mod-orders", "mod-orders/x-1.0.0"} {
+		t.Run(moduleID, func(t *testing.T) {
+			// Arrange
+			t.Setenv("HOME", t.TempDir())
+			run, _, _, _, _, _ := newTestRun(action.CacheDescriptors)
+
+			// Act
+			err := run.InvalidateDescriptorCache(moduleID)
+
+			// Assert
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		})
+	}
+}
+
+func TestDeployModules_ClearCacheRemovesCachedDescriptors(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, mockManagement, _, _, _, _ := newTestRun(action.DeployModules)
+	run.Config.Action.Param.ClearCache = true
+	run.Config.Action.Param.DryRun = true
+	mockModuleProps := &MockModuleProps{}
+	mockRegistrySvc := &MockRegistrySvc{}
+	run.Config.ModuleProps = mockModuleProps
+	run.Config.RegistrySvc = mockRegistrySvc
+	cachePath, err := helpers.GetDescriptorCacheFilePath("mod-orders-1.0.0")
+	assert.NoError(t, err)
+	assert.NoError(t, helpers.WriteJSONToFile(cachePath, map[string]any{"id": "mod-orders-1.0.0"}))
+
+	mockModuleProps.On("ReadBackendModules", false, true).Return(map[string]models.BackendModule{}, nil)
+	mockModuleProps.On("ReadFrontendModules", true).Return(map[string]models.FrontendModule{}, nil)
+	mockRegistrySvc.On("GetModules", true, true).Return(&models.ProxyModulesByRegistry{}, nil)
+	mockRegistrySvc.On("ResolveModuleMetadata", mock.Anything).Return()
+	mockManagement.On("CreateApplication", mock.Anything).Return(nil)
+
+	// Act
+	err = run.DeployModules()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoFileExists(t, cachePath)
+}
+
+// ==================== DeployPlan Tests ====================
+
+func TestDeployPlan_PrintsLayers(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.DeployPlan)
+	run.Config.Action.ConfigApplicationID = "app-test-1.0.0"
+	mockModuleProps := &MockModuleProps{}
+	mockRegistrySvc := &MockRegistrySvc{}
+	run.Config.ModuleProps = mockModuleProps
+	run.Config.RegistrySvc = mockRegistrySvc
+	mockModuleProps.On("ReadBackendModules", false, true).Return(map[string]models.BackendModule{}, nil)
+	mockModuleProps.On("ReadFrontendModules", true).Return(map[string]models.FrontendModule{}, nil)
+	mockRegistrySvc.On("GetModules", true, true).Return(&models.ProxyModulesByRegistry{}, nil)
+	mockRegistrySvc.On("ResolveModuleMetadata", mock.Anything).Return()
+	mockManagement.On("GetRegistryDependencies", mock.Anything).Return(newTestDependencyGraph(), nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.DeployPlan("", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	mockKeycloak.AssertNotCalled(t, "GetMasterAccessToken", mock.Anything)
+	mockManagement.AssertNotCalled(t, "GetApplicationDependencies", mock.Anything)
+	expected := "app-test-1.0.0\n" +
+		"Layer 1:\n" +
+		"  mod-users-19.0.0\n" +
+		"Layer 2:\n" +
+		"  mod-orders-13.0.0\n"
+	assert.Equal(t, expected, buf.String())
+	mockManagement.AssertExpectations(t)
+}
+
+func TestDeployPlan_ReportsCycle(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.DeployPlan)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationDependencies", "app-test-1.0.0").Return(&models.ApplicationDependencyGraph{
+		ApplicationID: "app-test-1.0.0",
+		Modules: []models.ModuleDependencies{
+			{ModuleID: "mod-a-1.0.0", Requires: []models.InterfaceDependency{{InterfaceID: "b", ProviderIDs: []string{"mod-b-1.0.0"}}}},
+			{ModuleID: "mod-b-1.0.0", Requires: []models.InterfaceDependency{{InterfaceID: "a", ProviderIDs: []string{"mod-a-1.0.0"}}}},
+			{ModuleID: "mod-c-1.0.0"},
+		},
+	}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.DeployPlan("app-test-1.0.0", &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "mod-a-1.0.0, mod-b-1.0.0")
+	expected := "app-test-1.0.0\n" +
+		"Layer 1:\n" +
+		"  mod-c-1.0.0\n" +
+		"Unresolved due to a dependency cycle:\n" +
+		"  mod-a-1.0.0\n" +
+		"  mod-b-1.0.0\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+// ==================== Bench Tests ====================
+
+func TestBench_ReportsThroughputAndStatusCodes(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.Bench)
+	t.Setenv("BENCH_ACCESS_TOKEN", "tenant-token")
+	run.Config.Action.Param.AccessTokenEnv = "BENCH_ACCESS_TOKEN"
+	run.Config.Action.Param.JSON = true
+	run.Config.Action.Param.StatusCodes = true
+	mockHTTP := run.Config.HTTPClient.(*testhelpers.MockHTTPClient)
+	requestURL := run.Config.Action.GetRequestURL(run.Config.Action.GetGatewayPort(), "/users")
+	mockHTTP.On("GetReturnRawBytes", requestURL, mock.MatchedBy(func(headers map[string]string) bool {
+		return headers[constant.OkapiTenantHeader] == "test-tenant" && headers[constant.OkapiTokenHeader] == "tenant-token"
+	})).Return([]byte("{}"), nil).Once()
+	mockHTTP.On("GetReturnRawBytes", requestURL, mock.Anything).Return(nil, apperrors.RequestFailed(503, "GET", requestURL))
+	var buf bytes.Buffer
+
+	// Act
+	err := run.Bench("users", "test-tenant", 2, 50*time.Millisecond, &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	var summary models.BenchSummary
+	if !assert.NoError(t, json.Unmarshal(buf.Bytes(), &summary)) {
+		return
+	}
+	assert.Equal(t, "/users", summary.Endpoint)
+	assert.Equal(t, 2, summary.Concurrency)
+	assert.Greater(t, summary.Requests, 1)
+	assert.Equal(t, summary.Requests-1, summary.Errors)
+	assert.Equal(t, map[string]int{"2xx": 1, "503": summary.Requests - 1}, summary.StatusCodes)
+	assert.Greater(t, summary.Throughput, 0.0)
+}
+
+func TestBench_RejectsInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpoint    string
+		concurrency int
+		duration    time.Duration
+	}{
+		{name: "missing endpoint", endpoint: "", concurrency: 1, duration: time.Second},
+		{name: "non-positive concurrency", endpoint: "/users", concurrency: 0, duration: time.Second},
+		{name: "non-positive duration", endpoint: "/users", concurrency: 1, duration: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			run, _, _, _, _, _ := newTestRun(action.Bench)
+			var buf bytes.Buffer
+
+			// Act
+			err := run.Bench(tt.endpoint, "test-tenant", tt.concurrency, tt.duration, &buf)
+
+			// Assert
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+			assert.Empty(t, buf.String())
+		})
+	}
+}
+
+func TestSummarizeBench_ComputesPercentiles(t *testing.T) {
+	// Arrange
+	var samples []benchSample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, benchSample{latency: time.Duration(i) * time.Millisecond, status: "2xx"})
+	}
+	samples = append(samples, benchSample{latency: 500 * time.Millisecond, status: "error"})
+
+	// Act
+	summary := summarizeBench(samples, 2*time.Second)
+
+	// Assert
+	assert.Equal(t, 101, summary.Requests)
+	assert.Equal(t, 1, summary.Errors)
+	assert.InDelta(t, 50.5, summary.Throughput, 0.001)
+	assert.Equal(t, models.BenchLatency{P50: 51, P90: 91, P99: 100, Max: 500}, summary.Latency)
+	assert.Equal(t, map[string]int{"2xx": 100, "error": 1}, summary.StatusCodes)
+}
+
+// ==================== VerifyLogins Tests ====================
+
+func newVerifyLoginsRun(t *testing.T) (*Run, *MockKeycloakSvc) {
+	t.Helper()
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.VerifyLogins)
+	run.Config.Action.ConfigTenants = map[string]any{"diku": map[string]any{}, "university": map[string]any{}}
+	run.Config.Action.ConfigUsers = map[string]any{
+		"diku_admin": map[string]any{field.UsersTenantEntry: "diku", field.UsersPasswordEntry: "admin"},
+		"diku_user":  map[string]any{field.UsersTenantEntry: "diku", field.UsersPasswordEntry: "secret"},
+		"univ_admin": map[string]any{field.UsersTenantEntry: "university", field.UsersPasswordEntry: "admin"},
+	}
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("root-token", nil)
+
+	return run, mockKeycloak
+}
+
+func TestVerifyLogins_ReportsPassAndFail(t *testing.T) {
+	// Arrange
+	run, mockKeycloak := newVerifyLoginsRun(t)
+	mockKeycloak.On("VerifyUserLogin", "diku", "diku_admin", "admin").Return(nil)
+	mockKeycloak.On("VerifyUserLogin", "diku", "diku_user", "secret").Return(assert.AnError)
+	mockKeycloak.On("VerifyUserLogin", "university", "univ_admin", "admin").Return(nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.VerifyLogins("", &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	assert.Contains(t, err.Error(), "diku_user")
+	output := buf.String()
+	assert.Regexp(t, `diku_admin\s+diku\s+PASS`, output)
+	assert.Regexp(t, `diku_user\s+diku\s+FAIL`, output)
+	assert.Regexp(t, `univ_admin\s+university\s+PASS`, output)
+	assert.NotContains(t, output, "secret")
+	assert.Equal(t, "root-token", run.Config.Action.VaultRootToken)
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestVerifyLogins_FiltersByTenantAsJSON(t *testing.T) {
+	// Arrange
+	run, mockKeycloak := newVerifyLoginsRun(t)
+	run.Config.Action.Param.JSON = true
+	mockKeycloak.On("VerifyUserLogin", "university", "univ_admin", "admin").Return(nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.VerifyLogins("university", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	var results []models.UserLoginResult
+	if !assert.NoError(t, json.Unmarshal(buf.Bytes(), &results)) {
+		return
+	}
+	assert.Equal(t, []models.UserLoginResult{{Username: "univ_admin", Tenant: "university", Success: true}}, results)
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestVerifyLogins_UnknownTenant(t *testing.T) {
+	// Arrange
+	run, mockKeycloak := newVerifyLoginsRun(t)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.VerifyLogins("missing", &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	mockKeycloak.AssertNotCalled(t, "VerifyUserLogin", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ==================== KafkaStatus Tests ====================
+
+func TestKafkaStatus_DefaultsToCapabilityConsumerGroup(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.KafkaStatus)
+	mockKafka := &MockKafkaSvc{}
+	run.Config.KafkaSvc = mockKafka
+	lag, offset, end := int64(2), int64(10), int64(12)
+	mockKafka.On("GetConsumerGroup").Return("folio-capability-group")
+	mockKafka.On("DescribeConsumerGroup", "folio-capability-group", false).Return([]models.ConsumerGroupPartition{
+		{Topic: "folio.diku.capability", Partition: 0, CurrentOffset: &offset, LogEndOffset: &end, Lag: &lag, ConsumerID: "consumer-1"},
+		{Topic: "folio.diku.capability", Partition: 1},
+	}, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.KafkaStatus("", false, &output)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"TOPIC", "PARTITION", "CURRENT-OFFSET", "LOG-END-OFFSET", "LAG", "MEMBER"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"folio.diku.capability", "0", "10", "12", "2", "consumer-1"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"folio.diku.capability", "1", "-", "-", "-", "-"}, strings.Fields(lines[2]))
+	mockKafka.AssertExpectations(t)
+}
+
+func TestKafkaStatus_NativeGroupJSON(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.KafkaStatus)
+	run.Config.Action.Param.JSON = true
+	mockKafka := &MockKafkaSvc{}
+	run.Config.KafkaSvc = mockKafka
+	mockKafka.On("DescribeConsumerGroup", "custom-group", true).Return([]models.ConsumerGroupPartition(nil), nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.KafkaStatus("custom-group", true, &output)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, "[]", output.String())
+	mockKafka.AssertNotCalled(t, "GetConsumerGroup")
+}
+
+func TestKafkaStatus_DescribeFails(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.KafkaStatus)
+	mockKafka := &MockKafkaSvc{}
+	run.Config.KafkaSvc = mockKafka
+	mockKafka.On("DescribeConsumerGroup", "custom-group", false).Return(nil, assert.AnError)
+
+	// Act
+	err := run.KafkaStatus("custom-group", false, &bytes.Buffer{})
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+// ==================== UnusedCapabilitySets Tests ====================
+
+func TestUnusedCapabilitySets_PrintsNamesAndCounts(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.UnusedCapabilitySets)
+	report := &models.UnusedCapabilitySetsReport{
+		Tenant:   "test-tenant",
+		Total:    3,
+		Attached: 1,
+		Roles:    2,
+		CapabilitySets: []models.KeycloakCapabilitySet{
+			{ID: "set-3", Name: "inventory_item.view", ApplicationID: "app-1"},
+			{ID: "set-2", Name: "users_item.edit", ApplicationID: "app-1"},
+		},
+	}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("GetUnusedCapabilitySets", "test-tenant").Return(report, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.UnusedCapabilitySets("test-tenant", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "inventory_item.view")
+	assert.Contains(t, output.String(), "users_item.edit")
+	assert.Contains(t, output.String(), "2 of 3 capability sets are not attached to any of 2 roles (1 attached)")
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestUnusedCapabilitySets_JSONOutput(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.UnusedCapabilitySets)
+	run.Config.Action.Param.JSON = true
+	report := &models.UnusedCapabilitySetsReport{Tenant: "test-tenant", Total: 1, Attached: 1, Roles: 1, CapabilitySets: []models.KeycloakCapabilitySet{}}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("GetUnusedCapabilitySets", "test-tenant").Return(report, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.UnusedCapabilitySets("test-tenant", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded models.UnusedCapabilitySetsReport
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	assert.Equal(t, *report, decoded)
+}
+
+// ==================== CheckCompatibility Tests ====================
+
+func newTestCompatibilityGraph(dependencies []models.ApplicationDependency) *models.ApplicationDependencyGraph {
+	return &models.ApplicationDependencyGraph{
+		ApplicationID: "app-test-1.0.0",
+		Dependencies:  dependencies,
+		Modules: []models.ModuleDependencies{
+			{ModuleID: "mod-users-19.0.0", Provides: []models.ProvidedInterface{{InterfaceID: "users", Version: "16.1"}}},
+			{ModuleID: "mod-orders-13.0.0", Requires: []models.InterfaceDependency{
+				{InterfaceID: "users", Version: "17.0"},
+				{InterfaceID: "finance", Version: "2.0"},
+			}},
+		},
+	}
+}
+
+func TestCheckCompatibility_ReportsIncompatibilities(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.CheckCompatibility)
+	run.Config.Action.ConfigApplicationID = "app-test-1.0.0"
+	mockModuleProps := &MockModuleProps{}
+	mockRegistrySvc := &MockRegistrySvc{}
+	run.Config.ModuleProps = mockModuleProps
+	run.Config.RegistrySvc = mockRegistrySvc
+	mockModuleProps.On("ReadBackendModules", false, true).Return(map[string]models.BackendModule{}, nil)
+	mockModuleProps.On("ReadFrontendModules", true).Return(map[string]models.FrontendModule{}, nil)
+	mockRegistrySvc.On("GetModules", true, true).Return(&models.ProxyModulesByRegistry{}, nil)
+	mockRegistrySvc.On("ResolveModuleMetadata", mock.Anything).Return()
+	mockManagement.On("GetRegistryDependencies", mock.Anything).Return(newTestCompatibilityGraph(nil), nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CheckCompatibility("", &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "2 interface incompatibilities")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"mod-orders-13.0.0", "users", "17.0", "mod-users-19.0.0", "(16.1)", "incompatible"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"mod-orders-13.0.0", "finance", "2.0", "-", "missing"}, strings.Fields(lines[2]))
+	mockManagement.AssertExpectations(t)
+	mockManagement.AssertNotCalled(t, "GetApplicationDependencies", mock.Anything)
+	mockKeycloak.AssertNotCalled(t, "GetMasterAccessToken", mock.Anything)
+}
+
+func TestCheckCompatibility_MissingInterfacesUnresolvedWithDependencies(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.CheckCompatibility)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	graph := newTestCompatibilityGraph([]models.ApplicationDependency{{Name: "app-platform-minimal", Version: "^1.0.0"}})
+	mockManagement.On("GetApplicationDependencies", "app-test-1.0.0").Return(graph, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CheckCompatibility("app-test-1.0.0", &buf)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "1 interface incompatibilities")
+	assert.Contains(t, buf.String(), "unresolved")
+	assert.Contains(t, buf.String(), "Unresolved interfaces may be provided by the application dependencies")
+}
+
+func TestCheckCompatibility_AllCompatibleJSON(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.CheckCompatibility)
+	run.Config.Action.Param.JSON = true
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	graph := newTestCompatibilityGraph(nil)
+	graph.Modules[1].Requires = []models.InterfaceDependency{{InterfaceID: "users", Version: "16.0"}}
+	mockManagement.On("GetApplicationDependencies", "app-test-1.0.0").Return(graph, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CheckCompatibility("app-test-1.0.0", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, "[]", buf.String())
+}
+
+// ==================== EntitleAll Tests ====================
+
+func TestEntitleAll_PrintsSummaryAndFailsOnFailedTenants(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.EntitleAll)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("EntitleTenants", constant.NoneConsortium, constant.TenantType(constant.Default), "app-2.0.0", 3).Return([]models.TenantEntitlementResult{
+		{Tenant: "diku", ApplicationID: "app-2.0.0", Status: models.TenantEntitlementEntitled, FlowID: "flow-1"},
+		{Tenant: "test", ApplicationID: "app-2.0.0", Status: models.TenantEntitlementSkipped},
+		{Tenant: "test2", ApplicationID: "app-2.0.0", Status: models.TenantEntitlementFailed, Error: "boom"},
+	}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.EntitleAll("app-2.0.0", 3, &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrDeploymentFailed)
+	assert.Contains(t, err.Error(), "[test2]")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{"diku", "entitled", "flow-1"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"test", "skipped", "-"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"test2", "failed", "boom"}, strings.Fields(lines[3]))
+	assert.Equal(t, "Entitled 1, skipped 1, failed 1 of 3 tenants", lines[len(lines)-1])
+	mockManagement.AssertExpectations(t)
+}
+
+func TestEntitleAll_JSONOutput(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.EntitleAll)
+	run.Config.Action.Param.JSON = true
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	results := []models.TenantEntitlementResult{{Tenant: "diku", ApplicationID: "app-2.0.0", Status: models.TenantEntitlementEntitled, FlowID: "flow-1"}}
+	mockManagement.On("EntitleTenants", constant.NoneConsortium, constant.TenantType(constant.Default), "app-2.0.0", 1).Return(results, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.EntitleAll("app-2.0.0", 1, &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded []models.TenantEntitlementResult
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, results, decoded)
+}
+
+func TestEntitleAll_InvalidParameters(t *testing.T) {
+	// Arrange
+	run, mockManagement, _, _, _, _ := newTestRun(action.EntitleAll)
+
+	// Act
+	missingErr := run.EntitleAll("", 1, &bytes.Buffer{})
+	concurrencyErr := run.EntitleAll("app-2.0.0", 0, &bytes.Buffer{})
+
+	// Assert
+	assert.ErrorIs(t, missingErr, apperrors.ErrInvalidInput)
+	assert.ErrorIs(t, concurrencyErr, apperrors.ErrInvalidInput)
+	mockManagement.AssertNotCalled(t, "EntitleTenants", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ==================== SaveDiscovery/LoadDiscovery Tests ====================
+
+func TestSaveDiscovery_LoadDiscovery_RoundTrip(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.SaveDiscovery)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	discovery := []json.RawMessage{
+		json.RawMessage(`{"id":"mod-users-1.0.0","name":"mod-users","version":"1.0.0","location":"http://mod-users-sc.eureka:8081","metadata":{"createdBy":"admin"}}`),
+		json.RawMessage(`{"id":"mod-notes-2.0.0","name":"mod-notes","version":"2.0.0","location":"http://host.docker.internal:9130"}`),
+	}
+	compact := func(entries []json.RawMessage) []string {
+		var result []string
+		for _, entry := range entries {
+			var buf bytes.Buffer
+			_ = json.Compact(&buf, entry)
+			result = append(result, buf.String())
+		}
+		return result
+	}
+	mockManagement.On("GetAllRawModuleDiscovery").Return(discovery, nil)
+	mockManagement.On("BatchUpdateRawModuleDiscovery", mock.MatchedBy(func(loaded []json.RawMessage) bool {
+		return assert.ObjectsAreEqual(compact(discovery), compact(loaded))
+	})).Return(nil)
+	filePath := filepath.Join(t.TempDir(), "discovery.json")
+
+	// Act
+	saveErr := run.SaveDiscovery(filePath)
+	loadErr := run.LoadDiscovery(filePath)
+
+	// Assert
+	assert.NoError(t, saveErr)
+	assert.NoError(t, loadErr)
+	data, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	var saved []json.RawMessage
+	assert.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, compact(discovery), compact(saved))
+	mockManagement.AssertExpectations(t)
+}
+
+func TestSaveDiscovery_NoEntries_WritesEmptyArray(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.SaveDiscovery)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetAllRawModuleDiscovery").Return(nil, nil)
+	filePath := filepath.Join(t.TempDir(), "discovery.json")
+
+	// Act
+	err := run.SaveDiscovery(filePath)
+
+	// Assert
+	assert.NoError(t, err)
+	data, readErr := os.ReadFile(filePath)
+	assert.NoError(t, readErr)
+	assert.Equal(t, "[]\n", string(data))
+}
+
+func TestLoadDiscovery_EntryWithoutID(t *testing.T) {
+	// Arrange
+	run, mockManagement, _, _, _, _ := newTestRun(action.LoadDiscovery)
+	filePath := filepath.Join(t.TempDir(), "discovery.json")
+	assert.NoError(t, os.WriteFile(filePath, []byte(`[{"id":"mod-users-1.0.0"},{"name":"mod-notes"}]`), 0600))
+
+	// Act
+	err := run.LoadDiscovery(filePath)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "entry 1")
+	mockManagement.AssertNotCalled(t, "BatchUpdateRawModuleDiscovery", mock.Anything)
+}
+
+func TestLoadDiscovery_MissingFile(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.LoadDiscovery)
+
+	// Act
+	err := run.LoadDiscovery(filepath.Join(t.TempDir(), "missing.json"))
+
+	// Assert
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// ==================== PhaseWait Tests ====================
+
+func TestGetPhaseWait_DefaultsPerPhase(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.DeployApplication)
+
+	// Act
+	systemWait := run.getPhaseWait(constant.SystemWaitPhase)
+	modulesWait := run.getPhaseWait(constant.ModulesWaitPhase)
+
+	// Assert
+	assert.Equal(t, constant.DeploySystemWait, systemWait)
+	assert.Equal(t, constant.DeployModulesWait, modulesWait)
+}
+
+func TestGetPhaseWait_FlagAppliesToEveryPhase(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.DeployApplication)
+	run.Config.Action.Param.PhaseWait = 2 * time.Second
+
+	// Act
+	systemWait := run.getPhaseWait(constant.SystemWaitPhase)
+	partitionWait := run.getPhaseWait(constant.PartitionWaitPhase)
+
+	// Assert
+	assert.Equal(t, 2*time.Second, systemWait)
+	assert.Equal(t, 2*time.Second, partitionWait)
+}
+
+func TestGetPhaseWait_ConfigOverridesPhase(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.DeployApplication)
+	run.Config.Action.Param.PhaseWait = 2 * time.Second
+	run.Config.Action.ConfigPhaseWaits = map[string]string{constant.ManagementWaitPhase: "0s"}
+
+	// Act
+	managementWait := run.getPhaseWait(constant.ManagementWaitPhase)
+	modulesWait := run.getPhaseWait(constant.ModulesWaitPhase)
+
+	// Assert
+	assert.Equal(t, time.Duration(0), managementWait)
+	assert.Equal(t, 2*time.Second, modulesWait)
+}
+
+// ==================== CheckMgrServices Tests ====================
+
+func newCheckMgrServicesTestRun() (*Run, *testhelpers.MockHTTPClient) {
+	run, _, mockKeycloak, _, _, _ := newTestRun(action.CheckMgrServices)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	run.Config.HTTPClient = mockHTTP
+	run.Config.Action.GatewayURLTemplate = "http://localhost:%s"
+	run.Config.Action.ConfigBackendModules = map[string]any{
+		"mgr-applications": map[string]any{"port": 9901},
+		"mgr-tenants":      map[string]any{"port": 9902},
+		"mod-users":        nil,
+	}
+
+	return run, mockHTTP
+}
+
+func TestCheckMgrServices_AllPathsWork(t *testing.T) {
+	// Arrange
+	run, mockHTTP := newCheckMgrServicesTestRun()
+	mockHTTP.On("Ping", mock.Anything).Return(200, nil)
+	mockHTTP.On("GetReturnRawBytes", mock.Anything, mock.Anything).Return([]byte(`{}`), nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CheckMgrServices(&buf)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"mgr-applications", "HTTP", "200", "HTTP", "200", "both",
+		"http://localhost:9901/admin/health", "http://localhost:8000/applications?limit=1"}, strings.Fields(lines[1]))
+	assert.Contains(t, lines[2], "mgr-tenants")
+	mockHTTP.AssertNumberOfCalls(t, "Ping", 2)
+}
+
+func TestCheckMgrServices_ReportsFailingPath(t *testing.T) {
+	// Arrange
+	run, mockHTTP := newCheckMgrServicesTestRun()
+	mockHTTP.On("Ping", "http://localhost:9901/admin/health").Return(200, nil)
+	mockHTTP.On("Ping", "http://localhost:9902/admin/health").Return(0, errors.New("connection refused"))
+	mockHTTP.On("GetReturnRawBytes", "http://localhost:8000/applications?limit=1", mock.Anything).
+		Return(nil, apperrors.RequestFailed(503, "GET", "http://localhost:8000/applications?limit=1"))
+	mockHTTP.On("GetReturnRawBytes", "http://localhost:8000/tenants?limit=1", mock.Anything).Return([]byte(`{}`), nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CheckMgrServices(&buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotReady)
+	assert.Contains(t, err.Error(), "[mgr-applications mgr-tenants]")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Contains(t, lines[1], "HTTP 503  direct only")
+	assert.Contains(t, lines[2], "unreachable  HTTP 200  gateway only")
+}
+
+// ==================== AttachCapabilitySetsWithReport Tests ====================
+
+func TestAttachCapabilitySetsWithReport_AggregatesTenants(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.AttachCapabilitySets)
+	mockKafkaSvc := &MockKafkaSvc{}
+	run.Config.KafkaSvc = mockKafkaSvc
+	run.Config.Action.ConfigTenants = map[string]any{"diku": nil, "test": nil}
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetTenants", mock.Anything, mock.Anything).Return([]any{
+		map[string]any{"name": "diku", "description": "nop-default"},
+		map[string]any{"name": "test", "description": "nop-default"},
+	}, nil)
+	mockKeycloak.On("UpdateRealmAccessTokenSettings", mock.Anything, mock.Anything).Return(nil)
+	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
+	mockKafkaSvc.On("PollConsumerGroup", mock.Anything).Return(nil)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "diku").Return([]models.RoleCapabilitySetsAttachResult{
+		{RoleName: "admin", Tenant: "diku", Attached: 10},
+		{RoleName: "viewer", Tenant: "diku", Attached: 2, Unresolved: []string{"missing.view"}},
+	}, nil)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "test").Return([]models.RoleCapabilitySetsAttachResult{
+		{RoleName: "admin", Tenant: "test", Attached: 5},
+	}, nil)
+	mockKeycloak.On("CountCapabilitySets", "diku").Return(500, nil)
+	mockKeycloak.On("CountCapabilitySets", "test").Return(300, nil)
+	report := &models.CapabilitySetsAttachReport{}
+
+	// Act
+	err := run.AttachCapabilitySetsWithReport(constant.NoneConsortium, constant.Default, 0, false, report)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Tenants)
+	assert.Equal(t, 3, report.Roles)
+	assert.Equal(t, 17, report.Attached)
+	assert.Equal(t, 800, report.CapabilitySets)
+	assert.Equal(t, 1, report.Unresolved)
+	assert.Len(t, report.Results, 2)
+	assert.Equal(t, map[string][]string{"viewer": {"missing.view"}}, report.Results[0].Unresolved)
+	assert.Nil(t, report.Results[1].Unresolved)
+}
+
+func TestWriteCapabilitySetsAttachReportJSON_EmptyReport(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := writeCapabilitySetsAttachReportJSON(&buf, &models.CapabilitySetsAttachReport{})
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, []any{}, decoded["results"])
+	assert.Equal(t, float64(0), decoded["tenants"])
+}
+
+// ==================== Nuke Tests ====================
+
+func TestNuke_RequiresConfirm(t *testing.T) {
+	// Arrange
+	run, mockManagement, _, _, mockDocker, _ := newTestRun(action.Nuke)
+	run.Config.Action.ConfigProfileName = "combined"
+	var buf bytes.Buffer
+
+	// Act
+	err := run.Nuke(strings.NewReader("combined\n"), &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "--confirm")
+	mockDocker.AssertNotCalled(t, "Create")
+	mockManagement.AssertNotCalled(t, "RemoveApplications", mock.Anything, mock.Anything)
+}
+
+func TestNuke_ConfirmationMismatch(t *testing.T) {
+	// Arrange
+	run, mockManagement, _, _, mockDocker, _ := newTestRun(action.Nuke)
+	run.Config.Action.Param.Confirm = true
+	run.Config.Action.ConfigProfileName = "combined"
+	var buf bytes.Buffer
+
+	// Act
+	err := run.Nuke(strings.NewReader("import\n"), &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), `"combined"`)
+	mockDocker.AssertNotCalled(t, "Create")
+	mockManagement.AssertNotCalled(t, "RemoveApplications", mock.Anything, mock.Anything)
+}
+
+func TestNuke_RemovesInDependencyOrder(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.Nuke)
+	run.Config.Action.Param.Confirm = true
+	run.Config.Action.ConfigProfileName = "combined"
+	run.Config.Action.ConfigApplicationName = "app-combined"
+	var buf bytes.Buffer
+	var order []string
+	record := func(step string) func(mock.Arguments) {
+		return func(mock.Arguments) { order = append(order, step) }
+	}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
+		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
+	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(2, nil).Run(record("detach"))
+	mockKeycloak.On("RemoveUsers", "test-tenant").Return(3, nil).Run(record("users"))
+	mockKeycloak.On("RemoveRoles", "test-tenant").Return(4, nil).Run(record("roles"))
+	mockManagement.On("RemoveTenantEntitlements", mock.Anything, mock.Anything, false, mock.Anything).Return(1, nil).Run(record("entitlements"))
+	mockManagement.On("RemoveTenants", mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.TenantRemovalResult{{Tenant: "test-tenant", Status: models.TenantRemovalRemoved}}, nil).Run(record("tenants"))
+	mockManagement.On("RemoveApplications", "app-combined", "").Return(5, nil).Run(record("applications"))
+
+	// Act
+	err := run.Nuke(strings.NewReader("combined\n"), &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"detach", "users", "roles", "entitlements", "tenants", "applications"}, order)
+	assert.Contains(t, buf.String(), "REMOVED")
+	for step, removed := range map[string]string{
+		"detach capability sets":     "2",
+		"remove users":               "3",
+		"remove roles":               "4",
+		"remove tenant entitlements": "1",
+		"remove tenants":             "1",
+		"remove applications":        "5",
+	} {
+		assert.Regexp(t, step+`\s+\S+\s+removed\s+`+removed+`\s`, buf.String())
+	}
+	assert.Contains(t, buf.String(), "app-combined")
+	mockKeycloak.AssertExpectations(t)
+	mockManagement.AssertExpectations(t)
+}
+
+func TestNuke_StopsAtFirstFailure(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.Nuke)
+	run.Config.Action.Param.Confirm = true
+	run.Config.Action.ConfigProfileName = "combined"
+	var buf bytes.Buffer
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
+		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
+	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(0, nil)
+	mockKeycloak.On("RemoveUsers", "test-tenant").Return(0, assert.AnError)
+
+	// Act
+	err := run.Nuke(strings.NewReader("combined\n"), &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, buf.String(), "failed")
+	assert.Equal(t, 4, strings.Count(buf.String(), "skipped"))
+	mockKeycloak.AssertNotCalled(t, "RemoveRoles", mock.Anything)
+	mockManagement.AssertNotCalled(t, "RemoveTenants", mock.Anything, mock.Anything, mock.Anything)
+	mockManagement.AssertNotCalled(t, "RemoveApplications", mock.Anything, mock.Anything)
+}
+
+func TestNuke_DetachFailureStopsRemoval(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.Nuke)
+	run.Config.Action.Param.Confirm = true
+	run.Config.Action.ConfigProfileName = "combined"
+	var buf bytes.Buffer
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
+		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
+	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(1, assert.AnError)
+
+	// Act
+	err := run.Nuke(strings.NewReader("combined\n"), &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Regexp(t, `detach capability sets\s+\S+\s+failed\s+1\s`, buf.String())
+	assert.Equal(t, 5, strings.Count(buf.String(), "skipped"))
+	mockKeycloak.AssertNotCalled(t, "RemoveUsers", mock.Anything)
+}
+
+// ==================== RewriteDiscovery Tests ====================
+
+func TestRewriteDiscovery_RewritesMatchingSuffix(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RewriteDiscovery)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetAllModuleDiscovery").Return([]models.ModuleDiscovery{
+		{ID: "mod-users-1.0.0", Name: "mod-users", Version: "1.0.0", Location: "http://mod-users-sc.eureka:8081"},
+		{ID: "mod-notes-2.0.0", Name: "mod-notes", Version: "2.0.0", Location: "http://host.docker.internal:9130"},
+	}, nil)
+	mockManagement.On("BatchUpdateModuleDiscovery", []models.ModuleDiscovery{
+		{ID: "mod-users-1.0.0", Name: "mod-users", Version: "1.0.0", Location: "http://mod-users-sc.newnet:8081"},
+	}).Return(nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.RewriteDiscovery(&buf, "eureka", ".newnet")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "http://mod-users-sc.newnet:8081")
+	assert.NotContains(t, buf.String(), "mod-notes-2.0.0")
+	mockManagement.AssertExpectations(t)
+}
+
+func TestRewriteDiscovery_DryRun(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RewriteDiscovery)
+	run.Config.Action.Param.DryRun = true
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetAllModuleDiscovery").Return([]models.ModuleDiscovery{
+		{ID: "mod-users-1.0.0", Location: "http://mod-users-sc.eureka:8081"},
+	}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.RewriteDiscovery(&buf, "eureka", "newnet")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "http://mod-users-sc.eureka:8081")
+	assert.Contains(t, buf.String(), "http://mod-users-sc.newnet:8081")
+	mockManagement.AssertNotCalled(t, "BatchUpdateModuleDiscovery", mock.Anything)
+}
+
+func TestRewriteDiscovery_InvalidSuffixes(t *testing.T) {
+	for _, suffixes := range [][2]string{{"", "newnet"}, {"eureka", ""}, {"eureka", "eureka."}} {
+		// Arrange
+		run, mockManagement, _, _, _, _ := newTestRun(action.RewriteDiscovery)
+
+		// Act
+		err := run.RewriteDiscovery(&bytes.Buffer{}, suffixes[0], suffixes[1])
+
+		// Assert
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		mockManagement.AssertNotCalled(t, "GetAllModuleDiscovery")
+	}
+}
+
+func TestRewriteDiscoveryLocation(t *testing.T) {
+	tests := []struct {
+		location string
+		expected string
+		ok       bool
+	}{
+		{"http://mod-users-sc.eureka:8081", "http://mod-users-sc.newnet:8081", true},
+		{"http://mod-users-sc.eureka/path", "http://mod-users-sc.newnet/path", true},
+		{"http://mod-users-sc.myeureka:8081", "http://mod-users-sc.myeureka:8081", false},
+		{"http://eureka:8081", "http://eureka:8081", false},
+		{"not a url", "not a url", false},
+	}
+	for _, tt := range tests {
+		// Act
+		location, ok := rewriteDiscoveryLocation(tt.location, "eureka", "newnet")
+
+		// Assert
+		assert.Equal(t, tt.expected, location, tt.location)
+		assert.Equal(t, tt.ok, ok, tt.location)
+	}
+}
+
+// ==================== ListModuleDiscovery Tests ====================
+
+func TestListModuleDiscovery_PrintsSortedTable(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.ListModuleDiscovery)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetAllModuleDiscovery").Return([]models.ModuleDiscovery{
+		{ID: "mod-users-19.0.0", Name: "mod-users", Version: "19.0.0", Location: "http://mod-users-sc.eureka:8081"},
+		{ID: "mod-orders-13.0.0", Name: "mod-orders", Version: "13.0.0", Location: "http://mod-orders-sc.eureka:8081"},
+	}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.ListModuleDiscovery("", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"MODULE", "NAME", "VERSION", "LOCATION"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"mod-orders-13.0.0", "mod-orders", "13.0.0", "http://mod-orders-sc.eureka:8081"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"mod-users-19.0.0", "mod-users", "19.0.0", "http://mod-users-sc.eureka:8081"}, strings.Fields(lines[2]))
+	mockManagement.AssertNotCalled(t, "GetTenantEntitlements", mock.Anything, mock.Anything)
+}
+
+func TestListModuleDiscovery_FiltersByTenantAsJSON(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.ListModuleDiscovery)
+	run.Config.Action.Param.JSON = true
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetAllModuleDiscovery").Return([]models.ModuleDiscovery{
+		{ID: "mod-users-19.0.0", Location: "http://mod-users-sc.eureka:8081"},
+		{ID: "mod-orders-13.0.0", Location: "http://mod-orders-sc.eureka:8081"},
+	}, nil)
+	mockManagement.On("GetTenantEntitlements", "diku", false).Return(models.TenantEntitlementResponse{
+		Entitlements: []models.TenantEntitlementDTO{{ApplicationID: "app-test-1.0.0", TenantID: "diku-id"}},
+	}, nil)
+	mockManagement.On("GetApplicationModules", "app-test-1.0.0").Return([]models.ApplicationModule{{ID: "mod-users-19.0.0"}}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.ListModuleDiscovery("diku", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	var discovery []models.ModuleDiscovery
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &discovery))
+	assert.Equal(t, []models.ModuleDiscovery{{ID: "mod-users-19.0.0", Location: "http://mod-users-sc.eureka:8081"}}, discovery)
+	mockManagement.AssertExpectations(t)
+}
+
+func TestListModuleDiscovery_EntitlementsFailure(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.ListModuleDiscovery)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetAllModuleDiscovery").Return([]models.ModuleDiscovery{{ID: "mod-users-19.0.0"}}, nil)
+	mockManagement.On("GetTenantEntitlements", "diku", false).Return(models.TenantEntitlementResponse{}, errors.New("gateway unavailable"))
+	var buf bytes.Buffer
+
+	// Act
+	err := run.ListModuleDiscovery("diku", &buf)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, buf.String())
+}
+
+// ==================== MissingDiscovery Tests ====================
+
+func TestMissingDiscovery_PrintsModulesWithoutDiscovery(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.MissingDiscovery)
+	run.Config.Action.ConfigApplicationID = "app-test-1.0.0"
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationModules", "app-test-1.0.0").Return([]models.ApplicationModule{
+		{ID: "mod-users-19.0.0"},
+		{ID: "mod-orders-13.0.0"},
+		{ID: "mod-finance-5.0.0"},
+	}, nil)
+	mockManagement.On("GetAllModuleDiscovery").Return([]models.ModuleDiscovery{
+		{ID: "mod-users-19.0.0", Location: "http://mod-users-sc.eureka:8081"},
+		{ID: "mod-orders-12.0.0", Location: "http://mod-orders-sc.eureka:8081"},
+	}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.MissingDiscovery("", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "mod-finance-5.0.0\nmod-orders-13.0.0\n", buf.String())
+	mockManagement.AssertExpectations(t)
+}
+
+func TestMissingDiscovery_AllRegistered(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.MissingDiscovery)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationModules", "app-test-1.0.0").Return([]models.ApplicationModule{{ID: "mod-users-19.0.0"}}, nil)
+	mockManagement.On("GetAllModuleDiscovery").Return([]models.ModuleDiscovery{{ID: "mod-users-19.0.0"}}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.MissingDiscovery("app-test-1.0.0", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestMissingDiscovery_ApplicationNotFound(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.MissingDiscovery)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationModules", "app-missing-1.0.0").Return(nil, apperrors.ApplicationIDNotFound("app-missing-1.0.0"))
+	var buf bytes.Buffer
+
+	// Act
+	err := run.MissingDiscovery("app-missing-1.0.0", &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	mockManagement.AssertNotCalled(t, "GetAllModuleDiscovery")
+}
+
+// ==================== RestoreModuleDiscovery Tests ====================
+
+func TestRestoreModuleDiscovery_RestoresApplicationModules(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RestoreModuleDiscovery)
+	run.Config.Action.ConfigApplicationID = "app-test-1.0.0"
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationModules", "app-test-1.0.0").Return([]models.ApplicationModule{
+		{ID: "mod-users-19.0.0"},
+		{ID: "mod-orders-13.0.0"},
+	}, nil)
+	mockManagement.On("RestoreModuleDiscovery", []string{"mod-users-19.0.0", "mod-orders-13.0.0"}, 8082).Return(nil)
+
+	// Act
+	err := run.RestoreModuleDiscovery("", 8082)
+
+	// Assert
+	assert.NoError(t, err)
+	mockManagement.AssertExpectations(t)
+}
+
+func TestRestoreModuleDiscovery_ApplicationNotFound(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RestoreModuleDiscovery)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationModules", "app-missing-1.0.0").Return(nil, apperrors.ApplicationIDNotFound("app-missing-1.0.0"))
+
+	// Act
+	err := run.RestoreModuleDiscovery("app-missing-1.0.0", 8081)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	mockManagement.AssertNotCalled(t, "RestoreModuleDiscovery", mock.Anything, mock.Anything)
+}
+
+// ==================== ValidateKongRoutes Tests ====================
+
+func TestValidateKongRoutes_AllRoutesMatch(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.ValidateKongRoutes)
+	mockKong := &MockKongSvc{}
+	run.Config.KongSvc = mockKong
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationModules", "app-test-1.0.0").Return([]models.ApplicationModule{{ID: "mod-users-19.0.0"}}, nil)
+	mockKong.On("ListAllServices").Return([]models.KongService{
+		{ID: "service-1", Name: "mod-users-19.0.0"},
+		{ID: "service-2", Name: "mgr-tenants"},
+	}, nil)
+	route := models.KongRoute{ID: "route-1", Name: "users-get"}
+	route.Service.ID = "service-1"
+	mgrRoute := models.KongRoute{ID: "route-2", Name: "tenants-get"}
+	mgrRoute.Service.ID = "service-2"
+	mockKong.On("ListAllRoutes").Return([]models.KongRoute{route, mgrRoute}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.ValidateKongRoutes("app-test-1.0.0", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "app-test-1.0.0: the Kong routes of all 1 modules match\n", buf.String())
+	mockKong.AssertExpectations(t)
+}
+
+func TestValidateKongRoutes_ReportsMismatchesInBothDirections(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.ValidateKongRoutes)
+	run.Config.Action.ConfigApplicationID = "app-test-1.0.0"
+	mockKong := &MockKongSvc{}
+	run.Config.KongSvc = mockKong
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationModules", "app-test-1.0.0").Return([]models.ApplicationModule{
+		{ID: "mod-users-19.0.0"},
+		{ID: "mod-orders-13.0.0"},
+	}, nil)
+	mockKong.On("ListAllServices").Return([]models.KongService{
+		{ID: "service-1", Name: "mod-users-19.0.0"},
+		{ID: "service-2", Name: "mod-orders-12.0.0"},
+	}, nil)
+	route := models.KongRoute{ID: "route-1", Name: "users-get"}
+	route.Service.ID = "service-1"
+	staleRoute := models.KongRoute{ID: "route-2", Name: "orders-get"}
+	staleRoute.Service.ID = "service-2"
+	danglingRoute := models.KongRoute{ID: "route-3"}
+	danglingRoute.Service.ID = "service-gone"
+	mockKong.On("ListAllRoutes").Return([]models.KongRoute{route, staleRoute, danglingRoute}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.ValidateKongRoutes("", &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "1 modules without Kong routes and 2 Kong routes without module")
+	output := buf.String()
+	assert.Contains(t, output, "Modules without Kong routes:\n  mod-orders-13.0.0\n")
+	lines := strings.Split(strings.TrimSpace(output[strings.Index(output, "Kong routes without module:"):]), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, []string{"route-3", "-"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"orders-get", "mod-orders-12.0.0"}, strings.Fields(lines[3]))
+}
+
+func TestValidateKongRoutes_ListServicesFailure(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.ValidateKongRoutes)
+	mockKong := &MockKongSvc{}
+	run.Config.KongSvc = mockKong
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationModules", "app-test-1.0.0").Return([]models.ApplicationModule{{ID: "mod-users-19.0.0"}}, nil)
+	mockKong.On("ListAllServices").Return(nil, errors.New("kong unavailable"))
+	var buf bytes.Buffer
+
+	// Act
+	err := run.ValidateKongRoutes("app-test-1.0.0", &buf)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, buf.String())
+	mockKong.AssertNotCalled(t, "ListAllRoutes")
+}
+
+// ==================== AddModule Tests ====================
+
+func TestAddModule_Success(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.AddModule)
+	mockModuleProps := &MockModuleProps{}
+	mockRegistrySvc := &MockRegistrySvc{}
+	mockUpgradeModuleSvc := &MockUpgradeModuleSvc{}
+	run.Config.ModuleProps = mockModuleProps
+	run.Config.RegistrySvc = mockRegistrySvc
+	run.Config.UpgradeModuleSvc = mockUpgradeModuleSvc
+	run.Config.Action.Param = &params
+	params.ModuleName = "mod-foo"
+	params.ModuleVersion = "1.2.3"
+
+	oldBackendModules := []any{map[string]any{"id": "mod-users-19.0.0", "name": "mod-users", "version": "19.0.0"}}
+	newBackendModules := []map[string]any{
+		{"id": "mod-users-19.0.0", "name": "mod-users", "version": "19.0.0"},
+		{"id": "mod-foo-1.2.3", "name": "mod-foo", "version": "1.2.3"},
+	}
+	newDiscoveryModules := []map[string]string{{"id": "mod-foo-1.2.3", "name": "mod-foo", "version": "1.2.3", "location": "http://mod-foo-sc.eureka:8081"}}
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("token", nil)
+	mockManagement.On("GetApplication", "app-1.0.0").Return(map[string]any{
+		"id":      "app-1.0.0",
+		"name":    "app",
+		"version": "1.0.0",
+		"modules": oldBackendModules,
+	}, nil)
+	mockUpgradeModuleSvc.On("AddBackendModule", "mod-foo", "1.2.3", false, oldBackendModules).Return(newBackendModules, newDiscoveryModules, nil)
+	mockUpgradeModuleSvc.On("UpdateFrontendModules", false, mock.Anything).Return(nil)
+	mockModuleProps.On("ReadBackendModules", false, false).Return(map[string]models.BackendModule{}, nil)
+	mockRegistrySvc.On("GetModules", false, false).Return(&models.ProxyModulesByRegistry{}, nil)
+	mockRegistrySvc.On("ResolveModuleMetadata", mock.Anything).Return()
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return(nil)
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockUpgradeModuleSvc.On("DeployModuleAndSidecarPair", mock.Anything, mock.MatchedBy(func(pair *modulesvc.ModulePair) bool {
+		return pair.ID == "mod-foo-1.2.3" && pair.ModuleName == "mod-foo" && pair.ModuleVersion == "1.2.3"
+	})).Return(nil)
+	mockManagement.On("CreateNewApplication", mock.MatchedBy(func(r *models.ApplicationUpgradeRequest) bool {
+		return r.NewApplicationID == "app-1.0.1" && len(r.NewBackendModules) == 2 && !r.ShouldBuild
+	})).Return(nil)
+	mockManagement.On("CreateNewModuleDiscovery", newDiscoveryModules).Return(nil)
+	mockManagement.On("UpgradeTenantEntitlement", constant.NoneConsortium, mock.Anything, "app-1.0.1").Return(nil)
+	mockManagement.On("RemoveApplications", "app", "app-1.0.1").Return(0, nil)
+
+	// Act
+	err := run.AddModule("app-1.0.0")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "mod-foo-1.2.3", params.ID)
+	mockManagement.AssertExpectations(t)
+	mockUpgradeModuleSvc.AssertExpectations(t)
+}
+
+func TestAddModule_AlreadyInApplication(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.AddModule)
+	mockUpgradeModuleSvc := &MockUpgradeModuleSvc{}
+	run.Config.UpgradeModuleSvc = mockUpgradeModuleSvc
+	params.ModuleName = "mod-users"
+	params.ModuleVersion = "19.1.0"
+
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("token", nil)
+	mockManagement.On("GetLatestApplication").Return(map[string]any{"id": "app-1.0.0", "name": "app", "version": "1.0.0"}, nil)
+	mockUpgradeModuleSvc.On("AddBackendModule", "mod-users", "19.1.0", false, mock.Anything).
+		Return(nil, nil, apperrors.ModuleAlreadyInApplication("mod-users-19.0.0"))
+
+	// Act
+	err := run.AddModule("")
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	mockUpgradeModuleSvc.AssertNotCalled(t, "DeployModuleAndSidecarPair", mock.Anything, mock.Anything)
+	mockManagement.AssertNotCalled(t, "CreateNewApplication", mock.Anything)
+}
+
+func TestAddModule_ApplicationNotFound(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.AddModule)
+	params.ModuleName = "mod-foo"
+	params.ModuleVersion = "1.2.3"
+
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("token", nil)
+	mockManagement.On("GetApplication", "app-missing-1.0.0").Return(nil, apperrors.ApplicationIDNotFound("app-missing-1.0.0"))
+
+	// Act
+	err := run.AddModule("app-missing-1.0.0")
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+// ==================== CompareApplications Tests ====================
+
+func newCompareApplicationsRun() (*Run, *MockManagementSvc) {
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.CompareApplications)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("token", nil)
+	mockManagement.On("GetApplicationDescriptor", "app-1.0.0").Return(&models.ApplicationDescriptor{
+		ID: "app-1.0.0",
+		Modules: []models.ApplicationModule{
+			{ID: "mod-users-19.0.0", Name: "mod-users", Version: "19.0.0"},
+			{ID: "mod-notes-5.0.0", Name: "mod-notes", Version: "5.0.0"},
+			{ID: "mod-orders-13.0.0", Name: "mod-orders", Version: "13.0.0"},
+		},
+		UIModules: []models.ApplicationModule{{ID: "folio_users-10.0.0", Name: "folio_users", Version: "10.0.0"}},
+	}, nil)
+	mockManagement.On("GetApplicationDescriptor", "app-2.0.0").Return(&models.ApplicationDescriptor{
+		ID: "app-2.0.0",
+		Modules: []models.ApplicationModule{
+			{ID: "mod-users-19.1.0", Name: "mod-users", Version: "19.1.0"},
+			{ID: "mod-orders-13.0.0", Name: "mod-orders", Version: "13.0.0"},
+			{ID: "mod-foo-1.2.3", Name: "mod-foo", Version: "1.2.3"},
+		},
+		UIModules: []models.ApplicationModule{{ID: "folio_users-10.1.0", Name: "folio_users", Version: "10.1.0"}},
+	}, nil)
+
+	return run, mockManagement
+}
+
+func TestCompareApplications_Text(t *testing.T) {
+	// Arrange
+	run, mockManagement := newCompareApplicationsRun()
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CompareApplications("app-1.0.0", "app-2.0.0", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "app-1.0.0 -> app-2.0.0\n"+
+		"Added:\n"+
+		"  mod-foo  1.2.3\n"+
+		"Removed:\n"+
+		"  mod-notes  5.0.0\n"+
+		"Changed:\n"+
+		"  folio_users  10.0.0 -> 10.1.0\n"+
+		"  mod-users    19.0.0 -> 19.1.0\n", buf.String())
+	mockManagement.AssertExpectations(t)
+}
+
+func TestCompareApplications_JSON(t *testing.T) {
+	// Arrange
+	run, _ := newCompareApplicationsRun()
+	run.Config.Action.Param.JSON = true
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CompareApplications("app-1.0.0", "app-2.0.0", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	var diff models.ApplicationDiff
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &diff))
+	assert.Equal(t, []models.ApplicationModule{{ID: "mod-foo-1.2.3", Name: "mod-foo", Version: "1.2.3"}}, diff.Added)
+	assert.Equal(t, []models.ApplicationModule{{ID: "mod-notes-5.0.0", Name: "mod-notes", Version: "5.0.0"}}, diff.Removed)
+	assert.Equal(t, []models.ApplicationModuleChange{
+		{Name: "folio_users", FromVersion: "10.0.0", ToVersion: "10.1.0"},
+		{Name: "mod-users", FromVersion: "19.0.0", ToVersion: "19.1.0"},
+	}, diff.Changed)
+}
+
+func TestCompareApplications_NoDifferences(t *testing.T) {
+	// Arrange
+	run, _ := newCompareApplicationsRun()
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CompareApplications("app-1.0.0", "app-1.0.0", &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "app-1.0.0 -> app-1.0.0: no module differences\n", buf.String())
+}
+
+func TestCompareApplications_ApplicationNotFound(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.CompareApplications)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("token", nil)
+	mockManagement.On("GetApplicationDescriptor", "app-missing-1.0.0").Return(nil, apperrors.ApplicationIDNotFound("app-missing-1.0.0"))
+
+	// Act
+	err := run.CompareApplications("app-missing-1.0.0", "app-2.0.0", &bytes.Buffer{})
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+// ==================== Init Tests ====================
+
+func TestWriteSampleConfig_ReadableByViper(t *testing.T) {
+	// Arrange
+	outputPath := filepath.Join(t.TempDir(), "config.sample.yaml")
+
+	// Act
+	err := WriteSampleConfig(outputPath, false)
+
+	// Assert
+	assert.NoError(t, err)
+	v := viper.New()
+	v.SetConfigFile(outputPath)
+	assert.NoError(t, v.ReadInConfig())
+	for _, key := range []string{field.ProfileName, field.ApplicationName, field.ApplicationVersion, field.Tenants, field.Users, field.Roles, field.BackendModules, field.FrontendModules} {
+		assert.True(t, v.IsSet(key), "expected key %s to be set", key)
+	}
+	role := v.GetStringMap(field.Roles)["diku_admin_role"].(map[string]any)
+	assert.Equal(t, "diku", role[field.RolesTenantEntry])
+	user := v.GetStringMap(field.Users)["diku_admin"].(map[string]any)
+	assert.Equal(t, "diku", user[field.UsersTenantEntry])
+	assert.NotEmpty(t, user[field.UsersPasswordEntry])
+}
+
+func TestWriteSampleConfig_RefusesToOverwrite(t *testing.T) {
+	// Arrange
+	outputPath := filepath.Join(t.TempDir(), "config.sample.yaml")
+	assert.NoError(t, os.WriteFile(outputPath, []byte("existing"), 0600))
+
+	// Act
+	err := WriteSampleConfig(outputPath, false)
+
+	// Assert
+	assert.Error(t, err)
+	content, _ := os.ReadFile(outputPath)
+	assert.Equal(t, "existing", string(content))
+	assert.NoError(t, WriteSampleConfig(outputPath, true))
+}
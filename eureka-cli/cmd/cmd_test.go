@@ -5,17 +5,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/gitrepository"
 	"github.com/folio-org/eureka-setup/eureka-cli/internal/testhelpers"
@@ -27,6 +32,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockManagementSvc is a mock for managementsvc.ManagementProcessor
@@ -42,14 +48,25 @@ func (m *MockManagementSvc) GetTenants(consortiumName string, tenantType constan
 	return args.Get(0).([]any), args.Error(1)
 }
 
+func (m *MockManagementSvc) GetConfigTenants(consortiumName string, tenantType constant.TenantType) []any {
+	args := m.Called(consortiumName, tenantType)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]any)
+}
+
 func (m *MockManagementSvc) CreateTenants() error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockManagementSvc) RemoveTenants(consortiumName string, tenantType constant.TenantType) error {
-	args := m.Called(consortiumName, tenantType)
-	return args.Error(0)
+func (m *MockManagementSvc) RemoveTenants(consortiumName string, tenantType constant.TenantType, purgeKafkaTopics bool) ([]models.TenantRemovalResult, error) {
+	args := m.Called(consortiumName, tenantType, purgeKafkaTopics)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TenantRemovalResult), args.Error(1)
 }
 
 func (m *MockManagementSvc) GetApplications() (models.ApplicationsResponse, error) {
@@ -68,6 +85,46 @@ func (m *MockManagementSvc) GetLatestApplication() (map[string]any, error) {
 	return args.Get(0).(map[string]any), args.Error(1)
 }
 
+func (m *MockManagementSvc) GetApplication(applicationID string) (map[string]any, error) {
+	args := m.Called(applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]any), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetApplicationDescriptor(applicationID string) (*models.ApplicationDescriptor, error) {
+	args := m.Called(applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplicationDescriptor), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetApplicationDependencies(applicationID string) (*models.ApplicationDependencyGraph, error) {
+	args := m.Called(applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplicationDependencyGraph), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetRegistryDependencies(extract *models.RegistryExtract) (*models.ApplicationDependencyGraph, error) {
+	args := m.Called(extract)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplicationDependencyGraph), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetApplicationModules(applicationID string) ([]models.ApplicationModule, error) {
+	args := m.Called(applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ApplicationModule), args.Error(1)
+}
+
 func (m *MockManagementSvc) CreateApplication(extract *models.RegistryExtract) error {
 	args := m.Called(extract)
 	return args.Error(0)
@@ -83,9 +140,13 @@ func (m *MockManagementSvc) RemoveApplication(applicationID string) error {
 	return args.Error(0)
 }
 
-func (m *MockManagementSvc) RemoveApplications(applicationName, ignoreApplicationID string) error {
+func (m *MockManagementSvc) RemoveApplications(applicationName, ignoreApplicationID string, applicationIDs ...string) (int, error) {
+	if len(applicationIDs) > 0 {
+		args := m.Called(applicationName, ignoreApplicationID, applicationIDs)
+		return args.Int(0), args.Error(1)
+	}
 	args := m.Called(applicationName, ignoreApplicationID)
-	return args.Error(0)
+	return args.Int(0), args.Error(1)
 }
 
 func (m *MockManagementSvc) GetModuleDiscovery(name string) (models.ModuleDiscoveryResponse, error) {
@@ -96,6 +157,22 @@ func (m *MockManagementSvc) GetModuleDiscovery(name string) (models.ModuleDiscov
 	return args.Get(0).(models.ModuleDiscoveryResponse), args.Error(1)
 }
 
+func (m *MockManagementSvc) GetAllModuleDiscovery() ([]models.ModuleDiscovery, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModuleDiscovery), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetAllRawModuleDiscovery() ([]json.RawMessage, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]json.RawMessage), args.Error(1)
+}
+
 func (m *MockManagementSvc) CreateNewModuleDiscovery(newDiscoveryModules []map[string]string) error {
 	args := m.Called(newDiscoveryModules)
 	return args.Error(0)
@@ -106,6 +183,21 @@ func (m *MockManagementSvc) UpdateModuleDiscovery(id string, restore bool, priva
 	return args.Error(0)
 }
 
+func (m *MockManagementSvc) BatchUpdateModuleDiscovery(discovery []models.ModuleDiscovery) error {
+	args := m.Called(discovery)
+	return args.Error(0)
+}
+
+func (m *MockManagementSvc) BatchUpdateRawModuleDiscovery(discovery []json.RawMessage) error {
+	args := m.Called(discovery)
+	return args.Error(0)
+}
+
+func (m *MockManagementSvc) RestoreModuleDiscovery(ids []string, privatePort int) error {
+	args := m.Called(ids, privatePort)
+	return args.Error(0)
+}
+
 func (m *MockManagementSvc) GetTenantEntitlements(tenantName string, includeModules bool) (models.TenantEntitlementResponse, error) {
 	args := m.Called(tenantName, includeModules)
 	if args.Get(0) == nil {
@@ -119,13 +211,26 @@ func (m *MockManagementSvc) CreateTenantEntitlement(consortiumName string, tenan
 	return args.Error(0)
 }
 
+func (m *MockManagementSvc) EntitleTenants(consortiumName string, tenantType constant.TenantType, applicationID string, concurrency int) ([]models.TenantEntitlementResult, error) {
+	args := m.Called(consortiumName, tenantType, applicationID, concurrency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TenantEntitlementResult), args.Error(1)
+}
+
 func (m *MockManagementSvc) UpgradeTenantEntitlement(consortiumName string, tenantType constant.TenantType, newApplicationID string) error {
 	args := m.Called(consortiumName, tenantType, newApplicationID)
 	return args.Error(0)
 }
 
-func (m *MockManagementSvc) RemoveTenantEntitlements(consortiumName string, tenantType constant.TenantType, purgeSchemas bool) error {
-	args := m.Called(consortiumName, tenantType, purgeSchemas)
+func (m *MockManagementSvc) RemoveTenantEntitlements(consortiumName string, tenantType constant.TenantType, purgeSchemas bool, applicationIDs ...string) (int, error) {
+	args := m.Called(consortiumName, tenantType, purgeSchemas, applicationIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockManagementSvc) WaitForTenantEntitlements(timeout time.Duration) error {
+	args := m.Called(timeout)
 	return args.Error(0)
 }
 
@@ -139,6 +244,11 @@ func (m *MockKeycloakSvc) GetAccessToken(tenantName string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockKeycloakSvc) VerifyUserLogin(tenantName string, username string, password string) error {
+	args := m.Called(tenantName, username, password)
+	return args.Error(0)
+}
+
 func (m *MockKeycloakSvc) GetMasterAccessToken(grantType constant.KeycloakGrantType) (string, error) {
 	args := m.Called(grantType)
 	return args.String(0), args.Error(1)
@@ -154,6 +264,14 @@ func (m *MockKeycloakSvc) UpdatePublicClientSettings(tenantName string, url stri
 	return args.Error(0)
 }
 
+func (m *MockKeycloakSvc) GetRealmReport(tenantName string) (*models.KeycloakRealmReport, error) {
+	args := m.Called(tenantName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KeycloakRealmReport), args.Error(1)
+}
+
 func (m *MockKeycloakSvc) GetUsers(tenantName string) ([]any, error) {
 	args := m.Called(tenantName)
 	if args.Get(0) == nil {
@@ -167,7 +285,12 @@ func (m *MockKeycloakSvc) CreateUsers(configTenant string) error {
 	return args.Error(0)
 }
 
-func (m *MockKeycloakSvc) RemoveUsers(tenantName string) error {
+func (m *MockKeycloakSvc) RemoveUsers(tenantName string) (int, error) {
+	args := m.Called(tenantName)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockKeycloakSvc) DetachAllUserRoles(tenantName string) error {
 	args := m.Called(tenantName)
 	return args.Error(0)
 }
@@ -188,30 +311,70 @@ func (m *MockKeycloakSvc) GetRoleByName(roleName string, headers map[string]stri
 	return args.Get(0).(map[string]any), args.Error(1)
 }
 
+func (m *MockKeycloakSvc) DescribeRole(tenantName string, roleName string) (*models.KeycloakRoleDetail, error) {
+	args := m.Called(tenantName, roleName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KeycloakRoleDetail), args.Error(1)
+}
+
+func (m *MockKeycloakSvc) GetRoleCapabilitySets(roleID string, headers map[string]string) ([]models.KeycloakCapabilitySet, error) {
+	args := m.Called(roleID, headers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.KeycloakCapabilitySet), args.Error(1)
+}
+
+func (m *MockKeycloakSvc) ExportRoleMappings(tenantName string) (*models.KeycloakRoleMappings, error) {
+	args := m.Called(tenantName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KeycloakRoleMappings), args.Error(1)
+}
+
 func (m *MockKeycloakSvc) CreateRoles(configTenant string) error {
 	args := m.Called(configTenant)
 	return args.Error(0)
 }
 
-func (m *MockKeycloakSvc) RemoveRoles(tenantName string) error {
+func (m *MockKeycloakSvc) RemoveRoles(tenantName string) (int, error) {
 	args := m.Called(tenantName)
-	return args.Error(0)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockKeycloakSvc) RecreateRole(tenantName string, roleName string) (*models.RoleCapabilitySetsAttachResult, error) {
+	args := m.Called(tenantName, roleName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RoleCapabilitySetsAttachResult), args.Error(1)
 }
 
-func (m *MockKeycloakSvc) GetCapabilitySets(headers map[string]string) ([]any, error) {
+func (m *MockKeycloakSvc) GetCapabilitySets(headers map[string]string) ([]models.KeycloakCapabilitySet, error) {
 	args := m.Called(headers)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]any), args.Error(1)
+	return args.Get(0).([]models.KeycloakCapabilitySet), args.Error(1)
 }
 
-func (m *MockKeycloakSvc) GetCapabilitySetsByName(headers map[string]string, capabilityName string) ([]any, error) {
+func (m *MockKeycloakSvc) GetCapabilitySetsByName(headers map[string]string, capabilityName string) ([]models.KeycloakCapabilitySet, error) {
 	args := m.Called(headers, capabilityName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]any), args.Error(1)
+	return args.Get(0).([]models.KeycloakCapabilitySet), args.Error(1)
+}
+
+func (m *MockKeycloakSvc) GetCapabilitySetsByApplication(tenantName string, applicationID string, offset int, limit int) (*models.KeycloakCapabilitySetsResponse, error) {
+	args := m.Called(tenantName, applicationID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KeycloakCapabilitySetsResponse), args.Error(1)
 }
 
 func (m *MockKeycloakSvc) HasCapabilitySets(tenantName string) (bool, error) {
@@ -234,16 +397,32 @@ func (m *MockKeycloakSvc) DetachCapabilitySets(tenantName string) error {
 	return args.Error(0)
 }
 
-func (m *MockKeycloakSvc) AttachCapabilitySetsToRoles(tenantName string) error {
+func (m *MockKeycloakSvc) AttachCapabilitySetsToRoles(tenantName string) ([]models.RoleCapabilitySetsAttachResult, error) {
 	args := m.Called(tenantName)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RoleCapabilitySetsAttachResult), args.Error(1)
 }
 
-func (m *MockKeycloakSvc) DetachCapabilitySetsFromRoles(tenantName string) error {
+func (m *MockKeycloakSvc) GetUnusedCapabilitySets(tenantName string) (*models.UnusedCapabilitySetsReport, error) {
 	args := m.Called(tenantName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UnusedCapabilitySetsReport), args.Error(1)
+}
+
+func (m *MockKeycloakSvc) DetachNamedCapabilitySetsFromRoles(tenantName string, roleFilter string, capabilitySetNames []string) error {
+	args := m.Called(tenantName, roleFilter, capabilitySetNames)
 	return args.Error(0)
 }
 
+func (m *MockKeycloakSvc) DetachCapabilitySetsFromRoles(tenantName string) (int, error) {
+	args := m.Called(tenantName)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockKeycloakSvc) UpdateKeycloakPublicClients(tenantName string) error {
 	args := m.Called(tenantName)
 	return args.Error(0)
@@ -377,12 +556,12 @@ func (m *MockModuleSvc) PullModule(cli *client.Client, imageName string) error {
 	return args.Error(0)
 }
 
-func (m *MockModuleSvc) DeployModules(cli *client.Client, containers *models.Containers, sidecarImage string, sidecarResources *container.Resources) (map[string]int, int, error) {
+func (m *MockModuleSvc) DeployModules(cli *client.Client, containers *models.Containers, sidecarImage string, sidecarResources *container.Resources) (map[string]models.BackendModule, int, error) {
 	args := m.Called(cli, containers, sidecarImage, sidecarResources)
 	if args.Get(0) == nil {
 		return nil, args.Int(1), args.Error(2)
 	}
-	return args.Get(0).(map[string]int), args.Int(1), args.Error(2)
+	return args.Get(0).(map[string]models.BackendModule), args.Int(1), args.Error(2)
 }
 
 func (m *MockModuleSvc) DeployModule(cli *client.Client, container *models.Container) error {
@@ -556,6 +735,19 @@ func (m *MockKafkaSvc) PollConsumerGroup(tenantName string) error {
 	return args.Error(0)
 }
 
+func (m *MockKafkaSvc) GetConsumerGroup() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockKafkaSvc) DescribeConsumerGroup(consumerGroup string, native bool) ([]models.ConsumerGroupPartition, error) {
+	args := m.Called(consumerGroup, native)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ConsumerGroupPartition), args.Error(1)
+}
+
 type MockModuleProps struct {
 	mock.Mock
 }
@@ -659,7 +851,7 @@ func TestRemoveTenants_Success(t *testing.T) {
 	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RemoveTenants)
 
 	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
-	mockManagement.On("RemoveTenants", mock.Anything, mock.Anything).Return(nil)
+	mockManagement.On("RemoveTenants", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	// Act
 	err := run.RemoveTenants(constant.NoneConsortium, constant.Default)
@@ -693,7 +885,7 @@ func TestRemoveTenants_RemoveTenantsError(t *testing.T) {
 
 	expectedError := assert.AnError
 	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
-	mockManagement.On("RemoveTenants", mock.Anything, mock.Anything).Return(expectedError)
+	mockManagement.On("RemoveTenants", mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedError)
 
 	// Act
 	err := run.RemoveTenants(constant.NoneConsortium, constant.Default)
@@ -705,6 +897,129 @@ func TestRemoveTenants_RemoveTenantsError(t *testing.T) {
 	mockManagement.AssertExpectations(t)
 }
 
+func TestRemoveTenants_FailedRemoval(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RemoveTenants)
+
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("RemoveTenants", mock.Anything, mock.Anything, mock.Anything).Return([]models.TenantRemovalResult{
+		{Tenant: "diku", Status: models.TenantRemovalRemoved},
+		{Tenant: "test", Status: models.TenantRemovalFailed, StatusCode: 500, Error: "boom"},
+	}, nil)
+
+	// Act
+	err := run.RemoveTenants(constant.NoneConsortium, constant.Default)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrDeploymentFailed)
+	assert.Contains(t, err.Error(), "[test]")
+}
+
+func TestRemoveAllTenants_PrintsSummaryAndFailsOnFailedTenants(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RemoveTenants)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("RemoveTenants", constant.NoneConsortium, constant.TenantType(constant.Default), false).Return([]models.TenantRemovalResult{
+		{Tenant: "diku", Status: models.TenantRemovalRemoved},
+		{Tenant: "test", Status: models.TenantRemovalFailed, StatusCode: 500, Error: "boom"},
+	}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.RemoveAllTenants(&buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrDeploymentFailed)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{"diku", "removed", "-", "-"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"test", "failed", "500", "boom"}, strings.Fields(lines[2]))
+	assert.Equal(t, "Removed 1, failed 1 of 2 tenants", lines[len(lines)-1])
+	mockManagement.AssertExpectations(t)
+}
+
+func TestRemoveAllTenants_JSONOutput(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RemoveTenants)
+	run.Config.Action.Param.JSON = true
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	results := []models.TenantRemovalResult{{Tenant: "diku", Status: models.TenantRemovalRemoved}}
+	mockManagement.On("RemoveTenants", mock.Anything, mock.Anything, mock.Anything).Return(results, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.RemoveAllTenants(&buf)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded []models.TenantRemovalResult
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, results, decoded)
+}
+
+// ==================== ListTenants Tests ====================
+
+func TestListTenants_WithEntitlementFailure(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.ListTenants)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetTenants", constant.NoneConsortium, constant.TenantType(constant.All)).Return([]any{
+		map[string]any{"id": "tenant-1", "name": "test-tenant", "description": "nop-default"},
+		map[string]any{"id": "tenant-2", "name": "other-tenant", "description": "nop-default"},
+	}, nil)
+	mockManagement.On("GetTenantEntitlements", "test-tenant", false).
+		Return(models.TenantEntitlementResponse{Entitlements: []models.TenantEntitlementDTO{{ApplicationID: "app-1"}, {ApplicationID: "app-2"}}}, nil)
+	mockManagement.On("GetTenantEntitlements", "other-tenant", false).Return(nil, assert.AnError)
+	var output bytes.Buffer
+
+	// Act
+	err := run.ListTenants(&output)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if !assert.Len(t, lines, 3) {
+		return
+	}
+	assert.NotContains(t, lines[0], "USERS")
+	assert.Regexp(t, `test-tenant\s+nop-default\s+2$`, lines[1])
+	assert.Regexp(t, `other-tenant\s+nop-default\s+unavailable$`, lines[2])
+	mockKeycloak.AssertNotCalled(t, "GetUsers", mock.Anything)
+}
+
+func TestListTenants_WithUsersJSON(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.ListTenants)
+	run.Config.Action.Param.JSON = true
+	run.Config.Action.Param.WithUsers = true
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetTenants", constant.NoneConsortium, constant.TenantType(constant.All)).Return([]any{
+		map[string]any{"id": "tenant-1", "name": "test-tenant", "description": "nop-default"},
+		map[string]any{"id": "tenant-2", "name": "other-tenant", "description": "nop-default"},
+	}, nil)
+	mockManagement.On("GetTenantEntitlements", mock.Anything, false).Return(models.TenantEntitlementResponse{}, nil)
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("GetUsers", "test-tenant").Return([]any{map[string]any{}, map[string]any{}, map[string]any{}}, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.ListTenants(&output)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded []models.TenantSummary
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	if !assert.Len(t, decoded, 2) {
+		return
+	}
+	assert.Equal(t, 0, *decoded[0].Applications)
+	assert.Equal(t, 3, *decoded[0].Users)
+	assert.Nil(t, decoded[1].Users)
+	mockKeycloak.AssertNotCalled(t, "GetUsers", "other-tenant")
+}
+
 // ==================== CreateUsers Tests ====================
 
 func TestCreateUsers_Success(t *testing.T) {
@@ -814,7 +1129,7 @@ func TestRemoveUsers_Success(t *testing.T) {
 	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
 		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
-	mockKeycloak.On("RemoveUsers", "test-tenant").Return(nil)
+	mockKeycloak.On("RemoveUsers", "test-tenant").Return(0, nil)
 
 	// Act
 	err := run.RemoveUsers(constant.NoneConsortium, constant.Default)
@@ -838,7 +1153,7 @@ func TestRemoveUsers_RemoveUsersError(t *testing.T) {
 	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
 		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
-	mockKeycloak.On("RemoveUsers", "test-tenant").Return(expectedError)
+	mockKeycloak.On("RemoveUsers", "test-tenant").Return(0, expectedError)
 
 	// Act
 	err := run.RemoveUsers(constant.NoneConsortium, constant.Default)
@@ -849,6 +1164,537 @@ func TestRemoveUsers_RemoveUsersError(t *testing.T) {
 	mockKeycloak.AssertExpectations(t)
 }
 
+// ==================== DetachAllUserRoles Tests ====================
+
+func TestDetachAllUserRoles_Success(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.DetachAllUserRoles)
+	run.Config.Action.Param.Confirm = true
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("DetachAllUserRoles", "test-tenant").Return(nil)
+
+	// Act
+	err := run.DetachAllUserRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "access-token", run.Config.Action.KeycloakAccessToken)
+	mockKeycloak.AssertExpectations(t)
+	mockDocker.AssertExpectations(t)
+}
+
+func TestDetachAllUserRoles_RequiresConfirm(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, _ := newTestRun(action.DetachAllUserRoles)
+
+	// Act
+	err := run.DetachAllUserRoles("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--confirm")
+	mockDocker.AssertNotCalled(t, "Create")
+	mockKeycloak.AssertNotCalled(t, "DetachAllUserRoles", mock.Anything)
+}
+
+// ==================== RecreateRole Tests ====================
+
+func TestRecreateRole_Success(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.RecreateRole)
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("RecreateRole", "test-tenant", "admin").
+		Return(&models.RoleCapabilitySetsAttachResult{RoleName: "admin", Tenant: "test-tenant", Attached: 3}, nil)
+
+	// Act
+	err := run.RecreateRole("test-tenant", "admin")
+
+	// Assert
+	assert.NoError(t, err)
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestRecreateRole_RecreateError(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.RecreateRole)
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("RecreateRole", "test-tenant", "admin").Return(nil, assert.AnError)
+
+	// Act
+	err := run.RecreateRole("test-tenant", "admin")
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestRecreateRole_UnknownTenant(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, _, _ := newTestRun(action.RecreateRole)
+
+	// Act
+	err := run.RecreateRole("other-tenant", "admin")
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	mockKeycloak.AssertNotCalled(t, "RecreateRole", mock.Anything, mock.Anything)
+}
+
+// ==================== ResetTenant Tests ====================
+
+func TestResetTenant_Success(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.ResetTenant)
+	run.Config.Action.Param.Confirm = true
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(0, nil)
+	mockKeycloak.On("RemoveUsers", "test-tenant").Return(0, nil)
+	mockKeycloak.On("RemoveRoles", "test-tenant").Return(0, nil)
+
+	// Act
+	err := run.ResetTenant("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockKeycloak.AssertExpectations(t)
+	mockDocker.AssertExpectations(t)
+}
+
+func TestResetTenant_DetachErrorContinues(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.ResetTenant)
+	run.Config.Action.Param.Confirm = true
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(0, assert.AnError)
+	mockKeycloak.On("RemoveUsers", "test-tenant").Return(0, nil)
+	mockKeycloak.On("RemoveRoles", "test-tenant").Return(0, nil)
+
+	// Act
+	err := run.ResetTenant("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestResetTenant_RemoveUsersError(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.ResetTenant)
+	run.Config.Action.Param.Confirm = true
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(0, nil)
+	mockKeycloak.On("RemoveUsers", "test-tenant").Return(0, assert.AnError)
+
+	// Act
+	err := run.ResetTenant("test-tenant")
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	mockKeycloak.AssertNotCalled(t, "RemoveRoles", mock.Anything)
+}
+
+func TestResetTenant_RequiresConfirm(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, _ := newTestRun(action.ResetTenant)
+
+	// Act
+	err := run.ResetTenant("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--confirm")
+	mockDocker.AssertNotCalled(t, "Create")
+	mockKeycloak.AssertNotCalled(t, "RemoveUsers", mock.Anything)
+}
+
+func TestResetTenant_UnknownTenant(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, _ := newTestRun(action.ResetTenant)
+	run.Config.Action.Param.Confirm = true
+
+	// Act
+	err := run.ResetTenant("unknown")
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	mockDocker.AssertNotCalled(t, "Create")
+	mockKeycloak.AssertNotCalled(t, "RemoveUsers", mock.Anything)
+}
+
+// ==================== AccessTokenEnv Tests ====================
+
+func TestSetTenantAccessTokenIntoContext_FromEnv(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, _ := newTestRun(action.DetachAllUserRoles)
+	run.Config.Action.Param.AccessTokenEnv = "EUREKA_ACCESS_TOKEN_%s"
+	t.Setenv("EUREKA_ACCESS_TOKEN_TEST-TENANT", "injected-token")
+
+	// Act
+	err := run.setTenantAccessTokenIntoContext("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "injected-token", run.Config.Action.KeycloakAccessToken)
+	mockDocker.AssertNotCalled(t, "Create")
+	mockKeycloak.AssertNotCalled(t, "GetAccessToken", mock.Anything)
+}
+
+func TestSetTenantAccessTokenIntoContext_EnvNotSet(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, _, _ := newTestRun(action.DetachAllUserRoles)
+	run.Config.Action.Param.AccessTokenEnv = "EUREKA_MISSING_ACCESS_TOKEN"
+
+	// Act
+	err := run.setTenantAccessTokenIntoContext("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "EUREKA_MISSING_ACCESS_TOKEN")
+	mockKeycloak.AssertNotCalled(t, "GetAccessToken", mock.Anything)
+}
+
+// ==================== AppDependencies Tests ====================
+
+func newTestDependencyGraph() *models.ApplicationDependencyGraph {
+	return &models.ApplicationDependencyGraph{
+		ApplicationID: "app-test-1.0.0",
+		Dependencies:  []models.ApplicationDependency{{Name: "app-platform-minimal", Version: "^1.0.0"}},
+		Modules: []models.ModuleDependencies{
+			{
+				ModuleID: "mod-orders-13.0.0",
+				Requires: []models.InterfaceDependency{
+					{InterfaceID: "users", Version: "16.0", ProviderIDs: []string{"mod-users-19.0.0"}},
+					{InterfaceID: "finance", Version: "2.0", Optional: true},
+				},
+			},
+			{ModuleID: "mod-users-19.0.0"},
+		},
+	}
+}
+
+func TestAppDependencies_TextOutput(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.AppDependencies)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationDependencies", "app-test-1.0.0").Return(newTestDependencyGraph(), nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.AppDependencies("app-test-1.0.0", constant.TextFormat, &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	expected := "app-test-1.0.0\n" +
+		"├── depends on app-platform-minimal ^1.0.0\n" +
+		"├── mod-orders-13.0.0\n" +
+		"│   ├── requires users 16.0 -> mod-users-19.0.0\n" +
+		"│   └── optional finance 2.0 -> (not provided by the application)\n" +
+		"└── mod-users-19.0.0\n"
+	assert.Equal(t, expected, buf.String())
+	mockManagement.AssertExpectations(t)
+}
+
+func TestAppDependencies_DOTOutput(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.AppDependencies)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationDependencies", "app-test-1.0.0").Return(newTestDependencyGraph(), nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.AppDependencies("app-test-1.0.0", constant.DotFormat, &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, `digraph "app-test-1.0.0" {`))
+	assert.Contains(t, output, `"app-test-1.0.0" -> "app-platform-minimal ^1.0.0" [style=dashed];`)
+	assert.Contains(t, output, `"mod-orders-13.0.0" -> "mod-users-19.0.0" [label="users 16.0", style=solid];`)
+	assert.Contains(t, output, `"mod-orders-13.0.0" -> "finance (unmet)" [label="finance 2.0", style=dotted, color=red];`)
+	assert.True(t, strings.HasSuffix(output, "}\n"))
+}
+
+func TestAppDependencies_UnsupportedFormat(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.AppDependencies)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.AppDependencies("app-test-1.0.0", "svg", &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	mockKeycloak.AssertNotCalled(t, "GetMasterAccessToken", mock.Anything)
+	mockManagement.AssertNotCalled(t, "GetApplicationDependencies", mock.Anything)
+}
+
+func TestAppDependencies_GetDependenciesError(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.AppDependencies)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("GetApplicationDependencies", "app-test-1.0.0").Return(nil, assert.AnError)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.AppDependencies("app-test-1.0.0", constant.TextFormat, &buf)
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, buf.String())
+}
+
+// ==================== DescribeRole Tests ====================
+
+func TestDescribeRole_HumanOutput(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.DescribeRole)
+	roleDetail := &models.KeycloakRoleDetail{
+		Role:           models.KeycloakRole{ID: "role-1", Name: "admin"},
+		CapabilitySets: []models.KeycloakCapabilitySet{{ID: "set-1", Name: "users_item.view", ApplicationID: "app-platform-minimal-1.0.0"}},
+		Capabilities:   []models.KeycloakCapability{{ID: "cap-1", Name: "users_item.view", Permission: "users.item.get"}},
+	}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("DescribeRole", "test-tenant", "admin").Return(roleDetail, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.DescribeRole("test-tenant", "admin", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "Role:         admin")
+	assert.Contains(t, output.String(), "Capability sets (1):")
+	assert.Contains(t, output.String(), "users.item.get")
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestDescribeRole_JSONOutput(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.DescribeRole)
+	run.Config.Action.Param.JSON = true
+	roleDetail := &models.KeycloakRoleDetail{Role: models.KeycloakRole{ID: "role-1", Name: "admin"}}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("DescribeRole", "test-tenant", "admin").Return(roleDetail, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.DescribeRole("test-tenant", "admin", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded models.KeycloakRoleDetail
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	assert.Equal(t, "role-1", decoded.Role.ID)
+	mockKeycloak.AssertExpectations(t)
+}
+
+// ==================== KeycloakReport Tests ====================
+
+func TestKeycloakReport_HumanOutput(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.KeycloakReport)
+	logins := 5
+	report := &models.KeycloakRealmReport{Realm: "test-tenant", Users: 12, Clients: 4, Roles: 7, LoginEvents: &logins}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockKeycloak.On("GetRealmReport", "test-tenant").Return(report, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.KeycloakReport("test-tenant", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "Users:         12")
+	assert.Contains(t, output.String(), "Login events:  5")
+	assert.Contains(t, output.String(), "Login errors:  unavailable")
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestKeycloakReport_JSONOutput(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.KeycloakReport)
+	run.Config.Action.Param.JSON = true
+	report := &models.KeycloakRealmReport{Realm: "test-tenant", Users: 12, Clients: 4, Roles: 7}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockKeycloak.On("GetRealmReport", "test-tenant").Return(report, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.KeycloakReport("test-tenant", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded models.KeycloakRealmReport
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	assert.Equal(t, 12, decoded.Users)
+	assert.Nil(t, decoded.LoginEvents)
+}
+
+func TestKeycloakReport_UnknownTenant(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, _, _ := newTestRun(action.KeycloakReport)
+
+	// Act
+	err := run.KeycloakReport("other-tenant", &bytes.Buffer{})
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	mockKeycloak.AssertNotCalled(t, "GetRealmReport", mock.Anything)
+}
+
+// ==================== ExportRoleMappings Tests ====================
+
+func TestExportRoleMappings_YAMLOutput(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.ExportRoleMappings)
+	roleMappings := &models.KeycloakRoleMappings{Roles: map[string]models.KeycloakRoleMapping{
+		"admin": {Tenant: "test-tenant", CapabilitySets: []string{"users_item.view"}},
+	}}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("ExportRoleMappings", "test-tenant").Return(roleMappings, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.ExportRoleMappings("test-tenant", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "roles:\n  admin:\n    tenant: test-tenant\n    capability-sets:\n      - users_item.view\n", output.String())
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestExportRoleMappings_JSONOutput(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.ExportRoleMappings)
+	run.Config.Action.Param.JSON = true
+	roleMappings := &models.KeycloakRoleMappings{Roles: map[string]models.KeycloakRoleMapping{
+		"admin": {Tenant: "test-tenant", CapabilitySets: []string{"users_item.view"}},
+	}}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("ExportRoleMappings", "test-tenant").Return(roleMappings, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.ExportRoleMappings("test-tenant", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded models.KeycloakRoleMappings
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	assert.Equal(t, []string{"users_item.view"}, decoded.Roles["admin"].CapabilitySets)
+	mockKeycloak.AssertExpectations(t)
+}
+
+// ==================== ListCapabilitySets Tests ====================
+
+func TestListCapabilitySets_Paginated(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.ListCapabilitySets)
+	run.Config.Action.Param.Offset = 10
+	run.Config.Action.Param.Limit = 2
+	response := &models.KeycloakCapabilitySetsResponse{
+		CapabilitySets: []models.KeycloakCapabilitySet{
+			{ID: "set-1", Name: "users_item.view", Resource: "Users Item", Action: "view"},
+			{ID: "set-2", Name: "users_item.edit", Resource: "Users Item", Action: "edit"},
+		},
+		TotalCount: 25,
+	}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("GetCapabilitySetsByApplication", "test-tenant", "app-1.0.0", 10, 2).Return(response, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.ListCapabilitySets("test-tenant", "app-1.0.0", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "users_item.view")
+	assert.Contains(t, output.String(), "users_item.edit")
+	assert.Contains(t, output.String(), "Showing 11-12 of 25")
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestListCapabilitySets_JSONOutput(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.ListCapabilitySets)
+	run.Config.Action.Param.JSON = true
+	run.Config.Action.Param.Limit = 100
+	response := &models.KeycloakCapabilitySetsResponse{
+		CapabilitySets: []models.KeycloakCapabilitySet{{ID: "set-1", Name: "users_item.view"}},
+		TotalCount:     1,
+	}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("access-token", nil)
+	mockKeycloak.On("GetCapabilitySetsByApplication", "test-tenant", "app-1.0.0", 0, 100).Return(response, nil)
+	var output bytes.Buffer
+
+	// Act
+	err := run.ListCapabilitySets("test-tenant", "app-1.0.0", &output)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded models.KeycloakCapabilitySetsResponse
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	assert.Equal(t, 1, decoded.TotalCount)
+	assert.Equal(t, "set-1", decoded.CapabilitySets[0].ID)
+}
+
 // ==================== CreateRoles Tests ====================
 
 func TestCreateRoles_Success(t *testing.T) {
@@ -888,12 +1734,60 @@ func TestCreateRoles_CreateRolesError(t *testing.T) {
 	mockKeycloak.On("CreateRoles", "test-tenant").Return(expectedError)
 
 	// Act
-	err := run.CreateRoles(constant.NoneConsortium, constant.Default)
+	err := run.CreateRoles(constant.NoneConsortium, constant.Default)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestCreateRolesForAllTenants_TenantFilter(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.CreateRoles)
+	run.Config.Action.Param.Tenant = "test-tenant"
+	run.Config.Action.ConfigTenants["other-tenant"] = map[string]any{"name": "other-tenant"}
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
+		Return([]any{
+			map[string]any{"name": "test-tenant", "description": "nop-default"},
+			map[string]any{"name": "other-tenant", "description": "nop-default"},
+		}, nil)
+	mockKeycloak.On("GetAccessToken", "test-tenant").Return("", nil)
+	mockKeycloak.On("CreateRoles", "test-tenant").Return(nil)
+
+	// Act
+	err := run.CreateRolesForAllTenants()
+
+	// Assert
+	assert.NoError(t, err)
+	mockKeycloak.AssertExpectations(t)
+	mockKeycloak.AssertNotCalled(t, "CreateRoles", "other-tenant")
+	mockKeycloak.AssertNotCalled(t, "GetAccessToken", "other-tenant")
+}
+
+func TestCreateRolesForAllTenants_UnknownTenant(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.CreateRoles)
+	run.Config.Action.Param.Tenant = "missing-tenant"
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
+		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
+
+	// Act
+	err := run.CreateRolesForAllTenants()
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	mockKeycloak.AssertExpectations(t)
+	assert.Contains(t, err.Error(), "missing-tenant")
+	mockKeycloak.AssertNotCalled(t, "CreateRoles", mock.Anything)
+	mockKeycloak.AssertNotCalled(t, "GetAccessToken", mock.Anything)
 }
 
 // ==================== RemoveRoles Tests ====================
@@ -908,7 +1802,7 @@ func TestRemoveRoles_Success(t *testing.T) {
 	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
 		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
-	mockKeycloak.On("RemoveRoles", "test-tenant").Return(nil)
+	mockKeycloak.On("RemoveRoles", "test-tenant").Return(0, nil)
 
 	// Act
 	err := run.RemoveRoles(constant.NoneConsortium, constant.Default)
@@ -932,7 +1826,7 @@ func TestRemoveRoles_RemoveRolesError(t *testing.T) {
 	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
 		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
-	mockKeycloak.On("RemoveRoles", "test-tenant").Return(expectedError)
+	mockKeycloak.On("RemoveRoles", "test-tenant").Return(0, expectedError)
 
 	// Act
 	err := run.RemoveRoles(constant.NoneConsortium, constant.Default)
@@ -1378,6 +2272,139 @@ func TestCreateFilter_SingleModule(t *testing.T) {
 	assert.Contains(t, result, "test-module")
 }
 
+// ==================== CheckSidecars Tests ====================
+
+func TestCheckSidecars_ReportsReachability(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.CheckSidecars)
+	mockExecSvc := &MockExecSvc{}
+	run.Config.ExecSvc = mockExecSvc
+	run.Config.Action.ConfigProfileName = "combined"
+
+	mockExecSvc.On("ExecFromDir", mock.Anything, mock.Anything).Return(nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetDeployedModules", mock.Anything, mock.Anything).Return([]container.Summary{
+		{Names: []string{"/eureka-combined-mod-users-sc"}},
+		{Names: []string{"/eureka-combined-mod-notes-sc"}},
+		{Names: []string{"/eureka-combined-mod-orders-sc"}},
+	}, nil)
+	mockManagement.On("GetModuleDiscovery", "mod-users").Return(models.ModuleDiscoveryResponse{
+		Discovery: []models.ModuleDiscovery{{Name: "mod-users", Location: "http://mod-users-sc.eureka:8081"}},
+	}, nil)
+	mockManagement.On("GetModuleDiscovery", "mod-notes").Return(models.ModuleDiscoveryResponse{
+		Discovery: []models.ModuleDiscovery{{Name: "mod-notes", Location: "http://mod-notes-sc.eureka:8081"}},
+	}, nil)
+	mockManagement.On("GetModuleDiscovery", "mod-orders").Return(models.ModuleDiscoveryResponse{}, nil)
+	mockExecSvc.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return cmd.Args[len(cmd.Args)-1] == "http://mod-users-sc.eureka:8081/admin/health"
+	})).Return(*bytes.NewBufferString("200 0.012"), bytes.Buffer{}, nil)
+	mockExecSvc.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return cmd.Args[len(cmd.Args)-1] == "http://mod-notes-sc.eureka:8081/admin/health"
+	})).Return(*bytes.NewBufferString("000 5.001"), bytes.Buffer{}, assert.AnError)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CheckSidecars(&buf)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !assert.Len(t, lines, 4) {
+		return
+	}
+	assert.Regexp(t, `^mod-notes\s+http://mod-notes-sc.eureka:8081\s+unreachable\s+unreachable\s+0s$`, lines[1])
+	assert.Regexp(t, `^mod-orders\s+-\s+unreachable\s+no discovery\s+0s$`, lines[2])
+	assert.Regexp(t, `^mod-users\s+http://mod-users-sc.eureka:8081\s+reachable\s+HTTP 200\s+12ms$`, lines[3])
+	mockExecSvc.AssertExpectations(t)
+}
+
+func TestCheckSidecars_NetcatDeployError(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, _, _ := newTestRun(action.CheckSidecars)
+	mockExecSvc := &MockExecSvc{}
+	run.Config.ExecSvc = mockExecSvc
+
+	mockExecSvc.On("ExecFromDir", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	// Act
+	err := run.CheckSidecars(&bytes.Buffer{})
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	mockKeycloak.AssertNotCalled(t, "GetMasterAccessToken", mock.Anything)
+}
+
+// ==================== ServeReadiness Tests ====================
+
+func TestCheckStackReadiness_Ready(t *testing.T) {
+	// Arrange
+	run, _, _, _, mockDocker, mockModule := newTestRun(action.DeployApplication)
+	mockHTTP := run.Config.HTTPClient.(*testhelpers.MockHTTPClient)
+	mockHTTP.On("Ping", mock.MatchedBy(func(url string) bool { return strings.HasSuffix(url, "/status") })).Return(http.StatusOK, nil)
+	mockHTTP.On("Ping", "http://localhost:36001/admin/health").Return(http.StatusOK, nil)
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetDeployedModules", mock.Anything, mock.Anything).Return([]container.Summary{
+		{Names: []string{"/eureka-combined-mod-users"}, State: container.StateRunning, Ports: []container.Port{{PrivatePort: 8081, PublicPort: 36001}}},
+	}, nil)
+
+	// Act
+	err := run.CheckStackReadiness()
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCheckStackReadiness_ReportsEveryReason(t *testing.T) {
+	// Arrange
+	run, _, _, _, mockDocker, mockModule := newTestRun(action.DeployApplication)
+	mockHTTP := run.Config.HTTPClient.(*testhelpers.MockHTTPClient)
+	mockHTTP.On("Ping", mock.MatchedBy(func(url string) bool { return strings.HasSuffix(url, "/status") })).Return(0, assert.AnError)
+	mockHTTP.On("Ping", "http://localhost:36001/admin/health").Return(http.StatusServiceUnavailable, nil)
+	mockDocker.On("Create").Return(nil, nil)
+	mockDocker.On("Close", mock.Anything).Return()
+	mockModule.On("GetDeployedModules", mock.Anything, mock.Anything).Return([]container.Summary{
+		{Names: []string{"/eureka-combined-mod-users"}, State: container.StateRunning, Ports: []container.Port{{PrivatePort: 8081, PublicPort: 36001}}},
+		{Names: []string{"/eureka-combined-mod-notes"}, State: container.StateExited},
+	}, nil)
+
+	// Act
+	err := run.CheckStackReadiness()
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotReady)
+	assert.Contains(t, err.Error(), "gateway status 0")
+	assert.Contains(t, err.Error(), "eureka-combined-mod-users health status 503")
+	assert.Contains(t, err.Error(), "eureka-combined-mod-notes is exited")
+}
+
+func TestReadinessHandler(t *testing.T) {
+	// Arrange
+	state := &readinessState{}
+	handler := newReadinessHandler(state)
+	probe := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		return recorder
+	}
+
+	// Act
+	unchecked := probe()
+	state.set(assert.AnError)
+	unhealthy := probe()
+	state.set(nil)
+	healthy := probe()
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, unchecked.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, unhealthy.Code)
+	assert.Contains(t, unhealthy.Body.String(), assert.AnError.Error())
+	assert.Equal(t, http.StatusOK, healthy.Code)
+}
+
 // ==================== CheckPorts Tests ====================
 
 func TestCheckPorts_Success(t *testing.T) {
@@ -1616,6 +2643,102 @@ func TestListModuleVersions_WithModuleName(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
+// ==================== CheckModuleVersions Tests ====================
+
+func TestCheckModuleVersions_ReportsStatuses(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.CheckModuleVersions)
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	mockModuleProps := &MockModuleProps{}
+	run.Config.HTTPClient = mockHTTP
+	run.Config.ModuleProps = mockModuleProps
+
+	pinnedOld, pinnedNew := "1.0.0", "2.1.0"
+	mockModuleProps.On("ReadBackendModules", false, false).Return(map[string]models.BackendModule{
+		"mod-users":    {ModuleName: "mod-users", ModuleVersion: &pinnedOld},
+		"mod-orders":   {ModuleName: "mod-orders", ModuleVersion: &pinnedNew},
+		"mod-notes":    {ModuleName: "mod-notes"},
+		"mod-internal": {ModuleName: "mod-internal", ModuleVersion: &pinnedOld},
+	}, nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			resp := args.Get(2).(*models.ProxyModulesResponse)
+			*resp = models.ProxyModulesResponse{
+				{ID: "mod-users-1.0.0"},
+				{ID: "mod-users-1.2.0"},
+				{ID: "mod-orders-2.1.0"},
+				{ID: "mod-orders-2.0.0"},
+				{ID: "mod-notes-5.0.0"},
+			}
+		}).Return(nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CheckModuleVersions(&buf)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !assert.Len(t, lines, 5) {
+		return
+	}
+	assert.Regexp(t, `^mod-internal\s+1\.0\.0\s+-\s+not in registry$`, lines[1])
+	assert.Regexp(t, `^mod-notes\s+-\s+5\.0\.0\s+unpinned$`, lines[2])
+	assert.Regexp(t, `^mod-orders\s+2\.1\.0\s+2\.1\.0\s+current$`, lines[3])
+	assert.Regexp(t, `^mod-users\s+1\.0\.0\s+1\.2\.0\s+outdated$`, lines[4])
+}
+
+func TestCheckModuleVersions_OnlyOutdated(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.CheckModuleVersions)
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	mockModuleProps := &MockModuleProps{}
+	run.Config.HTTPClient = mockHTTP
+	run.Config.ModuleProps = mockModuleProps
+	run.Config.Action.Param.OnlyOutdated = true
+
+	pinnedOld, pinnedNew := "1.0.0-SNAPSHOT.10", "2.1.0"
+	mockModuleProps.On("ReadBackendModules", false, false).Return(map[string]models.BackendModule{
+		"mod-users":  {ModuleName: "mod-users", ModuleVersion: &pinnedOld},
+		"mod-orders": {ModuleName: "mod-orders", ModuleVersion: &pinnedNew},
+	}, nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			resp := args.Get(2).(*models.ProxyModulesResponse)
+			*resp = models.ProxyModulesResponse{
+				{ID: "mod-users-1.0.0-SNAPSHOT.12"},
+				{ID: "mod-orders-2.1.0"},
+			}
+		}).Return(nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := run.CheckModuleVersions(&buf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "mod-users")
+	assert.NotContains(t, buf.String(), "mod-orders")
+}
+
+func TestCheckModuleVersions_RegistryError(t *testing.T) {
+	// Arrange
+	run, _, _, _, _, _ := newTestRun(action.CheckModuleVersions)
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	mockModuleProps := &MockModuleProps{}
+	run.Config.HTTPClient = mockHTTP
+	run.Config.ModuleProps = mockModuleProps
+
+	mockModuleProps.On("ReadBackendModules", false, false).Return(map[string]models.BackendModule{}, nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError)
+
+	// Act
+	err := run.CheckModuleVersions(&bytes.Buffer{})
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
 // ==================== AttachCapabilitySets Tests ====================
 
 func TestAttachCapabilitySets_Success(t *testing.T) {
@@ -1639,7 +2762,7 @@ func TestAttachCapabilitySets_Success(t *testing.T) {
 	mockKeycloak.On("UpdateRealmAccessTokenSettings", mock.Anything, mock.Anything).Return(nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
 	mockKafkaSvc.On("PollConsumerGroup", mock.Anything).Return(nil)
-	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil, nil)
 	mockKeycloak.On("CountCapabilitySets", "test-tenant").Return(530, nil)
 
 	// Act
@@ -1685,7 +2808,7 @@ func TestAttachCapabilitySets_FilePersisted_SkipsPoll(t *testing.T) {
 		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
 	mockKeycloak.On("UpdateRealmAccessTokenSettings", mock.Anything, mock.Anything).Return(nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
-	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil, nil)
 	mockKeycloak.On("CountCapabilitySets", "test-tenant").Return(530, nil)
 
 	// Act
@@ -1758,7 +2881,7 @@ func TestAttachCapabilitySets_AttachError(t *testing.T) {
 	mockKeycloak.On("UpdateRealmAccessTokenSettings", mock.Anything, mock.Anything).Return(nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
 	mockKafkaSvc.On("PollConsumerGroup", mock.Anything).Return(nil)
-	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(expectedError)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil, expectedError)
 
 	// Act
 	err := run.AttachCapabilitySets(constant.NoneConsortium, constant.Default, 0, false)
@@ -1797,7 +2920,7 @@ func TestAttachCapabilitySets_ForceRefresh_DeletesFile(t *testing.T) {
 	mockKeycloak.On("UpdateRealmAccessTokenSettings", mock.Anything, mock.Anything).Return(nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
 	mockKafkaSvc.On("PollConsumerGroup", mock.Anything).Return(nil)
-	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil, nil)
 	mockKeycloak.On("CountCapabilitySets", "test-tenant").Return(200, nil)
 
 	// Act
@@ -1843,7 +2966,7 @@ func TestAttachCapabilitySets_CountChanged_Polls(t *testing.T) {
 	mockKeycloak.On("UpdateRealmAccessTokenSettings", mock.Anything, mock.Anything).Return(nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
 	mockKafkaSvc.On("PollConsumerGroup", mock.Anything).Return(nil)
-	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil, nil)
 	// pre-check returns 200 (≠ persisted 100); post-attach also returns 200
 	mockKeycloak.On("CountCapabilitySets", "test-tenant").Return(200, nil)
 
@@ -1890,7 +3013,7 @@ func TestAttachCapabilitySets_PreCheckCountError_Polls(t *testing.T) {
 	mockKeycloak.On("UpdateRealmAccessTokenSettings", mock.Anything, mock.Anything).Return(nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
 	mockKafkaSvc.On("PollConsumerGroup", mock.Anything).Return(nil)
-	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil, nil)
 	mockKeycloak.On("CountCapabilitySets", "test-tenant").Return(0, assert.AnError).Once()
 	mockKeycloak.On("CountCapabilitySets", "test-tenant").Return(300, nil)
 
@@ -1924,7 +3047,7 @@ func TestAttachCapabilitySets_PostAttachCountError_ReturnsNil(t *testing.T) {
 	mockKeycloak.On("UpdateRealmAccessTokenSettings", mock.Anything, mock.Anything).Return(nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
 	mockKafkaSvc.On("PollConsumerGroup", mock.Anything).Return(nil)
-	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil)
+	mockKeycloak.On("AttachCapabilitySetsToRoles", "test-tenant").Return(nil, nil)
 	mockKeycloak.On("CountCapabilitySets", "test-tenant").Return(0, assert.AnError)
 
 	// Act
@@ -1948,7 +3071,7 @@ func TestDetachCapabilitySets_Success(t *testing.T) {
 	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
 		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
-	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(nil)
+	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(0, nil)
 
 	// Act
 	err := run.DetachCapabilitySets(constant.NoneConsortium, constant.Default)
@@ -1968,7 +3091,7 @@ func TestDetachCapabilitySets_DetachError(t *testing.T) {
 	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
 		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
 	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
-	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(assert.AnError)
+	mockKeycloak.On("DetachCapabilitySetsFromRoles", "test-tenant").Return(0, assert.AnError)
 
 	// Act
 	err := run.DetachCapabilitySets(constant.NoneConsortium, constant.Default)
@@ -1978,6 +3101,29 @@ func TestDetachCapabilitySets_DetachError(t *testing.T) {
 	mockKeycloak.AssertExpectations(t)
 }
 
+func TestDetachCapabilitySets_NamedCapabilitySets(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.DetachCapabilitySets)
+	run.Config.Action.Param.CapabilitySetNames = []string{"users.edit"}
+	run.Config.Action.Param.RoleName = "admin"
+
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetTenants", mock.Anything, mock.Anything).
+		Return([]any{map[string]any{"name": "test-tenant", "description": "nop-default"}}, nil)
+	mockKeycloak.On("GetAccessToken", mock.Anything).Return("", nil)
+	mockKeycloak.On("DetachNamedCapabilitySetsFromRoles", "test-tenant", "admin", []string{"users.edit"}).Return(nil)
+
+	// Act
+	err := run.DetachCapabilitySets(constant.NoneConsortium, constant.Default)
+
+	// Assert
+	assert.NoError(t, err)
+	mockKeycloak.AssertExpectations(t)
+	mockKeycloak.AssertNotCalled(t, "DetachCapabilitySetsFromRoles", mock.Anything)
+}
+
 // ==================== UpdateKeycloakPublicClients Tests ====================
 
 func TestUpdateKeycloakPublicClients_Success(t *testing.T) {
@@ -2302,7 +3448,7 @@ func TestRemoveTenantEntitlements_Success(t *testing.T) {
 	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.RemoveTenantEntitlements)
 
 	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
-	mockManagement.On("RemoveTenantEntitlements", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockManagement.On("RemoveTenantEntitlements", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 
 	// Act
 	err := run.RemoveTenantEntitlements(constant.NoneConsortium, constant.Default)
@@ -2335,7 +3481,7 @@ func TestRemoveTenantEntitlements_RemoveError(t *testing.T) {
 
 	expectedError := assert.AnError
 	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
-	mockManagement.On("RemoveTenantEntitlements", mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
+	mockManagement.On("RemoveTenantEntitlements", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, expectedError)
 
 	// Act
 	err := run.RemoveTenantEntitlements(constant.NoneConsortium, constant.Default)
@@ -2383,6 +3529,55 @@ func TestReindexIndices_InstanceError(t *testing.T) {
 	mockSearchSvc.AssertExpectations(t)
 }
 
+// ==================== WaitForEntitlements Tests ====================
+
+func TestWaitForEntitlements_Success(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.WaitForEntitlements)
+
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("WaitForTenantEntitlements", 5*time.Minute).Return(nil)
+
+	// Act
+	err := run.WaitForEntitlements(5 * time.Minute)
+
+	// Assert
+	assert.NoError(t, err)
+	mockKeycloak.AssertExpectations(t)
+	mockManagement.AssertExpectations(t)
+}
+
+func TestWaitForEntitlements_GetMasterTokenError(t *testing.T) {
+	// Arrange
+	run, _, mockKeycloak, _, _, _ := newTestRun(action.WaitForEntitlements)
+
+	expectedError := assert.AnError
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", expectedError)
+
+	// Act
+	err := run.WaitForEntitlements(time.Minute)
+
+	// Assert
+	assert.Equal(t, expectedError, err)
+	mockKeycloak.AssertExpectations(t)
+}
+
+func TestWaitForEntitlements_TimeoutError(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, _ := newTestRun(action.WaitForEntitlements)
+
+	expectedError := assert.AnError
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("master-token", nil)
+	mockManagement.On("WaitForTenantEntitlements", time.Minute).Return(expectedError)
+
+	// Act
+	err := run.WaitForEntitlements(time.Minute)
+
+	// Assert
+	assert.Equal(t, expectedError, err)
+	mockManagement.AssertExpectations(t)
+}
+
 // ==================== UndeployUI Tests ====================
 
 func TestUndeployUI_Success(t *testing.T) {
@@ -2495,6 +3690,76 @@ func TestUndeployModules_WithRemoveApplication(t *testing.T) {
 	mockManagement.AssertExpectations(t)
 }
 
+func TestUndeployModules_WithApplicationIDs(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.UndeployModules)
+	run.Config.Action.Param.ApplicationIDs = []string{"app-a-1.0.0", "app-b-1.0.0"}
+
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetApplicationDescriptor", "app-a-1.0.0").
+		Return(&models.ApplicationDescriptor{Modules: []models.ApplicationModule{{Name: "mod-a"}}}, nil)
+	mockManagement.On("GetApplicationDescriptor", "app-b-1.0.0").
+		Return(&models.ApplicationDescriptor{Modules: []models.ApplicationModule{{Name: "mod-b"}, {Name: "mod-c"}}}, nil)
+	mockManagement.On("RemoveApplications", "", "", []string{"app-a-1.0.0", "app-b-1.0.0"}).Return(0, nil)
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("UndeployModuleByNamePattern", mock.Anything, mock.Anything).Return(nil)
+	mockDocker.On("Close", mock.Anything).Return(nil)
+
+	// Act
+	err := run.UndeployModules(true)
+
+	// Assert
+	assert.NoError(t, err)
+	mockManagement.AssertExpectations(t)
+	mockManagement.AssertNotCalled(t, "RemoveApplication", mock.Anything)
+	profile := run.Config.Action.ConfigProfileName
+	for _, moduleName := range []string{"mod-a", "mod-b", "mod-c"} {
+		mockModule.AssertCalled(t, "UndeployModuleByNamePattern", mock.Anything, fmt.Sprintf(constant.SingleModuleOrSidecarContainerPattern, profile, moduleName))
+	}
+	mockModule.AssertNotCalled(t, "UndeployModuleByNamePattern", mock.Anything, fmt.Sprintf(constant.ProfileContainerPattern, profile))
+}
+
+func TestUndeployModules_WithApplicationIDs_DescriptorError(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, _, mockModule := newTestRun(action.UndeployModules)
+	run.Config.Action.Param.ApplicationIDs = []string{"app-a-1.0.0"}
+
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("GetApplicationDescriptor", "app-a-1.0.0").Return(nil, assert.AnError)
+
+	// Act
+	err := run.UndeployModules(true)
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	mockManagement.AssertNotCalled(t, "RemoveApplications", mock.Anything, mock.Anything, mock.Anything)
+	mockModule.AssertNotCalled(t, "UndeployModuleByNamePattern", mock.Anything, mock.Anything)
+}
+
+func TestUndeployApplication_WithApplicationIDs(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.UndeployApplication)
+	run.Config.Action.Param.ApplicationIDs = []string{"app-a-1.0.0"}
+
+	mockKeycloak.On("GetMasterAccessToken", mock.AnythingOfType("constant.KeycloakGrantType")).Return("", nil)
+	mockManagement.On("RemoveTenantEntitlements", mock.Anything, mock.Anything, mock.Anything, []string{"app-a-1.0.0"}).Return(0, nil)
+	mockManagement.On("GetApplicationDescriptor", "app-a-1.0.0").
+		Return(&models.ApplicationDescriptor{Modules: []models.ApplicationModule{{Name: "mod-a"}}}, nil)
+	mockManagement.On("RemoveApplications", "", "", []string{"app-a-1.0.0"}).Return(0, nil)
+	mockDocker.On("Create").Return(nil, nil)
+	mockModule.On("UndeployModuleByNamePattern", mock.Anything, mock.Anything).Return(nil)
+	mockDocker.On("Close", mock.Anything).Return(nil)
+
+	// Act
+	err := run.UndeployApplication()
+
+	// Assert
+	assert.NoError(t, err)
+	mockManagement.AssertExpectations(t)
+	mockModule.AssertNumberOfCalls(t, "UndeployModuleByNamePattern", 1)
+	mockModule.AssertCalled(t, "UndeployModuleByNamePattern", mock.Anything, fmt.Sprintf(constant.SingleModuleOrSidecarContainerPattern, run.Config.Action.ConfigProfileName, "mod-a"))
+}
+
 func TestUndeployModules_UndeployError(t *testing.T) {
 	// Arrange
 	run, _, _, _, mockDocker, mockModule := newTestRun(action.UndeployModules)
@@ -2529,7 +3794,7 @@ func TestDeployModules_Success(t *testing.T) {
 	mockDocker.On("Create").Return(nil, nil)
 	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
 	mockModule.On("GetSidecarImage", mock.Anything).Return("test-sidecar:latest", false, nil)
-	mockModule.On("DeployModules", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]int{"test-module": 8080}, 1, nil)
+	mockModule.On("DeployModules", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]models.BackendModule{"test-module": {ModuleExposedServerPort: 8080}}, 1, nil)
 	mockModule.On("CheckModuleReadiness", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
 	mockKeycloak.On("GetMasterAccessToken", mock.Anything).Return("access-token", nil)
 	mockManagement.On("CreateApplication", mock.Anything).Return(nil)
@@ -2558,7 +3823,7 @@ func TestDeployModules_AllAlreadyDeployed_SkipsHealthcheck(t *testing.T) {
 	mockModule.On("GetVaultRootToken", mock.Anything).Return("", nil)
 	mockModule.On("GetSidecarImage", mock.Anything).Return("test-sidecar:latest", false, nil)
 	// newlyDeployed=empty (all already existed), totalMatched=2
-	mockModule.On("DeployModules", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]int{}, 2, nil)
+	mockModule.On("DeployModules", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]models.BackendModule{}, 2, nil)
 	mockKeycloak.On("GetMasterAccessToken", mock.Anything).Return("access-token", nil)
 	mockManagement.On("CreateApplication", mock.Anything).Return(nil)
 	mockDocker.On("Close", mock.Anything).Return(nil)
@@ -2571,6 +3836,32 @@ func TestDeployModules_AllAlreadyDeployed_SkipsHealthcheck(t *testing.T) {
 	mockModule.AssertNotCalled(t, "CheckModuleReadiness")
 }
 
+func TestDeployModules_DryRun_OnlyCreatesApplicationPayloads(t *testing.T) {
+	// Arrange
+	run, mockManagement, mockKeycloak, _, mockDocker, mockModule := newTestRun(action.DeployModules)
+	run.Config.Action.Param.DryRun = true
+	mockModuleProps := &MockModuleProps{}
+	mockRegistrySvc := &MockRegistrySvc{}
+	run.Config.ModuleProps = mockModuleProps
+	run.Config.RegistrySvc = mockRegistrySvc
+
+	mockModuleProps.On("ReadBackendModules", false, true).Return(map[string]models.BackendModule{}, nil)
+	mockModuleProps.On("ReadFrontendModules", true).Return(map[string]models.FrontendModule{}, nil)
+	mockRegistrySvc.On("GetModules", true, true).Return(&models.ProxyModulesByRegistry{}, nil)
+	mockRegistrySvc.On("ResolveModuleMetadata", mock.Anything).Return()
+	mockManagement.On("CreateApplication", mock.Anything).Return(nil)
+
+	// Act
+	err := run.DeployModules()
+
+	// Assert
+	assert.NoError(t, err)
+	mockManagement.AssertExpectations(t)
+	mockDocker.AssertNotCalled(t, "Create")
+	mockModule.AssertNotCalled(t, "DeployModules", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockKeycloak.AssertNotCalled(t, "GetMasterAccessToken", mock.Anything)
+}
+
 func TestDeploySystem_Success(t *testing.T) {
 	// Arrange
 	run, _, _, _, _, _ := newTestRun(action.DeploySystem)
@@ -2593,6 +3884,39 @@ func TestDeploySystem_Success(t *testing.T) {
 	mockExecSvc.AssertExpectations(t)
 }
 
+func TestDeploySystem_WithSystemContainerResources_AddsOverrideToComposeChain(t *testing.T) {
+	// Arrange
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(homeDir, ".eureka", "misc"), 0755))
+	run, _, _, _, _, _ := newTestRun(action.DeploySystem)
+	mockGitClient := &testhelpers.MockGitClient{}
+	mockExecSvc := &MockExecSvc{}
+	run.Config.GitClient = mockGitClient
+	run.Config.ExecSvc = mockExecSvc
+	run.Config.Action.ConfigSystemContainers = map[string]any{
+		"postgres": map[string]any{field.ModuleResourceEntry: map[string]any{field.ModuleResourceMemoryEntry: "1g"}},
+	}
+	params.BuildImages = false
+
+	mockGitClient.On("KongRepository").Return(&gitrepository.GitRepository{}, nil)
+	mockGitClient.On("KeycloakRepository").Return(&gitrepository.GitRepository{}, nil)
+	mockGitClient.On("Clone", mock.Anything).Return(nil)
+	mockExecSvc.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return strings.Contains(strings.Join(cmd.Args, " "), "compose -f docker-compose.yaml -f docker-compose.resources.yaml --progress")
+	})).Return(bytes.Buffer{}, bytes.Buffer{}, nil)
+
+	// Act
+	err := run.DeploySystem()
+
+	// Assert
+	assert.NoError(t, err)
+	mockExecSvc.AssertExpectations(t)
+	content, err := os.ReadFile(filepath.Join(homeDir, ".eureka", "misc", constant.DockerComposeResourcesFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "mem_limit: 1073741824")
+}
+
 func TestDeploySystem_AlreadyRunning_SkipsSleep(t *testing.T) {
 	// Arrange
 	run, _, _, _, _, _ := newTestRun(action.DeploySystem)
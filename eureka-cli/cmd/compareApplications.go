@@ -0,0 +1,172 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// compareApplicationsCmd represents the compareApplications command
+var compareApplicationsCmd = &cobra.Command{
+	Use:   "compareApplications",
+	Short: "Compare applications",
+	Long:  `Compare the backend and frontend modules of two application descriptors.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.CompareApplications)
+		if err != nil {
+			return err
+		}
+
+		return run.CompareApplications(params.ApplicationA, params.ApplicationB, os.Stdout)
+	},
+}
+
+// CompareApplications prints the modules added, removed and changed in version between two applications
+func (run *Run) CompareApplications(fromApplicationID, toApplicationID string, writer io.Writer) error {
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	from, err := run.Config.ManagementSvc.GetApplicationDescriptor(fromApplicationID)
+	if err != nil {
+		return err
+	}
+	to, err := run.Config.ManagementSvc.GetApplicationDescriptor(toApplicationID)
+	if err != nil {
+		return err
+	}
+	slog.Info(run.Config.Action.Name, "text", "COMPARING APPLICATIONS", "from", from.ID, "to", to.ID)
+
+	diff := diffApplications(from, to)
+	if run.Config.Action.Param.JSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(diff)
+	}
+
+	return writeApplicationDiff(writer, diff)
+}
+
+// diffApplications matches the modules of both applications by name, backend and frontend modules alike
+func diffApplications(from, to *models.ApplicationDescriptor) models.ApplicationDiff {
+	diff := models.ApplicationDiff{
+		From:    from.ID,
+		To:      to.ID,
+		Added:   []models.ApplicationModule{},
+		Removed: []models.ApplicationModule{},
+		Changed: []models.ApplicationModuleChange{},
+	}
+	fromModules := getApplicationModulesByName(from)
+	toModules := getApplicationModulesByName(to)
+	for name, toModule := range toModules {
+		fromModule, found := fromModules[name]
+		if !found {
+			diff.Added = append(diff.Added, toModule)
+			continue
+		}
+		if fromModule.Version != toModule.Version {
+			diff.Changed = append(diff.Changed, models.ApplicationModuleChange{
+				Name:        name,
+				FromVersion: fromModule.Version,
+				ToVersion:   toModule.Version,
+			})
+		}
+	}
+	for name, fromModule := range fromModules {
+		if _, found := toModules[name]; !found {
+			diff.Removed = append(diff.Removed, fromModule)
+		}
+	}
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Name < diff.Changed[j].Name })
+
+	return diff
+}
+
+func getApplicationModulesByName(app *models.ApplicationDescriptor) map[string]models.ApplicationModule {
+	modules := make(map[string]models.ApplicationModule, len(app.Modules)+len(app.UIModules))
+	for _, module := range append(append([]models.ApplicationModule{}, app.Modules...), app.UIModules...) {
+		modules[module.Name] = module
+	}
+
+	return modules
+}
+
+func writeApplicationDiff(writer io.Writer, diff models.ApplicationDiff) error {
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		_, err := fmt.Fprintf(writer, "%s -> %s: no module differences\n", diff.From, diff.To)
+		return err
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s -> %s\n", diff.From, diff.To)
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	if len(diff.Added) > 0 {
+		_, _ = fmt.Fprintln(tw, "Added:")
+		for _, module := range diff.Added {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\n", module.Name, module.Version)
+		}
+	}
+	if len(diff.Removed) > 0 {
+		_, _ = fmt.Fprintln(tw, "Removed:")
+		for _, module := range diff.Removed {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\n", module.Name, module.Version)
+		}
+	}
+	if len(diff.Changed) > 0 {
+		_, _ = fmt.Fprintln(tw, "Changed:")
+		for _, change := range diff.Changed {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s -> %s\n", change.Name, change.FromVersion, change.ToVersion)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, sb.String())
+
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(compareApplicationsCmd)
+	compareApplicationsCmd.PersistentFlags().StringVarP(&params.ApplicationA, action.ApplicationA.Long, action.ApplicationA.Short, "", action.ApplicationA.Description)
+	compareApplicationsCmd.PersistentFlags().StringVarP(&params.ApplicationB, action.ApplicationB.Long, action.ApplicationB.Short, "", action.ApplicationB.Description)
+	compareApplicationsCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+
+	if err := compareApplicationsCmd.MarkPersistentFlagRequired(action.ApplicationA.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.ApplicationA, err).Error())
+		os.Exit(1)
+	}
+	if err := compareApplicationsCmd.MarkPersistentFlagRequired(action.ApplicationB.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.ApplicationB, err).Error())
+		os.Exit(1)
+	}
+}
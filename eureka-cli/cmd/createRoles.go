@@ -20,6 +20,7 @@ import (
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -27,26 +28,65 @@ import (
 var createRolesCmd = &cobra.Command{
 	Use:   "createRoles",
 	Short: "Create roles",
-	Long:  `Create all roles.`,
+	Long:  `Create all roles across all tenants, optionally filtered by a single tenant.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		run, err := New(action.CreateRoles)
 		if err != nil {
 			return err
 		}
 
-		return run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
-			return run.CreateRoles(consortiumName, tenantType)
-		})
+		return run.CreateRolesForAllTenants()
 	},
 }
 
+func (run *Run) CreateRolesForAllTenants() error {
+	var processedTenants []string
+	err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
+		tenants, err := run.createRoles(consortiumName, tenantType)
+		if err != nil {
+			return err
+		}
+		processedTenants = append(processedTenants, tenants...)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tenantFilter := run.Config.Action.Param.Tenant
+	if tenantFilter != "" && len(processedTenants) == 0 {
+		return errors.TenantNotFound(tenantFilter)
+	}
+	slog.Info(run.Config.Action.Name, "text", "Created roles across tenants", "count", len(processedTenants), "tenants", processedTenants)
+
+	return nil
+}
+
 func (run *Run) CreateRoles(consortiumName string, tenantType constant.TenantType) error {
-	return run.TenantPartition(consortiumName, tenantType, func(configTenant, tenantType string) error {
+	_, err := run.createRoles(consortiumName, tenantType)
+	return err
+}
+
+func (run *Run) createRoles(consortiumName string, tenantType constant.TenantType) ([]string, error) {
+	var processedTenants []string
+	err := run.filteredTenantPartition(consortiumName, tenantType, run.Config.Action.Param.Tenant, func(configTenant, tenantType string) error {
 		slog.Info(run.Config.Action.Name, "text", "CREATING ROLES", "tenant", configTenant)
-		return run.Config.KeycloakSvc.CreateRoles(configTenant)
+		if err := run.Config.KeycloakSvc.CreateRoles(configTenant); err != nil {
+			return err
+		}
+		processedTenants = append(processedTenants, configTenant)
+
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return processedTenants, nil
 }
 
 func init() {
 	rootCmd.AddCommand(createRolesCmd)
+	createRolesCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
 }
@@ -49,4 +49,5 @@ func (run *Run) CreateUsers(consortiumName string, tenantType constant.TenantTyp
 
 func init() {
 	rootCmd.AddCommand(createUsersCmd)
+	createUsersCmd.PersistentFlags().BoolVarP(&params.UpdateExisting, action.UpdateExisting.Long, action.UpdateExisting.Short, false, action.UpdateExisting.Description)
 }
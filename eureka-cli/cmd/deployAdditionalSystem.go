@@ -48,7 +48,7 @@ func (run *Run) DeployAdditionalSystem() error {
 	}
 
 	subCommand := append([]string{"compose", "--progress", "plain", "--ansi", "never", "--project-name", "eureka", "up", "--detach"}, finalRequiredContainers...)
-	return run.dockerComposeUp(subCommand, constant.DeployAdditionalSystemWait, "additional system")
+	return run.dockerComposeUp(subCommand, constant.AdditionalSystemWaitPhase, "additional system")
 }
 
 func init() {
@@ -16,12 +16,16 @@ limitations under the License.
 package cmd
 
 import (
+	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
 	"github.com/spf13/cobra"
 )
 
@@ -49,6 +53,9 @@ var deployApplicationCmd = &cobra.Command{
 			return err
 		}
 		slog.Info(run.Config.Action.Name, "text", "Command completed", "duration", time.Since(start))
+		if params.ServeReadiness != "" {
+			return run.ServeReadiness(cmd.Context(), params.ServeReadiness)
+		}
 
 		return nil
 	},
@@ -77,60 +84,155 @@ func (run *Run) DeployApplicationWithoutCleanup() error {
 }
 
 func (run *Run) DeployApplication() error {
-	if err := run.DeploySystem(); err != nil {
+	state, err := run.LoadDeployState()
+	if err != nil {
 		return err
 	}
-	if err := run.PingKongStatus(); err != nil {
+	if err := run.RunDeployPhase(state, constant.SystemPhase, func() error {
+		if err := run.DeploySystem(); err != nil {
+			return err
+		}
+		return run.PingKongStatus()
+	}); err != nil {
 		return err
 	}
-	if err := run.DeployManagement(); err != nil {
+	if err := run.RunDeployPhase(state, constant.ManagementPhase, run.DeployManagement); err != nil {
 		return err
 	}
-	if err := run.DeployModules(); err != nil {
+	if err := run.RunDeployPhase(state, constant.ApplicationPhase, run.DeployModules); err != nil {
 		return err
 	}
-	if err := run.CreateTenants(); err != nil {
+	if err := run.RunDeployPhase(state, constant.TenantsPhase, run.CreateTenants); err != nil {
 		return err
 	}
 	if err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
-		if err := run.CreateTenantEntitlements(consortiumName, tenantType); err != nil {
+		if err := run.RunDeployPhase(state, getPartitionPhase(constant.EntitlementsPhase, consortiumName, tenantType), func() error {
+			return run.CreateTenantEntitlements(consortiumName, tenantType)
+		}); err != nil {
 			return err
 		}
-		if err := run.CreateRoles(consortiumName, tenantType); err != nil {
+		if err := run.RunDeployPhase(state, getPartitionPhase(constant.RolesAndUsersPhase, consortiumName, tenantType), func() error {
+			if err := run.CreateRoles(consortiumName, tenantType); err != nil {
+				return err
+			}
+			return run.CreateUsers(consortiumName, tenantType)
+		}); err != nil {
 			return err
 		}
-		if err := run.CreateUsers(consortiumName, tenantType); err != nil {
-			return err
-		}
-		if err := run.AttachCapabilitySets(consortiumName, tenantType, constant.DeployApplicationPartitionWait, true); err != nil {
+		if err := run.RunDeployPhase(state, getPartitionPhase(constant.CapabilitySetsPhase, consortiumName, tenantType), func() error {
+			return run.AttachCapabilitySets(consortiumName, tenantType, run.getPhaseWait(constant.PartitionWaitPhase), true)
+		}); err != nil {
 			return err
 		}
 		if consortiumName != constant.NoneConsortium {
-			time.Sleep(constant.DeployApplicationPartitionWait)
+			run.waitBetweenPhases(constant.PartitionWaitPhase)
 		}
 
 		return nil
 	}); err != nil {
 		return err
 	}
-	if err := run.CreateConsortium(); err != nil {
+	if err := run.RunDeployPhase(state, constant.ConsortiumPhase, run.CreateConsortium); err != nil {
 		return err
 	}
 	return run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
-		if err := run.DeployUi(consortiumName, tenantType); err != nil {
-			return err
-		}
-		if err := run.UpdateKeycloakPublicClients(consortiumName, tenantType); err != nil {
-			return err
-		}
-		if helpers.IsModuleEnabled(constant.ModSearchModule, run.Config.Action.ConfigBackendModules) {
-			return run.ReindexIndices(consortiumName, tenantType)
-		}
+		return run.RunDeployPhase(state, getPartitionPhase(constant.UIPhase, consortiumName, tenantType), func() error {
+			if err := run.DeployUi(consortiumName, tenantType); err != nil {
+				return err
+			}
+			if err := run.UpdateKeycloakPublicClients(consortiumName, tenantType); err != nil {
+				return err
+			}
+			if helpers.IsModuleEnabled(constant.ModSearchModule, run.Config.Action.ConfigBackendModules) {
+				return run.ReindexIndices(consortiumName, tenantType)
+			}
 
-		return nil
+			return nil
+		})
 	})
 }
 
+// LoadDeployState reads the phases completed by a previous deploy when --resume is set,
+// otherwise it starts from an empty state
+func (run *Run) LoadDeployState() (*models.DeployState, error) {
+	state := &models.DeployState{}
+	if !run.Config.Action.Param.Resume {
+		return state, run.saveDeployState(state)
+	}
+
+	filePath, err := run.getDeployStateFilePath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		slog.Info(run.Config.Action.Name, "text", "No deploy state found, deploying all phases", "file", filepath.Base(filePath))
+		return state, nil
+	}
+	if err := helpers.ReadJSONFromFile(filePath, state); err != nil {
+		return nil, err
+	}
+	slog.Info(run.Config.Action.Name, "text", "Resuming deploy", "completedPhases", state.CompletedPhases)
+
+	return state, nil
+}
+
+// RunDeployPhase runs a deploy phase unless it was already completed,
+// and records the phase in the deploy state file once it succeeds
+func (run *Run) RunDeployPhase(state *models.DeployState, phase string, fn func() error) error {
+	if state.IsCompleted(phase) {
+		slog.Info(run.Config.Action.Name, "text", "Skipping completed deploy phase", "phase", phase)
+		return nil
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	state.MarkCompleted(phase)
+
+	return run.saveDeployState(state)
+}
+
+// RemoveDeployState removes the deploy state file of the profile and application so that the next deploy starts from scratch
+func (run *Run) RemoveDeployState() error {
+	filePath, err := run.getDeployStateFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (run *Run) saveDeployState(state *models.DeployState) error {
+	filePath, err := run.getDeployStateFilePath()
+	if err != nil {
+		return err
+	}
+
+	return helpers.WriteJSONToFile(filePath, state)
+}
+
+// getDeployStateFilePath keys the deploy state by profile and application so that
+// a resumed deploy never skips the phases completed for another profile or application
+func (run *Run) getDeployStateFilePath() (string, error) {
+	homeDir, err := helpers.GetHomeDirPath()
+	if err != nil {
+		return "", err
+	}
+	fileName := fmt.Sprintf(constant.DeployStateFilePattern, run.Config.Action.ConfigProfileName, run.Config.Action.ConfigApplicationID)
+
+	return filepath.Join(homeDir, fileName), nil
+}
+
+func getPartitionPhase(phase string, consortiumName string, tenantType constant.TenantType) string {
+	if consortiumName == constant.NoneConsortium {
+		return phase
+	}
+
+	return fmt.Sprintf("%s:%s:%s", phase, consortiumName, tenantType)
+}
+
 func (run *Run) DeployChildApplication() error {
 	if err := run.DeployAdditionalSystem(); err != nil {
 		return err
@@ -157,4 +259,17 @@ func init() {
 	deployApplicationCmd.PersistentFlags().BoolVarP(&params.OnlyRequired, action.OnlyRequired.Long, action.OnlyRequired.Short, false, action.OnlyRequired.Description)
 	deployApplicationCmd.PersistentFlags().BoolVarP(&params.Cleanup, action.Cleanup.Long, action.Cleanup.Short, false, action.Cleanup.Description)
 	deployApplicationCmd.PersistentFlags().BoolVarP(&params.SkipRegistry, action.SkipRegistry.Long, action.SkipRegistry.Short, false, action.SkipRegistry.Description)
+	deployApplicationCmd.PersistentFlags().StringSliceVarP(&params.IncludeModules, action.IncludeModules.Long, action.IncludeModules.Short, []string{}, action.IncludeModules.Description)
+	deployApplicationCmd.PersistentFlags().StringSliceVarP(&params.ExcludeModules, action.ExcludeModules.Long, action.ExcludeModules.Short, []string{}, action.ExcludeModules.Description)
+	deployApplicationCmd.PersistentFlags().BoolVarP(&params.SkipModuleDiscovery, action.SkipModuleDiscovery.Long, action.SkipModuleDiscovery.Short, false, action.SkipModuleDiscovery.Description)
+	deployApplicationCmd.PersistentFlags().BoolVarP(&params.NoCache, action.NoCache.Long, action.NoCache.Short, false, action.NoCache.Description)
+	deployApplicationCmd.PersistentFlags().BoolVarP(&params.ClearCache, action.ClearCache.Long, action.ClearCache.Short, false, action.ClearCache.Description)
+	deployApplicationCmd.PersistentFlags().BoolVarP(&params.Resume, action.Resume.Long, action.Resume.Short, false, action.Resume.Description)
+	deployApplicationCmd.PersistentFlags().StringVarP(&params.ServeReadiness, action.ServeReadiness.Long, action.ServeReadiness.Short, "", action.ServeReadiness.Description)
+	deployApplicationCmd.PersistentFlags().IntVarP(&params.HealthcheckConcurrency, action.HealthcheckConcurrency.Long, action.HealthcheckConcurrency.Short, constant.HealthcheckConcurrency, action.HealthcheckConcurrency.Description)
+	deployApplicationCmd.PersistentFlags().DurationVarP(&params.HealthcheckMinInterval, action.HealthcheckMinInterval.Long, action.HealthcheckMinInterval.Short, constant.ModuleReadinessMinWait, action.HealthcheckMinInterval.Description)
+	deployApplicationCmd.PersistentFlags().DurationVarP(&params.HealthcheckInterval, action.HealthcheckInterval.Long, action.HealthcheckInterval.Short, constant.ModuleReadinessWait, action.HealthcheckInterval.Description)
+	deployApplicationCmd.PersistentFlags().IntVarP(&params.HealthcheckMaxAttempts, action.HealthcheckMaxAttempts.Long, action.HealthcheckMaxAttempts.Short, constant.ModuleReadinessMaxRetries, action.HealthcheckMaxAttempts.Description)
+	deployApplicationCmd.PersistentFlags().IntVarP(&params.AttachRetries, action.AttachRetries.Long, action.AttachRetries.Short, 0, action.AttachRetries.Description)
+	deployApplicationCmd.PersistentFlags().DurationVarP(&params.AttachRetryWait, action.AttachRetryWait.Long, action.AttachRetryWait.Short, constant.AttachCapabilitySetsRetryWait, action.AttachRetryWait.Description)
 }
@@ -17,7 +17,6 @@ package cmd
 
 import (
 	"log/slog"
-	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
@@ -79,7 +78,7 @@ func (run *Run) DeployManagement() error {
 	if len(newlyDeployed) == 0 {
 		slog.Info(run.Config.Action.Name, "text", "All management modules already deployed, skipping healthchecks")
 	} else {
-		time.Sleep(constant.DeployManagementWait)
+		run.waitBetweenPhases(constant.ManagementWaitPhase)
 
 		slog.Info(run.Config.Action.Name, "text", "WAITING FOR MANAGEMENT MODULES TO BECOME READY")
 		if err := run.CheckDeployedModuleReadiness(constant.Management, newlyDeployed); err != nil {
@@ -103,4 +102,8 @@ func (run *Run) DeployManagement() error {
 func init() {
 	rootCmd.AddCommand(deployManagementCmd)
 	deployManagementCmd.PersistentFlags().BoolVarP(&params.SkipRegistry, action.SkipRegistry.Long, action.SkipRegistry.Short, false, action.SkipRegistry.Description)
+	deployManagementCmd.PersistentFlags().IntVarP(&params.HealthcheckConcurrency, action.HealthcheckConcurrency.Long, action.HealthcheckConcurrency.Short, constant.HealthcheckConcurrency, action.HealthcheckConcurrency.Description)
+	deployManagementCmd.PersistentFlags().DurationVarP(&params.HealthcheckMinInterval, action.HealthcheckMinInterval.Long, action.HealthcheckMinInterval.Short, constant.ModuleReadinessMinWait, action.HealthcheckMinInterval.Description)
+	deployManagementCmd.PersistentFlags().DurationVarP(&params.HealthcheckInterval, action.HealthcheckInterval.Long, action.HealthcheckInterval.Short, constant.ModuleReadinessWait, action.HealthcheckInterval.Description)
+	deployManagementCmd.PersistentFlags().IntVarP(&params.HealthcheckMaxAttempts, action.HealthcheckMaxAttempts.Long, action.HealthcheckMaxAttempts.Short, constant.ModuleReadinessMaxRetries, action.HealthcheckMaxAttempts.Description)
 }
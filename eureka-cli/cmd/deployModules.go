@@ -17,7 +17,6 @@ package cmd
 
 import (
 	"log/slog"
-	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
@@ -43,24 +42,20 @@ var deployModulesCmd = &cobra.Command{
 }
 
 func (run *Run) DeployModules() error {
-	slog.Info(run.Config.Action.Name, "text", "READING BACKEND MODULES")
-	backendModules, err := run.Config.ModuleProps.ReadBackendModules(false, true)
-	if err != nil {
-		return err
+	if run.Config.Action.Param.ClearCache {
+		if err := run.InvalidateDescriptorCache(""); err != nil {
+			return err
+		}
 	}
 
-	slog.Info(run.Config.Action.Name, "text", "READING FRONTEND MODULES")
-	frontendModules, err := run.Config.ModuleProps.ReadFrontendModules(true)
+	extract, err := run.readRegistryExtract()
 	if err != nil {
 		return err
 	}
-
-	slog.Info(run.Config.Action.Name, "text", "READING BACKEND MODULE REGISTRIES")
-	modules, err := run.Config.RegistrySvc.GetModules(true, true)
-	if err != nil {
-		return err
+	if run.Config.Action.Param.DryRun {
+		slog.Info(run.Config.Action.Name, "text", "PRINTING APPLICATION PAYLOADS")
+		return run.Config.ManagementSvc.CreateApplication(extract)
 	}
-	run.Config.RegistrySvc.ResolveModuleMetadata(modules)
 
 	client, err := run.Config.DockerClient.Create()
 	if err != nil {
@@ -73,8 +68,8 @@ func (run *Run) DeployModules() error {
 
 	slog.Info(run.Config.Action.Name, "text", "PREPARING SIDECAR IMAGE")
 	containers := &models.Containers{
-		Modules:        modules,
-		BackendModules: backendModules,
+		Modules:        extract.Modules,
+		BackendModules: extract.BackendModules,
 		IsManagement:   false,
 	}
 	sidecarImage, pullSidecarImage, err := run.Config.ModuleSvc.GetSidecarImage(containers.Modules.EurekaModules)
@@ -91,7 +86,10 @@ func (run *Run) DeployModules() error {
 	}
 
 	slog.Info(run.Config.Action.Name, "text", "DEPLOYING MODULES")
-	sidecarResources := helpers.CreateResources(false, run.Config.Action.ConfigSidecarModuleResources)
+	sidecarResources, err := helpers.CreateResources(false, run.Config.Action.ConfigSidecarModuleResources)
+	if err != nil {
+		return err
+	}
 	newlyDeployed, totalMatched, err := run.Config.ModuleSvc.DeployModules(client, containers, sidecarImage, sidecarResources)
 	if err != nil {
 		return err
@@ -102,7 +100,7 @@ func (run *Run) DeployModules() error {
 	if len(newlyDeployed) == 0 {
 		slog.Info(run.Config.Action.Name, "text", "All modules already deployed, skipping healthchecks")
 	} else {
-		time.Sleep(constant.DeployModulesWait)
+		run.waitBetweenPhases(constant.ModulesWaitPhase)
 
 		slog.Info(run.Config.Action.Name, "text", "WAITING FOR MODULES TO BECOME READY")
 		if err := run.CheckDeployedModuleReadiness(constant.Module, newlyDeployed); err != nil {
@@ -115,15 +113,48 @@ func (run *Run) DeployModules() error {
 		return err
 	}
 
-	return run.Config.ManagementSvc.CreateApplication(&models.RegistryExtract{
-		Modules:           modules,
-		BackendModules:    backendModules,
-		FrontendModules:   frontendModules,
-		ModuleDescriptors: make(map[string]any),
-	})
+	return run.Config.ManagementSvc.CreateApplication(extract)
+}
+
+// readRegistryExtract reads the configured backend and frontend modules together with the registry modules they resolve to
+func (run *Run) readRegistryExtract() (*models.RegistryExtract, error) {
+	slog.Info(run.Config.Action.Name, "text", "READING BACKEND MODULES")
+	backendModules, err := run.Config.ModuleProps.ReadBackendModules(false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info(run.Config.Action.Name, "text", "READING FRONTEND MODULES")
+	frontendModules, err := run.Config.ModuleProps.ReadFrontendModules(true)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info(run.Config.Action.Name, "text", "READING BACKEND MODULE REGISTRIES")
+	modules, err := run.Config.RegistrySvc.GetModules(true, true)
+	if err != nil {
+		return nil, err
+	}
+	run.Config.RegistrySvc.ResolveModuleMetadata(modules)
+
+	return &models.RegistryExtract{
+		Modules:         modules,
+		BackendModules:  backendModules,
+		FrontendModules: frontendModules,
+	}, nil
 }
 
 func init() {
 	rootCmd.AddCommand(deployModulesCmd)
 	deployModulesCmd.PersistentFlags().BoolVarP(&params.SkipRegistry, action.SkipRegistry.Long, action.SkipRegistry.Short, false, action.SkipRegistry.Description)
+	deployModulesCmd.PersistentFlags().StringSliceVarP(&params.IncludeModules, action.IncludeModules.Long, action.IncludeModules.Short, []string{}, action.IncludeModules.Description)
+	deployModulesCmd.PersistentFlags().StringSliceVarP(&params.ExcludeModules, action.ExcludeModules.Long, action.ExcludeModules.Short, []string{}, action.ExcludeModules.Description)
+	deployModulesCmd.PersistentFlags().BoolVarP(&params.DryRun, action.DryRun.Long, action.DryRun.Short, false, action.DryRun.Description)
+	deployModulesCmd.PersistentFlags().BoolVarP(&params.SkipModuleDiscovery, action.SkipModuleDiscovery.Long, action.SkipModuleDiscovery.Short, false, action.SkipModuleDiscovery.Description)
+	deployModulesCmd.PersistentFlags().BoolVarP(&params.NoCache, action.NoCache.Long, action.NoCache.Short, false, action.NoCache.Description)
+	deployModulesCmd.PersistentFlags().BoolVarP(&params.ClearCache, action.ClearCache.Long, action.ClearCache.Short, false, action.ClearCache.Description)
+	deployModulesCmd.PersistentFlags().IntVarP(&params.HealthcheckConcurrency, action.HealthcheckConcurrency.Long, action.HealthcheckConcurrency.Short, constant.HealthcheckConcurrency, action.HealthcheckConcurrency.Description)
+	deployModulesCmd.PersistentFlags().DurationVarP(&params.HealthcheckMinInterval, action.HealthcheckMinInterval.Long, action.HealthcheckMinInterval.Short, constant.ModuleReadinessMinWait, action.HealthcheckMinInterval.Description)
+	deployModulesCmd.PersistentFlags().DurationVarP(&params.HealthcheckInterval, action.HealthcheckInterval.Long, action.HealthcheckInterval.Short, constant.ModuleReadinessWait, action.HealthcheckInterval.Description)
+	deployModulesCmd.PersistentFlags().IntVarP(&params.HealthcheckMaxAttempts, action.HealthcheckMaxAttempts.Long, action.HealthcheckMaxAttempts.Short, constant.ModuleReadinessMaxRetries, action.HealthcheckMaxAttempts.Description)
 }
@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// deployPlanCmd represents the deployPlan command
+var deployPlanCmd = &cobra.Command{
+	Use:   "deployPlan",
+	Short: "Show deployment plan",
+	Long:  `Show the order in which the modules of an application would be deployed, without deploying them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.DeployPlan)
+		if err != nil {
+			return err
+		}
+
+		return run.DeployPlan(params.ApplicationID, os.Stdout)
+	},
+}
+
+// DeployPlan prints the modules of an application in layers of the dependency order, modules of a layer
+// only require interfaces provided by the preceding layers, a dependency cycle is printed and returned as an error
+func (run *Run) DeployPlan(applicationID string, writer io.Writer) error {
+	graph, err := run.getApplicationDependencyGraph(applicationID)
+	if err != nil {
+		return err
+	}
+
+	layers, cyclic := graph.DeploymentLayers()
+	var sb strings.Builder
+	sb.WriteString(graph.ApplicationID + "\n")
+	for i, layer := range layers {
+		_, _ = fmt.Fprintf(&sb, "Layer %d:\n", i+1)
+		for _, moduleID := range layer {
+			sb.WriteString("  " + moduleID + "\n")
+		}
+	}
+	if len(cyclic) > 0 {
+		sb.WriteString("Unresolved due to a dependency cycle:\n")
+		for _, moduleID := range cyclic {
+			sb.WriteString("  " + moduleID + "\n")
+		}
+	}
+	if _, err := io.WriteString(writer, sb.String()); err != nil {
+		return err
+	}
+	if len(cyclic) > 0 {
+		return errors.DependencyCycleDetected(cyclic)
+	}
+
+	return nil
+}
+
+// getApplicationDependencyGraph builds the graph of the configured application from the module descriptors in the registries,
+// the graph of another application is read from its registered descriptor
+func (run *Run) getApplicationDependencyGraph(applicationID string) (*models.ApplicationDependencyGraph, error) {
+	if applicationID != "" && applicationID != run.Config.Action.ConfigApplicationID {
+		if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+			return nil, err
+		}
+
+		return run.Config.ManagementSvc.GetApplicationDependencies(applicationID)
+	}
+
+	extract, err := run.readRegistryExtract()
+	if err != nil {
+		return nil, err
+	}
+
+	return run.Config.ManagementSvc.GetRegistryDependencies(extract)
+}
+
+func init() {
+	rootCmd.AddCommand(deployPlanCmd)
+	deployPlanCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+}
@@ -18,14 +18,16 @@ package cmd
 import (
 	"fmt"
 	"log/slog"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
 )
 
 // deploySystemCmd represents the deploySystem command
@@ -61,14 +63,19 @@ func (run *Run) DeploySystem() error {
 		subCommand = append(subCommand, finalRequiredContainers...)
 	}
 
-	return run.dockerComposeUp(subCommand, constant.DeploySystemWait, "system")
+	return run.dockerComposeUp(subCommand, constant.SystemWaitPhase, "system")
 }
 
-func (run *Run) dockerComposeUp(subCommand []string, wait time.Duration, label string) error {
+func (run *Run) dockerComposeUp(subCommand []string, phase string, label string) error {
 	homeDir, err := helpers.GetHomeMiscDir()
 	if err != nil {
 		return err
 	}
+	composeFileArgs, err := run.writeComposeResourcesOverride(homeDir)
+	if err != nil {
+		return err
+	}
+	subCommand = append(append([]string{subCommand[0]}, composeFileArgs...), subCommand[1:]...)
 	dockerCmd := exec.Command("docker", subCommand...)
 	dockerCmd.Dir = homeDir
 
@@ -80,7 +87,7 @@ func (run *Run) dockerComposeUp(subCommand []string, wait time.Duration, label s
 	combined := stdout.String() + stderr.String()
 	if strings.Contains(combined, " Started") || strings.Contains(combined, " Created") {
 		slog.Info(run.Config.Action.Name, "text", "WAITING FOR "+strings.ToUpper(label)+" CONTAINERS TO BECOME READY")
-		time.Sleep(wait)
+		run.waitBetweenPhases(phase)
 		slog.Info(run.Config.Action.Name, "text", fmt.Sprintf("All %s containers are ready", label))
 	} else {
 		slog.Info(run.Config.Action.Name, "text", fmt.Sprintf("All %s containers already running, skipping wait", label))
@@ -89,6 +96,25 @@ func (run *Run) dockerComposeUp(subCommand []string, wait time.Duration, label s
 	return nil
 }
 
+// writeComposeResourcesOverride generates the resources override of the system containers and returns
+// the compose -f chain that includes it, no flags are returned when no system container sets a limit
+func (run *Run) writeComposeResourcesOverride(homeDir string) ([]string, error) {
+	override := helpers.CreateComposeResourcesOverride(run.Config.Action.ConfigSystemContainers)
+	if override == nil {
+		return nil, nil
+	}
+	content, err := yaml.Marshal(override)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(filepath.Join(homeDir, constant.DockerComposeResourcesFile), content, 0644); err != nil {
+		return nil, err
+	}
+	slog.Info(run.Config.Action.Name, "text", "Generated system container resources override", "file", constant.DockerComposeResourcesFile)
+
+	return []string{"-f", constant.DockerComposeFile, "-f", constant.DockerComposeResourcesFile}, nil
+}
+
 func init() {
 	rootCmd.AddCommand(deploySystemCmd)
 	deploySystemCmd.PersistentFlags().BoolVarP(&params.BuildImages, action.BuildImages.Long, action.BuildImages.Short, false, action.BuildImages.Description)
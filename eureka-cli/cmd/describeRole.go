@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// describeRoleCmd represents the describeRole command
+var describeRoleCmd = &cobra.Command{
+	Use:   "describeRole",
+	Short: "Describe role",
+	Long:  `Describe a single role with its attached capability sets and capabilities.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.DescribeRole)
+		if err != nil {
+			return err
+		}
+
+		return run.DescribeRole(params.Tenant, params.RoleName, os.Stdout)
+	},
+}
+
+func (run *Run) DescribeRole(tenantName string, roleName string, writer io.Writer) error {
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		return err
+	}
+
+	roleDetail, err := run.Config.KeycloakSvc.DescribeRole(tenantName, roleName)
+	if err != nil {
+		return err
+	}
+	if run.Config.Action.Param.JSON {
+		return writeRoleDetailJSON(writer, roleDetail)
+	}
+
+	return writeRoleDetail(writer, roleDetail)
+}
+
+func writeRoleDetailJSON(writer io.Writer, roleDetail *models.KeycloakRoleDetail) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(roleDetail)
+}
+
+func writeRoleDetail(writer io.Writer, roleDetail *models.KeycloakRoleDetail) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Role:\t%s\n", roleDetail.Role.Name)
+	_, _ = fmt.Fprintf(tw, "ID:\t%s\n", roleDetail.Role.ID)
+	_, _ = fmt.Fprintf(tw, "Description:\t%s\n", roleDetail.Role.Description)
+
+	_, _ = fmt.Fprintf(tw, "\nCapability sets (%d):\n", len(roleDetail.CapabilitySets))
+	for _, capabilitySet := range roleDetail.CapabilitySets {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\n", capabilitySet.Name, capabilitySet.ApplicationID, capabilitySet.ID)
+	}
+
+	_, _ = fmt.Fprintf(tw, "\nCapabilities (%d):\n", len(roleDetail.Capabilities))
+	for _, capability := range roleDetail.Capabilities {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\n", capability.Name, capability.Permission, capability.ID)
+	}
+
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(describeRoleCmd)
+	describeRoleCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	describeRoleCmd.PersistentFlags().StringVarP(&params.RoleName, action.RoleName.Long, action.RoleName.Short, "", action.RoleName.Description)
+	describeRoleCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+	if err := describeRoleCmd.MarkPersistentFlagRequired(action.Tenant.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.Tenant, err).Error())
+		os.Exit(1)
+	}
+	if err := describeRoleCmd.MarkPersistentFlagRequired(action.RoleName.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.RoleName, err).Error())
+		os.Exit(1)
+	}
+}
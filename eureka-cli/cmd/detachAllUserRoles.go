@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/spf13/cobra"
+)
+
+// detachAllUserRolesCmd represents the detachAllUserRoles command
+var detachAllUserRolesCmd = &cobra.Command{
+	Use:   "detachAllUserRoles",
+	Short: "Detach all user roles",
+	Long:  `Detach all roles from all users in a tenant without removing the users.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.DetachAllUserRoles)
+		if err != nil {
+			return err
+		}
+
+		return run.DetachAllUserRoles(params.Tenant)
+	},
+}
+
+func (run *Run) DetachAllUserRoles(tenantName string) error {
+	if !run.Config.Action.Param.Confirm {
+		return errors.ConfirmationRequired(run.Config.Action.Name)
+	}
+
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		return err
+	}
+
+	slog.Info(run.Config.Action.Name, "text", "DETACHING ALL USER ROLES", "tenant", tenantName)
+	return run.Config.KeycloakSvc.DetachAllUserRoles(tenantName)
+}
+
+func init() {
+	rootCmd.AddCommand(detachAllUserRolesCmd)
+	detachAllUserRolesCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	detachAllUserRolesCmd.PersistentFlags().BoolVarP(&params.Confirm, action.Confirm.Long, action.Confirm.Short, false, action.Confirm.Description)
+	if err := detachAllUserRolesCmd.MarkPersistentFlagRequired(action.Tenant.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.Tenant, err).Error())
+		os.Exit(1)
+	}
+}
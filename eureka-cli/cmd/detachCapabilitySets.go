@@ -27,7 +27,7 @@ import (
 var detachCapabilitySetsCmd = &cobra.Command{
 	Use:   "detachCapabilitySets",
 	Short: "Detach capability sets",
-	Long:  `Detach all capability sets from roles.`,
+	Long:  `Detach all capability sets from roles, or only the named capability sets.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		run, err := New(action.DetachCapabilitySets)
 		if err != nil {
@@ -43,7 +43,14 @@ var detachCapabilitySetsCmd = &cobra.Command{
 func (run *Run) DetachCapabilitySets(consortiumName string, tenantType constant.TenantType) error {
 	return run.TenantPartition(consortiumName, tenantType, func(configTenant, tenantType string) error {
 		slog.Info(run.Config.Action.Name, "text", "DETACHING CAPABILITY SETS", "tenant", configTenant)
-		if err := run.Config.KeycloakSvc.DetachCapabilitySetsFromRoles(configTenant); err != nil {
+		if len(run.Config.Action.Param.CapabilitySetNames) > 0 {
+			err := run.Config.KeycloakSvc.DetachNamedCapabilitySetsFromRoles(configTenant, run.Config.Action.Param.RoleName, run.Config.Action.Param.CapabilitySetNames)
+			if err != nil {
+				slog.Warn(run.Config.Action.Name, "text", "Capability sets detachment was unsuccessful", "tenant", configTenant, "error", err)
+			}
+			return nil
+		}
+		if _, err := run.Config.KeycloakSvc.DetachCapabilitySetsFromRoles(configTenant); err != nil {
 			slog.Warn(run.Config.Action.Name, "text", "Capability sets detachment was unsuccessful", "tenant", configTenant, "error", err)
 		}
 
@@ -53,4 +60,6 @@ func (run *Run) DetachCapabilitySets(consortiumName string, tenantType constant.
 
 func init() {
 	rootCmd.AddCommand(detachCapabilitySetsCmd)
+	detachCapabilitySetsCmd.PersistentFlags().StringSliceVarP(&params.CapabilitySetNames, action.CapabilitySetNames.Long, action.CapabilitySetNames.Short, []string{}, action.CapabilitySetNames.Description)
+	detachCapabilitySetsCmd.PersistentFlags().StringVarP(&params.RoleName, action.RoleName.Long, action.RoleName.Short, "", action.RoleName.Description)
 }
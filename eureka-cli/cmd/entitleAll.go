@@ -0,0 +1,141 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// entitleAllCmd represents the entitleAll command
+var entitleAllCmd = &cobra.Command{
+	Use:   "entitleAll",
+	Short: "Entitle all tenants to an application",
+	Long:  `Entitle every configured tenant to an application other than the configured one and print a combined summary.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.EntitleAll)
+		if err != nil {
+			return err
+		}
+
+		return run.EntitleAll(params.ApplicationID, params.Concurrency, os.Stdout)
+	},
+}
+
+// EntitleAll entitles the configured tenants to an application, consortium partitions are entitled one after another
+// and the tenants of a partition concurrently, the command fails after all tenants were processed when any entitlement failed
+func (run *Run) EntitleAll(applicationID string, concurrency int, writer io.Writer) error {
+	if applicationID == "" {
+		return errors.RequiredParameterMissing(action.ApplicationID.Long)
+	}
+	if concurrency <= 0 {
+		return errors.NonPositiveParameter(action.Concurrency.Long, concurrency)
+	}
+	slog.Info(run.Config.Action.Name, "text", "ENTITLING ALL TENANTS", "application", applicationID, "concurrency", concurrency)
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	var results []models.TenantEntitlementResult
+	err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
+		partitionResults, err := run.Config.ManagementSvc.EntitleTenants(consortiumName, tenantType, applicationID, concurrency)
+		if err != nil {
+			return err
+		}
+		results = append(results, partitionResults...)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if run.Config.Action.Param.JSON {
+		err = writeTenantEntitlementResultsJSON(writer, results)
+	} else {
+		err = writeTenantEntitlementResults(writer, results)
+	}
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Status == models.TenantEntitlementFailed {
+			failed = append(failed, result.Tenant)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.TenantEntitlementsFailed(applicationID, failed)
+	}
+
+	return nil
+}
+
+func writeTenantEntitlementResultsJSON(writer io.Writer, results []models.TenantEntitlementResult) error {
+	if results == nil {
+		results = []models.TenantEntitlementResult{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(results)
+}
+
+func writeTenantEntitlementResults(writer io.Writer, results []models.TenantEntitlementResult) error {
+	counts := make(map[models.TenantEntitlementStatus]int)
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TENANT\tSTATUS\tDETAILS")
+	for _, result := range results {
+		counts[result.Status]++
+		details := result.FlowID
+		if result.Status == models.TenantEntitlementFailed {
+			details = result.Error
+		}
+		if details == "" {
+			details = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Tenant, result.Status, details)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "\nEntitled %d, skipped %d, failed %d of %d tenants\n",
+		counts[models.TenantEntitlementEntitled], counts[models.TenantEntitlementSkipped], counts[models.TenantEntitlementFailed], len(results))
+
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(entitleAllCmd)
+	entitleAllCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+	entitleAllCmd.PersistentFlags().IntVarP(&params.Concurrency, action.Concurrency.Long, action.Concurrency.Short, constant.TenantEntitlementConcurrency, action.Concurrency.Description)
+	entitleAllCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+	if err := entitleAllCmd.MarkPersistentFlagRequired(action.ApplicationID.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.ApplicationID, err).Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// exportRoleMappingsCmd represents the exportRoleMappings command
+var exportRoleMappingsCmd = &cobra.Command{
+	Use:   "exportRoleMappings",
+	Short: "Export role mappings",
+	Long:  `Export the capability sets attached to each role of a tenant in the roles config shape.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.ExportRoleMappings)
+		if err != nil {
+			return err
+		}
+
+		return run.ExportRoleMappings(params.Tenant, os.Stdout)
+	},
+}
+
+func (run *Run) ExportRoleMappings(tenantName string, writer io.Writer) error {
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		return err
+	}
+
+	roleMappings, err := run.Config.KeycloakSvc.ExportRoleMappings(tenantName)
+	if err != nil {
+		return err
+	}
+	if run.Config.Action.Param.JSON {
+		return writeRoleMappingsJSON(writer, roleMappings)
+	}
+
+	return writeRoleMappingsYAML(writer, roleMappings)
+}
+
+func writeRoleMappingsJSON(writer io.Writer, roleMappings *models.KeycloakRoleMappings) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(roleMappings)
+}
+
+func writeRoleMappingsYAML(writer io.Writer, roleMappings *models.KeycloakRoleMappings) error {
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(roleMappings); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+func init() {
+	rootCmd.AddCommand(exportRoleMappingsCmd)
+	exportRoleMappingsCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	exportRoleMappingsCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+	if err := exportRoleMappingsCmd.MarkPersistentFlagRequired(action.Tenant.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.Tenant, err).Error())
+		os.Exit(1)
+	}
+}
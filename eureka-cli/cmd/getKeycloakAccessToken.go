@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
@@ -77,6 +78,16 @@ func (run *Run) GetKeycloakAccessToken(tokenType, tenant string) (string, error)
 }
 
 func (run *Run) setKeycloakAccessTokenIntoContext(tenant string) error {
+	if run.Config.Action.Param.AccessTokenEnv != "" {
+		accessToken, err := getAccessTokenFromEnv(run.Config.Action.Param.AccessTokenEnv, tenant)
+		if err != nil {
+			return err
+		}
+		run.Config.Action.KeycloakAccessToken = accessToken
+
+		return nil
+	}
+
 	accessToken, err := run.Config.KeycloakSvc.GetAccessToken(tenant)
 	if err != nil {
 		return err
@@ -86,6 +97,40 @@ func (run *Run) setKeycloakAccessTokenIntoContext(tenant string) error {
 	return nil
 }
 
+// setTenantAccessTokenIntoContext sets a tenant access token, the vault root token is only
+// acquired when the access token is not injected through the environment
+func (run *Run) setTenantAccessTokenIntoContext(tenant string) error {
+	if run.Config.Action.Param.AccessTokenEnv != "" {
+		return run.setKeycloakAccessTokenIntoContext(tenant)
+	}
+
+	client, err := run.Config.DockerClient.Create()
+	if err != nil {
+		return err
+	}
+	defer run.Config.DockerClient.Close(client)
+
+	if err := run.setVaultRootTokenIntoContext(client); err != nil {
+		return err
+	}
+
+	return run.setKeycloakAccessTokenIntoContext(tenant)
+}
+
+func getAccessTokenFromEnv(envTemplate string, tenant string) (string, error) {
+	envName := envTemplate
+	if strings.Contains(envTemplate, "%s") {
+		envName = fmt.Sprintf(envTemplate, strings.ToUpper(tenant))
+	}
+
+	accessToken := os.Getenv(envName)
+	if accessToken == "" {
+		return "", errors.AccessTokenEnvNotSet(envName)
+	}
+
+	return accessToken, nil
+}
+
 func (run *Run) setKeycloakMasterAccessTokenIntoContext(grantType constant.KeycloakGrantType) error {
 	accessToken, err := run.Config.KeycloakSvc.GetMasterAccessToken(grantType)
 	if err != nil {
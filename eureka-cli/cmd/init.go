@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/spf13/cobra"
+)
+
+const sampleConfigFile = "config.sample.yaml"
+
+// sampleConfig is a minimal working template, every key is read by the CLI (see the field package)
+const sampleConfig = `# Sample eureka-cli config, use it with: eureka-cli -c config.sample.yaml deployApplication
+profile:
+  # Profile name, used in container names and log file names
+  name: sample
+application:
+  # Application name and version registered in mgr-applications
+  name: app-sample
+  version: 1.0.0
+  # Fetch module descriptors from the registry instead of sending descriptor URLs
+  fetch-descriptors: false
+  # Host port range used for modules without an explicit port
+  port-start: 30000
+  port-end: 30999
+# Description of applications and roles that do not set their own
+default-description: Default
+lsp:
+  # Platform descriptor listing the applications and their module versions
+  url: https://raw.githubusercontent.com/folio-org/platform-lsp/refs/heads/snapshot/platform-descriptor.json
+far:
+  # FOLIO Application Registry
+  url: https://far.ci.folio.org
+registry:
+  # Module descriptor registry
+  url: https://folio-registry.dev.folio.org
+ports:
+  # Host ports of the gateway and vault, override when they are remapped
+  gateway: 8000
+  gateway-admin: 8001
+  vault: 8200
+environment:
+  # Shared environment passed to every backend module
+  ENV: folio
+  DB_HOST: postgres.eureka
+  DB_PORT: 5432
+  DB_DATABASE: folio
+  DB_USERNAME: folio_rw
+  DB_PASSWORD: supersecret
+  KAFKA_HOST: kafka.eureka
+  KAFKA_PORT: 9092
+  KC_URL: http://keycloak.eureka:8080
+tenants:
+  # Tenant name as the key, an empty entry uses the defaults
+  diku:
+    deploy-ui: false
+roles:
+  # Role name as the key, capability-sets accepts set names, ["all"] or all:<application id> scopes
+  diku_admin_role:
+    tenant: diku
+    capability-sets: ["all"]
+users:
+  # Username as the key, roles reference the role names above
+  diku_admin:
+    tenant: diku
+    password: admin
+    first-name: DIKU
+    last-name: Admin
+    roles: ["diku_admin_role"]
+    # Optional, one of 001 (mail), 002 (email, default), 003 (text message), 004 (phone), 005 (mobile phone)
+    preferred-contact-type-id: "002"
+sidecar-module:
+  image: folio-module-sidecar
+  environment:
+    ENV: folio
+    KAFKA_HOST: kafka.eureka
+    KAFKA_PORT: 9092
+backend-modules:
+  # Management modules
+  mgr-applications:
+    port: 9901
+    use-vault: true
+  mgr-tenants:
+    port: 9902
+    use-vault: true
+  mgr-tenant-entitlements:
+    port: 9903
+    use-vault: true
+  # Application modules, an empty entry uses the defaults
+  mod-users-keycloak:
+    use-vault: true
+  mod-users:
+  mod-roles-keycloak:
+    use-vault: true
+    # Extra environment for this module only
+    environment:
+      KC_CONFIG_TTL: 3600s
+    # Container resource limits
+    resources:
+      memory: 600
+frontend-modules:
+  # UI modules, set deploy-module: false to exclude one
+  folio_users:
+`
+
+// initCmd represents the init command
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Generate a sample config",
+	Long:  `Generate a commented sample config file with tenants, users, roles, application and modules.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WriteSampleConfig(params.Output, params.OverwriteFiles)
+	},
+}
+
+func WriteSampleConfig(outputPath string, overwrite bool) error {
+	if outputPath == "" {
+		outputPath = sampleConfigFile
+	}
+	if _, err := os.Stat(outputPath); err == nil && !overwrite {
+		return errors.FileAlreadyExists(outputPath)
+	}
+	if err := os.WriteFile(outputPath, []byte(sampleConfig), 0600); err != nil {
+		return err
+	}
+	slog.Info(action.Init, "text", "Wrote sample config", "file", outputPath)
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.PersistentFlags().StringVarP(&params.Output, action.Output.Long, action.Output.Short, sampleConfigFile, action.Output.Description)
+}
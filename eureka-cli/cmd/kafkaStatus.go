@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// kafkaStatusCmd represents the kafkaStatus command
+var kafkaStatusCmd = &cobra.Command{
+	Use:   "kafkaStatus",
+	Short: "Kafka consumer group status",
+	Long:  `Describe a Kafka consumer group and print the offsets, lag and member of every partition.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.KafkaStatus)
+		if err != nil {
+			return err
+		}
+
+		return run.KafkaStatus(params.Group, params.Native, os.Stdout)
+	},
+}
+
+// KafkaStatus prints the partitions of a consumer group, by default of the capability consumer group awaited by attachCapabilitySets
+func (run *Run) KafkaStatus(consumerGroup string, native bool, writer io.Writer) error {
+	if consumerGroup == "" {
+		consumerGroup = run.Config.KafkaSvc.GetConsumerGroup()
+	}
+	slog.Info(run.Config.Action.Name, "text", "Describing consumer group", "consumerGroup", consumerGroup, "native", native)
+
+	partitions, err := run.Config.KafkaSvc.DescribeConsumerGroup(consumerGroup, native)
+	if err != nil {
+		return err
+	}
+	if run.Config.Action.Param.JSON {
+		return writeConsumerGroupPartitionsJSON(writer, partitions)
+	}
+
+	return writeConsumerGroupPartitions(writer, partitions)
+}
+
+func writeConsumerGroupPartitionsJSON(writer io.Writer, partitions []models.ConsumerGroupPartition) error {
+	if partitions == nil {
+		partitions = []models.ConsumerGroupPartition{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(partitions)
+}
+
+func writeConsumerGroupPartitions(writer io.Writer, partitions []models.ConsumerGroupPartition) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TOPIC\tPARTITION\tCURRENT-OFFSET\tLOG-END-OFFSET\tLAG\tMEMBER")
+	for _, partition := range partitions {
+		member := partition.ConsumerID
+		if member == "" {
+			member = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", partition.Topic, partition.Partition,
+			formatOffset(partition.CurrentOffset), formatOffset(partition.LogEndOffset), formatOffset(partition.Lag), member)
+	}
+
+	return tw.Flush()
+}
+
+func formatOffset(offset *int64) string {
+	if offset == nil {
+		return "-"
+	}
+
+	return strconv.FormatInt(*offset, 10)
+}
+
+func init() {
+	rootCmd.AddCommand(kafkaStatusCmd)
+	kafkaStatusCmd.PersistentFlags().StringVarP(&params.Group, action.Group.Long, action.Group.Short, "", action.Group.Description)
+	kafkaStatusCmd.PersistentFlags().BoolVarP(&params.Native, action.Native.Long, action.Native.Short, false, action.Native.Description)
+	kafkaStatusCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// keycloakReportCmd represents the keycloakReport command
+var keycloakReportCmd = &cobra.Command{
+	Use:   "keycloakReport",
+	Short: "Keycloak report",
+	Long:  `Report Keycloak realm statistics of a tenant: users, clients, roles and login events when the realm stores them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.KeycloakReport)
+		if err != nil {
+			return err
+		}
+
+		return run.KeycloakReport(params.Tenant, os.Stdout)
+	},
+}
+
+func (run *Run) KeycloakReport(tenantName string, writer io.Writer) error {
+	if !helpers.HasTenant(tenantName, run.Config.Action.ConfigTenants) {
+		return errors.TenantNotFound(tenantName)
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	report, err := run.Config.KeycloakSvc.GetRealmReport(tenantName)
+	if err != nil {
+		return err
+	}
+	if run.Config.Action.Param.JSON {
+		return writeKeycloakReportJSON(writer, report)
+	}
+
+	return writeKeycloakReport(writer, report)
+}
+
+func writeKeycloakReportJSON(writer io.Writer, report *models.KeycloakRealmReport) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+func writeKeycloakReport(writer io.Writer, report *models.KeycloakRealmReport) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Realm:\t%s\n", report.Realm)
+	_, _ = fmt.Fprintf(tw, "Users:\t%d\n", report.Users)
+	_, _ = fmt.Fprintf(tw, "Clients:\t%d\n", report.Clients)
+	_, _ = fmt.Fprintf(tw, "Roles:\t%d\n", report.Roles)
+	_, _ = fmt.Fprintf(tw, "Login events:\t%s\n", countOrUnavailable(report.LoginEvents))
+	_, _ = fmt.Fprintf(tw, "Login errors:\t%s\n", countOrUnavailable(report.LoginErrors))
+
+	return tw.Flush()
+}
+
+func countOrUnavailable(count *int) string {
+	if count == nil {
+		return "unavailable"
+	}
+
+	return fmt.Sprintf("%d", *count)
+}
+
+func init() {
+	rootCmd.AddCommand(keycloakReportCmd)
+	keycloakReportCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	keycloakReportCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+	if err := keycloakReportCmd.MarkPersistentFlagRequired(action.Tenant.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.Tenant, err).Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/spf13/cobra"
+)
+
+// listCapabilitySetsCmd represents the listCapabilitySets command
+var listCapabilitySetsCmd = &cobra.Command{
+	Use:   "listCapabilitySets",
+	Short: "List capability sets",
+	Long:  `List capability sets of a single application.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.ListCapabilitySets)
+		if err != nil {
+			return err
+		}
+
+		return run.ListCapabilitySets(params.Tenant, params.ApplicationID, os.Stdout)
+	},
+}
+
+func (run *Run) ListCapabilitySets(tenantName string, applicationID string, writer io.Writer) error {
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		return err
+	}
+
+	offset, limit := run.Config.Action.Param.Offset, run.Config.Action.Param.Limit
+	capabilitySets, err := run.Config.KeycloakSvc.GetCapabilitySetsByApplication(tenantName, applicationID, offset, limit)
+	if err != nil {
+		return err
+	}
+	if run.Config.Action.Param.JSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(capabilitySets)
+	}
+
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tRESOURCE\tACTION\tID")
+	for _, capabilitySet := range capabilitySets.CapabilitySets {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", capabilitySet.Name, capabilitySet.Resource, capabilitySet.Action, capabilitySet.ID)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(writer, "\nShowing %d-%d of %d\n", offset+min(1, len(capabilitySets.CapabilitySets)), offset+len(capabilitySets.CapabilitySets), capabilitySets.TotalCount)
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(listCapabilitySetsCmd)
+	listCapabilitySetsCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	listCapabilitySetsCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+	listCapabilitySetsCmd.PersistentFlags().IntVarP(&params.Offset, action.Offset.Long, action.Offset.Short, 0, action.Offset.Description)
+	listCapabilitySetsCmd.PersistentFlags().IntVarP(&params.Limit, action.Limit.Long, action.Limit.Short, 100, action.Limit.Description)
+	listCapabilitySetsCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+	if err := listCapabilitySetsCmd.MarkPersistentFlagRequired(action.Tenant.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.Tenant, err).Error())
+		os.Exit(1)
+	}
+	if err := listCapabilitySetsCmd.MarkPersistentFlagRequired(action.ApplicationID.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.ApplicationID, err).Error())
+		os.Exit(1)
+	}
+}
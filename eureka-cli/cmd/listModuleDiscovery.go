@@ -0,0 +1,125 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// listModuleDiscoveryCmd represents the listModuleDiscovery command
+var listModuleDiscoveryCmd = &cobra.Command{
+	Use:   "listModuleDiscovery",
+	Short: "List module discovery",
+	Long:  `List the module discovery entries with the sidecar locations the gateway routes to.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.ListModuleDiscovery)
+		if err != nil {
+			return err
+		}
+
+		return run.ListModuleDiscovery(params.Tenant, os.Stdout)
+	},
+}
+
+// ListModuleDiscovery prints the module discovery entries sorted by module id, with a tenant only the modules
+// of the applications the tenant is entitled to are printed
+func (run *Run) ListModuleDiscovery(tenantName string, writer io.Writer) error {
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	discovery, err := run.Config.ManagementSvc.GetAllModuleDiscovery()
+	if err != nil {
+		return err
+	}
+	if tenantName != "" {
+		discovery, err = run.filterTenantModuleDiscovery(tenantName, discovery)
+		if err != nil {
+			return err
+		}
+	}
+	sort.Slice(discovery, func(i, j int) bool {
+		return discovery[i].ID < discovery[j].ID
+	})
+	if run.Config.Action.Param.JSON {
+		return writeModuleDiscoveryJSON(writer, discovery)
+	}
+
+	return writeModuleDiscovery(writer, discovery)
+}
+
+func (run *Run) filterTenantModuleDiscovery(tenantName string, discovery []models.ModuleDiscovery) ([]models.ModuleDiscovery, error) {
+	entitlements, err := run.Config.ManagementSvc.GetTenantEntitlements(tenantName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	entitled := make(map[string]bool)
+	for _, entitlement := range entitlements.Entitlements {
+		modules, err := run.Config.ManagementSvc.GetApplicationModules(entitlement.ApplicationID)
+		if err != nil {
+			return nil, err
+		}
+		for _, module := range modules {
+			entitled[module.ID] = true
+		}
+	}
+
+	filtered := make([]models.ModuleDiscovery, 0, len(entitled))
+	for _, entry := range discovery {
+		if entitled[entry.ID] {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	return filtered, nil
+}
+
+func writeModuleDiscoveryJSON(writer io.Writer, discovery []models.ModuleDiscovery) error {
+	if discovery == nil {
+		discovery = []models.ModuleDiscovery{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(discovery)
+}
+
+func writeModuleDiscovery(writer io.Writer, discovery []models.ModuleDiscovery) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MODULE\tNAME\tVERSION\tLOCATION")
+	for _, entry := range discovery {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", entry.ID, valueOrDash(entry.Name), valueOrDash(entry.Version), valueOrDash(entry.Location))
+	}
+
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(listModuleDiscoveryCmd)
+	listModuleDiscoveryCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	listModuleDiscoveryCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+}
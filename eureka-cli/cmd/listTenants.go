@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// listTenantsCmd represents the listTenants command
+var listTenantsCmd = &cobra.Command{
+	Use:   "listTenants",
+	Short: "List tenants",
+	Long:  `List all tenants with the number of their entitled applications and optionally their users.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.ListTenants)
+		if err != nil {
+			return err
+		}
+
+		return run.ListTenants(os.Stdout)
+	},
+}
+
+func (run *Run) ListTenants(writer io.Writer) error {
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	tenants, err := run.Config.ManagementSvc.GetTenants(constant.NoneConsortium, constant.All)
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]models.TenantSummary, 0, len(tenants))
+	for _, value := range tenants {
+		entry := value.(map[string]any)
+		summary := models.TenantSummary{
+			ID:          helpers.GetString(entry, "id"),
+			Name:        helpers.GetString(entry, "name"),
+			Description: helpers.GetString(entry, "description"),
+		}
+		summary.Applications = run.countTenantApplications(summary.Name)
+		if run.Config.Action.Param.WithUsers {
+			summary.Users = run.countTenantUsers(summary.Name)
+		}
+		summaries = append(summaries, summary)
+	}
+	if run.Config.Action.Param.JSON {
+		return writeTenantSummariesJSON(writer, summaries)
+	}
+
+	return writeTenantSummaries(writer, summaries, run.Config.Action.Param.WithUsers)
+}
+
+func (run *Run) countTenantApplications(tenantName string) *int {
+	entitlements, err := run.Config.ManagementSvc.GetTenantEntitlements(tenantName, false)
+	if err != nil {
+		slog.Warn(run.Config.Action.Name, "text", "Tenant entitlements are unavailable", "tenant", tenantName, "error", err)
+		return nil
+	}
+	count := len(entitlements.Entitlements)
+
+	return &count
+}
+
+func (run *Run) countTenantUsers(tenantName string) *int {
+	if !helpers.HasTenant(tenantName, run.Config.Action.ConfigTenants) {
+		slog.Warn(run.Config.Action.Name, "text", "Tenant is not configured, skipping its users", "tenant", tenantName)
+		return nil
+	}
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		slog.Warn(run.Config.Action.Name, "text", "Tenant users are unavailable", "tenant", tenantName, "error", err)
+		return nil
+	}
+
+	users, err := run.Config.KeycloakSvc.GetUsers(tenantName)
+	if err != nil {
+		slog.Warn(run.Config.Action.Name, "text", "Tenant users are unavailable", "tenant", tenantName, "error", err)
+		return nil
+	}
+	count := len(users)
+
+	return &count
+}
+
+func writeTenantSummariesJSON(writer io.Writer, summaries []models.TenantSummary) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(summaries)
+}
+
+func writeTenantSummaries(writer io.Writer, summaries []models.TenantSummary, withUsers bool) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	header := "ID\tNAME\tDESCRIPTION\tAPPLICATIONS"
+	if withUsers {
+		header += "\tUSERS"
+	}
+	_, _ = fmt.Fprintln(tw, header)
+	for _, summary := range summaries {
+		row := fmt.Sprintf("%s\t%s\t%s\t%s", summary.ID, summary.Name, valueOrDash(summary.Description), countOrUnavailable(summary.Applications))
+		if withUsers {
+			row += "\t" + countOrUnavailable(summary.Users)
+		}
+		_, _ = fmt.Fprintln(tw, row)
+	}
+
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(listTenantsCmd)
+	listTenantsCmd.PersistentFlags().BoolVarP(&params.WithUsers, action.WithUsers.Long, action.WithUsers.Short, false, action.WithUsers.Description)
+	listTenantsCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// loadDiscoveryCmd represents the loadDiscovery command
+var loadDiscoveryCmd = &cobra.Command{
+	Use:   "loadDiscovery <file>",
+	Short: "Load module discovery",
+	Long:  `Restore module discovery entries from a file created with saveDiscovery.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.LoadDiscovery)
+		if err != nil {
+			return err
+		}
+
+		return run.LoadDiscovery(args[0])
+	},
+}
+
+// LoadDiscovery restores the raw module discovery array of a file
+func (run *Run) LoadDiscovery(filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	var discovery []json.RawMessage
+	if err := json.Unmarshal(data, &discovery); err != nil {
+		return err
+	}
+	for idx, rawEntry := range discovery {
+		var entry models.ModuleDiscovery
+		if err := json.Unmarshal(rawEntry, &entry); err != nil || entry.ID == "" {
+			return errors.ModuleDiscoveryEntryInvalid(filePath, idx)
+		}
+	}
+	if len(discovery) == 0 {
+		slog.Info(run.Config.Action.Name, "text", "No module discovery to load", "file", filePath)
+		return nil
+	}
+
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+	slog.Info(run.Config.Action.Name, "text", "LOADING MODULE DISCOVERY", "count", len(discovery), "file", filePath)
+
+	return run.Config.ManagementSvc.BatchUpdateRawModuleDiscovery(discovery)
+}
+
+func init() {
+	rootCmd.AddCommand(loadDiscoveryCmd)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/spf13/cobra"
+)
+
+// missingDiscoveryCmd represents the missingDiscovery command
+var missingDiscoveryCmd = &cobra.Command{
+	Use:   "missingDiscovery",
+	Short: "List modules missing discovery",
+	Long:  `List the modules of an application that have no discovery registration, requests to these modules fail with 404 in the gateway.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.MissingDiscovery)
+		if err != nil {
+			return err
+		}
+
+		return run.MissingDiscovery(params.ApplicationID, os.Stdout)
+	},
+}
+
+// MissingDiscovery prints the sorted ids of the application modules that are not registered in the module discovery
+func (run *Run) MissingDiscovery(applicationID string, writer io.Writer) error {
+	if applicationID == "" {
+		applicationID = run.Config.Action.ConfigApplicationID
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	modules, err := run.Config.ManagementSvc.GetApplicationModules(applicationID)
+	if err != nil {
+		return err
+	}
+	discovery, err := run.Config.ManagementSvc.GetAllModuleDiscovery()
+	if err != nil {
+		return err
+	}
+
+	registered := make(map[string]bool, len(discovery))
+	for _, entry := range discovery {
+		registered[entry.ID] = true
+	}
+	var missing []string
+	for _, module := range modules {
+		if !registered[module.ID] {
+			missing = append(missing, module.ID)
+		}
+	}
+	if len(missing) == 0 {
+		slog.Info(run.Config.Action.Name, "text", "All application modules have a discovery registration", "application", applicationID, "modules", len(modules))
+		return nil
+	}
+	sort.Strings(missing)
+
+	_, err = io.WriteString(writer, strings.Join(missing, "\n")+"\n")
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(missingDiscoveryCmd)
+	missingDiscoveryCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// nukeCmd represents the nuke command
+var nukeCmd = &cobra.Command{
+	Use:   "nuke",
+	Short: "Remove all test data",
+	Long:  `Remove capability set attachments, users, roles, tenant entitlements, tenants and applications of all configured tenants in dependency order.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.Nuke)
+		if err != nil {
+			return err
+		}
+
+		return run.Nuke(os.Stdin, os.Stdout)
+	},
+}
+
+type nukeStep struct {
+	name    string
+	scope   string
+	status  string
+	removed int
+	err     error
+	took    time.Duration
+}
+
+// Nuke asks to type the profile name and then removes all test data, dependent resources are removed before their
+// parents and the first failing step stops the removal, the summary lists every step including the skipped ones
+// together with the number of resources each step removed
+func (run *Run) Nuke(reader io.Reader, writer io.Writer) error {
+	if !run.Config.Action.Param.Confirm {
+		return errors.ConfirmationRequired(run.Config.Action.Name)
+	}
+
+	expected := run.Config.Action.ConfigProfileName
+	_, _ = fmt.Fprintf(writer, "Type %q to remove all test data of the profile: ", expected)
+	scanner := bufio.NewScanner(reader)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != expected {
+		return errors.ConfirmationMismatch(run.Config.Action.Name, expected)
+	}
+
+	tenants := strings.Join(helpers.SortedMapKeys(run.Config.Action.ConfigTenants), ", ")
+	steps := []struct {
+		name  string
+		scope string
+		fn    func() (int, error)
+	}{
+		{"detach capability sets", tenants, func() (int, error) { return run.nukeTenants(run.Config.KeycloakSvc.DetachCapabilitySetsFromRoles) }},
+		{"remove users", tenants, func() (int, error) { return run.nukeTenants(run.Config.KeycloakSvc.RemoveUsers) }},
+		{"remove roles", tenants, func() (int, error) { return run.nukeTenants(run.Config.KeycloakSvc.RemoveRoles) }},
+		{"remove tenant entitlements", tenants, run.removeNukeTenantEntitlements},
+		{"remove tenants", tenants, run.removeNukeTenants},
+		{"remove applications", run.Config.Action.ConfigApplicationName, run.removeNukeApplications},
+	}
+
+	var results []nukeStep
+	var failed error
+	for _, step := range steps {
+		result := nukeStep{name: step.name, scope: step.scope, status: "skipped"}
+		if failed == nil {
+			slog.Info(run.Config.Action.Name, "text", "NUKE STEP", "step", step.name)
+			startedAt := time.Now()
+			result.removed, result.err = step.fn()
+			result.took = time.Since(startedAt).Round(time.Millisecond)
+			result.status = "removed"
+			if result.err != nil {
+				result.status = "failed"
+				failed = result.err
+			}
+		}
+		results = append(results, result)
+	}
+	if err := writeNukeSummary(writer, results); err != nil {
+		return err
+	}
+
+	return failed
+}
+
+// nukeTenants runs a removal for every configured tenant and sums up the removed resources,
+// unlike the standalone commands a failed removal is returned instead of logged
+func (run *Run) nukeTenants(fn func(tenantName string) (int, error)) (int, error) {
+	var removed int
+	err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
+		return run.TenantPartition(consortiumName, tenantType, func(configTenant, _ string) error {
+			count, err := fn(configTenant)
+			removed += count
+
+			return err
+		})
+	})
+
+	return removed, err
+}
+
+func (run *Run) removeNukeTenantEntitlements() (int, error) {
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return 0, err
+	}
+
+	var removed int
+	err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
+		count, err := run.Config.ManagementSvc.RemoveTenantEntitlements(consortiumName, tenantType, run.Config.Action.Param.PurgeSchemas)
+		removed += count
+
+		return err
+	})
+
+	return removed, err
+}
+
+func (run *Run) removeNukeTenants() (int, error) {
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return 0, err
+	}
+
+	var results []models.TenantRemovalResult
+	err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
+		partitionResults, err := run.Config.ManagementSvc.RemoveTenants(consortiumName, tenantType, run.Config.Action.Param.PurgeKafkaTopics)
+		results = append(results, partitionResults...)
+
+		return err
+	})
+	var removed int
+	for _, result := range results {
+		if result.Status == models.TenantRemovalRemoved {
+			removed++
+		}
+	}
+	if err != nil {
+		return removed, err
+	}
+
+	return removed, getTenantRemovalsError(results)
+}
+
+func (run *Run) removeNukeApplications() (int, error) {
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return 0, err
+	}
+
+	return run.Config.ManagementSvc.RemoveApplications(run.Config.Action.ConfigApplicationName, "")
+}
+
+func writeNukeSummary(writer io.Writer, results []nukeStep) error {
+	_, _ = fmt.Fprintln(writer)
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "STEP\tSCOPE\tSTATUS\tREMOVED\tDURATION\tERROR")
+	for _, result := range results {
+		errText := "-"
+		if result.err != nil {
+			errText = result.err.Error()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", result.name, valueOrDash(result.scope), result.status, result.removed, result.took, errText)
+	}
+
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(nukeCmd)
+	nukeCmd.PersistentFlags().BoolVarP(&params.Confirm, action.Confirm.Long, action.Confirm.Short, false, action.Confirm.Description)
+	nukeCmd.PersistentFlags().BoolVarP(&params.PurgeKafkaTopics, action.PurgeKafkaTopics.Long, action.PurgeKafkaTopics.Short, true, action.PurgeKafkaTopics.Description)
+	nukeCmd.PersistentFlags().BoolVarP(&params.PurgeSchemas, action.PurgeSchemas.Long, action.PurgeSchemas.Short, false, action.PurgeSchemas.Description)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+)
+
+// readinessState holds the outcome of the latest stack readiness check, the zero value is not ready
+type readinessState struct {
+	mu      sync.RWMutex
+	checked bool
+	err     error
+}
+
+func (rs *readinessState) set(err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.checked, rs.err = true, err
+}
+
+func (rs *readinessState) get() (bool, error) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	return rs.checked && rs.err == nil, rs.err
+}
+
+// ServeReadiness serves /ready on an address until the context is cancelled, the stack readiness
+// is checked right away and then periodically, /ready answers 200 when healthy and 503 otherwise
+func (run *Run) ServeReadiness(ctx context.Context, address string) error {
+	state := &readinessState{}
+	server := &http.Server{
+		Addr:              address,
+		Handler:           newReadinessHandler(state),
+		ReadHeaderTimeout: constant.ReadinessHeaderTimeout,
+	}
+
+	serverErrCh := make(chan error, 1)
+	go func() {
+		serverErrCh <- server.ListenAndServe()
+	}()
+	slog.Info(run.Config.Action.Name, "text", "Serving readiness probe", "address", address, "path", "/ready")
+
+	ticker := time.NewTicker(constant.ReadinessProbeInterval)
+	defer ticker.Stop()
+	run.updateReadiness(state)
+	for {
+		select {
+		case err := <-serverErrCh:
+			return err
+		case <-ticker.C:
+			run.updateReadiness(state)
+		case <-ctx.Done():
+			slog.Info(run.Config.Action.Name, "text", "Shutting down readiness probe", "address", address)
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), constant.ContextTimeoutReadinessShutdown)
+			defer cancel()
+
+			return server.Shutdown(shutdownCtx)
+		}
+	}
+}
+
+func newReadinessHandler(state *readinessState) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		ready, err := state.get()
+		if !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			if err != nil {
+				_, _ = fmt.Fprintln(w, err.Error())
+				return
+			}
+			_, _ = fmt.Fprintln(w, "not checked yet")
+			return
+		}
+		_, _ = fmt.Fprintln(w, "ready")
+	})
+
+	return mux
+}
+
+func (run *Run) updateReadiness(state *readinessState) {
+	err := run.CheckStackReadiness()
+	if err != nil {
+		slog.Warn(run.Config.Action.Name, "text", "Stack is not ready", "error", err)
+	}
+	state.set(err)
+}
+
+// CheckStackReadiness checks that the gateway answers its status endpoint and that
+// every deployed module of the profile is running and healthy
+func (run *Run) CheckStackReadiness() error {
+	var reasons []string
+	gatewayURL := run.Config.Action.GetRequestURL(run.Config.Action.GetGatewayAdminPort(), "/status")
+	if statusCode, err := run.Config.HTTPClient.Ping(gatewayURL); err != nil || statusCode != http.StatusOK {
+		reasons = append(reasons, fmt.Sprintf("gateway status %d", statusCode))
+	}
+
+	client, err := run.Config.DockerClient.Create()
+	if err != nil {
+		return err
+	}
+	defer run.Config.DockerClient.Close(client)
+
+	filters := filters.NewArgs(filters.KeyValuePair{
+		Key:   "name",
+		Value: fmt.Sprintf(constant.ModuleContainerPattern, run.Config.Action.ConfigProfileName),
+	})
+	containers, err := run.Config.ModuleSvc.GetDeployedModules(client, filters)
+	if err != nil {
+		return err
+	}
+	for _, summary := range containers {
+		if reason := run.checkModuleContainerReadiness(summary); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) > 0 {
+		return errors.StackNotReady(reasons)
+	}
+
+	return nil
+}
+
+func (run *Run) checkModuleContainerReadiness(summary container.Summary) string {
+	name := summary.ID
+	if len(summary.Names) > 0 {
+		name = strings.TrimPrefix(summary.Names[0], "/")
+	}
+	if summary.State != container.StateRunning {
+		return fmt.Sprintf("%s is %s", name, summary.State)
+	}
+
+	privatePort, _ := strconv.Atoi(constant.PrivateServerPort)
+	for _, port := range summary.Ports {
+		if int(port.PrivatePort) != privatePort || port.PublicPort == 0 {
+			continue
+		}
+		requestURL := run.Config.Action.GetRequestURL(strconv.Itoa(int(port.PublicPort)), "/admin/health")
+		if statusCode, err := run.Config.HTTPClient.Ping(requestURL); err != nil || statusCode != http.StatusOK {
+			return fmt.Sprintf("%s health status %d", name, statusCode)
+		}
+		return ""
+	}
+
+	return fmt.Sprintf("%s has no published server port", name)
+}
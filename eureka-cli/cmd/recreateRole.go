@@ -0,0 +1,80 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/spf13/cobra"
+)
+
+// recreateRoleCmd represents the recreateRole command
+var recreateRoleCmd = &cobra.Command{
+	Use:   "recreateRole",
+	Short: "Recreate role",
+	Long:  `Remove a single configured role of a tenant, create it anew from the config and re-attach its capability sets.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.RecreateRole)
+		if err != nil {
+			return err
+		}
+
+		return run.RecreateRole(params.Tenant, params.RoleName)
+	},
+}
+
+func (run *Run) RecreateRole(tenantName string, roleName string) error {
+	if !helpers.HasTenant(tenantName, run.Config.Action.ConfigTenants) {
+		return errors.TenantNotFound(tenantName)
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		return err
+	}
+
+	slog.Info(run.Config.Action.Name, "text", "RECREATING ROLE", "role", roleName, "tenant", tenantName)
+	result, err := run.Config.KeycloakSvc.RecreateRole(tenantName, roleName)
+	if err != nil {
+		return err
+	}
+	if len(result.Unresolved) > 0 {
+		slog.Warn(run.Config.Action.Name, "text", "Capability sets could not be resolved", "role", result.RoleName, "tenant", tenantName, "names", result.Unresolved)
+	}
+	slog.Info(run.Config.Action.Name, "text", "Recreated role", "role", result.RoleName, "tenant", tenantName, "attached", result.Attached)
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(recreateRoleCmd)
+	recreateRoleCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	recreateRoleCmd.PersistentFlags().StringVarP(&params.RoleName, action.RoleName.Long, action.RoleName.Short, "", action.RoleName.Description)
+	if err := recreateRoleCmd.MarkPersistentFlagRequired(action.Tenant.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.Tenant, err).Error())
+		os.Exit(1)
+	}
+	if err := recreateRoleCmd.MarkPersistentFlagRequired(action.RoleName.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.RoleName, err).Error())
+		os.Exit(1)
+	}
+}
@@ -43,7 +43,8 @@ var removeRolesCmd = &cobra.Command{
 func (run *Run) RemoveRoles(consortiumName string, tenantType constant.TenantType) error {
 	return run.TenantPartition(consortiumName, tenantType, func(configTenant, tenantType string) error {
 		slog.Info(run.Config.Action.Name, "text", "REMOVING ROLES", "tenant", configTenant)
-		return run.Config.KeycloakSvc.RemoveRoles(configTenant)
+		_, err := run.Config.KeycloakSvc.RemoveRoles(configTenant)
+		return err
 	})
 }
 
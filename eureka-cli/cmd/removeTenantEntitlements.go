@@ -46,7 +46,9 @@ func (run *Run) RemoveTenantEntitlements(consortiumName string, tenantType const
 		return err
 	}
 
-	return run.Config.ManagementSvc.RemoveTenantEntitlements(consortiumName, tenantType, params.PurgeSchemas)
+	_, err := run.Config.ManagementSvc.RemoveTenantEntitlements(consortiumName, tenantType, params.PurgeSchemas, run.Config.Action.Param.ApplicationIDs...)
+
+	return err
 }
 
 func init() {
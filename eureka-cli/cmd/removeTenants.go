@@ -16,10 +16,17 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
+	"os"
+	"text/tabwriter"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
 	"github.com/spf13/cobra"
 )
 
@@ -34,21 +41,106 @@ var removeTenantsCmd = &cobra.Command{
 			return err
 		}
 
-		return run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
-			return run.RemoveTenants(consortiumName, tenantType)
-		})
+		return run.RemoveAllTenants(os.Stdout)
 	},
 }
 
+// RemoveAllTenants removes the configured tenants of all consortium partitions and prints a summary, the command fails
+// after all tenants were processed when any removal failed
+func (run *Run) RemoveAllTenants(writer io.Writer) error {
+	slog.Info(run.Config.Action.Name, "text", "REMOVING TENANTS", "purgeKafkaTopics", run.Config.Action.Param.PurgeKafkaTopics)
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	var results []models.TenantRemovalResult
+	err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
+		partitionResults, err := run.Config.ManagementSvc.RemoveTenants(consortiumName, tenantType, run.Config.Action.Param.PurgeKafkaTopics)
+		if err != nil {
+			return err
+		}
+		results = append(results, partitionResults...)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if run.Config.Action.Param.JSON {
+		err = writeTenantRemovalResultsJSON(writer, results)
+	} else {
+		err = writeTenantRemovalResults(writer, results)
+	}
+	if err != nil {
+		return err
+	}
+
+	return getTenantRemovalsError(results)
+}
+
+// RemoveTenants removes the configured tenants of a single partition
 func (run *Run) RemoveTenants(consortiumName string, tenantType constant.TenantType) error {
 	slog.Info(run.Config.Action.Name, "text", "REMOVING TENANTS")
 	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
 		return err
 	}
 
-	return run.Config.ManagementSvc.RemoveTenants(consortiumName, tenantType)
+	results, err := run.Config.ManagementSvc.RemoveTenants(consortiumName, tenantType, run.Config.Action.Param.PurgeKafkaTopics)
+	if err != nil {
+		return err
+	}
+
+	return getTenantRemovalsError(results)
+}
+
+func getTenantRemovalsError(results []models.TenantRemovalResult) error {
+	var failed []string
+	for _, result := range results {
+		if result.Status == models.TenantRemovalFailed {
+			failed = append(failed, result.Tenant)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.TenantRemovalsFailed(failed)
+	}
+
+	return nil
+}
+
+func writeTenantRemovalResultsJSON(writer io.Writer, results []models.TenantRemovalResult) error {
+	if results == nil {
+		results = []models.TenantRemovalResult{}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(results)
+}
+
+func writeTenantRemovalResults(writer io.Writer, results []models.TenantRemovalResult) error {
+	counts := make(map[models.TenantRemovalStatus]int)
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TENANT\tSTATUS\tHTTP STATUS\tERROR")
+	for _, result := range results {
+		counts[result.Status]++
+		statusCode := "-"
+		if result.StatusCode != 0 {
+			statusCode = fmt.Sprint(result.StatusCode)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Tenant, result.Status, statusCode, valueOrDash(result.Error))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "\nRemoved %d, failed %d of %d tenants\n",
+		counts[models.TenantRemovalRemoved], counts[models.TenantRemovalFailed], len(results))
+
+	return err
 }
 
 func init() {
 	rootCmd.AddCommand(removeTenantsCmd)
+	removeTenantsCmd.PersistentFlags().BoolVarP(&params.PurgeKafkaTopics, action.PurgeKafkaTopics.Long, action.PurgeKafkaTopics.Short, true, action.PurgeKafkaTopics.Description)
+	removeTenantsCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
 }
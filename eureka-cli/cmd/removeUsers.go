@@ -43,7 +43,8 @@ var removeUsersCmd = &cobra.Command{
 func (run *Run) RemoveUsers(consortiumName string, tenantType constant.TenantType) error {
 	return run.TenantPartition(consortiumName, tenantType, func(configTenant, tenantType string) error {
 		slog.Info(run.Config.Action.Name, "text", "REMOVING USERS", "tenant", configTenant)
-		return run.Config.KeycloakSvc.RemoveUsers(configTenant)
+		_, err := run.Config.KeycloakSvc.RemoveUsers(configTenant)
+		return err
 	})
 }
 
@@ -0,0 +1,75 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/spf13/cobra"
+)
+
+// resetTenantCmd represents the resetTenant command
+var resetTenantCmd = &cobra.Command{
+	Use:   "resetTenant",
+	Short: "Reset tenant",
+	Long:  `Remove all configured users and roles of a tenant and detach their capability sets, leaving the tenant and its realm intact.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.ResetTenant)
+		if err != nil {
+			return err
+		}
+
+		return run.ResetTenant(params.Tenant)
+	},
+}
+
+func (run *Run) ResetTenant(tenantName string) error {
+	if !run.Config.Action.Param.Confirm {
+		return errors.ConfirmationRequired(run.Config.Action.Name)
+	}
+	if !helpers.HasTenant(tenantName, run.Config.Action.ConfigTenants) {
+		return errors.TenantNotFound(tenantName)
+	}
+
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		return err
+	}
+
+	slog.Info(run.Config.Action.Name, "text", "RESETTING TENANT", "tenant", tenantName)
+	if _, err := run.Config.KeycloakSvc.DetachCapabilitySetsFromRoles(tenantName); err != nil {
+		slog.Warn(run.Config.Action.Name, "text", "Capability sets detachment was unsuccessful", "tenant", tenantName, "error", err)
+	}
+	if _, err := run.Config.KeycloakSvc.RemoveUsers(tenantName); err != nil {
+		return err
+	}
+	_, err := run.Config.KeycloakSvc.RemoveRoles(tenantName)
+
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(resetTenantCmd)
+	resetTenantCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	resetTenantCmd.PersistentFlags().BoolVarP(&params.Confirm, action.Confirm.Long, action.Confirm.Short, false, action.Confirm.Description)
+	if err := resetTenantCmd.MarkPersistentFlagRequired(action.Tenant.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.Tenant, err).Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/spf13/cobra"
+)
+
+// restoreModuleDiscoveryCmd represents the restoreModuleDiscovery command
+var restoreModuleDiscoveryCmd = &cobra.Command{
+	Use:   "restoreModuleDiscovery",
+	Short: "Restore module discovery",
+	Long:  `Restore the module discovery of all backend modules of an application to their default sidecar URLs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.RestoreModuleDiscovery)
+		if err != nil {
+			return err
+		}
+
+		return run.RestoreModuleDiscovery(params.ApplicationID, params.PrivatePort)
+	},
+}
+
+// RestoreModuleDiscovery points the discovery of every backend module of the application back to its sidecar,
+// use updateModuleDiscovery for targeted updates of a single module
+func (run *Run) RestoreModuleDiscovery(applicationID string, privatePort int) error {
+	if applicationID == "" {
+		applicationID = run.Config.Action.ConfigApplicationID
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	modules, err := run.Config.ManagementSvc.GetApplicationModules(applicationID)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(modules))
+	for _, module := range modules {
+		ids = append(ids, module.ID)
+	}
+	slog.Info(run.Config.Action.Name, "text", "RESTORING MODULE DISCOVERY", "application", applicationID, "count", len(ids))
+
+	return run.Config.ManagementSvc.RestoreModuleDiscovery(ids, privatePort)
+}
+
+func init() {
+	rootCmd.AddCommand(restoreModuleDiscoveryCmd)
+	restoreModuleDiscoveryCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+	restoreModuleDiscoveryCmd.PersistentFlags().IntVarP(&params.PrivatePort, action.PrivatePort.Long, action.PrivatePort.Short, 8081, action.PrivatePort.Description)
+	restoreModuleDiscoveryCmd.PersistentFlags().BoolVarP(&params.Force, action.Force.Long, action.Force.Short, false, action.Force.Description)
+}
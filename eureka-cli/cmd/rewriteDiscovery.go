@@ -0,0 +1,130 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// rewriteDiscoveryCmd represents the rewriteDiscovery command
+var rewriteDiscoveryCmd = &cobra.Command{
+	Use:   "rewriteDiscovery",
+	Short: "Rewrite module discovery",
+	Long:  `Rewrite the network suffix of the sidecar hosts in all module discovery locations, e.g. after a Docker network rename.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.RewriteDiscovery)
+		if err != nil {
+			return err
+		}
+
+		return run.RewriteDiscovery(os.Stdout, params.From, params.To)
+	},
+}
+
+// RewriteDiscovery replaces the from network suffix of the discovery location hosts with the to suffix,
+// with --dryRun the rewritten locations are only printed
+func (run *Run) RewriteDiscovery(writer io.Writer, from, to string) error {
+	from, to = strings.Trim(from, "."), strings.Trim(to, ".")
+	if from == "" || to == "" || from == to {
+		return errors.DiscoverySuffixInvalid(from, to)
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	discovery, err := run.Config.ManagementSvc.GetAllModuleDiscovery()
+	if err != nil {
+		return err
+	}
+
+	var rewritten []models.ModuleDiscovery
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MODULE\tOLD LOCATION\tNEW LOCATION")
+	for _, entry := range discovery {
+		location, ok := rewriteDiscoveryLocation(entry.Location, from, to)
+		if !ok {
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", entry.ID, entry.Location, location)
+		entry.Location = location
+		rewritten = append(rewritten, entry)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(rewritten) == 0 {
+		slog.Info(run.Config.Action.Name, "text", "No module discovery to rewrite", "from", from, "total", len(discovery))
+		return nil
+	}
+	if run.Config.Action.Param.DryRun {
+		slog.Info(run.Config.Action.Name, "text", "Skipped module discovery rewrite", "count", len(rewritten), "total", len(discovery))
+		return nil
+	}
+	slog.Info(run.Config.Action.Name, "text", "REWRITING MODULE DISCOVERY", "count", len(rewritten), "from", from, "to", to)
+
+	return run.Config.ManagementSvc.BatchUpdateModuleDiscovery(rewritten)
+}
+
+// rewriteDiscoveryLocation swaps the from suffix of the location host for the to suffix,
+// keeping the scheme, port and path, locations on other hosts are left unchanged
+func rewriteDiscoveryLocation(location, from, to string) (string, bool) {
+	parsedURL, err := url.Parse(location)
+	if err != nil || parsedURL.Host == "" {
+		return location, false
+	}
+
+	hostname := parsedURL.Hostname()
+	prefix, found := strings.CutSuffix(hostname, "."+from)
+	if !found || prefix == "" {
+		return location, false
+	}
+
+	host := prefix + "." + to
+	if port := parsedURL.Port(); port != "" {
+		host = net.JoinHostPort(host, port)
+	}
+	parsedURL.Host = host
+
+	return parsedURL.String(), true
+}
+
+func init() {
+	rootCmd.AddCommand(rewriteDiscoveryCmd)
+	rewriteDiscoveryCmd.PersistentFlags().StringVarP(&params.From, action.From.Long, action.From.Short, "", action.From.Description)
+	rewriteDiscoveryCmd.PersistentFlags().StringVarP(&params.To, action.To.Long, action.To.Short, "", action.To.Description)
+	rewriteDiscoveryCmd.PersistentFlags().BoolVarP(&params.DryRun, action.DryRun.Long, action.DryRun.Short, false, action.DryRun.Description)
+	if err := rewriteDiscoveryCmd.MarkPersistentFlagRequired(action.From.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.From, err).Error())
+		os.Exit(1)
+	}
+	if err := rewriteDiscoveryCmd.MarkPersistentFlagRequired(action.To.Long); err != nil {
+		slog.Error(errors.MarkFlagRequiredFailed(action.To, err).Error())
+		os.Exit(1)
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
@@ -49,10 +51,27 @@ var rootCmd = &cobra.Command{
 
 func Execute(fs *embed.FS) {
 	runFs = fs
-	err := rootCmd.Execute()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go exitAfterInterrupt(signals, cancel)
+
+	err := rootCmd.ExecuteContext(ctx)
 	cobra.CheckErr(err)
 }
 
+// exitAfterInterrupt cancels the command context on the first Ctrl-C and restores the default signal handling,
+// so a second Ctrl-C exits at once, and exits when the aborted command does not return within the wait
+func exitAfterInterrupt(signals chan os.Signal, cancel context.CancelFunc) {
+	<-signals
+	signal.Reset(os.Interrupt, syscall.SIGTERM)
+	cancel()
+	slog.Warn(action.Root, "text", "Interrupted, cancelling the requests in flight")
+	time.Sleep(constant.InterruptExitWait)
+	os.Exit(constant.InterruptExitCode)
+}
+
 func initConfig() {
 	setConfig(&params)
 	viper.AutomaticEnv()
@@ -67,11 +86,21 @@ func initConfig() {
 
 	err := viper.ReadInConfig()
 	cobra.CheckErr(err)
+	cobra.CheckErr(expandConfigEnvVars())
 
 	logger, err = setDefaultLogger()
 	cobra.CheckErr(err)
 }
 
+func expandConfigEnvVars() error {
+	settings, err := helpers.ExpandEnvVarReferences(viper.AllSettings())
+	if err != nil {
+		return err
+	}
+
+	return viper.MergeConfigMap(settings)
+}
+
 func setConfig(params *action.Param) {
 	if params.ConfigFile == "" {
 		home, err := os.UserHomeDir()
@@ -154,6 +183,18 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&params.ConfigFile, action.ConfigFile.Long, action.ConfigFile.Short, "", action.ConfigFile.Description)
 	rootCmd.PersistentFlags().BoolVarP(&params.OverwriteFiles, action.OverwriteFiles.Long, action.OverwriteFiles.Short, false, fmt.Sprintf(action.OverwriteFiles.Description, constant.ConfigDir))
 	rootCmd.PersistentFlags().BoolVarP(&params.EnableDebug, action.EnableDebug.Long, action.EnableDebug.Short, false, action.EnableDebug.Description)
+	rootCmd.PersistentFlags().BoolVarP(&params.Verbose, action.Verbose.Long, action.Verbose.Short, false, action.Verbose.Description)
+	rootCmd.PersistentFlags().StringVarP(&params.AccessTokenEnv, action.AccessTokenEnv.Long, action.AccessTokenEnv.Short, "", action.AccessTokenEnv.Description)
+	rootCmd.PersistentFlags().StringVarP(&params.BaseURL, action.BaseURL.Long, action.BaseURL.Short, "", action.BaseURL.Description)
+	rootCmd.PersistentFlags().StringVarP(&params.HARFile, action.HARFile.Long, action.HARFile.Short, "", action.HARFile.Description)
+	rootCmd.PersistentFlags().StringVarP(&params.Tag, action.Tag.Long, action.Tag.Short, "", action.Tag.Description)
+	rootCmd.PersistentFlags().BoolVarP(&params.IgnoreExisting, action.IgnoreExisting.Long, action.IgnoreExisting.Short, true, action.IgnoreExisting.Description)
+	rootCmd.PersistentFlags().IntVarP(&params.MaxConcurrentRequests, action.MaxConcurrentRequests.Long, action.MaxConcurrentRequests.Short, 0, action.MaxConcurrentRequests.Description)
+	rootCmd.PersistentFlags().DurationVarP(&params.RequestTimeout, action.RequestTimeout.Long, action.RequestTimeout.Short, 0, action.RequestTimeout.Description)
+	rootCmd.PersistentFlags().IntVarP(&params.HTTPRetries, action.HTTPRetries.Long, action.HTTPRetries.Short, 0, action.HTTPRetries.Description)
+	rootCmd.PersistentFlags().IntVarP(&params.MaxTotalRetries, action.MaxTotalRetries.Long, action.MaxTotalRetries.Short, 0, action.MaxTotalRetries.Description)
+	rootCmd.PersistentFlags().DurationVarP(&params.PhaseWait, action.PhaseWait.Long, action.PhaseWait.Short, 0, action.PhaseWait.Description)
+	rootCmd.PersistentFlags().IntVarP(&params.CapabilityConcurrency, action.CapabilityConcurrency.Long, action.CapabilityConcurrency.Short, constant.CapabilitySetsConcurrency, action.CapabilityConcurrency.Description)
 
 	if err := rootCmd.RegisterFlagCompletionFunc(action.Profile.Long, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return profiles, cobra.ShellCompDirectiveNoFileComp
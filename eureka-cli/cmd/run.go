@@ -17,12 +17,17 @@ package cmd
 
 import (
 	"log/slog"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/httpclient"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
 	"github.com/folio-org/eureka-setup/eureka-cli/runconfig"
 )
 
@@ -32,11 +37,25 @@ type Run struct {
 }
 
 func New(name string) (*Run, error) {
-	gatewayURLTemplate, err := action.GetGatewayURLTemplate(name)
-	if err != nil {
-		return nil, err
+	var gatewayURLTemplate string
+	if params.BaseURL == "" {
+		var err error
+		if gatewayURLTemplate, err = action.GetGatewayURLTemplate(name); err != nil {
+			return nil, err
+		}
 	}
 	action := action.New(name, gatewayURLTemplate, &params)
+	if params.BaseURL != "" {
+		if err := action.SetBaseURL(params.BaseURL); err != nil {
+			return nil, err
+		}
+	}
+	if err := action.ValidateConfig(); err != nil {
+		return nil, err
+	}
+	helpers.SetHeaderNames(action.ConfigTenantHeaderName, action.ConfigTokenHeaderName)
+	helpers.SetModuleNameRules(action.ConfigModuleNameStripPrefixes, action.ConfigModuleNameSeparators)
+	httpclient.SetBaseContext(rootCmd.Context())
 
 	runConfig, err := runconfig.New(action, logger)
 	if err != nil {
@@ -47,7 +66,7 @@ func New(name string) (*Run, error) {
 }
 
 func (run *Run) PingKongStatus() error {
-	requestURL := run.Config.Action.GetRequestURL(constant.KongAdminPort, "/status")
+	requestURL := run.Config.Action.GetRequestURL(run.Config.Action.GetGatewayAdminPort(), "/status")
 	return run.Config.HTTPClient.PingRetry(requestURL)
 }
 
@@ -67,18 +86,13 @@ func (run *Run) ConsortiumPartition(fn func(string, constant.TenantType) error)
 }
 
 func (run *Run) TenantPartition(consortiumName string, tenantType constant.TenantType, fn func(string, string) error) error {
-	client, err := run.Config.DockerClient.Create()
-	if err != nil {
-		return err
-	}
-	if err := run.setVaultRootTokenIntoContext(client); err != nil {
-		return err
-	}
-	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
-		return err
-	}
+	return run.filteredTenantPartition(consortiumName, tenantType, "", fn)
+}
 
-	tenants, err := run.Config.ManagementSvc.GetTenants(consortiumName, tenantType)
+// filteredTenantPartition visits only the tenant matching a non-empty filter, so that no access token
+// is fetched for the other tenants of the partition
+func (run *Run) filteredTenantPartition(consortiumName string, tenantType constant.TenantType, tenantFilter string, fn func(string, string) error) error {
+	tenants, err := run.getPartitionTenants(consortiumName, tenantType)
 	if err != nil {
 		return err
 	}
@@ -89,6 +103,9 @@ func (run *Run) TenantPartition(consortiumName string, tenantType constant.Tenan
 		if !helpers.HasTenant(configTenant, run.Config.Action.ConfigTenants) {
 			continue
 		}
+		if tenantFilter != "" && configTenant != tenantFilter {
+			continue
+		}
 		if err := run.setKeycloakAccessTokenIntoContext(configTenant); err != nil {
 			return err
 		}
@@ -101,23 +118,120 @@ func (run *Run) TenantPartition(consortiumName string, tenantType constant.Tenan
 	return nil
 }
 
-func (run *Run) CheckDeployedModuleReadiness(moduleType string, modules map[string]int) error {
-	var (
-		wg    sync.WaitGroup
-		errCh = make(chan error, len(modules))
-	)
+// getPartitionTenants queries the tenants of a partition with the master access token, with an access token injected
+// through the environment there is no vault or master access token to use so the configured tenants are taken instead
+func (run *Run) getPartitionTenants(consortiumName string, tenantType constant.TenantType) ([]any, error) {
+	if run.Config.Action.Param.AccessTokenEnv != "" {
+		return run.Config.ManagementSvc.GetConfigTenants(consortiumName, tenantType), nil
+	}
 
-	wg.Add(len(modules))
-	for deployedModule := range modules {
-		go run.Config.ModuleSvc.CheckModuleReadiness(&wg, errCh, deployedModule, modules[deployedModule])
+	client, err := run.Config.DockerClient.Create()
+	if err != nil {
+		return nil, err
+	}
+	if err := run.setVaultRootTokenIntoContext(client); err != nil {
+		return nil, err
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return nil, err
+	}
+
+	return run.Config.ManagementSvc.GetTenants(consortiumName, tenantType)
+}
+
+// newHealthcheckSemaphore bounds the number of modules healthchecked at once, modules over the limit queue until
+// a slot frees up, a nil semaphore means no limit
+func (run *Run) newHealthcheckSemaphore() chan struct{} {
+	concurrency := constant.HealthcheckConcurrency
+	if run.Config.Action.Param != nil {
+		concurrency = run.Config.Action.Param.HealthcheckConcurrency
+	}
+	if concurrency <= 0 {
+		return nil
+	}
+
+	return make(chan struct{}, concurrency)
+}
+
+// waitBetweenPhases pauses a deploy after a phase for the wait resolved by getPhaseWait
+func (run *Run) waitBetweenPhases(phase string) {
+	wait := run.getPhaseWait(phase)
+	slog.Debug(run.Config.Action.Name, "text", "Waiting between deploy phases", "phase", phase, "wait", wait)
+	time.Sleep(wait)
+}
+
+// getPhaseWait resolves the wait of a phase from phase-waits in the config, then from --phaseWait and
+// finally falls back to the built-in default of the phase
+func (run *Run) getPhaseWait(phase string) time.Duration {
+	if value, ok := run.Config.Action.ConfigPhaseWaits[phase]; ok {
+		if wait, err := time.ParseDuration(value); err == nil {
+			return wait
+		}
+	}
+	if run.Config.Action.Param != nil && run.Config.Action.Param.PhaseWait > 0 {
+		return run.Config.Action.Param.PhaseWait
+	}
+
+	return constant.GetPhaseWaits()[phase]
+}
+
+func (run *Run) CheckDeployedModuleReadiness(moduleType string, modules map[string]models.BackendModule) error {
+	moduleNames := make([]string, 0, len(modules))
+	for moduleName := range modules {
+		moduleNames = append(moduleNames, moduleName)
+	}
+	slices.Sort(moduleNames)
+
+	results := make([]error, len(moduleNames))
+	startupTimes := make([]models.ModuleStartupTime, len(moduleNames))
+	semaphore := run.newHealthcheckSemaphore()
+	var wg sync.WaitGroup
+	wg.Add(len(moduleNames))
+	for idx, moduleName := range moduleNames {
+		go func(innerIdx int, innerModuleName string) {
+			defer wg.Done()
+			if semaphore != nil {
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+			}
+			startedAt := time.Now()
+			var moduleWG sync.WaitGroup
+			moduleErrCh := make(chan error, 1)
+			moduleWG.Add(1)
+			run.Config.ModuleSvc.CheckModuleReadiness(&moduleWG, moduleErrCh, innerModuleName, modules[innerModuleName].GetHealthPort())
+			moduleWG.Wait()
+			close(moduleErrCh)
+			results[innerIdx] = <-moduleErrCh
+			endedAt := time.Now()
+			startupTimes[innerIdx] = models.ModuleStartupTime{
+				Module:     innerModuleName,
+				Type:       moduleType,
+				Healthy:    results[innerIdx] == nil,
+				StartedAt:  startedAt,
+				EndedAt:    endedAt,
+				DurationMs: endedAt.Sub(startedAt).Milliseconds(),
+			}
+		}(idx, moduleName)
 	}
 	wg.Wait()
-	close(errCh)
 
-	for err := range errCh {
+	var healthyModules, unhealthyModules []string
+	for idx, err := range results {
 		if err != nil {
-			return err
+			unhealthyModules = append(unhealthyModules, moduleNames[idx])
+			slog.Error(run.Config.Action.Name, "text", "Module is unhealthy", "type", moduleType, "module", moduleNames[idx], "error", err)
+			continue
 		}
+		healthyModules = append(healthyModules, moduleNames[idx])
+	}
+	slog.Info(run.Config.Action.Name, "text", "Module readiness summary", "type", moduleType, "healthy", len(healthyModules), "unhealthy", len(unhealthyModules))
+	if len(startupTimes) > 0 {
+		if err := saveModuleStartupTimes(startupTimes); err != nil {
+			slog.Warn(run.Config.Action.Name, "text", "Module startup times are not saved", "error", err)
+		}
+	}
+	if len(unhealthyModules) > 0 {
+		return errors.ModulesNotReady(unhealthyModules, len(moduleNames))
 	}
 	slog.Info(run.Config.Action.Name, "text", "All modules are ready", "type", moduleType)
 
@@ -0,0 +1,72 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/spf13/cobra"
+)
+
+// saveDiscoveryCmd represents the saveDiscovery command
+var saveDiscoveryCmd = &cobra.Command{
+	Use:   "saveDiscovery <file>",
+	Short: "Save module discovery",
+	Long:  `Save all module discovery entries into a file to be restored later with loadDiscovery.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.SaveDiscovery)
+		if err != nil {
+			return err
+		}
+
+		return run.SaveDiscovery(args[0])
+	},
+}
+
+// SaveDiscovery writes the raw module discovery array into a file
+func (run *Run) SaveDiscovery(filePath string) error {
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	discovery, err := run.Config.ManagementSvc.GetAllRawModuleDiscovery()
+	if err != nil {
+		return err
+	}
+	if discovery == nil {
+		discovery = []json.RawMessage{}
+	}
+
+	data, err := json.MarshalIndent(discovery, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	slog.Info(run.Config.Action.Name, "text", "Saved module discovery", "count", len(discovery), "file", filePath)
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(saveDiscoveryCmd)
+}
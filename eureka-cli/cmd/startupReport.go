@@ -0,0 +1,135 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// startupReportCmd represents the startupReport command
+var startupReportCmd = &cobra.Command{
+	Use:   "startupReport",
+	Short: "Startup report",
+	Long:  `Report the time modules took to become healthy during the latest deploys, sorted from the slowest module.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.StartupReport)
+		if err != nil {
+			return err
+		}
+
+		return run.StartupReport(os.Stdout)
+	},
+}
+
+func (run *Run) StartupReport(writer io.Writer) error {
+	startupTimes, err := loadModuleStartupTimes()
+	if err != nil {
+		return err
+	}
+	if startupTimes == nil {
+		return errors.StartupTimesNotFound(constant.StartupTimesFile)
+	}
+
+	slowest := startupTimes.Slowest(run.Config.Action.Param.Limit)
+	if run.Config.Action.Param.JSON {
+		return writeStartupTimesJSON(writer, slowest)
+	}
+
+	return writeStartupTimes(writer, slowest)
+}
+
+func writeStartupTimesJSON(writer io.Writer, startupTimes []models.ModuleStartupTime) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(startupTimes)
+}
+
+func writeStartupTimes(writer io.Writer, startupTimes []models.ModuleStartupTime) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MODULE\tTYPE\tHEALTHY\tDURATION\tSTARTED")
+	for _, startupTime := range startupTimes {
+		duration := (time.Duration(startupTime.DurationMs) * time.Millisecond).Round(100 * time.Millisecond)
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n", startupTime.Module, startupTime.Type, startupTime.Healthy,
+			duration, startupTime.StartedAt.Format(time.DateTime))
+	}
+
+	return tw.Flush()
+}
+
+// saveModuleStartupTimes merges the startup times of a readiness check into the startup times file
+func saveModuleStartupTimes(startupTimes []models.ModuleStartupTime) error {
+	existing, err := loadModuleStartupTimes()
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		existing = &models.ModuleStartupTimes{}
+	}
+	existing.Merge(startupTimes)
+
+	filePath, err := getStartupTimesFilePath()
+	if err != nil {
+		return err
+	}
+
+	return helpers.WriteJSONToFile(filePath, existing)
+}
+
+func loadModuleStartupTimes() (*models.ModuleStartupTimes, error) {
+	filePath, err := getStartupTimesFilePath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var startupTimes models.ModuleStartupTimes
+	if err := helpers.ReadJSONFromFile(filePath, &startupTimes); err != nil {
+		return nil, err
+	}
+
+	return &startupTimes, nil
+}
+
+func getStartupTimesFilePath() (string, error) {
+	homeDir, err := helpers.GetHomeDirPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, constant.StartupTimesFile), nil
+}
+
+func init() {
+	rootCmd.AddCommand(startupReportCmd)
+	startupReportCmd.PersistentFlags().IntVarP(&params.Limit, action.Limit.Long, action.Limit.Short, 0, action.Limit.Description)
+	startupReportCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+}
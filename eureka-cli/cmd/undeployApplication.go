@@ -54,6 +54,9 @@ var undeployApplicationCmd = &cobra.Command{
 }
 
 func (run *Run) UndeployApplication() error {
+	if len(run.Config.Action.Param.ApplicationIDs) > 0 {
+		return run.undeploySelectedApplications()
+	}
 	if err := run.UndeployUI(); err != nil {
 		slog.Warn(run.Config.Action.Name, "text", "UI undeploy was unsuccessful", "error", err)
 	}
@@ -63,18 +66,25 @@ func (run *Run) UndeployApplication() error {
 	if err := run.UndeployManagement(); err != nil {
 		return err
 	}
+	if err := run.UndeploySystem(); err != nil {
+		return err
+	}
 
-	return run.UndeploySystem()
+	return run.RemoveDeployState()
 }
 
-func (run *Run) UndeployChildApplication() error {
-	if err := run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
-		if err := run.RemoveTenantEntitlements(consortiumName, tenantType); err != nil {
-			slog.Warn(run.Config.Action.Name, "text", "Remove tenant entitlement was unsuccessful", "error", err)
-		}
+// undeploySelectedApplications keeps the shared platform running and only tears down
+// the entitlements, the application descriptors and the module containers of the given applications
+func (run *Run) undeploySelectedApplications() error {
+	if err := run.removeApplicationEntitlements(); err != nil {
+		return err
+	}
 
-		return nil
-	}); err != nil {
+	return run.UndeployModules(true)
+}
+
+func (run *Run) UndeployChildApplication() error {
+	if err := run.removeApplicationEntitlements(); err != nil {
 		return err
 	}
 	if err := run.UndeployModules(true); err != nil {
@@ -95,8 +105,19 @@ func (run *Run) UndeployChildApplication() error {
 	})
 }
 
+func (run *Run) removeApplicationEntitlements() error {
+	return run.ConsortiumPartition(func(consortiumName string, tenantType constant.TenantType) error {
+		if err := run.RemoveTenantEntitlements(consortiumName, tenantType); err != nil {
+			slog.Warn(run.Config.Action.Name, "text", "Remove tenant entitlement was unsuccessful", "error", err)
+		}
+
+		return nil
+	})
+}
+
 func init() {
 	rootCmd.AddCommand(undeployApplicationCmd)
 	undeployApplicationCmd.PersistentFlags().BoolVarP(&params.PurgeSchemas, action.PurgeSchemas.Long, action.PurgeSchemas.Short, false, action.PurgeSchemas.Description)
+	undeployApplicationCmd.PersistentFlags().StringArrayVarP(&params.ApplicationIDs, action.ApplicationIDs.Long, action.ApplicationIDs.Short, []string{}, action.ApplicationIDs.Description)
 	undeployApplicationCmd.PersistentFlags().BoolVarP(&params.SkipCapabilitySets, action.SkipCapabilitySets.Long, action.SkipCapabilitySets.Short, false, action.SkipCapabilitySets.Description)
 }
@@ -40,12 +40,24 @@ var undeployModulesCmd = &cobra.Command{
 }
 
 func (run *Run) UndeployModules(removeApplication bool) error {
-	if removeApplication {
-		slog.Info(run.Config.Action.Name, "text", "REMOVING APPLICATION")
+	applicationIDs := run.Config.Action.Param.ApplicationIDs
+	if removeApplication || len(applicationIDs) > 0 {
 		if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
 			return err
 		}
-		if err := run.Config.ManagementSvc.RemoveApplication(run.Config.Action.ConfigApplicationID); err != nil {
+	}
+
+	patterns := []string{fmt.Sprintf(constant.ProfileContainerPattern, run.Config.Action.ConfigProfileName)}
+	if len(applicationIDs) > 0 {
+		var err error
+		if patterns, err = run.getApplicationContainerPatterns(applicationIDs); err != nil {
+			return err
+		}
+	}
+
+	if removeApplication {
+		slog.Info(run.Config.Action.Name, "text", "REMOVING APPLICATION")
+		if err := run.removeApplications(applicationIDs); err != nil {
 			slog.Warn(run.Config.Action.Name, "text", "Application removal was unsuccessful", "error", err)
 		}
 	}
@@ -57,11 +69,46 @@ func (run *Run) UndeployModules(removeApplication bool) error {
 	}
 	defer run.Config.DockerClient.Close(client)
 
-	pattern := fmt.Sprintf(constant.ProfileContainerPattern, run.Config.Action.ConfigProfileName)
-	return run.Config.ModuleSvc.UndeployModuleByNamePattern(client, pattern)
+	for _, pattern := range patterns {
+		if err := run.Config.ModuleSvc.UndeployModuleByNamePattern(client, pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getApplicationContainerPatterns matches the module and sidecar containers of the given applications,
+// the descriptors are read before the applications are removed
+func (run *Run) getApplicationContainerPatterns(applicationIDs []string) ([]string, error) {
+	var patterns []string
+	for _, applicationID := range applicationIDs {
+		descriptor, err := run.Config.ManagementSvc.GetApplicationDescriptor(applicationID)
+		if err != nil {
+			return nil, err
+		}
+		for _, module := range descriptor.Modules {
+			patterns = append(patterns, fmt.Sprintf(constant.SingleModuleOrSidecarContainerPattern, run.Config.Action.ConfigProfileName, module.Name))
+		}
+	}
+
+	return patterns, nil
+}
+
+// removeApplications removes only the given applications, so that the applications of co-tenants
+// in a shared environment are kept, without ids the configured application is removed
+func (run *Run) removeApplications(applicationIDs []string) error {
+	if len(applicationIDs) == 0 {
+		return run.Config.ManagementSvc.RemoveApplication(run.Config.Action.ConfigApplicationID)
+	}
+
+	_, err := run.Config.ManagementSvc.RemoveApplications("", "", applicationIDs...)
+
+	return err
 }
 
 func init() {
 	rootCmd.AddCommand(undeployModulesCmd)
 	undeployModulesCmd.PersistentFlags().BoolVarP(&params.RemoveApplication, action.RemoveApplication.Long, action.RemoveApplication.Short, true, action.RemoveApplication.Description)
+	undeployModulesCmd.PersistentFlags().StringArrayVarP(&params.ApplicationIDs, action.ApplicationIDs.Long, action.ApplicationIDs.Short, []string{}, action.ApplicationIDs.Description)
 }
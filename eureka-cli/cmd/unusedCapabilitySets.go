@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/spf13/cobra"
+)
+
+// unusedCapabilitySetsCmd represents the unusedCapabilitySets command
+var unusedCapabilitySetsCmd = &cobra.Command{
+	Use:   "unusedCapabilitySets",
+	Short: "List unused capability sets",
+	Long:  `List capability sets of a tenant that are not attached to any role.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.UnusedCapabilitySets)
+		if err != nil {
+			return err
+		}
+
+		return run.UnusedCapabilitySets(params.Tenant, os.Stdout)
+	},
+}
+
+func (run *Run) UnusedCapabilitySets(tenantName string, writer io.Writer) error {
+	if err := run.setTenantAccessTokenIntoContext(tenantName); err != nil {
+		return err
+	}
+
+	report, err := run.Config.KeycloakSvc.GetUnusedCapabilitySets(tenantName)
+	if err != nil {
+		return err
+	}
+	if run.Config.Action.Param.JSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tAPPLICATION\tID")
+	for _, capabilitySet := range report.CapabilitySets {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", capabilitySet.Name, capabilitySet.ApplicationID, capabilitySet.ID)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(writer, "\n%d of %d capability sets are not attached to any of %d roles (%d attached)\n",
+		len(report.CapabilitySets), report.Total, report.Roles, report.Attached)
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(unusedCapabilitySetsCmd)
+	unusedCapabilitySetsCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	unusedCapabilitySetsCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+}
@@ -74,6 +74,7 @@ func init() {
 	updateModuleDiscoveryCmd.PersistentFlags().StringVarP(&params.SidecarURL, action.SidecarURL.Long, action.SidecarURL.Short, "", action.SidecarURL.Description)
 	updateModuleDiscoveryCmd.PersistentFlags().IntVarP(&params.PrivatePort, action.PrivatePort.Long, action.PrivatePort.Short, 8081, action.PrivatePort.Description)
 	updateModuleDiscoveryCmd.PersistentFlags().BoolVarP(&params.Restore, action.Restore.Long, action.Restore.Short, false, action.Restore.Description)
+	updateModuleDiscoveryCmd.PersistentFlags().BoolVarP(&params.Force, action.Force.Long, action.Force.Short, false, action.Force.Description)
 
 	if err := updateModuleDiscoveryCmd.MarkPersistentFlagRequired(action.ModuleName.Long); err != nil {
 		slog.Error(errors.MarkFlagRequiredFailed(action.ModuleName, err).Error())
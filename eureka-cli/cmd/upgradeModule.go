@@ -164,7 +164,7 @@ func (run *Run) UpgradeModule() error {
 		}
 	}
 	slog.Info(run.Config.Action.Name, "text", "REMOVING APPLICATIONS", "name", appName)
-	if err := run.Config.ManagementSvc.RemoveApplications(appName, newAppID); err != nil {
+	if _, err := run.Config.ManagementSvc.RemoveApplications(appName, newAppID); err != nil {
 		return err
 	}
 	if params.Cleanup {
@@ -250,7 +250,7 @@ func (run *Run) cleanupApplicationsOnFailure(consortiumName string, tenantType c
 		entitlement := entitlements.Entitlements[0]
 
 		slog.Info(run.Config.Action.Name, "text", "REMOVING APPLICATIONS ON FAILURE", "name", appName)
-		if err := run.Config.ManagementSvc.RemoveApplications(appName, entitlement.ApplicationID); err != nil {
+		if _, err := run.Config.ManagementSvc.RemoveApplications(appName, entitlement.ApplicationID); err != nil {
 			return errors.Wrapf(err, "failed to cleanup apps on failure - cannot remove apps (%s app id is ignored)", entitlement.ApplicationID)
 		}
 	}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// validateKongRoutesCmd represents the validateKongRoutes command
+var validateKongRoutesCmd = &cobra.Command{
+	Use:   "validateKongRoutes",
+	Short: "Validate Kong routes",
+	Long:  `Cross-check the routes registered in Kong against the modules of an application.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.ValidateKongRoutes)
+		if err != nil {
+			return err
+		}
+
+		return run.ValidateKongRoutes(params.ApplicationID, os.Stdout)
+	},
+}
+
+// ValidateKongRoutes reports the modules of an application without a Kong route and the Kong routes without a module,
+// a route has no module when its service is gone or is named after another version of an application module
+func (run *Run) ValidateKongRoutes(applicationID string, writer io.Writer) error {
+	if applicationID == "" {
+		applicationID = run.Config.Action.ConfigApplicationID
+	}
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	modules, err := run.Config.ManagementSvc.GetApplicationModules(applicationID)
+	if err != nil {
+		return err
+	}
+	services, err := run.Config.KongSvc.ListAllServices()
+	if err != nil {
+		return err
+	}
+	routes, err := run.Config.KongSvc.ListAllRoutes()
+	if err != nil {
+		return err
+	}
+
+	missing, orphaned := matchKongRoutes(modules, services, routes)
+	if err := writeKongRoutesValidation(writer, applicationID, len(modules), missing, orphaned); err != nil {
+		return err
+	}
+	if len(missing) > 0 || len(orphaned) > 0 {
+		return errors.KongRoutesMismatch(applicationID, len(missing), len(orphaned))
+	}
+
+	return nil
+}
+
+// orphanedKongRoute is a Kong route without a module, service is empty when the route service is gone
+type orphanedKongRoute struct {
+	route   string
+	service string
+}
+
+// matchKongRoutes returns the sorted ids of the modules without a route and the routes without a module
+func matchKongRoutes(modules []models.ApplicationModule, services []models.KongService, routes []models.KongRoute) (missing []string, orphaned []orphanedKongRoute) {
+	moduleIDs := make(map[string]bool, len(modules))
+	moduleNames := make(map[string]bool, len(modules))
+	for _, module := range modules {
+		moduleIDs[module.ID] = true
+		name, _ := helpers.ExtractModuleNameAndVersion(module.ID)
+		moduleNames[name] = true
+	}
+	serviceNames := make(map[string]string, len(services))
+	for _, service := range services {
+		serviceNames[service.ID] = service.Name
+	}
+
+	routed := make(map[string]bool)
+	for _, route := range routes {
+		serviceName, found := serviceNames[route.Service.ID]
+		if !found {
+			orphaned = append(orphaned, orphanedKongRoute{route: getKongRouteName(route)})
+			continue
+		}
+		routed[serviceName] = true
+		name, version := helpers.ExtractModuleNameAndVersion(serviceName)
+		if version != "" && moduleNames[name] && !moduleIDs[serviceName] {
+			orphaned = append(orphaned, orphanedKongRoute{route: getKongRouteName(route), service: serviceName})
+		}
+	}
+	for _, module := range modules {
+		if !routed[module.ID] {
+			missing = append(missing, module.ID)
+		}
+	}
+	sort.Strings(missing)
+	sort.Slice(orphaned, func(i, j int) bool {
+		if orphaned[i].service != orphaned[j].service {
+			return orphaned[i].service < orphaned[j].service
+		}
+		return orphaned[i].route < orphaned[j].route
+	})
+
+	return missing, orphaned
+}
+
+func getKongRouteName(route models.KongRoute) string {
+	if route.Name != "" {
+		return route.Name
+	}
+
+	return route.ID
+}
+
+func writeKongRoutesValidation(writer io.Writer, applicationID string, moduleCount int, missing []string, orphaned []orphanedKongRoute) error {
+	if len(missing) == 0 && len(orphaned) == 0 {
+		_, err := fmt.Fprintf(writer, "%s: the Kong routes of all %d modules match\n", applicationID, moduleCount)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(applicationID + "\n")
+	if len(missing) > 0 {
+		sb.WriteString("Modules without Kong routes:\n")
+		for _, moduleID := range missing {
+			sb.WriteString("  " + moduleID + "\n")
+		}
+	}
+	if len(orphaned) > 0 {
+		sb.WriteString("Kong routes without module:\n")
+		tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  ROUTE\tSERVICE")
+		for _, route := range orphaned {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\n", route.route, valueOrDash(route.service))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(writer, sb.String())
+
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(validateKongRoutesCmd)
+	validateKongRoutesCmd.PersistentFlags().StringVarP(&params.ApplicationID, action.ApplicationID.Long, action.ApplicationID.Short, "", action.ApplicationID.Description)
+	validateKongRoutesCmd.PersistentFlags().StringVarP(&params.KongAdminURL, action.KongAdminURL.Long, action.KongAdminURL.Short, "", action.KongAdminURL.Description)
+}
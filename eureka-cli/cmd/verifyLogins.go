@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/spf13/cobra"
+)
+
+// verifyLoginsCmd represents the verifyLogins command
+var verifyLoginsCmd = &cobra.Command{
+	Use:   "verifyLogins",
+	Short: "Verify user logins",
+	Long:  `Log in every configured user with the configured password and report whether the login succeeded.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.VerifyLogins)
+		if err != nil {
+			return err
+		}
+
+		return run.VerifyLogins(params.Tenant, os.Stdout)
+	},
+}
+
+// VerifyLogins logs in the configured users, optionally only those of a tenant, and fails when any login fails
+func (run *Run) VerifyLogins(tenantName string, writer io.Writer) error {
+	if tenantName != "" && !helpers.HasTenant(tenantName, run.Config.Action.ConfigTenants) {
+		return errors.TenantNotFound(tenantName)
+	}
+	if err := run.GetVaultRootToken(); err != nil {
+		return err
+	}
+
+	var (
+		results []models.UserLoginResult
+		failed  []string
+	)
+	for _, username := range helpers.SortedMapKeys(run.Config.Action.ConfigUsers) {
+		entry := helpers.GetMap(run.Config.Action.ConfigUsers, username)
+		userTenant := helpers.GetString(entry, field.UsersTenantEntry)
+		if tenantName != "" && userTenant != tenantName {
+			continue
+		}
+
+		result := models.UserLoginResult{Username: username, Tenant: userTenant, Success: true}
+		if err := run.Config.KeycloakSvc.VerifyUserLogin(userTenant, username, helpers.GetString(entry, field.UsersPasswordEntry)); err != nil {
+			slog.Debug(run.Config.Action.Name, "text", "User login failed", "username", username, "tenant", userTenant, "error", err)
+			result.Success = false
+			failed = append(failed, username)
+		}
+		results = append(results, result)
+	}
+
+	var err error
+	if run.Config.Action.Param.JSON {
+		err = writeUserLoginResultsJSON(writer, results)
+	} else {
+		err = writeUserLoginResults(writer, results)
+	}
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return errors.UserLoginsFailed(failed)
+	}
+
+	return nil
+}
+
+func writeUserLoginResultsJSON(writer io.Writer, results []models.UserLoginResult) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(results)
+}
+
+func writeUserLoginResults(writer io.Writer, results []models.UserLoginResult) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "USERNAME\tTENANT\tLOGIN")
+	for _, result := range results {
+		status := "PASS"
+		if !result.Success {
+			status = "FAIL"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Username, result.Tenant, status)
+	}
+
+	return tw.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(verifyLoginsCmd)
+	verifyLoginsCmd.PersistentFlags().StringVarP(&params.Tenant, action.Tenant.Long, action.Tenant.Short, "", action.Tenant.Description)
+	verifyLoginsCmd.PersistentFlags().BoolVarP(&params.JSON, action.JSON.Long, action.JSON.Short, false, action.JSON.Description)
+}
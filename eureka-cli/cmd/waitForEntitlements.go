@@ -0,0 +1,54 @@
+/*
+Copyright © 2025 Open Library Foundation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/spf13/cobra"
+)
+
+// waitForEntitlementsCmd represents the waitForEntitlements command
+var waitForEntitlementsCmd = &cobra.Command{
+	Use:   "waitForEntitlements",
+	Short: "Wait for tenant entitlements",
+	Long:  `Wait until all configured tenants are entitled to the configured application.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := New(action.WaitForEntitlements)
+		if err != nil {
+			return err
+		}
+
+		return run.WaitForEntitlements(params.Timeout)
+	},
+}
+
+func (run *Run) WaitForEntitlements(timeout time.Duration) error {
+	slog.Info(run.Config.Action.Name, "text", "WAITING FOR TENANT ENTITLEMENTS")
+	if err := run.setKeycloakMasterAccessTokenIntoContext(constant.ClientCredentials); err != nil {
+		return err
+	}
+
+	return run.Config.ManagementSvc.WaitForTenantEntitlements(timeout)
+}
+
+func init() {
+	rootCmd.AddCommand(waitForEntitlementsCmd)
+	waitForEntitlementsCmd.PersistentFlags().DurationVarP(&params.Timeout, action.Timeout.Long, action.Timeout.Short, constant.TenantEntitlementWaitTimeout, action.Timeout.Description)
+}
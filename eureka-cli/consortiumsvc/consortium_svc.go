@@ -6,7 +6,6 @@ import (
 	"log/slog"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
-	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/httpclient"
@@ -44,8 +43,8 @@ func New(action *action.Action, httpClient httpclient.HTTPClientRunner, userSvc
 }
 
 func (cs *ConsortiumSvc) GetConsortiumByName(centralTenant string, consortiumName string) (any, error) {
-	requestURL := cs.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/consortia?query=name==%s&limit=1", consortiumName))
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(centralTenant, cs.Action.KeycloakAccessToken)
+	requestURL := cs.Action.GetRequestURL(cs.Action.GetGatewayPort(), fmt.Sprintf("/consortia?query=name==%s&limit=1", consortiumName))
+	headers, err := cs.Action.BuildTenantHeaders(centralTenant, cs.Action.KeycloakAccessToken)
 	if err != nil {
 		return nil, err
 	}
@@ -138,8 +137,8 @@ func (cs *ConsortiumSvc) CreateConsortium(centralTenant string, consortiumName s
 		return "", err
 	}
 
-	requestURL := cs.Action.GetRequestURL(constant.KongPort, "/consortia")
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(centralTenant, cs.Action.KeycloakAccessToken)
+	requestURL := cs.Action.GetRequestURL(cs.Action.GetGatewayPort(), "/consortia")
+	headers, err := cs.Action.BuildTenantHeaders(centralTenant, cs.Action.KeycloakAccessToken)
 	if err != nil {
 		return "", err
 	}
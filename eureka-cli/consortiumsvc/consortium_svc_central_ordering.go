@@ -6,8 +6,6 @@ import (
 	"log/slog"
 	"strconv"
 
-	"github.com/folio-org/eureka-setup/eureka-cli/constant"
-	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
 )
 
@@ -35,8 +33,8 @@ func (cs *ConsortiumSvc) EnableCentralOrdering(centralTenant string) error {
 		return err
 	}
 
-	requestURL := cs.Action.GetRequestURL(constant.KongPort, "/orders-storage/settings")
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(centralTenant, cs.Action.KeycloakAccessToken)
+	requestURL := cs.Action.GetRequestURL(cs.Action.GetGatewayPort(), "/orders-storage/settings")
+	headers, err := cs.Action.BuildTenantHeaders(centralTenant, cs.Action.KeycloakAccessToken)
 	if err != nil {
 		return err
 	}
@@ -49,8 +47,8 @@ func (cs *ConsortiumSvc) EnableCentralOrdering(centralTenant string) error {
 }
 
 func (cs *ConsortiumSvc) getEnableCentralOrderingByKey(centralTenant string, key string) (bool, error) {
-	requestURL := cs.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/orders-storage/settings?query=key==%s&limit=1", key))
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(centralTenant, cs.Action.KeycloakAccessToken)
+	requestURL := cs.Action.GetRequestURL(cs.Action.GetGatewayPort(), fmt.Sprintf("/orders-storage/settings?query=key==%s&limit=1", key))
+	headers, err := cs.Action.BuildTenantHeaders(centralTenant, cs.Action.KeycloakAccessToken)
 	if err != nil {
 		return false, err
 	}
@@ -47,7 +47,7 @@ func (cs *ConsortiumSvc) GetSortedConsortiumTenants(consortiumName string) model
 }
 
 func (cs *ConsortiumSvc) CreateConsortiumTenants(centralTenant string, consortiumID string, consortiumTenants models.SortedConsortiumTenants, adminUsername string) error {
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(centralTenant, cs.Action.KeycloakAccessToken)
+	headers, err := cs.Action.BuildTenantHeaders(centralTenant, cs.Action.KeycloakAccessToken)
 	if err != nil {
 		return err
 	}
@@ -82,7 +82,7 @@ func (cs *ConsortiumSvc) CreateConsortiumTenants(centralTenant string, consortiu
 		}
 
 		slog.Info(cs.Action.Name, "text", "Trying to create consortium tenant", "tenant", consortiumTenant.Name, "consortium", consortiumID)
-		finalRequestURL := cs.Action.GetRequestURL(constant.KongPort, requestURL)
+		finalRequestURL := cs.Action.GetRequestURL(cs.Action.GetGatewayPort(), requestURL)
 		if err := cs.HTTPClient.PostReturnNoContent(finalRequestURL, payload, headers); err != nil {
 			return err
 		}
@@ -95,8 +95,8 @@ func (cs *ConsortiumSvc) CreateConsortiumTenants(centralTenant string, consortiu
 }
 
 func (cs *ConsortiumSvc) getConsortiumTenantByIDAndName(centralTenant string, consortiumID string, tenant string) (any, error) {
-	requestURL := cs.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/consortia/%s/tenants", consortiumID))
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(centralTenant, cs.Action.KeycloakAccessToken)
+	requestURL := cs.Action.GetRequestURL(cs.Action.GetGatewayPort(), fmt.Sprintf("/consortia/%s/tenants", consortiumID))
+	headers, err := cs.Action.BuildTenantHeaders(centralTenant, cs.Action.KeycloakAccessToken)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +118,7 @@ func (cs *ConsortiumSvc) getConsortiumTenantByIDAndName(centralTenant string, co
 }
 
 func (cs *ConsortiumSvc) checkConsortiumTenantStatus(centralTenant string, consortiumID string, tenantName string, headers map[string]string) error {
-	requestURL := cs.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/consortia/%s/tenants/%s", consortiumID, tenantName))
+	requestURL := cs.Action.GetRequestURL(cs.Action.GetGatewayPort(), fmt.Sprintf("/consortia/%s/tenants/%s", consortiumID, tenantName))
 
 	var decodedResponse models.ConsortiumTenantStatus
 	if err := cs.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
@@ -10,17 +10,44 @@ const (
 	DeployManagementWait              = 5 * time.Second
 	DeployModulesWait                 = 5 * time.Second
 	ModuleReadinessWait               = 10 * time.Second
+	ModuleReadinessMinWait            = 2 * time.Second
 	KongReadinessWait                 = 10 * time.Second
 	AttachCapabilitySetsPollWait      = 30 * time.Second
 	AttachCapabilitySetsRebalanceWait = 30 * time.Second
 	AttachCapabilitySetsTimeoutWait   = 30 * time.Second
+	AttachCapabilitySetsRetryWait     = 10 * time.Second
 	ConsortiumTenantStatusWait        = 10 * time.Second
+	TenantEntitlementPollWait         = 10 * time.Second
+	TenantEntitlementWaitTimeout      = 10 * time.Minute
+	TenantEntitlementFlowTimeout      = 30 * time.Minute
+	KafkaTopicWait                    = 10 * time.Second
+	ReadinessProbeInterval            = 15 * time.Second
+	InterruptExitWait                 = 5 * time.Second
 
 	// Readiness retries
 	ModuleReadinessMaxRetries     = 70
+	ModuleReadinessHealthyChecks  = 1
 	KongRouteReadinessMaxRetries  = 30
 	ConsumerGroupRebalanceRetries = 70
 	ConsumerGroupPollMaxRetries   = 70
+	KafkaTopicMaxRetries          = 30
+
+	// Exit code of a command aborted by Ctrl-C
+	InterruptExitCode = 130
+
+	// Poll jitter fraction applied to wait durations
+	PollJitterFraction = 0.2
+
+	// Concurrency limits
+	CapabilitySetsConcurrency    = 4
+	TenantEntitlementConcurrency = 2
+	HealthcheckConcurrency       = 0
+
+	// Page sizes
+	UsersPageSize = 500
+
+	// Batch sizes
+	CapabilitySetsBatchSize = 250
 
 	// Context timeout durations
 	ContextTimeoutDockerAPIVersion   = 15 * time.Second
@@ -31,10 +58,18 @@ const (
 	ContextTimeoutVaultClient        = 30 * time.Second
 	ContextTimeoutVaultContainerLogs = 30 * time.Second
 	ContextTimeoutAWSConfig          = 30 * time.Second
+	ContextTimeoutReadinessShutdown  = 5 * time.Second
+	ContextTimeoutKafkaCommand       = 30 * time.Second
 
 	// HTTP client timeouts
-	HTTPClientPingTimeout = 15 * time.Second
-	HTTPClientTimeout     = 10 * time.Minute
+	HTTPClientPingTimeout  = 15 * time.Second
+	HTTPClientTimeout      = 10 * time.Minute
+	SidecarHealthTimeout   = 5 * time.Second
+	ReadinessHeaderTimeout = 5 * time.Second
+
+	// Gateway benchmark defaults
+	BenchConcurrency = 10
+	BenchDuration    = 30 * time.Second
 
 	// Custom HTTP client transport settings
 	HTTPClientDialTimeout           = 30 * time.Second
@@ -58,6 +93,13 @@ const (
 	DockerLogHeaderSize = 8
 	DockerLogSizeOffset = 4
 
+	// HTTP archive properties
+	HARVersion         = "1.2"
+	HARCreatorName     = "eureka-cli"
+	HARMaxBodySize     = 64 * 1024
+	HARTruncatedSuffix = "...(truncated)"
+	HARRedactedValue   = "[REDACTED]"
+
 	// Retry HTTP client properties
 	RetryHTTPClientRetryMax     = 5
 	RetryHTTPClientRetryWaitMin = 2 * time.Second
@@ -132,10 +174,15 @@ const (
 
 	// Files
 	ModulesFile               = "modules.json"
+	DeployStateFilePattern    = "deploy_state_%s_%s.json"
+	StartupTimesFile          = "startup_times.json"
 	CapabilitySetsFilePattern = "%s_capability_sets.json"
+	DescriptorCacheDir        = "descriptors"
 
 	// Docker compose properties
-	DockerComposeWorkDir = "./misc"
+	DockerComposeWorkDir       = "./misc"
+	DockerComposeFile          = "docker-compose.yaml"
+	DockerComposeResourcesFile = "docker-compose.resources.yaml"
 
 	// Container network properties
 	NetworkID         = "eureka"
@@ -158,11 +205,11 @@ const (
 	SingleUiContainerPattern              = "eureka-platform-complete-ui-%s"
 
 	// Other regexp patterns
-	VaultRootTokenPattern = "init.sh: Root VAULT TOKEN is:"
-	ColonDelimitedPattern = ".*:"
-	ModuleIDPattern       = `^([a-z_-]+)([\d_.-]+)([-\w.]+)$`
-	NewLinePattern        = `[\r\n\s-]+`
-	ProtocolPattern       = `^[a-zA-Z]+://`
+	VaultRootTokenPattern  = "init.sh: Root VAULT TOKEN is:"
+	ColonDelimitedPattern  = ".*:"
+	ModuleIDPattern        = `^(.+?)-(\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.+-]+)?)$`
+	ProtocolPattern        = `^[a-zA-Z]+://`
+	EnvVarReferencePattern = `\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`
 
 	// System containers name
 	DozzleContainer        = "dozzle"
@@ -185,6 +232,7 @@ const (
 	KeycloakMasterRealm                    = "master"
 	KeycloakMasterRealmAccessTokenLifespan = 3600
 	KeycloakTenantRealmAccessTokenLifespan = 3600
+	KeycloakReportMaxEvents                = 10000
 
 	// System container ports
 	KongPort        = "8000"
@@ -198,17 +246,26 @@ const (
 
 	// System container external endpoints
 	KongExternalHTTP     = "http://localhost:8000"
+	KafkaExternalTCP     = "localhost:29092"
 	KeycloakExternalHTTP = "http://keycloak.eureka:8080"
 
 	// Backend modules
 	ModSearchModule           = "mod-search"
 	ModDataExportWorkerModule = "mod-data-export-worker"
 
+	// Resource descriptions
+	DefaultDescription = "Default"
+	TagAttribute       = "tag"
+
 	// Kafka consumer group properties
 	ConsumerGroupSuffix = "mod-roles-keycloak-capability-group"
-	ErrNoActiveMembers  = "Consumer group 'folio-mod-roles-keycloak-capability-group' has no active members."
-	ErrRebalancing      = "Consumer group 'folio-mod-roles-keycloak-capability-group' is rebalancing."
+	CapabilityTopic     = "mgr-tenant-entitlements.capability"
+	ErrNoActiveMembers  = "has no active members"
+	ErrRebalancing      = "is rebalancing"
 	ErrTimeoutException = "TimeoutException"
+
+	// Kafka CLI scripts
+	KafkaConsumerGroupsScript = "kafka-consumer-groups.sh"
 )
 
 // ==================== Container Types ====================
@@ -238,6 +295,113 @@ func GetTenantTypes() []TenantType {
 	return []TenantType{Central, Member}
 }
 
+// ==================== Application Platforms ====================
+
+const (
+	BasePlatform     = "base"
+	CompletePlatform = "complete"
+	CustomPlatform   = "custom"
+)
+
+func GetApplicationPlatforms() []string {
+	return []string{BasePlatform, CompletePlatform, CustomPlatform}
+}
+
+// ==================== Dependency Graph Formats ====================
+
+const (
+	TextFormat = "text"
+	DotFormat  = "dot"
+)
+
+func GetDependencyGraphFormats() []string {
+	return []string{TextFormat, DotFormat}
+}
+
+// ==================== HTTP Archive Redaction ====================
+
+func GetHARRedactedHeaders() []string {
+	return []string{AuthorizationHeader, OkapiTokenHeader}
+}
+
+// GetHARRedactedFields returns the lowercase names without underscores of the form and JSON body fields
+// whose values are redacted
+func GetHARRedactedFields() []string {
+	return []string{"accesstoken", "clientsecret", "idtoken", "password", "refreshtoken", "secret", "token"}
+}
+
+// ==================== Deploy Phases ====================
+
+const (
+	SystemPhase         = "system"
+	ManagementPhase     = "management"
+	ApplicationPhase    = "application"
+	TenantsPhase        = "tenants"
+	EntitlementsPhase   = "entitlements"
+	RolesAndUsersPhase  = "roles-users"
+	CapabilitySetsPhase = "capability-sets"
+	ConsortiumPhase     = "consortium"
+	UIPhase             = "ui"
+)
+
+// ==================== Management Gateway Routes ====================
+
+// GetManagementGatewayRoutes returns the gateway route of every management module used to check its reachability through the gateway
+func GetManagementGatewayRoutes() map[string]string {
+	return map[string]string{
+		"mgr-applications":        "/applications?limit=1",
+		"mgr-tenants":             "/tenants?limit=1",
+		"mgr-tenant-entitlements": "/entitlements?limit=1",
+	}
+}
+
+// ==================== Phase Waits ====================
+
+const (
+	SystemWaitPhase           = "system"
+	AdditionalSystemWaitPhase = "additional-system"
+	ManagementWaitPhase       = "management"
+	ModulesWaitPhase          = "modules"
+	PartitionWaitPhase        = "partition"
+)
+
+func GetPhaseWaits() map[string]time.Duration {
+	return map[string]time.Duration{
+		SystemWaitPhase:           DeploySystemWait,
+		AdditionalSystemWaitPhase: DeployAdditionalSystemWait,
+		ManagementWaitPhase:       DeployManagementWait,
+		ModulesWaitPhase:          DeployModulesWait,
+		PartitionWaitPhase:        DeployApplicationPartitionWait,
+	}
+}
+
+// ==================== Preferred Contact Types ====================
+
+const (
+	MailContactType        = "001"
+	EmailContactType       = "002"
+	TextMessageContactType = "003"
+	PhoneContactType       = "004"
+	MobilePhoneContactType = "005"
+)
+
+func GetPreferredContactTypeIDs() []string {
+	return []string{MailContactType, EmailContactType, TextMessageContactType, PhoneContactType, MobilePhoneContactType}
+}
+
+// ==================== User Types ====================
+
+const StaffUserType = "staff"
+
+// ==================== Role Capability Sets ====================
+
+const (
+	// AllCapabilitySets selects the capability sets of all applications,
+	// with the scope prefix it selects only those of one application, e.g. all:app-inventory-1.0.0
+	AllCapabilitySets            = "all"
+	AllCapabilitySetsScopePrefix = "all:"
+)
+
 // ==================== Keycloak Grant Types ====================
 
 type KeycloakGrantType string
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // FlagReader interface allows us to accept flag structs without importing the flags package
@@ -23,6 +25,8 @@ var (
 	ErrDeploymentFailed = errors.New("deployment failed")
 	ErrAccessTokenBlank = errors.New("access token cannot be blank")
 	ErrTenantNameBlank  = errors.New("tenant name cannot be blank")
+
+	ErrRoleAttachmentFailed = errors.New("role attachment failed")
 )
 
 // ==================== Generic Error Helpers ====================
@@ -64,10 +68,22 @@ func RequiredParameterMissing(param string) error {
 	return fmt.Errorf("%w: %s parameter required", ErrInvalidInput, param)
 }
 
+func NonPositiveParameter(param string, value any) error {
+	return fmt.Errorf("%w: %s parameter must be positive but got %v", ErrInvalidInput, param, value)
+}
+
 func AccessTokenBlank() error {
 	return ErrAccessTokenBlank
 }
 
+func ConfirmationRequired(operation string) error {
+	return fmt.Errorf("%w: %s is destructive and requires the --confirm flag", ErrInvalidInput, operation)
+}
+
+func ConfirmationMismatch(operation string, expected string) error {
+	return fmt.Errorf("%w: %s was not confirmed, expected %q to be typed", ErrInvalidInput, operation, expected)
+}
+
 func TenantNameBlank() error {
 	return ErrTenantNameBlank
 }
@@ -95,6 +111,7 @@ func (e *HTTPError) Is(target error) bool {
 
 var (
 	ErrHTTP404NotFound = &HTTPError{StatusCode: http.StatusNotFound}
+	ErrHTTP409Conflict = &HTTPError{StatusCode: http.StatusConflict}
 )
 
 func PingFailed(url string, err error) error {
@@ -123,6 +140,10 @@ func UnsupportedPlatform(platform, address string) error {
 	return fmt.Errorf("unsupported %s platform for %s", platform, address)
 }
 
+func InvalidBaseURL(baseURL string) error {
+	return fmt.Errorf("%w: base URL %q must have a scheme and a port", ErrInvalidInput, baseURL)
+}
+
 func GatewayURLConstructFailed(platform string, err error) error {
 	return fmt.Errorf("failed to construct a gateway url for %s platform: %w", platform, err)
 }
@@ -161,6 +182,24 @@ func ECRInvalidTokenFormat() error {
 	return fmt.Errorf("%w: invalid ECR authorization token format", ErrUnauthorized)
 }
 
+// ==================== Config Errors ====================
+
+func InvalidResourceLimit(name, key string, value any) error {
+	return fmt.Errorf("%w: resource limit %s=%v of %s is not a valid docker resource value", ErrInvalidInput, key, value, name)
+}
+
+func InvalidResourceValue(key string, value any) error {
+	return fmt.Errorf("%w: resource limit %s=%v is not a valid docker resource value", ErrInvalidInput, key, value)
+}
+
+func InvalidHealthyChecks(name string, value any) error {
+	return fmt.Errorf("%w: healthy-checks=%v of %s must be a positive integer", ErrInvalidInput, value, name)
+}
+
+func UnresolvedEnvVars(names []string) error {
+	return fmt.Errorf("%w: unresolved environment variables %s referenced in config", ErrConfigMissing, strings.Join(names, ", "))
+}
+
 // ==================== Consortium Errors ====================
 
 func ConsortiumMissingCentralTenant(consortiumName string) error {
@@ -169,6 +208,10 @@ func ConsortiumMissingCentralTenant(consortiumName string) error {
 
 // ==================== File Errors ====================
 
+func FileAlreadyExists(fileName string) error {
+	return fmt.Errorf("%w: file %s already exists, use --overwriteFiles to replace it", ErrInvalidInput, fileName)
+}
+
 func NotRegularFile(fileName string) error {
 	return fmt.Errorf("%w: %s is not a regular file", ErrInvalidInput, fileName)
 }
@@ -197,16 +240,36 @@ func ConsumerGroupPollTimeout(consumerGroup string, maxRetries int) error {
 	return fmt.Errorf("%w: consumer group %s polling exceeded maximum retries (%d)", ErrTimeout, consumerGroup, maxRetries)
 }
 
+func KafkaTopicWaitTimeout(missing []string, maxRetries int) error {
+	return fmt.Errorf("%w: kafka resources %v did not appear after maximum retries (%d)", ErrTimeout, missing, maxRetries)
+}
+
+func ConsumerGroupDescribeFailed(consumerGroup string, stderr string, err error) error {
+	if err != nil {
+		return fmt.Errorf("failed to describe consumer group %s, stderr: %s: %w", consumerGroup, stderr, err)
+	}
+
+	return fmt.Errorf("failed to describe consumer group %s, stderr: %s", consumerGroup, stderr)
+}
+
 func ContainerCommandFailed(stderr string) error {
 	return fmt.Errorf("failed to execute container command, stderr: %s", stderr)
 }
 
 // ==================== Keycloak Errors ====================
 
+func UserLoginsFailed(usernames []string) error {
+	return fmt.Errorf("%w: login failed for users %s", ErrUnauthorized, strings.Join(usernames, ", "))
+}
+
 func AccessTokenNotFound(requestURL string) error {
 	return fmt.Errorf("%w: access token from response: %s", ErrNotFound, requestURL)
 }
 
+func AccessTokenEnvNotSet(envName string) error {
+	return fmt.Errorf("%w: access token in environment variable %s", ErrNotFound, envName)
+}
+
 func ClientNotFound(clientID string) error {
 	return fmt.Errorf("%w: expected exactly 1 client with id %s", ErrNotFound, clientID)
 }
@@ -215,10 +278,42 @@ func RoleNotFound(roleName string) error {
 	return fmt.Errorf("%w: expected exactly 1 role with name %s", ErrNotFound, roleName)
 }
 
+func RoleNotConfigured(roleName, tenantName string) error {
+	return fmt.Errorf("%w: role %s is not configured for tenant %s", ErrNotFound, roleName, tenantName)
+}
+
+func InvalidModuleID(moduleID string) error {
+	return fmt.Errorf("%w: %s is not a module id of the form <name>-<semver>", ErrInvalidInput, moduleID)
+}
+
+func ModuleNotConfigured(moduleID string) error {
+	return fmt.Errorf("%w: module %s is not configured", ErrNotFound, moduleID)
+}
+
 func UserNotFound(username, tenantName string) error {
 	return fmt.Errorf("%w: user %s in tenant %s", ErrNotFound, username, tenantName)
 }
 
+func RoleAttachmentFailed(username string, err error) error {
+	return fmt.Errorf("%w for user %s: %w", ErrRoleAttachmentFailed, username, err)
+}
+
+func UsersWithoutRoles(tenantName string, usernames []string) error {
+	return fmt.Errorf("%w: users %v in tenant %s were left without their roles", ErrRoleAttachmentFailed, usernames, tenantName)
+}
+
+func InvalidPreferredContactTypeID(username, contactTypeID string, allowedContactTypeIDs []string) error {
+	return fmt.Errorf("%w: user %s preferred contact type id %s is not one of %v", ErrInvalidInput, username, contactTypeID, allowedContactTypeIDs)
+}
+
+func UnknownConfigTenants(section string, offenders []string) error {
+	return fmt.Errorf("%w: %s reference tenants missing from the tenants section: %v", ErrInvalidInput, section, offenders)
+}
+
+func DuplicateConfigNames(collisions []string) error {
+	return fmt.Errorf("%w: names differ only in case: %s", ErrInvalidInput, strings.Join(collisions, "; "))
+}
+
 // ==================== Kong Errors ====================
 
 func KongRoutesNotReady(expected int) error {
@@ -231,12 +326,52 @@ func KongAdminAPIFailed(statusCode int, status string) error {
 
 // ==================== Application Errors ====================
 
+func InvalidApplicationPlatform(platform string, allowedPlatforms []string) error {
+	return fmt.Errorf("%w: application platform %s is not one of %v", ErrInvalidInput, platform, allowedPlatforms)
+}
+
+func UnknownPhaseWait(phase string, phases []string) error {
+	return fmt.Errorf("%w: phase wait %s is not one of %v", ErrInvalidInput, phase, phases)
+}
+
+func InvalidPhaseWait(phase string, value string) error {
+	return fmt.Errorf("%w: phase wait %s must be a non-negative duration but got %q", ErrInvalidInput, phase, value)
+}
+
 func ApplicationNotFound(applicationName string) error {
 	return fmt.Errorf("%w: failed to find the latest application for %s profile", ErrNotFound, applicationName)
 }
 
+func ApplicationIDNotFound(applicationID string) error {
+	return fmt.Errorf("%w: application %s", ErrNotFound, applicationID)
+}
+
+func InterfaceIncompatibilitiesFound(applicationID string, count int) error {
+	return fmt.Errorf("%w: application %s has %d interface incompatibilities", ErrInvalidInput, applicationID, count)
+}
+
+func KongRoutesMismatch(applicationID string, missing, orphaned int) error {
+	return fmt.Errorf("%w: application %s has %d modules without Kong routes and %d Kong routes without module", ErrInvalidInput, applicationID, missing, orphaned)
+}
+
+func DependencyCycleDetected(moduleIDs []string) error {
+	return fmt.Errorf("%w: dependency cycle detected, unresolved modules %s", ErrInvalidInput, strings.Join(moduleIDs, ", "))
+}
+
+func UnsupportedOutputFormat(format string, allowedFormats []string) error {
+	return fmt.Errorf("%w: output format %s is not one of %v", ErrInvalidInput, format, allowedFormats)
+}
+
+func ApplicationHasNoModules(applicationID string) error {
+	return fmt.Errorf("%w: application %s has no deployable modules, check the backend and frontend modules in the config", ErrInvalidInput, applicationID)
+}
+
 // ==================== Module Errors ====================
 
+func DuplicateModulePort(port int, moduleName string, otherModuleName string) error {
+	return fmt.Errorf("%w: port %d is configured for both %s and %s", ErrInvalidInput, port, otherModuleName, moduleName)
+}
+
 func ModulesNotDeployed(expectedModules int) error {
 	return fmt.Errorf("%d modules not deployed", expectedModules)
 }
@@ -245,6 +380,22 @@ func ModuleNotReady(moduleName string) error {
 	return fmt.Errorf("%w: module %s", ErrNotReady, moduleName)
 }
 
+func ModulesNotReady(unhealthyModules []string, totalModules int) error {
+	return fmt.Errorf("%w: %d of %d modules %v", ErrNotReady, len(unhealthyModules), totalModules, unhealthyModules)
+}
+
+func ManagementServicesUnreachable(modules []string) error {
+	return fmt.Errorf("%w: management services %v are unreachable directly or through the gateway", ErrNotReady, modules)
+}
+
+func StartupTimesNotFound(fileName string) error {
+	return fmt.Errorf("%w: no module startup times recorded in %s, deploy modules first", ErrNotFound, fileName)
+}
+
+func StackNotReady(reasons []string) error {
+	return fmt.Errorf("%w: %s", ErrNotReady, strings.Join(reasons, "; "))
+}
+
 func ModulePullFailed(imageName string, err error) error {
 	return fmt.Errorf("%w: failed to pull module image %s: %w", ErrDeploymentFailed, imageName, err)
 }
@@ -273,10 +424,22 @@ func ImageKeyNotSet(imageName, fieldName string) error {
 	return fmt.Errorf("%w: cannot run image %s, key %s not set in config", ErrConfigMissing, imageName, fieldName)
 }
 
+func ModuleAlreadyInApplication(moduleID string) error {
+	return fmt.Errorf("%w: module %s is already part of the application, use upgradeModule to change its version", ErrInvalidInput, moduleID)
+}
+
 func ModuleDiscoveryNotFound(moduleName string) error {
 	return fmt.Errorf("%w: module discovery %s in application", ErrNotFound, moduleName)
 }
 
+func DiscoverySuffixInvalid(from, to string) error {
+	return fmt.Errorf("%w: discovery suffixes must be non-blank and different but got --from %q and --to %q", ErrInvalidInput, from, to)
+}
+
+func ModuleDiscoveryEntryInvalid(filePath string, index int) error {
+	return fmt.Errorf("%w: module discovery entry %d in %s has no id", ErrInvalidInput, index, filePath)
+}
+
 func ModuleDescriptorNotFound(moduleName, moduleVersion, descriptorPath string) error {
 	return fmt.Errorf("%w: module descriptor for %s-%s at path %s", ErrNotFound, moduleName, moduleVersion, descriptorPath)
 }
@@ -295,6 +458,10 @@ func ModulePathNotDirectory(modulePath string) error {
 
 // ==================== Tenant Errors ====================
 
+func InvalidEntitlementParameter(tenantName, name, value string) error {
+	return fmt.Errorf("%w: entitlement parameter %s=%s of tenant %s cannot contain , or =", ErrInvalidInput, name, value, tenantName)
+}
+
 func TenantNotFound(tenantName string) error {
 	return fmt.Errorf("%w: tenant %s in config", ErrNotFound, tenantName)
 }
@@ -307,6 +474,26 @@ func TenantNotCreated(tenantName string) error {
 	return fmt.Errorf("%w: consortium tenant %s not created", ErrDeploymentFailed, tenantName)
 }
 
+func TenantEntitlementsTimeout(pendingTenants []string, timeout time.Duration) error {
+	return fmt.Errorf("%w: tenants %v were not entitled within %s", ErrTimeout, pendingTenants, timeout)
+}
+
+func TenantEntitlementFlowFailed(tenantName string, flowID string, status string) error {
+	return fmt.Errorf("%w: entitlement flow %s of tenant %s ended with status %s", ErrDeploymentFailed, flowID, tenantName, status)
+}
+
+func TenantEntitlementFlowTimeout(tenantName string, flowID string, status string, timeout time.Duration) error {
+	return fmt.Errorf("%w: entitlement flow %s of tenant %s did not finish within %s, last status %s", ErrTimeout, flowID, tenantName, timeout, status)
+}
+
+func TenantRemovalsFailed(tenants []string) error {
+	return fmt.Errorf("%w: tenants %v were not removed", ErrDeploymentFailed, tenants)
+}
+
+func TenantEntitlementsFailed(applicationID string, tenants []string) error {
+	return fmt.Errorf("%w: tenants %v were not entitled to application %s", ErrDeploymentFailed, tenants, applicationID)
+}
+
 // ==================== Search/Reindex Errors ====================
 
 func ReindexJobHasErrors(jobErrors []any) error {
@@ -12,10 +12,32 @@ const (
 	ApplicationStripesBranch             = "application.stripes-branch"
 	ApplicationGatewayHostname           = "application.gateway-hostname"
 	ApplicationDependencies              = "application.dependencies"
+	ApplicationPlatform                  = "application.platform"
+	ApplicationAllowedPlatforms          = "application.allowed-platforms"
+	ApplicationIncludeModules            = "application.include-modules"
+	ApplicationExcludeModules            = "application.exclude-modules"
+	ApplicationEntitlements              = "application.entitlements"
+	DefaultDescription                   = "default-description"
+	DescriptionEntry                     = "description"
+	HeaderNames                          = "header-names"
+	HeaderNamesTenant                    = "header-names.tenant"
+	HeaderNamesToken                     = "header-names.token"
+	ModuleName                           = "module-name"
+	ModuleNameStripPrefixes              = "module-name.strip-prefixes"
+	ModuleNameSeparators                 = "module-name.separators"
+	PhaseWaits                           = "phase-waits"
 	Lsp                                  = "lsp"
 	LspURL                               = "lsp.url"
 	Far                                  = "far"
 	FarURL                               = "far.url"
+	Kafka                                = "kafka"
+	KafkaConsumerGroupSuffix             = "kafka.consumer-group-suffix"
+	Keycloak                             = "keycloak"
+	KeycloakURL                          = "keycloak.url"
+	Ports                                = "ports"
+	PortsGateway                         = "ports.gateway"
+	PortsGatewayAdmin                    = "ports.gateway-admin"
+	PortsVault                           = "ports.vault"
 	Registry                             = "registry"
 	RegistryURL                          = "registry.url"
 	Namespaces                           = "namespaces"
@@ -33,6 +55,11 @@ const (
 	TenantsConsortiumEntry               = "consortium"
 	TenantsCentralTenantEntry            = "central-tenant"
 	TenantsPlatformCompleteURLEntry      = "platform-complete-url"
+	TenantsHeadersEntry                  = "headers"
+	TenantsAttributesEntry               = "attributes"
+	TenantsLoadReferenceEntry            = "load-reference"
+	TenantsLoadSampleEntry               = "load-sample"
+	TenantsEntitlementParametersEntry    = "entitlement-parameters"
 	Users                                = "users"
 	UsersConsortiumEntry                 = "consortium"
 	UsersTenantEntry                     = "tenant"
@@ -40,10 +67,14 @@ const (
 	UsersLastNameEntry                   = "last-name"
 	UsersFirstNameEntry                  = "first-name"
 	UsersRolesEntry                      = "roles"
+	UsersPreferredContactTypeIDEntry     = "preferred-contact-type-id"
+	UsersEmailEntry                      = "email"
+	UsersTypeEntry                       = "type"
 	Roles                                = "roles"
 	RolesConsortiumEntry                 = "consortium"
 	RolesTenantEntry                     = "tenant"
 	RolesCapabilitySetsEntry             = "capability-sets"
+	RolesCompositesEntry                 = "composites"
 	SidecarModule                        = "sidecar-module"
 	SidecarModuleEnv                     = "sidecar-module.environment"
 	SidecarModuleResources               = "sidecar-module.resources"
@@ -52,6 +83,7 @@ const (
 	SidecarModuleImageEntry              = "image"
 	SidecarModuleCustomNamespaceEntry    = "custom-namespace"
 	SidecarModuleVersionEntry            = "version"
+	SystemContainers                     = "system-containers"
 	BackendModules                       = "backend-modules"
 	BackendModulesManagementTopicSharing = "backend-modules.mgr-tenant-entitlements.environment.KAFKA_PRODUCER_TENANT_COLLECTION"
 	FrontendModules                      = "frontend-modules"
@@ -65,12 +97,16 @@ const (
 	ModuleUseVaultEntry                  = "use-vault"
 	ModuleUseOkapiURLEntry               = "use-okapi-url"
 	ModuleDisableSystemUserEntry         = "disable-system-user"
+	ModuleHealthyChecksEntry             = "healthy-checks"
+	ModuleHealthPortEntry                = "health-port"
 	ModuleLocalDescriptorPathEntry       = "local-descriptor-path"
+	ModuleDescriptorFileEntry            = "descriptor-file"
 	ModuleEnvEntry                       = "environment"
 	ModuleSidecarEnvEntry                = "sidecar-environment"
 	ModuleVolumesEntry                   = "volumes"
 	ModuleResourceEntry                  = "resources"
 	ModuleResourceCpuCountEntry          = "cpu-count"
+	ModuleResourceCpusEntry              = "cpus"
 	ModuleResourceMemoryReservationEntry = "memory-reservation"
 	ModuleResourceMemoryEntry            = "memory"
 	ModuleResourceMemorySwapEntry        = "memory-swap"
@@ -1,8 +1,11 @@
 package helpers
 
 import (
+	"maps"
+	"os"
 	"slices"
 
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 )
 
@@ -55,3 +58,48 @@ func GetBackendModuleNames(configBackendModules map[string]any) []string {
 
 	return names
 }
+
+// ExpandEnvVarReferences replaces ${VAR} and ${VAR:-default} references in the string values of the settings,
+// the default applies to unset or empty variables and a reference without a default to an unset variable fails the expansion
+func ExpandEnvVarReferences(settings map[string]any) (map[string]any, error) {
+	unresolved := map[string]struct{}{}
+	expanded := expandEnvVarReferences(settings, unresolved).(map[string]any)
+	if len(unresolved) > 0 {
+		return nil, errors.UnresolvedEnvVars(slices.Sorted(maps.Keys(unresolved)))
+	}
+
+	return expanded, nil
+}
+
+func expandEnvVarReferences(value any, unresolved map[string]struct{}) any {
+	switch v := value.(type) {
+	case string:
+		return envVarReference.ReplaceAllStringFunc(v, func(reference string) string {
+			match := envVarReference.FindStringSubmatch(reference)
+			envValue, ok := os.LookupEnv(match[1])
+			if match[2] != "" && envValue == "" {
+				return match[3]
+			}
+			if ok {
+				return envValue
+			}
+			unresolved[match[1]] = struct{}{}
+
+			return reference
+		})
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, entry := range v {
+			expanded[key] = expandEnvVarReferences(entry, unresolved)
+		}
+		return expanded
+	case []any:
+		expanded := make([]any, len(v))
+		for i, entry := range v {
+			expanded[i] = expandEnvVarReferences(entry, unresolved)
+		}
+		return expanded
+	default:
+		return value
+	}
+}
@@ -3,6 +3,7 @@ package helpers_test
 import (
 	"testing"
 
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/stretchr/testify/assert"
@@ -310,3 +311,67 @@ func TestGetBackendModuleNames_ModulesWithDifferentValues(t *testing.T) {
 	assert.Len(t, result, 4)
 	assert.ElementsMatch(t, []string{"mod-users", "mod-orders", "mod-audit", "mod-notes"}, result)
 }
+
+func TestExpandEnvVarReferences_NestedValues(t *testing.T) {
+	// Arrange
+	t.Setenv("EUREKA_TEST_REGISTRY", "https://registry.example.org")
+	t.Setenv("EUREKA_TEST_PASSWORD", "secret")
+	settings := map[string]any{
+		"registry": map[string]any{
+			"url": "${EUREKA_TEST_REGISTRY}/folio",
+		},
+		"volumes": []any{"$HOME/data:/data", "${EUREKA_TEST_MISSING:-/tmp}:/tmp"},
+		"credentials": map[string]any{
+			"password": "${EUREKA_TEST_PASSWORD}",
+			"port":     8080,
+		},
+	}
+
+	// Act
+	expanded, err := helpers.ExpandEnvVarReferences(settings)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"registry": map[string]any{
+			"url": "https://registry.example.org/folio",
+		},
+		"volumes": []any{"$HOME/data:/data", "/tmp:/tmp"},
+		"credentials": map[string]any{
+			"password": "secret",
+			"port":     8080,
+		},
+	}, expanded)
+}
+
+func TestExpandEnvVarReferences_EmptyVariable(t *testing.T) {
+	// Arrange
+	t.Setenv("EUREKA_TEST_EMPTY", "")
+	settings := map[string]any{
+		"withDefault":    "${EUREKA_TEST_EMPTY:-default}",
+		"withoutDefault": "${EUREKA_TEST_EMPTY}",
+	}
+
+	// Act
+	expanded, err := helpers.ExpandEnvVarReferences(settings)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"withDefault": "default", "withoutDefault": ""}, expanded)
+}
+
+func TestExpandEnvVarReferences_UnresolvedVariables(t *testing.T) {
+	// Arrange
+	settings := map[string]any{
+		"a": "${EUREKA_TEST_UNSET_B}",
+		"b": map[string]any{"c": "${EUREKA_TEST_UNSET_A}-${EUREKA_TEST_UNSET_B}"},
+	}
+
+	// Act
+	expanded, err := helpers.ExpandEnvVarReferences(settings)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrConfigMissing)
+	assert.Contains(t, err.Error(), "EUREKA_TEST_UNSET_A, EUREKA_TEST_UNSET_B")
+	assert.Nil(t, expanded)
+}
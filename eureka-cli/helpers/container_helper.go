@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
@@ -45,16 +48,19 @@ func GetSidecarName(moduleName string) string {
 	return fmt.Sprintf("%s-sc", moduleName)
 }
 
-func CreateExposedPorts(privateServerPort int) *nat.PortSet {
+func CreateExposedPorts(privateServerPort int, healthPort int) *nat.PortSet {
 	exposedPorts := make(map[nat.Port]struct{})
 	exposedPorts[nat.Port(strconv.Itoa(privateServerPort))] = struct{}{}
 	exposedPorts[nat.Port(constant.PrivateDebugPort)] = struct{}{}
+	if healthPort != 0 {
+		exposedPorts[nat.Port(strconv.Itoa(healthPort))] = struct{}{}
+	}
 	portSet := nat.PortSet(exposedPorts)
 
 	return &portSet
 }
 
-func CreatePortBindings(hostServerPort int, hostServerDebugPort int, privateServerPort int) *nat.PortMap {
+func CreatePortBindings(hostServerPort int, hostServerDebugPort int, privateServerPort int, healthPort int) *nat.PortMap {
 	var (
 		serverPortBinding []nat.PortBinding
 		debugPortBinding  []nat.PortBinding
@@ -70,23 +76,209 @@ func CreatePortBindings(hostServerPort int, hostServerDebugPort int, privateServ
 	portBindings := make(map[nat.Port][]nat.PortBinding)
 	portBindings[nat.Port(strconv.Itoa(privateServerPort))] = serverPortBinding
 	portBindings[nat.Port(constant.PrivateDebugPort)] = debugPortBinding
+	if healthPort != 0 {
+		healthPortKey := nat.Port(strconv.Itoa(healthPort))
+		portBindings[healthPortKey] = append(portBindings[healthPortKey], nat.PortBinding{
+			HostIP:   constant.HostIP,
+			HostPort: strconv.Itoa(healthPort),
+		})
+	}
 	portMap := nat.PortMap(portBindings)
 
 	return &portMap
 }
 
-func CreateResources(isModule bool, r map[string]any) *container.Resources {
+// CreateResources builds the container resources from a resources section, unset limits fall back
+// to the defaults while an invalid limit is returned as an error
+func CreateResources(isModule bool, r map[string]any) (*container.Resources, error) {
 	if len(r) == 0 {
-		return createDefaultResources(isModule)
+		return createDefaultResources(isModule), nil
+	}
+
+	nanoCPUs, err := getNanoCPUsOrDefault(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	memoryReservation, err := getMemoryBytesOrDefault(r, field.ModuleResourceMemoryReservationEntry, constant.ModuleMemoryReservation)
+	if err != nil {
+		return nil, err
+	}
+	memory, err := getMemoryBytesOrDefault(r, field.ModuleResourceMemoryEntry, constant.ModuleMemory)
+	if err != nil {
+		return nil, err
+	}
+	memorySwap, err := getMemoryBytesOrDefault(r, field.ModuleResourceMemorySwapEntry, constant.ModuleSwap)
+	if err != nil {
+		return nil, err
 	}
 
 	return &container.Resources{
 		CPUCount:          GetIntOrDefault(r, field.ModuleResourceCpuCountEntry, constant.ModuleCPU),
-		MemoryReservation: ConvertMemory(MibToBytes, GetIntOrDefault(r, field.ModuleResourceMemoryReservationEntry, constant.ModuleMemoryReservation)),
-		Memory:            ConvertMemory(MibToBytes, GetIntOrDefault(r, field.ModuleResourceMemoryEntry, constant.ModuleMemory)),
-		MemorySwap:        ConvertMemory(MibToBytes, GetIntOrDefault(r, field.ModuleResourceMemorySwapEntry, constant.ModuleSwap)),
+		NanoCPUs:          nanoCPUs,
+		MemoryReservation: memoryReservation,
+		Memory:            memory,
+		MemorySwap:        memorySwap,
 		OomKillDisable:    BoolPtr(GetBoolOrDefault(r, field.ModuleResourceOomKillDisableEntry, false)),
+	}, nil
+}
+
+// ValidateResources checks that the limits of a resources section are parseable, memory values
+// are either integers in MiB or docker size strings (e.g. 512m, 1g) and cpus is a decimal number
+func ValidateResources(name string, r map[string]any) error {
+	memoryEntries := []string{
+		field.ModuleResourceMemoryReservationEntry,
+		field.ModuleResourceMemoryEntry,
+		field.ModuleResourceMemorySwapEntry,
+	}
+	for _, key := range memoryEntries {
+		if _, err := parseMemoryBytes(key, r[key]); err != nil {
+			return errors.InvalidResourceLimit(name, key, r[key])
+		}
+	}
+	if _, err := parseNanoCPUs(field.ModuleResourceCpusEntry, r[field.ModuleResourceCpusEntry]); err != nil {
+		return errors.InvalidResourceLimit(name, field.ModuleResourceCpusEntry, r[field.ModuleResourceCpusEntry])
+	}
+	if cpuCount, ok := r[field.ModuleResourceCpuCountEntry]; ok {
+		if value, isInt := cpuCount.(int); !isInt || value < 0 {
+			return errors.InvalidResourceLimit(name, field.ModuleResourceCpuCountEntry, cpuCount)
+		}
+	}
+	if oomKillDisable, ok := r[field.ModuleResourceOomKillDisableEntry]; ok {
+		if _, isBool := oomKillDisable.(bool); !isBool {
+			return errors.InvalidResourceLimit(name, field.ModuleResourceOomKillDisableEntry, oomKillDisable)
+		}
+	}
+
+	return nil
+}
+
+// ValidateSystemContainerResources checks the resources section of every system container entry
+func ValidateSystemContainerResources(systemContainers map[string]any) error {
+	for name, value := range systemContainers {
+		entry, _ := value.(map[string]any)
+		if err := ValidateResources(name, GetMap(entry, field.ModuleResourceEntry)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateComposeResourcesOverride builds a compose override that applies the resources section of every
+// system container entry to its compose service, it returns nil when no system container sets any limit
+func CreateComposeResourcesOverride(systemContainers map[string]any) map[string]any {
+	services := make(map[string]any)
+	for name, value := range systemContainers {
+		entry, _ := value.(map[string]any)
+		r := GetMap(entry, field.ModuleResourceEntry)
+		service := make(map[string]any)
+		if nanoCPUs, err := parseNanoCPUs(field.ModuleResourceCpusEntry, r[field.ModuleResourceCpusEntry]); err == nil && nanoCPUs != nil {
+			service["cpus"] = float64(*nanoCPUs) / 1e9
+		}
+		if cpuCount, ok := r[field.ModuleResourceCpuCountEntry].(int); ok {
+			service["cpu_count"] = cpuCount
+		}
+		composeMemoryEntries := map[string]string{
+			field.ModuleResourceMemoryReservationEntry: "mem_reservation",
+			field.ModuleResourceMemoryEntry:            "mem_limit",
+			field.ModuleResourceMemorySwapEntry:        "memswap_limit",
+		}
+		for key, composeKey := range composeMemoryEntries {
+			if bytes, err := parseMemoryBytes(key, r[key]); err == nil && bytes != nil {
+				service[composeKey] = *bytes
+			}
+		}
+		if oomKillDisable, ok := r[field.ModuleResourceOomKillDisableEntry].(bool); ok {
+			service["oom_kill_disable"] = oomKillDisable
+		}
+		if len(service) > 0 {
+			services[name] = service
+		}
 	}
+	if len(services) == 0 {
+		return nil
+	}
+
+	return map[string]any{"services": services}
+}
+
+func getMemoryBytesOrDefault(r map[string]any, key string, defaultMib int64) (int64, error) {
+	value, err := parseMemoryBytes(key, r[key])
+	if err != nil {
+		return 0, err
+	}
+	if value == nil {
+		return ConvertMemory(MibToBytes, defaultMib), nil
+	}
+
+	return *value, nil
+}
+
+// parseMemoryBytes returns nil for an unset value, integers and unit-less strings are read as MiB to stay
+// compatible with older configs, other strings are parsed as docker size strings with -1 meaning unlimited
+func parseMemoryBytes(key string, rawValue any) (*int64, error) {
+	switch value := rawValue.(type) {
+	case nil:
+		return nil, nil
+	case int:
+		bytes := ConvertMemory(MibToBytes, int64(value))
+		return &bytes, nil
+	case string:
+		if strings.TrimSpace(value) == "-1" {
+			bytes := int64(-1)
+			return &bytes, nil
+		}
+		if mib, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+			bytes := ConvertMemory(MibToBytes, mib)
+			return &bytes, nil
+		}
+		bytes, err := units.RAMInBytes(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errors.InvalidResourceValue(key, rawValue)
+		}
+		return &bytes, nil
+	}
+
+	return nil, errors.InvalidResourceValue(key, rawValue)
+}
+
+func getNanoCPUsOrDefault(r map[string]any, defaultValue int64) (int64, error) {
+	value, err := parseNanoCPUs(field.ModuleResourceCpusEntry, r[field.ModuleResourceCpusEntry])
+	if err != nil {
+		return 0, err
+	}
+	if value == nil {
+		return defaultValue, nil
+	}
+
+	return *value, nil
+}
+
+// parseNanoCPUs returns nil for an unset value, otherwise the number of cpus (e.g. 1.5) in units of 1e-9 cpus
+func parseNanoCPUs(key string, rawValue any) (*int64, error) {
+	var cpus float64
+	switch value := rawValue.(type) {
+	case nil:
+		return nil, nil
+	case int:
+		cpus = float64(value)
+	case float64:
+		cpus = value
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, errors.InvalidResourceValue(key, rawValue)
+		}
+		cpus = parsed
+	default:
+		return nil, errors.InvalidResourceValue(key, rawValue)
+	}
+	if cpus <= 0 {
+		return nil, errors.InvalidResourceValue(key, rawValue)
+	}
+	nanoCPUs := int64(cpus * 1e9)
+
+	return &nanoCPUs, nil
 }
 
 func createDefaultResources(isModule bool) *container.Resources {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/docker/go-connections/nat"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/stretchr/testify/assert"
@@ -97,7 +98,7 @@ func TestCreateExposedPorts_ValidPort(t *testing.T) {
 	privateServerPort := 8081
 
 	// Act
-	result := helpers.CreateExposedPorts(privateServerPort)
+	result := helpers.CreateExposedPorts(privateServerPort, 0)
 
 	// Assert
 	assert.NotNil(t, result)
@@ -113,7 +114,7 @@ func TestCreatePortBindings_ValidPorts(t *testing.T) {
 	privateServerPort := 8081
 
 	// Act
-	result := helpers.CreatePortBindings(hostServerPort, hostServerDebugPort, privateServerPort)
+	result := helpers.CreatePortBindings(hostServerPort, hostServerDebugPort, privateServerPort, 0)
 
 	// Assert
 	assert.NotNil(t, result)
@@ -130,6 +131,27 @@ func TestCreatePortBindings_ValidPorts(t *testing.T) {
 	assert.Equal(t, "5005", debugBindings[0].HostPort)
 }
 
+func TestCreatePortBindings_WithHealthPort(t *testing.T) {
+	// Arrange
+	hostServerPort := 9000
+	hostServerDebugPort := 5005
+	privateServerPort := 8081
+	healthPort := 9091
+
+	// Act
+	exposedPorts := helpers.CreateExposedPorts(privateServerPort, healthPort)
+	result := helpers.CreatePortBindings(hostServerPort, hostServerDebugPort, privateServerPort, healthPort)
+
+	// Assert
+	assert.Contains(t, *exposedPorts, nat.Port("9091"))
+	assert.Len(t, *result, 3)
+
+	healthBindings := (*result)[nat.Port("9091")]
+	assert.Len(t, healthBindings, 1)
+	assert.Equal(t, constant.HostIP, healthBindings[0].HostIP)
+	assert.Equal(t, "9091", healthBindings[0].HostPort)
+}
+
 func TestCreateResources_WithCustomResources(t *testing.T) {
 	// Arrange
 	resources := map[string]any{
@@ -141,9 +163,10 @@ func TestCreateResources_WithCustomResources(t *testing.T) {
 	}
 
 	// Act
-	result := helpers.CreateResources(true, resources)
+	result, err := helpers.CreateResources(true, resources)
 
 	// Assert
+	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, int64(2), result.CPUCount)
 	assert.Equal(t, int64(268435456), result.MemoryReservation) // 256 MiB in bytes
@@ -158,9 +181,10 @@ func TestCreateResources_EmptyResourcesForModule(t *testing.T) {
 	resources := map[string]any{}
 
 	// Act
-	result := helpers.CreateResources(true, resources)
+	result, err := helpers.CreateResources(true, resources)
 
 	// Assert
+	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, int64(constant.ModuleCPU), result.CPUCount)
 	assert.Equal(t, int64(constant.ModuleMemoryReservation*1024*1024), result.MemoryReservation)
@@ -175,9 +199,10 @@ func TestCreateResources_EmptyResourcesForSidecar(t *testing.T) {
 	resources := map[string]any{}
 
 	// Act
-	result := helpers.CreateResources(false, resources)
+	result, err := helpers.CreateResources(false, resources)
 
 	// Assert
+	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, int64(constant.SidecarCPU), result.CPUCount)
 	assert.Equal(t, int64(constant.SidecarMemoryReservation*1024*1024), result.MemoryReservation)
@@ -277,3 +302,138 @@ func TestAppendRequiredContainers_ModulesDisabled(t *testing.T) {
 	// Assert
 	assert.Empty(t, result)
 }
+
+func TestCreateResources_WithDockerResourceStrings(t *testing.T) {
+	// Arrange
+	resources := map[string]any{
+		field.ModuleResourceCpusEntry:              "1.5",
+		field.ModuleResourceMemoryReservationEntry: "256m",
+		field.ModuleResourceMemoryEntry:            "1g",
+		field.ModuleResourceMemorySwapEntry:        "-1",
+	}
+
+	// Act
+	result, err := helpers.CreateResources(true, resources)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500000000), result.NanoCPUs)
+	assert.Equal(t, int64(268435456), result.MemoryReservation)
+	assert.Equal(t, int64(1073741824), result.Memory)
+	assert.Equal(t, int64(-1), result.MemorySwap)
+}
+
+func TestCreateResources_UnitLessMemoryStringIsMib(t *testing.T) {
+	// Arrange
+	resources := map[string]any{
+		field.ModuleResourceMemoryEntry: "512",
+	}
+
+	// Act
+	result, err := helpers.CreateResources(true, resources)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(536870912), result.Memory)
+}
+
+func TestCreateResources_InvalidLimit(t *testing.T) {
+	// Arrange
+	resources := map[string]any{
+		field.ModuleResourceMemoryEntry: "lots",
+	}
+
+	// Act
+	result, err := helpers.CreateResources(true, resources)
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "memory=lots")
+	assert.Nil(t, result)
+}
+
+func TestValidateResources_AcceptsIntegersAndDockerStrings(t *testing.T) {
+	// Arrange
+	resources := map[string]any{
+		field.ModuleResourceCpusEntry:              2,
+		field.ModuleResourceMemoryReservationEntry: 256,
+		field.ModuleResourceMemoryEntry:            "512m",
+	}
+
+	// Act
+	err := helpers.ValidateResources("mod-users", resources)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestValidateResources_RejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources map[string]any
+	}{
+		{name: "invalid memory string", resources: map[string]any{field.ModuleResourceMemoryEntry: "512 megs"}},
+		{name: "invalid cpus string", resources: map[string]any{field.ModuleResourceCpusEntry: "two"}},
+		{name: "non-positive cpus", resources: map[string]any{field.ModuleResourceCpusEntry: 0}},
+		{name: "unsupported memory type", resources: map[string]any{field.ModuleResourceMemorySwapEntry: true}},
+		{name: "non-integer cpu count", resources: map[string]any{field.ModuleResourceCpuCountEntry: "two"}},
+		{name: "non-boolean oom kill disable", resources: map[string]any{field.ModuleResourceOomKillDisableEntry: "yes"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := helpers.ValidateResources("mod-users", tt.resources)
+
+			// Assert
+			assert.ErrorIs(t, err, errors.ErrInvalidInput)
+			assert.Contains(t, err.Error(), "mod-users")
+		})
+	}
+}
+
+func TestValidateSystemContainerResources_RejectsInvalidValues(t *testing.T) {
+	// Arrange
+	systemContainers := map[string]any{
+		"postgres": map[string]any{field.ModuleResourceEntry: map[string]any{field.ModuleResourceMemoryEntry: "2 gigs"}},
+	}
+
+	// Act
+	err := helpers.ValidateSystemContainerResources(systemContainers)
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "postgres")
+}
+
+func TestCreateComposeResourcesOverride_MapsLimitsToComposeKeys(t *testing.T) {
+	// Arrange
+	systemContainers := map[string]any{
+		"postgres": map[string]any{field.ModuleResourceEntry: map[string]any{
+			field.ModuleResourceCpusEntry:              "1.5",
+			field.ModuleResourceMemoryReservationEntry: 256,
+			field.ModuleResourceMemoryEntry:            "1g",
+		}},
+		"kafka": map[string]any{},
+	}
+
+	// Act
+	result := helpers.CreateComposeResourcesOverride(systemContainers)
+
+	// Assert
+	services := result["services"].(map[string]any)
+	assert.Len(t, services, 1)
+	assert.Equal(t, map[string]any{
+		"cpus":            1.5,
+		"mem_reservation": int64(268435456),
+		"mem_limit":       int64(1073741824),
+	}, services["postgres"])
+}
+
+func TestCreateComposeResourcesOverride_NoLimits(t *testing.T) {
+	// Act
+	result := helpers.CreateComposeResourcesOverride(map[string]any{"kafka": map[string]any{}})
+
+	// Assert
+	assert.Nil(t, result)
+}
@@ -145,6 +145,31 @@ func GetHomeMiscDir() (string, error) {
 	return filepath.Join(homeDir, constant.DockerComposeWorkDir), nil
 }
 
+// GetDescriptorCacheDirPath returns the directory of cached module descriptors under the home misc dir, creating it when missing
+func GetDescriptorCacheDirPath() (string, error) {
+	homeMiscDir, err := GetHomeMiscDir()
+	if err != nil {
+		return "", err
+	}
+
+	cacheDir := filepath.Join(homeMiscDir, constant.DescriptorCacheDir)
+	if err = os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", err
+	}
+
+	return cacheDir, nil
+}
+
+// GetDescriptorCacheFilePath returns the path of the cached descriptor of a module id
+func GetDescriptorCacheFilePath(moduleID string) (string, error) {
+	cacheDir, err := GetDescriptorCacheDirPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, moduleID+".json"), nil
+}
+
 func GetHomeDirPath() (string, error) {
 	userHome, err := os.UserHomeDir()
 	if err != nil {
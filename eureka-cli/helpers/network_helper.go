@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"cmp"
 	"fmt"
 	"net"
 	"strings"
@@ -30,6 +31,28 @@ func ConstructURL(url string, gatewayURL string) string {
 	return fmt.Sprintf("%s:%s", gatewayURL, url)
 }
 
+// ==================== Header Names ====================
+
+var (
+	tenantHeader = constant.OkapiTenantHeader
+	tokenHeader  = constant.OkapiTokenHeader
+)
+
+// SetHeaderNames overrides the tenant and token header names used by all header builders,
+// it is meant to be called once at startup and a blank name keeps the default
+func SetHeaderNames(tenantHeaderName string, tokenHeaderName string) {
+	tenantHeader = cmp.Or(strings.TrimSpace(tenantHeaderName), constant.OkapiTenantHeader)
+	tokenHeader = cmp.Or(strings.TrimSpace(tokenHeaderName), constant.OkapiTokenHeader)
+}
+
+func GetTenantHeader() string {
+	return tenantHeader
+}
+
+func GetTokenHeader() string {
+	return tokenHeader
+}
+
 // ==================== Okapi Headers ====================
 
 func SecureOkapiApplicationJSONHeaders(accessToken string) (map[string]string, error) {
@@ -39,7 +62,7 @@ func SecureOkapiApplicationJSONHeaders(accessToken string) (map[string]string, e
 
 	return map[string]string{
 		constant.ContentTypeHeader: constant.ApplicationJSON,
-		constant.OkapiTokenHeader:  accessToken,
+		GetTokenHeader():           accessToken,
 	}, nil
 }
 
@@ -53,8 +76,8 @@ func SecureOkapiTenantApplicationJSONHeaders(tenantName string, accessToken stri
 
 	return map[string]string{
 		constant.ContentTypeHeader: constant.ApplicationJSON,
-		constant.OkapiTenantHeader: tenantName,
-		constant.OkapiTokenHeader:  accessToken,
+		GetTenantHeader():          tenantName,
+		GetTokenHeader():           accessToken,
 	}, nil
 }
 
@@ -70,7 +93,7 @@ func SecureTenantApplicationJSONHeaders(tenantName string, accessToken string) (
 
 	return map[string]string{
 		constant.ContentTypeHeader:   constant.ApplicationJSON,
-		constant.OkapiTenantHeader:   tenantName,
+		GetTenantHeader():            tenantName,
 		constant.AuthorizationHeader: fmt.Sprintf("Bearer %s", accessToken),
 	}, nil
 }
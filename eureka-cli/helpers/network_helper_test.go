@@ -226,6 +226,40 @@ func TestApplicationFormURLEncodedHeaders_NoInputs(t *testing.T) {
 	assert.Equal(t, "application/x-www-form-urlencoded", result["Content-Type"])
 }
 
+func TestSetHeaderNames_OverridesHeaderBuilders(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() { helpers.SetHeaderNames("", "") })
+	helpers.SetHeaderNames("X-Tenant-Id", " X-Access-Token ")
+
+	// Act
+	okapiHeaders, okapiErr := helpers.SecureOkapiTenantApplicationJSONHeaders("diku", "token")
+	nonOkapiHeaders, nonOkapiErr := helpers.SecureTenantApplicationJSONHeaders("diku", "token")
+
+	// Assert
+	assert.NoError(t, okapiErr)
+	assert.NoError(t, nonOkapiErr)
+	assert.Equal(t, "X-Tenant-Id", helpers.GetTenantHeader())
+	assert.Equal(t, "X-Access-Token", helpers.GetTokenHeader())
+	assert.Equal(t, "diku", okapiHeaders["X-Tenant-Id"])
+	assert.Equal(t, "token", okapiHeaders["X-Access-Token"])
+	assert.NotContains(t, okapiHeaders, "X-Okapi-Tenant")
+	assert.NotContains(t, okapiHeaders, "X-Okapi-Token")
+	assert.Equal(t, "diku", nonOkapiHeaders["X-Tenant-Id"])
+}
+
+func TestSetHeaderNames_BlankKeepsDefaults(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() { helpers.SetHeaderNames("", "") })
+	helpers.SetHeaderNames("X-Tenant-Id", "X-Access-Token")
+
+	// Act
+	helpers.SetHeaderNames("", "  ")
+
+	// Assert
+	assert.Equal(t, "X-Okapi-Tenant", helpers.GetTenantHeader())
+	assert.Equal(t, "X-Okapi-Token", helpers.GetTokenHeader())
+}
+
 func TestGetSidecarURL_EdgeModule(t *testing.T) {
 	// Arrange
 	moduleName := "edge-oai-pmh"
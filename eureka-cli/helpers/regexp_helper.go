@@ -1,7 +1,6 @@
 package helpers
 
 import (
-	"bytes"
 	"regexp"
 	"strconv"
 	"strings"
@@ -10,10 +9,10 @@ import (
 )
 
 var (
-	colonDelimited = regexp.MustCompile(constant.ColonDelimitedPattern)
-	moduleId       = regexp.MustCompile(constant.ModuleIDPattern)
-	newLine        = regexp.MustCompile(constant.NewLinePattern)
-	protocol       = regexp.MustCompile(constant.ProtocolPattern)
+	colonDelimited  = regexp.MustCompile(constant.ColonDelimitedPattern)
+	moduleId        = regexp.MustCompile(constant.ModuleIDPattern)
+	protocol        = regexp.MustCompile(constant.ProtocolPattern)
+	envVarReference = regexp.MustCompile(constant.EnvVarReferencePattern)
 )
 
 // ==================== Vault ====================
@@ -63,12 +62,31 @@ func GetHostnameFromURL(url string) string {
 
 // ==================== Module ====================
 
+// ExtractModuleNameAndVersion splits a module id at the last dash preceding its semantic version, so digits within
+// the name are kept, e.g. edge-sip2-3.4.0, an id without a full MAJOR.MINOR.PATCH version (e.g. mod-users-1.0)
+// is not a valid module id and is returned as the name with an empty version
+func ExtractModuleNameAndVersion(id string) (name string, version string) {
+	matches := moduleId.FindStringSubmatch(id)
+	if matches == nil {
+		return id, ""
+	}
+
+	return matches[1], matches[2]
+}
+
+// IsModuleID reports whether an id consists of a module name and a semantic version, e.g. mod-users-19.5.0
+func IsModuleID(id string) bool {
+	return moduleId.MatchString(id)
+}
+
 func GetModuleNameFromID(id string) string {
-	return StripModuleVersion(moduleId.ReplaceAllString(id, `$1`))
+	name, _ := ExtractModuleNameAndVersion(id)
+	return TrimModuleName(name)
 }
 
 func GetModuleVersionFromID(id string) string {
-	return moduleId.ReplaceAllString(id, `$2$3`)
+	_, version := ExtractModuleNameAndVersion(id)
+	return version
 }
 
 func GetOptionalModuleVersion(id string) *string {
@@ -83,30 +101,3 @@ func MatchesModuleName(moduleID string, moduleName string) bool {
 	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(moduleName) + `-\d`)
 	return pattern.MatchString(moduleID)
 }
-
-// ==================== Kafka ====================
-
-func GetKafkaConsumerLagFromLogLine(stdout bytes.Buffer) string {
-	if stdout.Len() == 0 {
-		return "0"
-	}
-
-	cleaned := newLine.ReplaceAllString(stdout.String(), " ")
-	cleaned = strings.TrimSpace(cleaned)
-	if cleaned == "" {
-		return "0"
-	}
-
-	parts := strings.Fields(cleaned)
-	totalLag := 0
-	for _, part := range parts {
-		if part == "-" {
-			continue
-		}
-		if lag, err := strconv.Atoi(part); err == nil {
-			totalLag += lag
-		}
-	}
-
-	return strconv.Itoa(totalLag)
-}
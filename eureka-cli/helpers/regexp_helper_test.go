@@ -1,7 +1,6 @@
 package helpers_test
 
 import (
-	"bytes"
 	"testing"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
@@ -304,15 +303,14 @@ func TestGetOptionalModuleVersion_WithVersion(t *testing.T) {
 }
 
 func TestGetOptionalModuleVersion_NoVersion(t *testing.T) {
-	// Arrange - Pattern doesn't match, returns original string minus module name
+	// Arrange
 	moduleID := "mod-users"
 
 	// Act
 	result := helpers.GetOptionalModuleVersion(moduleID)
 
-	// Assert - When pattern doesn't match fully, returns non-empty result
-	assert.NotNil(t, result)
-	assert.Equal(t, "-users", *result)
+	// Assert
+	assert.Nil(t, result)
 }
 
 func TestGetModuleVersionFromID_StandardVersion(t *testing.T) {
@@ -338,131 +336,128 @@ func TestGetModuleVersionFromID_SnapshotVersion(t *testing.T) {
 }
 
 func TestGetModuleVersionFromID_NoVersion(t *testing.T) {
-	// Arrange - Pattern extracts groups 2 and 3, even if not a valid version
+	// Arrange
 	moduleID := "mod-users"
 
 	// Act
 	result := helpers.GetModuleVersionFromID(moduleID)
 
-	// Assert - Returns whatever matches groups 2+3 in the pattern
-	assert.Equal(t, "-users", result)
-}
-
-func TestGetKafkaConsumerLagFromLogLine_WithNewlines(t *testing.T) {
-	// Arrange
-	var stdout bytes.Buffer
-	stdout.WriteString("100\n50\n")
-
-	// Act
-	result := helpers.GetKafkaConsumerLagFromLogLine(stdout)
-
 	// Assert
-	assert.Equal(t, "150", result)
+	assert.Empty(t, result)
+}
+
+func TestExtractModuleNameAndVersion_FolioModuleIDs(t *testing.T) {
+	tests := []struct {
+		id      string
+		name    string
+		version string
+	}{
+		{"mod-users-19.3.0", "mod-users", "19.3.0"},
+		{"mod-oai-pmh-3.9.0", "mod-oai-pmh", "3.9.0"},
+		{"edge-oai-pmh-2.0.0", "edge-oai-pmh", "2.0.0"},
+		{"mod-di-converter-storage-2.1.0", "mod-di-converter-storage", "2.1.0"},
+		{"edge-sip2-3.4.0", "edge-sip2", "3.4.0"},
+		{"mod-z3950-1.0.0", "mod-z3950", "1.0.0"},
+		{"mod-inventory-storage-25.0.0-SNAPSHOT.456", "mod-inventory-storage", "25.0.0-SNAPSHOT.456"},
+		{"mod-search-1.2.3-SNAPSHOT.456", "mod-search", "1.2.3-SNAPSHOT.456"},
+		{"folio_users-11.0.0", "folio_users", "11.0.0"},
+		{"mgr-tenant-entitlements-3.1.0", "mgr-tenant-entitlements", "3.1.0"},
+		{"mod-users", "mod-users", ""},
+		// Intended: FOLIO module ids carry a full MAJOR.MINOR.PATCH version, a partial version is kept in the name
+		{"mod-users-1.0", "mod-users-1.0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			// Act
+			name, version := helpers.ExtractModuleNameAndVersion(tt.id)
+
+			// Assert
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.version, version)
+		})
+	}
 }
 
-func TestGetKafkaConsumerLagFromLogLine_EmptyBuffer(t *testing.T) {
-	// Arrange
-	var stdout bytes.Buffer
-
-	// Act
-	result := helpers.GetKafkaConsumerLagFromLogLine(stdout)
-
-	// Assert
-	assert.Equal(t, "0", result)
-}
-
-func TestGetKafkaConsumerLagFromLogLine_WithDashes(t *testing.T) {
-	// Arrange - Dashes should be treated as 0
-	var stdout bytes.Buffer
-	stdout.WriteString("-\n0")
-
-	// Act
-	result := helpers.GetKafkaConsumerLagFromLogLine(stdout)
-
-	// Assert
-	assert.Equal(t, "0", result)
-}
-
-func TestGetKafkaConsumerLagFromLogLine_MixedValues(t *testing.T) {
+func TestMatchesModuleName_Matching(t *testing.T) {
 	// Arrange
-	var stdout bytes.Buffer
-	stdout.WriteString("-\n100\n-\n50\n25")
+	moduleID := "mod-users-19.3.0"
+	moduleName := "mod-users"
 
 	// Act
-	result := helpers.GetKafkaConsumerLagFromLogLine(stdout)
+	result := helpers.MatchesModuleName(moduleID, moduleName)
 
 	// Assert
-	assert.Equal(t, "175", result)
+	assert.True(t, result)
 }
 
-func TestGetKafkaConsumerLagFromLogLine_SingleValue(t *testing.T) {
+func TestMatchesModuleName_NotMatching(t *testing.T) {
 	// Arrange
-	var stdout bytes.Buffer
-	stdout.WriteString("42")
+	moduleID := "mod-inventory-19.3.0"
+	moduleName := "mod-users"
 
 	// Act
-	result := helpers.GetKafkaConsumerLagFromLogLine(stdout)
+	result := helpers.MatchesModuleName(moduleID, moduleName)
 
 	// Assert
-	assert.Equal(t, "42", result)
+	assert.False(t, result)
 }
 
-func TestGetKafkaConsumerLagFromLogLine_AllDashes(t *testing.T) {
+func TestMatchesModuleName_NoVersion(t *testing.T) {
 	// Arrange
-	var stdout bytes.Buffer
-	stdout.WriteString("-\n-\n-")
+	moduleID := "mod-users"
+	moduleName := "mod-users"
 
 	// Act
-	result := helpers.GetKafkaConsumerLagFromLogLine(stdout)
+	result := helpers.MatchesModuleName(moduleID, moduleName)
 
 	// Assert
-	assert.Equal(t, "0", result)
+	assert.False(t, result)
 }
 
-func TestMatchesModuleName_Matching(t *testing.T) {
+func TestMatchesModuleName_PartialMatch(t *testing.T) {
 	// Arrange
-	moduleID := "mod-users-19.3.0"
+	moduleID := "mod-users-extra-19.3.0"
 	moduleName := "mod-users"
 
 	// Act
 	result := helpers.MatchesModuleName(moduleID, moduleName)
 
 	// Assert
-	assert.True(t, result)
+	assert.False(t, result)
 }
 
-func TestMatchesModuleName_NotMatching(t *testing.T) {
+func TestGetModuleNameFromID_StripsConfiguredPrefix(t *testing.T) {
 	// Arrange
-	moduleID := "mod-inventory-19.3.0"
-	moduleName := "mod-users"
+	t.Cleanup(func() { helpers.SetModuleNameRules(nil, nil) })
+	helpers.SetModuleNameRules([]string{"", "folio_"}, nil)
 
 	// Act
-	result := helpers.MatchesModuleName(moduleID, moduleName)
+	result := helpers.GetModuleNameFromID("folio_mod-users-19.3.0")
 
 	// Assert
-	assert.False(t, result)
+	assert.Equal(t, "mod-users", result)
 }
 
-func TestMatchesModuleName_NoVersion(t *testing.T) {
+func TestGetModuleNameFromID_NormalizesConfiguredSeparators(t *testing.T) {
 	// Arrange
-	moduleID := "mod-users"
-	moduleName := "mod-users"
+	t.Cleanup(func() { helpers.SetModuleNameRules(nil, nil) })
+	helpers.SetModuleNameRules([]string{"folio_"}, []string{"_"})
 
 	// Act
-	result := helpers.MatchesModuleName(moduleID, moduleName)
+	result := helpers.GetModuleNameFromID("folio_mod_inventory_storage-25.0.0-SNAPSHOT.1")
 
 	// Assert
-	assert.False(t, result)
+	assert.Equal(t, "mod-inventory-storage", result)
 }
 
-func TestMatchesModuleName_PartialMatch(t *testing.T) {
+func TestGetModuleNameFromID_PrefixedNameKeptWithoutRules(t *testing.T) {
 	// Arrange
-	moduleID := "mod-users-extra-19.3.0"
-	moduleName := "mod-users"
+	helpers.SetModuleNameRules(nil, nil)
 
 	// Act
-	result := helpers.MatchesModuleName(moduleID, moduleName)
+	result := helpers.GetModuleNameFromID("folio_mod-users-19.3.0")
 
 	// Assert
-	assert.False(t, result)
+	assert.Equal(t, "folio_mod-users", result)
 }
@@ -3,6 +3,8 @@ package helpers
 import (
 	"fmt"
 	"math/rand"
+	"path"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,10 +14,28 @@ import (
 	"github.com/folio-org/eureka-setup/eureka-cli/errors"
 )
 
-func StripModuleVersion(name string) string {
-	idx := strings.LastIndex(name, "-")
-	if idx >= 0 && idx < len(name) && name[idx] == 45 {
-		name = name[:idx]
+var (
+	moduleNameStripPrefixes []string
+	moduleNameSeparators    []string
+)
+
+// SetModuleNameRules configures the prefixes stripped from and the separators normalized to "-" in module names
+// extracted from module ids, it is meant to be called once at startup and no rules keep the names untouched
+func SetModuleNameRules(stripPrefixes []string, separators []string) {
+	moduleNameStripPrefixes = slices.DeleteFunc(slices.Clone(stripPrefixes), func(prefix string) bool { return prefix == "" })
+	moduleNameSeparators = slices.DeleteFunc(slices.Clone(separators), func(separator string) bool { return separator == "" })
+}
+
+// TrimModuleName applies the configured rules, the first matching prefix is stripped before the separators are normalized
+func TrimModuleName(name string) string {
+	for _, prefix := range moduleNameStripPrefixes {
+		if trimmed, found := strings.CutPrefix(name, prefix); found && trimmed != "" {
+			name = trimmed
+			break
+		}
+	}
+	for _, separator := range moduleNameSeparators {
+		name = strings.ReplaceAll(name, separator, "-")
 	}
 
 	return name
@@ -41,6 +61,22 @@ func FilterEmptyLines(input string) string {
 	return strings.Join(filteredLines, "\n")
 }
 
+// MatchAnyGlob returns the first pattern matching the name, patterns are globs in the path.Match syntax
+// and malformed ones are compared literally
+func MatchAnyGlob(name string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			matched = pattern == name
+		}
+		if matched {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
 func IsVersionGreater(version1, version2 string) bool {
 	semVer1, err1 := semver.NewVersion(version1)
 	semVer2, err2 := semver.NewVersion(version2)
@@ -51,6 +87,44 @@ func IsVersionGreater(version1, version2 string) bool {
 	return semVer1.GreaterThan(semVer2)
 }
 
+// IsInterfaceVersionCompatible checks a provided interface version against a required one, the required version
+// may list several space-separated alternatives, e.g. "1.2 2.0", and each is satisfied by the same major version with an
+// equal or higher minor and patch version, as the gateway checks interface dependencies
+func IsInterfaceVersionCompatible(required string, provided string) bool {
+	providedParts, ok := parseInterfaceVersion(provided)
+	if !ok {
+		return false
+	}
+	for alternative := range strings.FieldsSeq(required) {
+		requiredParts, ok := parseInterfaceVersion(alternative)
+		if !ok || requiredParts[0] != providedParts[0] {
+			continue
+		}
+		if providedParts[1] > requiredParts[1] || providedParts[1] == requiredParts[1] && providedParts[2] >= requiredParts[2] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseInterfaceVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	fields := strings.Split(strings.TrimSpace(version), ".")
+	if len(fields) < 2 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = number
+	}
+
+	return parts, true
+}
+
 func IncrementSnapshotVersion(version string) (string, error) {
 	if version == "" {
 		return "", errors.VersionEmpty()
@@ -102,3 +176,31 @@ func SortedMapKeys(m map[string]any) []string {
 
 	return keys
 }
+
+// DiffStrings returns the desired values that are missing from current and the current values that are not desired,
+// both in the order they first appear and without duplicates
+func DiffStrings(desired []string, current []string) (missing []string, extra []string) {
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, value := range desired {
+		desiredSet[value] = struct{}{}
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	for _, value := range current {
+		currentSet[value] = struct{}{}
+	}
+
+	for _, value := range desired {
+		if _, exists := currentSet[value]; !exists {
+			missing = append(missing, value)
+			currentSet[value] = struct{}{}
+		}
+	}
+	for _, value := range current {
+		if _, exists := desiredSet[value]; !exists {
+			extra = append(extra, value)
+			desiredSet[value] = struct{}{}
+		}
+	}
+
+	return missing, extra
+}
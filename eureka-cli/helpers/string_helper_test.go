@@ -114,61 +114,6 @@ func TestSortedMapKeys_DifferentValueTypes(t *testing.T) {
 	assert.Equal(t, "key4", result[3])
 }
 
-func TestStripModuleVersion_WithDashAndSuffix(t *testing.T) {
-	// Arrange - Removes everything after LAST dash
-	moduleName := "mod-users-19.3.0"
-
-	// Act
-	result := helpers.StripModuleVersion(moduleName)
-
-	// Assert
-	assert.Equal(t, "mod-users", result) // Removes "-19.3.0"
-}
-
-func TestStripModuleVersion_WithMultipleDashes(t *testing.T) {
-	// Arrange - Removes everything after LAST dash
-	moduleName := "mod-inventory-storage-25.0.0"
-
-	// Act
-	result := helpers.StripModuleVersion(moduleName)
-
-	// Assert
-	assert.Equal(t, "mod-inventory-storage", result) // Removes "-25.0.0"
-}
-
-func TestStripModuleVersion_NoDash(t *testing.T) {
-	// Arrange
-	moduleName := "module"
-
-	// Act
-	result := helpers.StripModuleVersion(moduleName)
-
-	// Assert
-	assert.Equal(t, "module", result)
-}
-
-func TestStripModuleVersion_EmptyString(t *testing.T) {
-	// Arrange
-	moduleName := ""
-
-	// Act
-	result := helpers.StripModuleVersion(moduleName)
-
-	// Assert
-	assert.Equal(t, "", result)
-}
-
-func TestStripModuleVersion_OnlyDash(t *testing.T) {
-	// Arrange
-	moduleName := "-"
-
-	// Act
-	result := helpers.StripModuleVersion(moduleName)
-
-	// Assert
-	assert.Equal(t, "", result)
-}
-
 func TestFilterEmptyLines_EmptyString(t *testing.T) {
 	// Arrange
 	input := ""
@@ -307,6 +252,23 @@ func TestFilterEmptyLines_WindowsLineEndings(t *testing.T) {
 
 // ==================== IsVersionGreater Tests ====================
 
+func TestMatchAnyGlob(t *testing.T) {
+	// Arrange
+	patterns := []string{"mod-users", "edge-*", "[bad"}
+
+	// Act & Assert
+	pattern, ok := helpers.MatchAnyGlob("edge-orders", patterns)
+	assert.True(t, ok)
+	assert.Equal(t, "edge-*", pattern)
+
+	pattern, ok = helpers.MatchAnyGlob("[bad", patterns)
+	assert.True(t, ok)
+	assert.Equal(t, "[bad", pattern)
+
+	_, ok = helpers.MatchAnyGlob("mod-notes", patterns)
+	assert.False(t, ok)
+}
+
 func TestIsVersionGreater_ValidSemanticVersions(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1296,3 +1258,89 @@ func TestGenerateRandomCode_ConsistentLength(t *testing.T) {
 		assert.Len(t, result, 3, "Every generated code should be exactly 3 characters")
 	}
 }
+
+// ==================== DiffStrings Tests ====================
+
+func TestDiffStrings(t *testing.T) {
+	tests := []struct {
+		name            string
+		desired         []string
+		current         []string
+		expectedMissing []string
+		expectedExtra   []string
+	}{
+		{"nothing attached", []string{"a", "b"}, nil, []string{"a", "b"}, nil},
+		{"all attached", []string{"a", "b"}, []string{"b", "a"}, nil, nil},
+		{"partially attached", []string{"a", "b", "c"}, []string{"b"}, []string{"a", "c"}, nil},
+		{"extras attached", []string{"a"}, []string{"a", "x", "y"}, nil, []string{"x", "y"}},
+		{"missing and extras", []string{"a", "b"}, []string{"b", "x"}, []string{"a"}, []string{"x"}},
+		{"duplicates collapsed", []string{"a", "a", "b"}, []string{"x", "x"}, []string{"a", "b"}, []string{"x"}},
+		{"nothing desired", nil, []string{"x"}, nil, []string{"x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			missing, extra := helpers.DiffStrings(tt.desired, tt.current)
+
+			// Assert
+			assert.Equal(t, tt.expectedMissing, missing)
+			assert.Equal(t, tt.expectedExtra, extra)
+		})
+	}
+}
+
+func TestTrimModuleName_StripsOnlyFirstMatchingPrefix(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() { helpers.SetModuleNameRules(nil, nil) })
+	helpers.SetModuleNameRules([]string{"folio_", "folio_folio_"}, nil)
+
+	// Act
+	result := helpers.TrimModuleName("folio_folio_mod-users")
+
+	// Assert
+	assert.Equal(t, "folio_mod-users", result)
+}
+
+func TestTrimModuleName_KeepsNameEqualToPrefix(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() { helpers.SetModuleNameRules(nil, nil) })
+	helpers.SetModuleNameRules([]string{"edge"}, nil)
+
+	// Act
+	result := helpers.TrimModuleName("edge")
+
+	// Assert
+	assert.Equal(t, "edge", result)
+}
+
+// ==================== IsInterfaceVersionCompatible Tests ====================
+
+func TestIsInterfaceVersionCompatible(t *testing.T) {
+	tests := []struct {
+		name     string
+		required string
+		provided string
+		expected bool
+	}{
+		{name: "EqualVersions", required: "16.1", provided: "16.1", expected: true},
+		{name: "HigherMinor", required: "16.0", provided: "16.3", expected: true},
+		{name: "LowerMinor", required: "16.2", provided: "16.1", expected: false},
+		{name: "DifferentMajor", required: "15.0", provided: "16.0", expected: false},
+		{name: "PatchVersions", required: "1.2.3", provided: "1.2.4", expected: true},
+		{name: "LowerPatch", required: "1.2.3", provided: "1.2", expected: false},
+		{name: "AlternativeMatches", required: "1.0 2.0", provided: "2.1", expected: true},
+		{name: "NoAlternativeMatches", required: "1.0 2.0", provided: "3.0", expected: false},
+		{name: "InvalidProvided", required: "1.0", provided: "one", expected: false},
+		{name: "BlankRequired", required: "", provided: "1.0", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := helpers.IsInterfaceVersionCompatible(tt.required, tt.provided)
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// AddJitter spreads a wait duration by up to the given fraction in either direction,
+// so that concurrent pollers do not hit the same endpoint in lockstep
+func AddJitter(wait time.Duration, fraction float64) time.Duration {
+	if wait <= 0 || fraction <= 0 {
+		return wait
+	}
+
+	spread := float64(wait) * fraction
+	return wait + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+// ExponentialBackoff doubles the initial wait with every attempt up to the max wait, an initial wait that is
+// not positive or not below the max wait disables the backoff and the max wait is used for every attempt
+func ExponentialBackoff(initialWait, maxWait time.Duration, attempt int) time.Duration {
+	if initialWait <= 0 || initialWait >= maxWait {
+		return maxWait
+	}
+
+	wait := initialWait
+	for range attempt {
+		wait *= 2
+		if wait >= maxWait {
+			return maxWait
+		}
+	}
+
+	return wait
+}
@@ -0,0 +1,65 @@
+package helpers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddJitter_WithinBounds(t *testing.T) {
+	// Arrange
+	wait := 10 * time.Second
+
+	for range 100 {
+		// Act
+		result := helpers.AddJitter(wait, 0.2)
+
+		// Assert
+		assert.GreaterOrEqual(t, result, 8*time.Second)
+		assert.LessOrEqual(t, result, 12*time.Second)
+	}
+}
+
+func TestAddJitter_ZeroFraction(t *testing.T) {
+	// Arrange
+	wait := 10 * time.Second
+
+	// Act
+	result := helpers.AddJitter(wait, 0)
+
+	// Assert
+	assert.Equal(t, wait, result)
+}
+
+func TestAddJitter_ZeroWait(t *testing.T) {
+	// Act
+	result := helpers.AddJitter(0, 0.2)
+
+	// Assert
+	assert.Equal(t, time.Duration(0), result)
+}
+
+func TestExponentialBackoff_DoublesUpToMax(t *testing.T) {
+	// Arrange
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+
+	for attempt, want := range expected {
+		// Act
+		result := helpers.ExponentialBackoff(2*time.Second, 30*time.Second, attempt)
+
+		// Assert
+		assert.Equal(t, want, result, "attempt %d", attempt)
+	}
+}
+
+func TestExponentialBackoff_DisabledUsesMax(t *testing.T) {
+	// Act
+	zeroInitial := helpers.ExponentialBackoff(0, 10*time.Second, 0)
+	initialAboveMax := helpers.ExponentialBackoff(15*time.Second, 10*time.Second, 0)
+
+	// Assert
+	assert.Equal(t, 10*time.Second, zeroInitial)
+	assert.Equal(t, 10*time.Second, initialAboveMax)
+}
@@ -2,9 +2,12 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"log/slog"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
@@ -24,21 +27,54 @@ type HTTPClientRunner interface {
 
 // HTTPClient provides functionality for HTTP client operations with retry logic
 type HTTPClient struct {
-	Action       *action.Action
-	customClient *http.Client
-	retryClient  *retryablehttp.Client
-	pingClient   *retryablehttp.Client
+	Action                *action.Action
+	customClient          *http.Client
+	retryClient           *retryablehttp.Client
+	pingClient            *retryablehttp.Client
+	idempotentRetryClient *retryablehttp.Client
+}
+
+// baseContext is the parent context of all requests, cancelling it aborts the requests in flight
+var baseContext = context.Background()
+
+// SetBaseContext sets the parent context of all requests, it is meant to be called once at startup
+// with a context cancelled on interrupt, a nil context restores the background context
+func SetBaseContext(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	baseContext = ctx
 }
 
 // New creates a new HTTPClient instance
 func New(action *action.Action, logger *slog.Logger) *HTTPClient {
-	customClient := createCustomClient(constant.HTTPClientTimeout)
+	var (
+		budget         *retryBudget
+		recorder       *harRecorder
+		throttle       chan struct{}
+		verbose        bool
+		requestTimeout time.Duration
+		httpRetries    int
+	)
+	if action.Param != nil {
+		budget = newRetryBudget(logger, action.Param.MaxTotalRetries)
+		recorder = newHARRecorder(logger, action.Param.HARFile)
+		throttle = newThrottle(action.Param.MaxConcurrentRequests)
+		verbose = action.Param.Verbose
+		requestTimeout = action.Param.RequestTimeout
+		httpRetries = action.Param.HTTPRetries
+	}
+	customClient := createCustomClient(helpers.DefaultDuration(requestTimeout, constant.HTTPClientTimeout))
 	pingClient := createPingClient(constant.HTTPClientPingTimeout)
+	customClient.Transport = wrapThrottle(throttle, wrapVerbose(verbose, os.Stderr, recorder.wrap(customClient.Transport)))
+	pingClient.Transport = wrapThrottle(throttle, wrapVerbose(verbose, os.Stderr, recorder.wrap(pingClient.Transport)))
+
 	return &HTTPClient{
-		Action:       action,
-		customClient: customClient,
-		retryClient:  createRetryClient(logger, customClient),
-		pingClient:   createRetryClient(logger, pingClient),
+		Action:                action,
+		customClient:          customClient,
+		retryClient:           createRetryClient(logger, customClient, budget),
+		pingClient:            createRetryClient(logger, pingClient, nil),
+		idempotentRetryClient: createIdempotentRetryClient(logger, customClient, budget, httpRetries),
 	}
 }
 
@@ -52,7 +88,7 @@ func (hc *HTTPClient) doRequest(method, url string, payload []byte, headers map[
 		bodyReader = bytes.NewReader(payload)
 	}
 
-	httpRequest, err := http.NewRequest(method, url, bodyReader)
+	httpRequest, err := http.NewRequestWithContext(baseContext, method, url, bodyReader)
 	if err != nil {
 		return nil, err
 	}
@@ -63,12 +99,12 @@ func (hc *HTTPClient) doRequest(method, url string, payload []byte, headers map[
 	}
 
 	var httpResponse *http.Response
-	if useRetry {
+	if retryClient := hc.getRetryClient(method, useRetry); retryClient != nil {
 		retryReq, err := retryablehttp.FromRequest(httpRequest)
 		if err != nil {
 			return nil, err
 		}
-		httpResponse, err = hc.retryClient.Do(retryReq)
+		httpResponse, err = retryClient.Do(retryReq)
 		if err != nil {
 			return nil, err
 		}
@@ -90,6 +126,28 @@ func (hc *HTTPClient) doRequest(method, url string, payload []byte, headers map[
 	return httpResponse, nil
 }
 
+// getRetryClient returns nil when the request must be sent once, requests without explicit retries
+// are only retried when they are idempotent and --httpRetries is set
+func (hc *HTTPClient) getRetryClient(method string, useRetry bool) *retryablehttp.Client {
+	if useRetry {
+		return hc.retryClient
+	}
+	if hc.idempotentRetryClient != nil && isIdempotentMethod(method) {
+		return hc.idempotentRetryClient
+	}
+
+	return nil
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
 func setRequestHeaders(httpRequest *http.Request, headers map[string]string) {
 	if len(headers) == 0 {
 		httpRequest.Header.Add(constant.ContentTypeHeader, constant.ApplicationJSON)
@@ -1,9 +1,12 @@
 package httpclient
 
 import (
+	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
@@ -29,6 +32,85 @@ func (l *LoggingRoundTripper) RoundTrip(httpRequest *http.Request) (*http.Respon
 	return httpResponse, nil
 }
 
+// VerboseRoundTripper prints a one-line summary of every exchange without the request and response bodies
+type VerboseRoundTripper struct {
+	writer io.Writer
+	next   http.RoundTripper
+}
+
+func (v *VerboseRoundTripper) RoundTrip(httpRequest *http.Request) (*http.Response, error) {
+	start := time.Now()
+	httpResponse, err := v.next.RoundTrip(httpRequest)
+	duration := time.Since(start).Milliseconds()
+	if err != nil {
+		_, _ = fmt.Fprintf(v.writer, "%s %s -> error in %dms: %v\n", httpRequest.Method, httpRequest.URL.String(), duration, err)
+
+		return httpResponse, err
+	}
+	_, _ = fmt.Fprintf(v.writer, "%s %s -> %d in %dms\n", httpRequest.Method, httpRequest.URL.String(), httpResponse.StatusCode, duration)
+
+	return httpResponse, nil
+}
+
+func wrapVerbose(verbose bool, writer io.Writer, next http.RoundTripper) http.RoundTripper {
+	if !verbose {
+		return next
+	}
+
+	return &VerboseRoundTripper{writer: writer, next: next}
+}
+
+// ThrottleRoundTripper limits the number of requests in flight, a request holds its slot until its response body is closed
+type ThrottleRoundTripper struct {
+	semaphore chan struct{}
+	next      http.RoundTripper
+}
+
+func (t *ThrottleRoundTripper) RoundTrip(httpRequest *http.Request) (*http.Response, error) {
+	select {
+	case t.semaphore <- struct{}{}:
+	case <-httpRequest.Context().Done():
+		return nil, httpRequest.Context().Err()
+	}
+
+	release := sync.OnceFunc(func() { <-t.semaphore })
+	httpResponse, err := t.next.RoundTrip(httpRequest)
+	if err != nil || httpResponse == nil || httpResponse.Body == nil {
+		release()
+		return httpResponse, err
+	}
+	httpResponse.Body = &releaseOnCloseBody{ReadCloser: httpResponse.Body, release: release}
+
+	return httpResponse, nil
+}
+
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// newThrottle returns a semaphore shared by the transports of a client, a non-positive limit is unlimited
+func newThrottle(maxConcurrentRequests int) chan struct{} {
+	if maxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	return make(chan struct{}, maxConcurrentRequests)
+}
+
+func wrapThrottle(semaphore chan struct{}, next http.RoundTripper) http.RoundTripper {
+	if semaphore == nil {
+		return next
+	}
+
+	return &ThrottleRoundTripper{semaphore: semaphore, next: next}
+}
+
 func createCustomClient(timeout time.Duration) *http.Client {
 	lenientTransport := &http.Transport{
 		DialContext: (&net.Dialer{
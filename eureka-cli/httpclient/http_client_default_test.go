@@ -0,0 +1,141 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerboseRoundTripper_PrintsSummary(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("body that must not be printed"))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	client := &http.Client{Transport: wrapVerbose(true, &output, http.DefaultTransport)}
+
+	// Act
+	httpResponse, err := client.Get(server.URL + "/tenants")
+
+	// Assert
+	require.NoError(t, err)
+	_ = httpResponse.Body.Close()
+	assert.Regexp(t, regexp.MustCompile(`^GET `+regexp.QuoteMeta(server.URL)+`/tenants -> 202 in \d+ms\n$`), output.String())
+	assert.NotContains(t, output.String(), "body")
+}
+
+func TestVerboseRoundTripper_PrintsError(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	client := &http.Client{Transport: wrapVerbose(true, &output, http.DefaultTransport)}
+
+	// Act
+	_, err := client.Get("http://127.0.0.1:0/unreachable")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, output.String(), "GET http://127.0.0.1:0/unreachable -> error in")
+}
+
+func TestWrapVerbose_DisabledReturnsNext(t *testing.T) {
+	// Act
+	result := wrapVerbose(false, &bytes.Buffer{}, http.DefaultTransport)
+
+	// Assert
+	assert.Same(t, http.DefaultTransport, result)
+}
+
+func TestThrottleRoundTripper_LimitsRequestsInFlight(t *testing.T) {
+	// Arrange
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: wrapThrottle(newThrottle(2), http.DefaultTransport)}
+
+	// Act
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			httpResponse, err := client.Get(server.URL)
+			if assert.NoError(t, err) {
+				_ = httpResponse.Body.Close()
+			}
+		})
+	}
+	wg.Wait()
+
+	// Assert
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+	assert.Positive(t, maxInFlight.Load())
+}
+
+func TestThrottleRoundTripper_ReleasesSlotOnBodyClose(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	semaphore := newThrottle(1)
+	client := &http.Client{Transport: wrapThrottle(semaphore, http.DefaultTransport)}
+
+	// Act
+	httpResponse, err := client.Get(server.URL)
+	require.NoError(t, err)
+	heldSlots := len(semaphore)
+	_ = httpResponse.Body.Close()
+	_ = httpResponse.Body.Close()
+
+	// Assert
+	assert.Equal(t, 1, heldSlots)
+	assert.Empty(t, semaphore)
+}
+
+func TestThrottleRoundTripper_CanceledWhileWaiting(t *testing.T) {
+	// Arrange
+	semaphore := newThrottle(1)
+	semaphore <- struct{}{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:0/unreachable", nil)
+	require.NoError(t, err)
+
+	// Act
+	httpResponse, err := wrapThrottle(semaphore, http.DefaultTransport).RoundTrip(httpRequest)
+
+	// Assert
+	assert.Nil(t, httpResponse)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWrapThrottle_DisabledReturnsNext(t *testing.T) {
+	// Act
+	result := wrapThrottle(newThrottle(0), http.DefaultTransport)
+
+	// Assert
+	assert.Same(t, http.DefaultTransport, result)
+}
@@ -0,0 +1,305 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+)
+
+// harRecorder collects the HTTP exchanges of a single command run and appends them to a HAR file
+type harRecorder struct {
+	logger   *slog.Logger
+	filePath string
+	mu       sync.Mutex
+	file     *os.File
+}
+
+func newHARRecorder(logger *slog.Logger, filePath string) *harRecorder {
+	if filePath == "" {
+		return nil
+	}
+
+	return &harRecorder{logger: logger, filePath: filePath}
+}
+
+// wrap returns a transport recording every exchange of next, a nil recorder returns next unchanged
+func (hr *harRecorder) wrap(next http.RoundTripper) http.RoundTripper {
+	if hr == nil {
+		return next
+	}
+
+	return &HARRoundTripper{recorder: hr, next: next}
+}
+
+func (hr *harRecorder) record(entry models.HAREntry) {
+	hr.mu.Lock()
+	defer hr.mu.Unlock()
+
+	if err := hr.append(entry); err != nil {
+		hr.logger.Warn("Could not write HAR file", "file", hr.filePath, "error", err)
+	}
+}
+
+// append writes an entry over the closing brackets of the archive and closes it again, so the file stays
+// a complete HAR document when a command fails midway without rewriting the earlier entries
+func (hr *harRecorder) append(entry models.HAREntry) error {
+	data, err := marshalHARJSON(entry)
+	if err != nil {
+		return err
+	}
+
+	if hr.file == nil {
+		file, err := os.OpenFile(hr.filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		prefix, err := newHARPrefix()
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+		if _, err := file.Write(prefix); err != nil {
+			_ = file.Close()
+			return err
+		}
+		hr.file = file
+	} else {
+		if _, err := hr.file.Seek(-int64(len(harSuffix)), io.SeekEnd); err != nil {
+			return err
+		}
+		data = append([]byte(",\n"), data...)
+	}
+	_, err = hr.file.Write(append(data, harSuffix...))
+
+	return err
+}
+
+// harSuffix closes the entries array, the log and the document of the archive
+const harSuffix = "\n]}}\n"
+
+func newHARPrefix() ([]byte, error) {
+	data, err := marshalHARJSON(models.HAR{Log: models.HARLog{
+		Version: constant.HARVersion,
+		Creator: models.HARCreator{Name: constant.HARCreatorName, Version: constant.HARVersion},
+		Entries: []models.HAREntry{},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(bytes.TrimSuffix(data, []byte("]}}")), '\n'), nil
+}
+
+func marshalHARJSON(value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// HARRoundTripper records requests and responses passing through the next transport
+type HARRoundTripper struct {
+	recorder *harRecorder
+	next     http.RoundTripper
+}
+
+func (h *HARRoundTripper) RoundTrip(httpRequest *http.Request) (*http.Response, error) {
+	requestBody, err := readAndRestoreRequestBody(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	httpResponse, err := h.next.RoundTrip(httpRequest)
+	duration := float64(time.Since(start).Microseconds()) / 1000
+
+	entry := models.HAREntry{
+		StartedDateTime: start.Format(time.RFC3339Nano),
+		Time:            duration,
+		Request:         newHARRequest(httpRequest, requestBody),
+		Timings:         models.HARTimings{Wait: duration},
+	}
+	if err != nil {
+		entry.Comment = err.Error()
+		h.recorder.record(entry)
+		return httpResponse, err
+	}
+
+	responseBody, readErr := io.ReadAll(httpResponse.Body)
+	_ = httpResponse.Body.Close()
+	httpResponse.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if readErr != nil {
+		entry.Comment = readErr.Error()
+	}
+	entry.Response = newHARResponse(httpResponse, responseBody)
+	h.recorder.record(entry)
+
+	return httpResponse, readErr
+}
+
+func readAndRestoreRequestBody(httpRequest *http.Request) ([]byte, error) {
+	if httpRequest.Body == nil || httpRequest.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(httpRequest.Body)
+	_ = httpRequest.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func newHARRequest(httpRequest *http.Request, body []byte) models.HARRequest {
+	request := models.HARRequest{
+		Method:      httpRequest.Method,
+		URL:         httpRequest.URL.String(),
+		HTTPVersion: httpRequest.Proto,
+		Headers:     newHARHeaders(httpRequest.Header),
+		QueryString: []models.HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	for name, values := range httpRequest.URL.Query() {
+		for _, value := range values {
+			request.QueryString = append(request.QueryString, models.HARNameValue{Name: name, Value: value})
+		}
+	}
+	if len(body) > 0 {
+		request.PostData = &models.HARPostData{
+			MimeType: httpRequest.Header.Get(constant.ContentTypeHeader),
+			Text:     truncateHARBody(redactHARBody(httpRequest.Header.Get(constant.ContentTypeHeader), body)),
+		}
+	}
+
+	return request
+}
+
+func newHARResponse(httpResponse *http.Response, body []byte) models.HARResponse {
+	return models.HARResponse{
+		Status:      httpResponse.StatusCode,
+		StatusText:  http.StatusText(httpResponse.StatusCode),
+		HTTPVersion: httpResponse.Proto,
+		Headers:     newHARHeaders(httpResponse.Header),
+		Content: models.HARContent{
+			Size:     len(body),
+			MimeType: httpResponse.Header.Get(constant.ContentTypeHeader),
+			Text:     truncateHARBody(redactHARBody(httpResponse.Header.Get(constant.ContentTypeHeader), body)),
+		},
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+}
+
+func newHARHeaders(header http.Header) []models.HARNameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := make([]models.HARNameValue, 0, len(names))
+	for _, name := range names {
+		for _, value := range header[name] {
+			if isRedactedHeader(name) {
+				value = constant.HARRedactedValue
+			}
+			headers = append(headers, models.HARNameValue{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
+
+func isRedactedHeader(name string) bool {
+	return slices.Contains(constant.GetHARRedactedHeaders(), http.CanonicalHeaderKey(name)) ||
+		http.CanonicalHeaderKey(name) == http.CanonicalHeaderKey(helpers.GetTokenHeader())
+}
+
+// redactHARBody replaces the values of secret fields in form and JSON bodies, such as Keycloak client
+// secrets, user passwords and issued tokens, other bodies are recorded as is
+func redactHARBody(contentType string, body []byte) []byte {
+	if strings.HasPrefix(contentType, constant.ApplicationFormURLEncoded) {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+
+		var redacted bool
+		for name := range values {
+			if isRedactedField(name) {
+				values[name] = []string{constant.HARRedactedValue}
+				redacted = true
+			}
+		}
+		if !redacted {
+			return body
+		}
+
+		return []byte(values.Encode())
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil || !redactHARValue(value) {
+		return body
+	}
+	data, err := marshalHARJSON(value)
+	if err != nil {
+		return body
+	}
+
+	return data
+}
+
+func redactHARValue(value any) bool {
+	var redacted bool
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for name, fieldValue := range typedValue {
+			if isRedactedField(name) {
+				typedValue[name] = constant.HARRedactedValue
+				redacted = true
+				continue
+			}
+			redacted = redactHARValue(fieldValue) || redacted
+		}
+	case []any:
+		for _, item := range typedValue {
+			redacted = redactHARValue(item) || redacted
+		}
+	}
+
+	return redacted
+}
+
+// isRedactedField matches field names regardless of case and underscores, e.g. access_token and accessToken
+func isRedactedField(name string) bool {
+	return slices.Contains(constant.GetHARRedactedFields(), strings.ToLower(strings.ReplaceAll(name, "_", "")))
+}
+
+func truncateHARBody(body []byte) string {
+	if len(body) <= constant.HARMaxBodySize {
+		return string(body)
+	}
+
+	return string(body[:constant.HARMaxBodySize]) + constant.HARTruncatedSuffix
+}
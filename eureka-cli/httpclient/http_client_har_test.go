@@ -0,0 +1,126 @@
+package httpclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/httpclient"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHARFile_RecordsRedactedExchanges(t *testing.T) {
+	// Arrange
+	largeBody := strings.Repeat("a", constant.HARMaxBodySize+10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(largeBody))
+	}))
+	defer server.Close()
+
+	harFile := filepath.Join(t.TempDir(), "run.har")
+	testAction := &action.Action{Name: "TestAction", Param: &action.Param{HARFile: harFile}}
+	client := httpclient.New(testAction, createTestLogger())
+	headers := map[string]string{
+		constant.AuthorizationHeader: "Bearer secret",
+		constant.OkapiTokenHeader:    "secret",
+		constant.OkapiTenantHeader:   "diku",
+	}
+
+	// Act
+	err := client.PostReturnNoContent(server.URL+"/tenants?limit=1", []byte(`{"name":"diku"}`), headers)
+
+	// Assert
+	require.NoError(t, err)
+	var har models.HAR
+	require.NoError(t, helpers.ReadJSONFromFile(harFile, &har))
+	require.Len(t, har.Log.Entries, 1)
+
+	entry := har.Log.Entries[0]
+	assert.Equal(t, http.MethodPost, entry.Request.Method)
+	assert.Equal(t, server.URL+"/tenants?limit=1", entry.Request.URL)
+	assert.Contains(t, entry.Request.QueryString, models.HARNameValue{Name: "limit", Value: "1"})
+	assert.Contains(t, entry.Request.Headers, models.HARNameValue{Name: constant.AuthorizationHeader, Value: constant.HARRedactedValue})
+	assert.Contains(t, entry.Request.Headers, models.HARNameValue{Name: constant.OkapiTokenHeader, Value: constant.HARRedactedValue})
+	assert.Contains(t, entry.Request.Headers, models.HARNameValue{Name: constant.OkapiTenantHeader, Value: "diku"})
+	require.NotNil(t, entry.Request.PostData)
+	assert.Equal(t, `{"name":"diku"}`, entry.Request.PostData.Text)
+	assert.Equal(t, http.StatusCreated, entry.Response.Status)
+	assert.Equal(t, len(largeBody), entry.Response.Content.Size)
+	assert.True(t, strings.HasSuffix(entry.Response.Content.Text, constant.HARTruncatedSuffix))
+	assert.Len(t, entry.Response.Content.Text, constant.HARMaxBodySize+len(constant.HARTruncatedSuffix))
+}
+
+func TestHARFile_RedactsSecretBodyFields(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", constant.ApplicationJSON)
+		_, _ = w.Write([]byte(`{"access_token":"issued-token","expires_in":300,"tokens":[{"refreshToken":"issued-refresh"}]}`))
+	}))
+	defer server.Close()
+
+	harFile := filepath.Join(t.TempDir(), "run.har")
+	testAction := &action.Action{Name: "TestAction", Param: &action.Param{HARFile: harFile}}
+	client := httpclient.New(testAction, createTestLogger())
+
+	// Act
+	err := client.PostReturnNoContent(server.URL+"/token", []byte("grant_type=client_credentials&client_id=cli&client_secret=top-secret"),
+		map[string]string{constant.ContentTypeHeader: constant.ApplicationFormURLEncoded})
+	require.NoError(t, err)
+	err = client.PostReturnNoContent(server.URL+"/authn/credentials", []byte(`{"username":"diku_admin","password":"admin"}`),
+		map[string]string{constant.ContentTypeHeader: constant.ApplicationJSON})
+	require.NoError(t, err)
+
+	// Assert
+	info, err := os.Stat(harFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	var har models.HAR
+	require.NoError(t, helpers.ReadJSONFromFile(harFile, &har))
+	require.Len(t, har.Log.Entries, 2)
+
+	formValues, err := url.ParseQuery(har.Log.Entries[0].Request.PostData.Text)
+	require.NoError(t, err)
+	assert.Equal(t, constant.HARRedactedValue, formValues.Get("client_secret"))
+	assert.Equal(t, "cli", formValues.Get("client_id"))
+	assert.JSONEq(t, `{"username":"diku_admin","password":"[REDACTED]"}`, har.Log.Entries[1].Request.PostData.Text)
+	for _, entry := range har.Log.Entries {
+		assert.JSONEq(t, `{"access_token":"[REDACTED]","expires_in":300,"tokens":[{"refreshToken":"[REDACTED]"}]}`, entry.Response.Content.Text)
+	}
+
+	data, err := os.ReadFile(harFile)
+	require.NoError(t, err)
+	for _, secret := range []string{"top-secret", "issued-token", "issued-refresh", `"admin"`} {
+		assert.NotContains(t, string(data), secret)
+	}
+}
+
+func TestHARFile_DisabledByDefault(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	harFile := filepath.Join(t.TempDir(), "run.har")
+	testAction := &action.Action{Name: "TestAction", Param: &action.Param{}}
+	client := httpclient.New(testAction, createTestLogger())
+
+	// Act
+	err := client.PostReturnNoContent(server.URL, nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoFileExists(t, harFile)
+}
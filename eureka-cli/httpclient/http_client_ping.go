@@ -30,7 +30,7 @@ func (hc *HTTPClient) Ping(url string) (int, error) {
 }
 
 func (hc *HTTPClient) doStatusCheck(url string, useRetry bool) (int, error) {
-	httpRequest, err := http.NewRequest(http.MethodGet, url, nil)
+	httpRequest, err := http.NewRequestWithContext(baseContext, http.MethodGet, url, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -63,7 +63,7 @@ func (hc *HTTPClient) PostReturnStruct(url string, payload []byte, headers map[s
 func (hc *HTTPClient) PostFormDataReturnStruct(url string, formValues url.Values, headers map[string]string, target any) error {
 	helpers.DumpRequestFormData(formValues)
 
-	httpRequest, err := http.NewRequest(http.MethodPost, url, strings.NewReader(formValues.Encode()))
+	httpRequest, err := http.NewRequestWithContext(baseContext, http.MethodPost, url, strings.NewReader(formValues.Encode()))
 	if err != nil {
 		return err
 	}
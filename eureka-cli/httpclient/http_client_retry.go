@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/hashicorp/go-retryablehttp"
@@ -30,7 +31,38 @@ func (l *LoggerAdapter) Warn(msg string, keysAndValues ...any) {
 	l.logger.Warn(msg, keysAndValues...)
 }
 
-func createRetryClient(logger *slog.Logger, customClient *http.Client) *retryablehttp.Client {
+// retryBudget caps the total number of retries shared by all requests of a single command run
+type retryBudget struct {
+	logger    *slog.Logger
+	max       int64
+	used      atomic.Int64
+	exhausted atomic.Bool
+}
+
+func newRetryBudget(logger *slog.Logger, maxTotalRetries int) *retryBudget {
+	if maxTotalRetries <= 0 {
+		return nil
+	}
+
+	return &retryBudget{logger: logger, max: int64(maxTotalRetries)}
+}
+
+// take reserves a single retry from the budget, a nil budget is unlimited
+func (rb *retryBudget) take() bool {
+	if rb == nil {
+		return true
+	}
+	if rb.used.Add(1) <= rb.max {
+		return true
+	}
+	if rb.exhausted.CompareAndSwap(false, true) {
+		rb.logger.Warn("Retry budget exhausted, failing fast on further errors", "maxTotalRetries", rb.max)
+	}
+
+	return false
+}
+
+func createRetryClient(logger *slog.Logger, customClient *http.Client, budget *retryBudget) *retryablehttp.Client {
 	retryClient := retryablehttp.NewClient()
 	retryClient.HTTPClient = customClient
 	retryClient.RetryMax = constant.RetryHTTPClientRetryMax
@@ -40,12 +72,12 @@ func createRetryClient(logger *slog.Logger, customClient *http.Client) *retryabl
 		// Use default retry policy for other errors
 		shouldRetry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, httpResponse, err)
 		if shouldRetry {
-			return true, checkErr
+			return budget.take(), checkErr
 		}
 		// Also retry on 429 Too Many Requests and 503 Service Unavailable
 		if httpResponse != nil && (httpResponse.StatusCode == http.StatusTooManyRequests ||
 			httpResponse.StatusCode == http.StatusServiceUnavailable) {
-			return true, nil
+			return budget.take(), nil
 		}
 
 		return false, checkErr
@@ -54,3 +86,17 @@ func createRetryClient(logger *slog.Logger, customClient *http.Client) *retryabl
 
 	return retryClient
 }
+
+// createIdempotentRetryClient returns nil when the retries of idempotent requests are disabled,
+// every retry attempt is logged at debug level by the retry client
+func createIdempotentRetryClient(logger *slog.Logger, customClient *http.Client, budget *retryBudget, httpRetries int) *retryablehttp.Client {
+	if httpRetries <= 0 {
+		return nil
+	}
+
+	retryClient := createRetryClient(logger, customClient, budget)
+	retryClient.RetryMax = httpRetries
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	return retryClient
+}
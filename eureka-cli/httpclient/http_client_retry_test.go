@@ -3,7 +3,11 @@ package httpclient
 import (
 	"bytes"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // TestLoggerAdapter tests the LoggerAdapter methods
@@ -144,7 +148,7 @@ func TestCreateRetryClient_Configuration(t *testing.T) {
 	customClient := createCustomClient(5)
 
 	// Act
-	retryClient := createRetryClient(logger, customClient)
+	retryClient := createRetryClient(logger, customClient, nil)
 
 	// Assert
 	if retryClient == nil {
@@ -166,7 +170,7 @@ func TestCreateRetryClient_LoggerAdapterSet(t *testing.T) {
 	customClient := createCustomClient(5)
 
 	// Act
-	retryClient := createRetryClient(logger, customClient)
+	retryClient := createRetryClient(logger, customClient, nil)
 
 	// Assert
 	adapter, ok := retryClient.Logger.(*LoggerAdapter)
@@ -177,3 +181,164 @@ func TestCreateRetryClient_LoggerAdapterSet(t *testing.T) {
 		t.Error("Expected LoggerAdapter to wrap the provided logger")
 	}
 }
+
+func TestRetryBudget_NilIsUnlimited(t *testing.T) {
+	// Arrange
+	var budget *retryBudget
+
+	// Act & Assert
+	for range 100 {
+		if !budget.take() {
+			t.Fatal("Expected nil budget to always allow retries")
+		}
+	}
+}
+
+func TestNewRetryBudget_DisabledWhenNotPositive(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	// Act
+	budget := newRetryBudget(logger, 0)
+
+	// Assert
+	if budget != nil {
+		t.Error("Expected no budget for a non-positive limit")
+	}
+}
+
+func TestCreateRetryClient_SharedBudgetStopsRetrying(t *testing.T) {
+	// Arrange
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	budget := newRetryBudget(logger, 2)
+	retryClient := createRetryClient(logger, createCustomClient(5*time.Second), budget)
+	retryClient.RetryWaitMin = time.Millisecond
+	retryClient.RetryWaitMax = time.Millisecond
+
+	// Act
+	for range 3 {
+		resp, err := retryClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Expected response once budget is exhausted, got error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// Assert
+	// 3 initial attempts + 2 budgeted retries
+	if got := requests.Load(); got != 5 {
+		t.Errorf("Expected 5 requests, got %d", got)
+	}
+	if !contains(buf.String(), "Retry budget exhausted") {
+		t.Errorf("Expected exhaustion warning, got: %s", buf.String())
+	}
+}
+
+func TestCreateIdempotentRetryClient_DisabledWhenNotPositive(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	// Act
+	retryClient := createIdempotentRetryClient(logger, createCustomClient(5*time.Second), nil, 0)
+
+	// Assert
+	if retryClient != nil {
+		t.Error("Expected no idempotent retry client when the retries are disabled")
+	}
+}
+
+func TestCreateIdempotentRetryClient_RetriesUntilSuccess(t *testing.T) {
+	// Arrange
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	retryClient := createIdempotentRetryClient(logger, createCustomClient(5*time.Second), nil, 3)
+	retryClient.RetryWaitMin = time.Millisecond
+	retryClient.RetryWaitMax = time.Millisecond
+
+	// Act
+	resp, err := retryClient.Get(server.URL)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected success after retries, got error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 requests, got %d", got)
+	}
+	if !contains(buf.String(), "level=DEBUG msg=\"retrying request\"") {
+		t.Errorf("Expected retry attempts logged at debug level, got: %s", buf.String())
+	}
+}
+
+func TestCreateIdempotentRetryClient_ReturnsLastResponseWhenExhausted(t *testing.T) {
+	// Arrange
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	retryClient := createIdempotentRetryClient(logger, createCustomClient(5*time.Second), nil, 2)
+	retryClient.RetryWaitMin = time.Millisecond
+	retryClient.RetryWaitMax = time.Millisecond
+
+	// Act
+	resp, err := retryClient.Get(server.URL)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected the last response to be passed through, got error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", resp.StatusCode)
+	}
+	// 1 initial attempt + 2 retries
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 requests, got %d", got)
+	}
+}
+
+func TestGetRetryClient_OnlyIdempotentMethodsRetried(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	customClient := createCustomClient(5 * time.Second)
+	hc := &HTTPClient{
+		retryClient:           createRetryClient(logger, customClient, nil),
+		idempotentRetryClient: createIdempotentRetryClient(logger, customClient, nil, 3),
+	}
+
+	// Act & Assert
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete} {
+		if hc.getRetryClient(method, false) != hc.idempotentRetryClient {
+			t.Errorf("Expected %s to be retried", method)
+		}
+	}
+	if hc.getRetryClient(http.MethodPost, false) != nil {
+		t.Error("Expected POST without explicit retries to be sent once")
+	}
+	if hc.getRetryClient(http.MethodPost, true) != hc.retryClient {
+		t.Error("Expected POST with explicit retries to use the retry client")
+	}
+}
@@ -1,6 +1,7 @@
 package httpclient_test
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log/slog"
@@ -8,6 +9,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/httpclient"
@@ -50,6 +52,53 @@ func TestGetReturnStruct_Success(t *testing.T) {
 	assert.Equal(t, "test", result.Message)
 }
 
+func TestGetReturnStruct_RequestTimeout(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	testAction := createTestAction()
+	testAction.Param = &action.Param{RequestTimeout: 50 * time.Millisecond}
+	client := httpclient.New(testAction, createTestLogger())
+	var result TestResponse
+
+	// Act
+	start := time.Now()
+	err := client.GetReturnStruct(server.URL, nil, &result)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestGetReturnStruct_CancelledBaseContext(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	httpclient.SetBaseContext(ctx)
+	t.Cleanup(func() { httpclient.SetBaseContext(nil) })
+	client := httpclient.New(createTestAction(), createTestLogger())
+	var result TestResponse
+
+	// Act
+	err := client.GetReturnStruct(server.URL, nil, &result)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestGetReturnStruct_EmptyResponse(t *testing.T) {
 	// Arrange
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
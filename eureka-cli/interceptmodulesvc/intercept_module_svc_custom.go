@@ -47,7 +47,7 @@ func (is *InterceptModuleSvc) prepareSidecarNetwork(pair *modulesvc.ModulePair)
 	pair.BackendModule.SidecarExposedServerPort = sidecarServerPort
 	pair.BackendModule.SidecarExposedDebugPort = sidecarDebugPort
 
-	pair.BackendModule.SidecarPortBindings = helpers.CreatePortBindings(sidecarServerPort, sidecarDebugPort, pair.BackendModule.PrivatePort)
+	pair.BackendModule.SidecarPortBindings = helpers.CreatePortBindings(sidecarServerPort, sidecarDebugPort, pair.BackendModule.PrivatePort, 0)
 	if err := is.updateModuleDiscovery(pair); err != nil {
 		return err
 	}
@@ -0,0 +1,516 @@
+package testhelpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+)
+
+// MockPlatformToken is the access token issued by the token endpoints of MockPlatformServer
+const MockPlatformToken = "mock-platform-token"
+
+var cqlEquality = regexp.MustCompile(`([A-Za-z]+)==([^\s)&]+)`)
+
+// MockPlatformServer is an in-memory stand-in for the gateway and Keycloak APIs used by the deploy flow:
+// applications, module discovery, tenants, entitlements, roles, users and capability sets.
+// Point an action at it with action.SetBaseURL(server.URL()) to run the services without real infrastructure.
+type MockPlatformServer struct {
+	Server *httptest.Server
+
+	mu                 sync.Mutex
+	nextID             int
+	applications       []map[string]any
+	discovery          []models.ModuleDiscovery
+	tenants            []models.Tenant
+	entitlements       []models.TenantEntitlementDTO
+	entitlementFlows   []string
+	roles              map[string][]models.KeycloakRole
+	users              map[string][]models.KeycloakUser
+	passwords          map[string]string
+	userRoleIDs        map[string][]string
+	capabilitySets     []models.KeycloakCapabilitySet
+	roleCapabilitySets map[string][]string
+}
+
+// NewMockPlatformServer starts a MockPlatformServer, close it with Close
+func NewMockPlatformServer() *MockPlatformServer {
+	ps := &MockPlatformServer{
+		roles:              map[string][]models.KeycloakRole{},
+		users:              map[string][]models.KeycloakUser{},
+		passwords:          map[string]string{},
+		userRoleIDs:        map[string][]string{},
+		roleCapabilitySets: map[string][]string{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /realms/{realm}/protocol/openid-connect/token", ps.createToken)
+	mux.HandleFunc("GET /applications", ps.authorized(ps.getApplications))
+	mux.HandleFunc("GET /applications/{id}", ps.authorized(ps.getApplication))
+	mux.HandleFunc("POST /applications", ps.authorized(ps.createApplication))
+	mux.HandleFunc("DELETE /applications/{id}", ps.authorized(ps.removeApplication))
+	mux.HandleFunc("GET /modules/discovery", ps.authorized(ps.getDiscovery))
+	mux.HandleFunc("POST /modules/discovery", ps.authorized(ps.createDiscovery))
+	mux.HandleFunc("GET /tenants", ps.authorized(ps.getTenants))
+	mux.HandleFunc("POST /tenants", ps.authorized(ps.createTenant))
+	mux.HandleFunc("DELETE /tenants/{id}", ps.authorized(ps.removeTenant))
+	mux.HandleFunc("GET /entitlements", ps.authorized(ps.getEntitlements))
+	mux.HandleFunc("POST /entitlements", ps.authorized(ps.createEntitlement))
+	mux.HandleFunc("GET /entitlement-flows/{id}", ps.authorized(ps.getEntitlementFlow))
+	mux.HandleFunc("GET /roles", ps.authorized(ps.tenantScoped(ps.getRoles)))
+	mux.HandleFunc("POST /roles", ps.authorized(ps.tenantScoped(ps.createRole)))
+	mux.HandleFunc("GET /users", ps.authorized(ps.tenantScoped(ps.getUsers)))
+	mux.HandleFunc("POST /users-keycloak/users", ps.authorized(ps.tenantScoped(ps.createUser)))
+	mux.HandleFunc("POST /authn/credentials", ps.authorized(ps.tenantScoped(ps.createCredentials)))
+	mux.HandleFunc("POST /roles/users", ps.authorized(ps.tenantScoped(ps.createUserRoles)))
+	mux.HandleFunc("GET /capability-sets", ps.authorized(ps.tenantScoped(ps.getCapabilitySets)))
+	mux.HandleFunc("GET /roles/{id}/capability-sets", ps.authorized(ps.tenantScoped(ps.getRoleCapabilitySets)))
+	mux.HandleFunc("POST /roles/capability-sets", ps.authorized(ps.tenantScoped(ps.createRoleCapabilitySets)))
+	ps.Server = httptest.NewServer(mux)
+
+	return ps
+}
+
+// URL returns the base URL of the server
+func (ps *MockPlatformServer) URL() string {
+	return ps.Server.URL
+}
+
+// Close shuts the server down
+func (ps *MockPlatformServer) Close() {
+	ps.Server.Close()
+}
+
+// AddCapabilitySets registers capability sets of an application, they become visible to every tenant
+func (ps *MockPlatformServer) AddCapabilitySets(applicationID string, names ...string) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	for _, name := range names {
+		ps.capabilitySets = append(ps.capabilitySets, models.KeycloakCapabilitySet{ID: ps.newID("capability-set"), Name: name, ApplicationID: applicationID})
+	}
+}
+
+// ==================== State ====================
+
+// Applications returns the registered application descriptors
+func (ps *MockPlatformServer) Applications() []map[string]any {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return slices.Clone(ps.applications)
+}
+
+// Discovery returns the registered module discovery entries
+func (ps *MockPlatformServer) Discovery() []models.ModuleDiscovery {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return slices.Clone(ps.discovery)
+}
+
+// Tenants returns the created tenants
+func (ps *MockPlatformServer) Tenants() []models.Tenant {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return slices.Clone(ps.tenants)
+}
+
+// Entitlements returns the created tenant entitlements
+func (ps *MockPlatformServer) Entitlements() []models.TenantEntitlementDTO {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return slices.Clone(ps.entitlements)
+}
+
+// Roles returns the roles of a tenant
+func (ps *MockPlatformServer) Roles(tenantName string) []models.KeycloakRole {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return slices.Clone(ps.roles[tenantName])
+}
+
+// Users returns the users of a tenant
+func (ps *MockPlatformServer) Users(tenantName string) []models.KeycloakUser {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return slices.Clone(ps.users[tenantName])
+}
+
+// Password returns the password attached to a user id
+func (ps *MockPlatformServer) Password(userID string) string {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.passwords[userID]
+}
+
+// UserRoleIDs returns the role ids attached to a user id
+func (ps *MockPlatformServer) UserRoleIDs(userID string) []string {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return slices.Clone(ps.userRoleIDs[userID])
+}
+
+// RoleCapabilitySetIDs returns the capability set ids attached to a role id
+func (ps *MockPlatformServer) RoleCapabilitySetIDs(roleID string) []string {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return slices.Clone(ps.roleCapabilitySets[roleID])
+}
+
+// ==================== Middleware ====================
+
+func (ps *MockPlatformServer) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(constant.OkapiTokenHeader) != MockPlatformToken && r.Header.Get(constant.AuthorizationHeader) != "Bearer "+MockPlatformToken {
+			writeMockError(w, http.StatusUnauthorized, "missing or invalid access token")
+			return
+		}
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		next(w, r)
+	}
+}
+
+func (ps *MockPlatformServer) tenantScoped(next func(http.ResponseWriter, *http.Request, string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantName := r.Header.Get(constant.OkapiTenantHeader)
+		if !slices.ContainsFunc(ps.tenants, func(tenant models.Tenant) bool { return tenant.Name == tenantName }) {
+			writeMockError(w, http.StatusBadRequest, fmt.Sprintf("unknown tenant %q", tenantName))
+			return
+		}
+		next(w, r, tenantName)
+	}
+}
+
+// ==================== Token ====================
+
+func (ps *MockPlatformServer) createToken(w http.ResponseWriter, r *http.Request) {
+	writeMockJSON(w, http.StatusOK, map[string]any{"access_token": MockPlatformToken, "expires_in": 300})
+}
+
+// ==================== Applications ====================
+
+func (ps *MockPlatformServer) getApplications(w http.ResponseWriter, r *http.Request) {
+	appName := r.URL.Query().Get("appName")
+	applications := []map[string]any{}
+	for _, application := range ps.applications {
+		if appName == "" || application["name"] == appName {
+			applications = append(applications, application)
+		}
+	}
+	writeMockJSON(w, http.StatusOK, models.ApplicationsResponse{ApplicationDescriptors: applications, TotalRecords: len(applications)})
+}
+
+func (ps *MockPlatformServer) getApplication(w http.ResponseWriter, r *http.Request) {
+	index := ps.findApplication(r.PathValue("id"))
+	if index == -1 {
+		writeMockError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, ps.applications[index])
+}
+
+func (ps *MockPlatformServer) createApplication(w http.ResponseWriter, r *http.Request) {
+	var application map[string]any
+	if !decodeMockBody(w, r, &application) {
+		return
+	}
+	if ps.findApplication(fmt.Sprint(application["id"])) != -1 {
+		writeMockError(w, http.StatusConflict, "application already exists")
+		return
+	}
+	ps.applications = append(ps.applications, application)
+	writeMockJSON(w, http.StatusCreated, application)
+}
+
+func (ps *MockPlatformServer) removeApplication(w http.ResponseWriter, r *http.Request) {
+	index := ps.findApplication(r.PathValue("id"))
+	if index == -1 {
+		writeMockError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	ps.applications = slices.Delete(ps.applications, index, index+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ps *MockPlatformServer) findApplication(id string) int {
+	return slices.IndexFunc(ps.applications, func(application map[string]any) bool { return application["id"] == id })
+}
+
+// ==================== Module Discovery ====================
+
+func (ps *MockPlatformServer) getDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeMockJSON(w, http.StatusOK, models.ModuleDiscoveryResponse{Discovery: ps.discovery, TotalRecords: len(ps.discovery)})
+}
+
+func (ps *MockPlatformServer) createDiscovery(w http.ResponseWriter, r *http.Request) {
+	var request models.ModuleDiscoveryRequest
+	if !decodeMockBody(w, r, &request) {
+		return
+	}
+	for _, entry := range request.Discovery {
+		if slices.ContainsFunc(ps.discovery, func(existing models.ModuleDiscovery) bool { return existing.ID == entry.ID }) {
+			writeMockError(w, http.StatusConflict, fmt.Sprintf("module discovery %s already exists", entry.ID))
+			return
+		}
+	}
+	ps.discovery = append(ps.discovery, request.Discovery...)
+	writeMockJSON(w, http.StatusCreated, models.ModuleDiscoveryResponse{Discovery: request.Discovery, TotalRecords: len(request.Discovery)})
+}
+
+// ==================== Tenants ====================
+
+func (ps *MockPlatformServer) getTenants(w http.ResponseWriter, r *http.Request) {
+	criteria := parseMockQuery(r)
+	tenants := []models.Tenant{}
+	for _, tenant := range ps.tenants {
+		if matchesMockCriteria(criteria, map[string]string{"id": tenant.ID, "name": tenant.Name, "description": tenant.Description}) {
+			tenants = append(tenants, tenant)
+		}
+	}
+	writeMockJSON(w, http.StatusOK, models.TenantsResponse{Tenants: tenants})
+}
+
+func (ps *MockPlatformServer) createTenant(w http.ResponseWriter, r *http.Request) {
+	var tenant models.Tenant
+	if !decodeMockBody(w, r, &tenant) {
+		return
+	}
+	if slices.ContainsFunc(ps.tenants, func(existing models.Tenant) bool { return existing.Name == tenant.Name }) {
+		writeMockError(w, http.StatusConflict, "tenant already exists")
+		return
+	}
+	tenant.ID = ps.newID("tenant")
+	ps.tenants = append(ps.tenants, tenant)
+	writeMockJSON(w, http.StatusCreated, tenant)
+}
+
+func (ps *MockPlatformServer) removeTenant(w http.ResponseWriter, r *http.Request) {
+	index := slices.IndexFunc(ps.tenants, func(tenant models.Tenant) bool { return tenant.ID == r.PathValue("id") })
+	if index == -1 {
+		writeMockError(w, http.StatusNotFound, "tenant not found")
+		return
+	}
+	ps.tenants = slices.Delete(ps.tenants, index, index+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ==================== Entitlements ====================
+
+func (ps *MockPlatformServer) getEntitlements(w http.ResponseWriter, r *http.Request) {
+	tenantName := r.URL.Query().Get("tenant")
+	entitlements := []models.TenantEntitlementDTO{}
+	for _, entitlement := range ps.entitlements {
+		if tenantName == "" || ps.getTenantName(entitlement.TenantID) == tenantName {
+			entitlements = append(entitlements, entitlement)
+		}
+	}
+	writeMockJSON(w, http.StatusOK, models.TenantEntitlementResponse{Entitlements: entitlements, TotalRecords: len(entitlements)})
+}
+
+func (ps *MockPlatformServer) createEntitlement(w http.ResponseWriter, r *http.Request) {
+	var request models.TenantEntitlementRequest
+	if !decodeMockBody(w, r, &request) {
+		return
+	}
+	if ps.getTenantName(request.TenantID) == "" {
+		writeMockError(w, http.StatusNotFound, "tenant not found")
+		return
+	}
+	for _, applicationID := range request.Applications {
+		if ps.findApplication(applicationID) == -1 {
+			writeMockError(w, http.StatusNotFound, fmt.Sprintf("application %s not found", applicationID))
+			return
+		}
+	}
+
+	var entitlements []models.TenantEntitlementDTO
+	for _, applicationID := range request.Applications {
+		entitlements = append(entitlements, models.TenantEntitlementDTO{ApplicationID: applicationID, TenantID: request.TenantID})
+	}
+	ps.entitlements = append(ps.entitlements, entitlements...)
+	flowID := ps.newID("flow")
+	ps.entitlementFlows = append(ps.entitlementFlows, flowID)
+	writeMockJSON(w, http.StatusCreated, models.TenantEntitlementResponse{FlowID: flowID, Entitlements: entitlements, TotalRecords: len(entitlements)})
+}
+
+func (ps *MockPlatformServer) getEntitlementFlow(w http.ResponseWriter, r *http.Request) {
+	if !slices.Contains(ps.entitlementFlows, r.PathValue("id")) {
+		writeMockError(w, http.StatusNotFound, "entitlement flow not found")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, models.TenantEntitlementFlow{ID: r.PathValue("id"), Status: models.TenantEntitlementFlowFinished})
+}
+
+func (ps *MockPlatformServer) getTenantName(tenantID string) string {
+	for _, tenant := range ps.tenants {
+		if tenant.ID == tenantID {
+			return tenant.Name
+		}
+	}
+
+	return ""
+}
+
+// ==================== Roles ====================
+
+func (ps *MockPlatformServer) getRoles(w http.ResponseWriter, r *http.Request, tenantName string) {
+	criteria := parseMockQuery(r)
+	roles := []models.KeycloakRole{}
+	for _, role := range ps.roles[tenantName] {
+		if matchesMockCriteria(criteria, map[string]string{"id": role.ID, "name": role.Name}) {
+			roles = append(roles, role)
+		}
+	}
+	writeMockJSON(w, http.StatusOK, models.KeycloakRolesResponse{Roles: roles, TotalCount: len(roles)})
+}
+
+func (ps *MockPlatformServer) createRole(w http.ResponseWriter, r *http.Request, tenantName string) {
+	var role models.KeycloakRole
+	if !decodeMockBody(w, r, &role) {
+		return
+	}
+	if slices.ContainsFunc(ps.roles[tenantName], func(existing models.KeycloakRole) bool { return existing.Name == role.Name }) {
+		writeMockError(w, http.StatusConflict, "role already exists")
+		return
+	}
+	role.ID = ps.newID("role")
+	ps.roles[tenantName] = append(ps.roles[tenantName], role)
+	writeMockJSON(w, http.StatusCreated, role)
+}
+
+func (ps *MockPlatformServer) createUserRoles(w http.ResponseWriter, r *http.Request, tenantName string) {
+	var request struct {
+		UserID  string   `json:"userId"`
+		RoleIDs []string `json:"roleIds"`
+	}
+	if !decodeMockBody(w, r, &request) {
+		return
+	}
+	ps.userRoleIDs[request.UserID] = append(ps.userRoleIDs[request.UserID], request.RoleIDs...)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// ==================== Users ====================
+
+func (ps *MockPlatformServer) getUsers(w http.ResponseWriter, r *http.Request, tenantName string) {
+	criteria := parseMockQuery(r)
+	users := []models.KeycloakUser{}
+	for _, user := range ps.users[tenantName] {
+		if matchesMockCriteria(criteria, map[string]string{"id": user.ID, "username": user.Username}) {
+			users = append(users, user)
+		}
+	}
+	writeMockJSON(w, http.StatusOK, models.KeycloakUsersResponse{Users: users, TotalRecords: len(users)})
+}
+
+func (ps *MockPlatformServer) createUser(w http.ResponseWriter, r *http.Request, tenantName string) {
+	var user models.KeycloakUser
+	if !decodeMockBody(w, r, &user) {
+		return
+	}
+	if slices.ContainsFunc(ps.users[tenantName], func(existing models.KeycloakUser) bool { return existing.Username == user.Username }) {
+		writeMockError(w, http.StatusConflict, "user already exists")
+		return
+	}
+	user.ID = ps.newID("user")
+	ps.users[tenantName] = append(ps.users[tenantName], user)
+	writeMockJSON(w, http.StatusCreated, user)
+}
+
+func (ps *MockPlatformServer) createCredentials(w http.ResponseWriter, r *http.Request, tenantName string) {
+	var request struct {
+		UserID   string `json:"userId"`
+		Password string `json:"password"`
+	}
+	if !decodeMockBody(w, r, &request) {
+		return
+	}
+	ps.passwords[request.UserID] = request.Password
+	w.WriteHeader(http.StatusCreated)
+}
+
+// ==================== Capability Sets ====================
+
+func (ps *MockPlatformServer) getCapabilitySets(w http.ResponseWriter, r *http.Request, tenantName string) {
+	criteria := parseMockQuery(r)
+	capabilitySets := []models.KeycloakCapabilitySet{}
+	for _, capabilitySet := range ps.capabilitySets {
+		if matchesMockCriteria(criteria, map[string]string{"id": capabilitySet.ID, "name": capabilitySet.Name, "applicationId": capabilitySet.ApplicationID}) {
+			capabilitySets = append(capabilitySets, capabilitySet)
+		}
+	}
+	writeMockJSON(w, http.StatusOK, models.KeycloakCapabilitySetsResponse{CapabilitySets: capabilitySets, TotalCount: len(capabilitySets)})
+}
+
+func (ps *MockPlatformServer) getRoleCapabilitySets(w http.ResponseWriter, r *http.Request, tenantName string) {
+	capabilitySets := []models.KeycloakCapabilitySet{}
+	for _, capabilitySet := range ps.capabilitySets {
+		if slices.Contains(ps.roleCapabilitySets[r.PathValue("id")], capabilitySet.ID) {
+			capabilitySets = append(capabilitySets, capabilitySet)
+		}
+	}
+	writeMockJSON(w, http.StatusOK, models.KeycloakCapabilitySetsResponse{CapabilitySets: capabilitySets, TotalCount: len(capabilitySets)})
+}
+
+func (ps *MockPlatformServer) createRoleCapabilitySets(w http.ResponseWriter, r *http.Request, tenantName string) {
+	var request struct {
+		RoleID           string   `json:"roleId"`
+		CapabilitySetIDs []string `json:"capabilitySetIds"`
+	}
+	if !decodeMockBody(w, r, &request) {
+		return
+	}
+	ps.roleCapabilitySets[request.RoleID] = append(ps.roleCapabilitySets[request.RoleID], request.CapabilitySetIDs...)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// ==================== Helpers ====================
+
+func (ps *MockPlatformServer) newID(prefix string) string {
+	ps.nextID++
+	return fmt.Sprintf("%s-%d", prefix, ps.nextID)
+}
+
+// parseMockQuery extracts the field==value pairs of a CQL query parameter, other CQL clauses match everything
+func parseMockQuery(r *http.Request) map[string]string {
+	criteria := map[string]string{}
+	for _, match := range cqlEquality.FindAllStringSubmatch(r.URL.Query().Get("query"), -1) {
+		criteria[match[1]] = match[2]
+	}
+
+	return criteria
+}
+
+func matchesMockCriteria(criteria map[string]string, fields map[string]string) bool {
+	for name, value := range criteria {
+		if fields[name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodeMockBody(w http.ResponseWriter, r *http.Request, target any) bool {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		writeMockError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeMockJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set(constant.ContentTypeHeader, constant.ApplicationJSON)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeMockError(w http.ResponseWriter, statusCode int, message string) {
+	writeMockJSON(w, statusCode, map[string]any{"errors": []map[string]string{{"message": message}}})
+}
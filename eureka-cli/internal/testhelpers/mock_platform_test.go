@@ -0,0 +1,150 @@
+package testhelpers_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
+	"github.com/folio-org/eureka-setup/eureka-cli/httpclient"
+	"github.com/folio-org/eureka-setup/eureka-cli/internal/testhelpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/keycloaksvc"
+	"github.com/folio-org/eureka-setup/eureka-cli/managementsvc"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
+	"github.com/folio-org/eureka-setup/eureka-cli/tenantsvc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockPlatformAction(t *testing.T, server *testhelpers.MockPlatformServer) *action.Action {
+	t.Helper()
+	mockAction := testhelpers.NewMockAction()
+	require.NoError(t, mockAction.SetBaseURL(server.URL()))
+	mockAction.ConfigApplicationName = "app-test"
+	mockAction.ConfigApplicationVersion = "1.0.0"
+	mockAction.ConfigApplicationID = "app-test-1.0.0"
+	mockAction.ConfigTenants = map[string]any{"diku": map[string]any{}}
+	mockAction.ConfigRoles = map[string]any{
+		"admin": map[string]any{field.RolesTenantEntry: "diku", field.RolesCapabilitySetsEntry: []any{"all"}},
+	}
+	mockAction.ConfigUsers = map[string]any{
+		"diku_admin": map[string]any{
+			field.UsersTenantEntry:   "diku",
+			field.UsersPasswordEntry: "admin",
+			field.UsersRolesEntry:    []any{"admin"},
+		},
+	}
+
+	return mockAction
+}
+
+func newMockPlatformRegistryExtract() *models.RegistryExtract {
+	version := "19.0.0"
+	return &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{ID: "mod-users-19.0.0", Metadata: models.ProxyModuleMetadata{Name: "mod-users", SidecarName: "mod-users-sc", Version: &version}},
+			},
+		},
+		BackendModules: map[string]models.BackendModule{"mod-users": {DeployModule: true, PrivatePort: 8081}},
+	}
+}
+
+// runMockPlatformPipeline registers the application, creates and entitles the tenants,
+// then creates the roles and users and attaches the capability sets of the roles
+func runMockPlatformPipeline(t *testing.T, mockAction *action.Action) {
+	t.Helper()
+	httpClient := httpclient.New(mockAction, slog.Default())
+	managementSvc := managementsvc.New(mockAction, httpClient, tenantsvc.New(mockAction, nil))
+	keycloakSvc := keycloaksvc.New(mockAction, httpClient, nil, managementSvc)
+
+	accessToken, err := keycloakSvc.GetMasterAccessToken(constant.ClientCredentials)
+	require.NoError(t, err)
+	mockAction.KeycloakMasterAccessToken = accessToken
+	mockAction.KeycloakAccessToken = accessToken
+
+	require.NoError(t, managementSvc.CreateApplication(newMockPlatformRegistryExtract()))
+	require.NoError(t, managementSvc.CreateTenants())
+	require.NoError(t, managementSvc.CreateTenantEntitlement(constant.NoneConsortium, constant.Default))
+	require.NoError(t, keycloakSvc.CreateRoles("diku"))
+	require.NoError(t, keycloakSvc.CreateUsers("diku"))
+	_, err = keycloakSvc.AttachCapabilitySetsToRoles("diku")
+	require.NoError(t, err)
+}
+
+func TestMockPlatformServer_DeployPipeline(t *testing.T) {
+	// Arrange
+	server := testhelpers.NewMockPlatformServer()
+	defer server.Close()
+	server.AddCapabilitySets("app-test-1.0.0", "users.all", "users.view")
+	mockAction := newMockPlatformAction(t, server)
+
+	// Act
+	runMockPlatformPipeline(t, mockAction)
+
+	// Assert
+	applications := server.Applications()
+	if !assert.Len(t, applications, 1) {
+		return
+	}
+	assert.Equal(t, "app-test-1.0.0", applications[0]["id"])
+	assert.Equal(t, []models.ModuleDiscovery{
+		{ID: "mod-users-19.0.0", Name: "mod-users", Version: "19.0.0", Location: "http://mod-users-sc.eureka:8081"},
+	}, server.Discovery())
+
+	tenants := server.Tenants()
+	if !assert.Len(t, tenants, 1) {
+		return
+	}
+	assert.Equal(t, "diku", tenants[0].Name)
+	assert.Equal(t, []models.TenantEntitlementDTO{{ApplicationID: "app-test-1.0.0", TenantID: tenants[0].ID}}, server.Entitlements())
+
+	roles := server.Roles("diku")
+	users := server.Users("diku")
+	if !assert.Len(t, roles, 1) || !assert.Len(t, users, 1) {
+		return
+	}
+	assert.Equal(t, "admin", roles[0].Name)
+	assert.Equal(t, "diku_admin", users[0].Username)
+	assert.Equal(t, "admin", server.Password(users[0].ID))
+	assert.Equal(t, []string{roles[0].ID}, server.UserRoleIDs(users[0].ID))
+	assert.Len(t, server.RoleCapabilitySetIDs(roles[0].ID), 2)
+}
+
+func TestMockPlatformServer_DeployPipelineIsRepeatable(t *testing.T) {
+	// Arrange
+	server := testhelpers.NewMockPlatformServer()
+	defer server.Close()
+	server.AddCapabilitySets("app-test-1.0.0", "users.all", "users.view")
+	mockAction := newMockPlatformAction(t, server)
+	runMockPlatformPipeline(t, mockAction)
+
+	// Act
+	runMockPlatformPipeline(t, mockAction)
+
+	// Assert
+	assert.Len(t, server.Applications(), 1)
+	assert.Len(t, server.Discovery(), 1)
+	assert.Len(t, server.Tenants(), 1)
+	assert.Len(t, server.Entitlements(), 1)
+	assert.Len(t, server.Roles("diku"), 1)
+	assert.Len(t, server.Users("diku"), 1)
+	assert.Len(t, server.RoleCapabilitySetIDs(server.Roles("diku")[0].ID), 2)
+}
+
+func TestMockPlatformServer_RejectsMissingToken(t *testing.T) {
+	// Arrange
+	server := testhelpers.NewMockPlatformServer()
+	defer server.Close()
+	mockAction := newMockPlatformAction(t, server)
+	mockAction.KeycloakMasterAccessToken = "invalid-token"
+	managementSvc := managementsvc.New(mockAction, httpclient.New(mockAction, slog.Default()), tenantsvc.New(mockAction, nil))
+
+	// Act
+	err := managementSvc.CreateTenants()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, server.Tenants())
+}
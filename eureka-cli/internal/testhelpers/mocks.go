@@ -227,8 +227,8 @@ type MockTenantSvc struct {
 	mock.Mock
 }
 
-func (m *MockTenantSvc) GetEntitlementTenantParameters(consortiumName string) (string, error) {
-	args := m.Called(consortiumName)
+func (m *MockTenantSvc) GetEntitlementTenantParameters(consortiumName string, tenantName string) (string, error) {
+	args := m.Called(consortiumName, tenantName)
 	return args.String(0), args.Error(1)
 }
 
@@ -1,9 +1,12 @@
 package kafkasvc
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"log/slog"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -13,12 +16,15 @@ import (
 	"github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/execsvc"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+	"github.com/folio-org/eureka-setup/eureka-cli/models"
 )
 
 // KafkaProcessor defines the interface for Kafka service operations
 type KafkaProcessor interface {
 	CheckBrokerReadiness() error
 	PollConsumerGroup(tenantName string) error
+	GetConsumerGroup() string
+	DescribeConsumerGroup(consumerGroup string, native bool) ([]models.ConsumerGroupPartition, error)
 }
 
 // KafkaSvc provides functionality for Kafka operations including health checks and consumer lag monitoring
@@ -30,6 +36,8 @@ type KafkaSvc struct {
 	RebalanceWait    time.Duration
 	PollWait         time.Duration
 	TimeoutWait      time.Duration
+	TopicMaxRetries  int
+	TopicWait        time.Duration
 }
 
 // New creates a new KafkaSvc instance
@@ -60,7 +68,10 @@ func (ks *KafkaSvc) PollConsumerGroup(tenantName string) error {
 		slog.Warn(ks.Action.Name, "text", "Broker is not fully ready", "error", err)
 	}
 
-	consumerGroup := fmt.Sprintf("%s-%s", ks.Action.ConfigEnvFolio, constant.ConsumerGroupSuffix)
+	consumerGroup := ks.GetConsumerGroup()
+	if err := ks.waitForTopicAndConsumerGroup(tenantName, consumerGroup); err != nil {
+		return err
+	}
 	slog.Info(ks.Action.Name, "text", "Polling consumer group", "consumerGroup", consumerGroup, "tenant", tenantName)
 
 	var lag int
@@ -95,12 +106,72 @@ func (ks *KafkaSvc) PollConsumerGroup(tenantName string) error {
 	return errors.ConsumerGroupPollTimeout(consumerGroup, pollMaxRetries)
 }
 
+// GetConsumerGroup returns the capability consumer group name prefixed with the folio environment,
+// the suffix is taken from the config and falls back to the mod-roles-keycloak default
+func (ks *KafkaSvc) GetConsumerGroup() string {
+	suffix := ks.Action.ConfigKafkaConsumerGroupSuffix
+	if suffix == "" {
+		suffix = constant.ConsumerGroupSuffix
+	}
+
+	return fmt.Sprintf("%s-%s", ks.Action.ConfigEnvFolio, suffix)
+}
+
+// GetCapabilityTopic returns the capability topic name of a tenant prefixed with the folio environment
+func (ks *KafkaSvc) GetCapabilityTopic(tenantName string) string {
+	return fmt.Sprintf("%s.%s.%s", ks.Action.ConfigEnvFolio, tenantName, constant.CapabilityTopic)
+}
+
+// waitForTopicAndConsumerGroup blocks until both the capability topic and the consumer group are listed by the broker,
+// describing the lag of a missing topic or group yields empty output that would otherwise be read as zero lag
+func (ks *KafkaSvc) waitForTopicAndConsumerGroup(tenantName string, consumerGroup string) error {
+	topic := ks.GetCapabilityTopic(tenantName)
+	maxRetries := helpers.DefaultInt(ks.TopicMaxRetries, constant.KafkaTopicMaxRetries)
+	topicWait := helpers.DefaultDuration(ks.TopicWait, constant.KafkaTopicWait)
+
+	var missing []string
+	for retryCount := range maxRetries {
+		missing = nil
+		if !ks.listContains("kafka-topics.sh", topic) {
+			missing = append(missing, topic)
+		}
+		if !ks.listContains("kafka-consumer-groups.sh", consumerGroup) {
+			missing = append(missing, consumerGroup)
+		}
+		if len(missing) == 0 {
+			slog.Info(ks.Action.Name, "text", "Topic and consumer group exist", "topic", topic, "consumerGroup", consumerGroup)
+			return nil
+		}
+
+		slog.Warn(ks.Action.Name, "text", "Waiting for Kafka resources to appear", "missing", missing, "count", retryCount, "max", maxRetries)
+		time.Sleep(helpers.AddJitter(topicWait, constant.PollJitterFraction))
+	}
+
+	return errors.KafkaTopicWaitTimeout(missing, maxRetries)
+}
+
+func (ks *KafkaSvc) listContains(script string, name string) bool {
+	kafkaCmd := fmt.Sprintf("timeout 30s %s --bootstrap-server %s --list", script, constant.KafkaTCP)
+	stdout, stderr, err := ks.ExecSvc.ExecReturnOutput(exec.Command("docker", "exec", "-i", "kafka-tools", "bash", "-c", kafkaCmd))
+	if err != nil {
+		slog.Warn(ks.Action.Name, "text", "Failed to list Kafka resources", "script", script, "error", err, "stderr", stderr.String())
+		return false
+	}
+
+	for line := range strings.SplitSeq(stdout.String(), "\n") {
+		if strings.TrimSpace(line) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (ks *KafkaSvc) getConsumerGroupLag(tenant string, consumerGroup string, initialLag int) (lag int, err error) {
 	rebalanceWait := helpers.DefaultDuration(ks.RebalanceWait, constant.AttachCapabilitySetsRebalanceWait)
 	timeoutWait := helpers.DefaultDuration(ks.TimeoutWait, constant.AttachCapabilitySetsTimeoutWait)
 
-	kafkaCmd := fmt.Sprintf("timeout 30s kafka-consumer-groups.sh --bootstrap-server %s --describe --group %s | grep %s | awk '{print $6}'", constant.KafkaTCP, consumerGroup, tenant)
-	stdout, stderr, err := ks.ExecSvc.ExecReturnOutput(exec.Command("docker", "exec", "-i", "kafka-tools", "bash", "-c", kafkaCmd))
+	stdout, stderr, err := ks.ExecSvc.ExecReturnOutput(newDescribeCommand(context.Background(), consumerGroup, false))
 	if err != nil {
 		return initialLag, err
 	}
@@ -120,11 +191,108 @@ func (ks *KafkaSvc) getConsumerGroupLag(tenant string, consumerGroup string, ini
 		return initialLag, errors.ContainerCommandFailed(stderrText)
 	}
 
-	lag, err = strconv.Atoi(helpers.GetKafkaConsumerLagFromLogLine(stdout))
-	if err != nil {
-		slog.Error(ks.Action.Name, "error", err)
-		return initialLag, nil
+	topic := ks.GetCapabilityTopic(tenant)
+	for _, partition := range parseConsumerGroupDescribe(stdout.String()) {
+		if partition.Topic != topic || partition.Lag == nil {
+			continue
+		}
+		lag += int(*partition.Lag)
 	}
 
 	return lag, nil
 }
+
+// DescribeConsumerGroup returns the partitions of a consumer group with their offsets, lag and assigned member
+func (ks *KafkaSvc) DescribeConsumerGroup(consumerGroup string, native bool) ([]models.ConsumerGroupPartition, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constant.ContextTimeoutKafkaCommand)
+	defer cancel()
+
+	stdout, stderr, err := ks.ExecSvc.ExecReturnOutput(newDescribeCommand(ctx, consumerGroup, native))
+	if err != nil {
+		return nil, errors.ConsumerGroupDescribeFailed(consumerGroup, stderr.String(), err)
+	}
+	if stderr.Len() > 0 {
+		stderrText := strings.TrimSpace(stderr.String())
+		if !strings.Contains(stderrText, constant.ErrNoActiveMembers) && !strings.Contains(stderrText, constant.ErrRebalancing) {
+			return nil, errors.ConsumerGroupDescribeFailed(consumerGroup, stderrText, nil)
+		}
+		slog.Warn(ks.Action.Name, "text", "Consumer group is not stable", "consumerGroup", consumerGroup, "stderr", stderrText)
+	}
+
+	return parseConsumerGroupDescribe(stdout.String()), nil
+}
+
+// newDescribeCommand builds the consumer group describe command, it runs either in the kafka-tools container against the
+// internal listener or with the Kafka CLI installed on the host against the listener published to the host
+func newDescribeCommand(ctx context.Context, consumerGroup string, native bool) *exec.Cmd {
+	if native {
+		return exec.CommandContext(ctx, constant.KafkaConsumerGroupsScript, "--bootstrap-server", constant.KafkaExternalTCP, "--describe", "--group", consumerGroup)
+	}
+	kafkaCmd := fmt.Sprintf("timeout 30s %s --bootstrap-server %s --describe --group %s", constant.KafkaConsumerGroupsScript, constant.KafkaTCP, consumerGroup)
+
+	return exec.Command("docker", "exec", "-i", constant.KafkaToolsContainer, "bash", "-c", kafkaCmd)
+}
+
+// parseConsumerGroupDescribe parses the describe output by its header columns, so it tolerates
+// column order changes between Kafka versions and output split into several blocks
+func parseConsumerGroupDescribe(output string) []models.ConsumerGroupPartition {
+	var (
+		header     map[string]int
+		partitions []models.ConsumerGroupPartition
+	)
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "GROUP" {
+			header = make(map[string]int, len(fields))
+			for i, name := range fields {
+				header[name] = i
+			}
+			continue
+		}
+		if header == nil || len(fields) < len(header) {
+			continue
+		}
+
+		partition, err := strconv.Atoi(describeColumn(fields, header, "PARTITION"))
+		if err != nil {
+			continue
+		}
+		partitions = append(partitions, models.ConsumerGroupPartition{
+			Group:         describeColumn(fields, header, "GROUP"),
+			Topic:         describeColumn(fields, header, "TOPIC"),
+			Partition:     partition,
+			CurrentOffset: parseOffset(describeColumn(fields, header, "CURRENT-OFFSET")),
+			LogEndOffset:  parseOffset(describeColumn(fields, header, "LOG-END-OFFSET")),
+			Lag:           parseOffset(describeColumn(fields, header, "LAG")),
+			ConsumerID:    describeColumn(fields, header, "CONSUMER-ID"),
+			Host:          describeColumn(fields, header, "HOST"),
+			ClientID:      describeColumn(fields, header, "CLIENT-ID"),
+		})
+	}
+	slices.SortFunc(partitions, func(a, b models.ConsumerGroupPartition) int {
+		return cmp.Or(cmp.Compare(a.Topic, b.Topic), cmp.Compare(a.Partition, b.Partition))
+	})
+
+	return partitions
+}
+
+func describeColumn(fields []string, header map[string]int, name string) string {
+	i, ok := header[name]
+	if !ok || i >= len(fields) || fields[i] == "-" {
+		return ""
+	}
+
+	return fields[i]
+}
+
+func parseOffset(value string) *int64 {
+	offset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &offset
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os/exec"
+	"strings"
 	"testing"
 	"time"
 
@@ -131,6 +132,10 @@ func TestPollConsumerGroup_ZeroLag(t *testing.T) {
 	stderr := bytes.NewBuffer(nil)
 	mockExec.On("ExecReturnOutput", mock.Anything).Return(*stdout, *stderr, nil).Once()
 
+	// Mock topic and consumer group listings
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*bytes.NewBufferString("test-env.diku.mgr-tenant-entitlements.capability\n"), *bytes.NewBuffer(nil), nil).Once()
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*bytes.NewBufferString("test-env-mod-roles-keycloak-capability-group\n"), *bytes.NewBuffer(nil), nil).Once()
+
 	// Mock getConsumerGroupLag returning 0
 	lagStdout := bytes.NewBufferString("0\n")
 	lagStderr := bytes.NewBuffer(nil)
@@ -144,6 +149,126 @@ func TestPollConsumerGroup_ZeroLag(t *testing.T) {
 	mockExec.AssertExpectations(t)
 }
 
+func TestPollConsumerGroup_CustomConsumerGroupSuffix(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	action.ConfigEnvFolio = "test-env"
+	action.ConfigKafkaConsumerGroupSuffix = "custom-capability-group"
+	mockExec := new(testhelpers.MockCommandExecutor)
+	svc := New(action, mockExec)
+	svc.PollMaxRetries = 1
+
+	stdout := bytes.NewBufferString("broker ready")
+	stderr := bytes.NewBuffer(nil)
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*stdout, *stderr, nil).Once()
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*bytes.NewBufferString("test-env.diku.mgr-tenant-entitlements.capability\n"), *bytes.NewBuffer(nil), nil).Once()
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*bytes.NewBufferString("test-env-custom-capability-group\n"), *bytes.NewBuffer(nil), nil).Once()
+
+	lagStdout := bytes.NewBufferString("0\n")
+	lagStderr := bytes.NewBuffer(nil)
+	mockExec.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return strings.HasSuffix(strings.Join(cmd.Args, " "), "--group test-env-custom-capability-group")
+	})).Return(*lagStdout, *lagStderr, nil).Once()
+
+	// Act
+	err := svc.PollConsumerGroup("diku")
+
+	// Assert
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetConsumerGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		suffix   string
+		expected string
+	}{
+		{"default suffix", "", "folio-mod-roles-keycloak-capability-group"},
+		{"custom suffix", "custom-group", "folio-custom-group"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			action := testhelpers.NewMockAction()
+			action.ConfigEnvFolio = "folio"
+			action.ConfigKafkaConsumerGroupSuffix = tt.suffix
+			svc := New(action, new(testhelpers.MockCommandExecutor))
+
+			// Act
+			result := svc.GetConsumerGroup()
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPollConsumerGroup_TopicNeverAppears(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	action.ConfigEnvFolio = "test-env"
+	mockExec := new(testhelpers.MockCommandExecutor)
+	svc := New(action, mockExec)
+	svc.TopicMaxRetries = 2
+	svc.TopicWait = 1 * time.Millisecond
+
+	mockExec.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return strings.Contains(strings.Join(cmd.Args, " "), "kafka-broker-api-versions.sh")
+	})).Return(*bytes.NewBufferString("broker ready"), *bytes.NewBuffer(nil), nil).Once()
+	mockExec.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return strings.Contains(strings.Join(cmd.Args, " "), "kafka-topics.sh")
+	})).Return(*bytes.NewBufferString("__consumer_offsets\n"), *bytes.NewBuffer(nil), nil).Times(2)
+	mockExec.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return strings.Contains(strings.Join(cmd.Args, " "), "kafka-consumer-groups.sh --bootstrap-server kafka.eureka:9092 --list")
+	})).Return(*bytes.NewBufferString("test-env-mod-roles-keycloak-capability-group\n"), *bytes.NewBuffer(nil), nil).Times(2)
+
+	// Act
+	err := svc.PollConsumerGroup("diku")
+
+	// Assert
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrTimeout)
+	assert.Contains(t, err.Error(), "test-env.diku.mgr-tenant-entitlements.capability")
+	mockExec.AssertExpectations(t)
+}
+
+func TestWaitForTopicAndConsumerGroup_AppearsAfterRetry(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	action.ConfigEnvFolio = "folio"
+	mockExec := new(testhelpers.MockCommandExecutor)
+	svc := New(action, mockExec)
+	svc.TopicMaxRetries = 3
+	svc.TopicWait = 1 * time.Millisecond
+
+	topics := "folio.diku.mgr-tenant-entitlements.capability\n"
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*bytes.NewBufferString(topics), *bytes.NewBuffer(nil), nil).Once()
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*bytes.NewBuffer(nil), *bytes.NewBufferString("TimeoutException"), fmt.Errorf("exit status 1")).Once()
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*bytes.NewBufferString(topics), *bytes.NewBuffer(nil), nil).Once()
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*bytes.NewBufferString("folio-mod-roles-keycloak-capability-group\n"), *bytes.NewBuffer(nil), nil).Once()
+
+	// Act
+	err := svc.waitForTopicAndConsumerGroup("diku", "folio-mod-roles-keycloak-capability-group")
+
+	// Assert
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetCapabilityTopic(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	action.ConfigEnvFolio = "folio"
+	svc := New(action, new(testhelpers.MockCommandExecutor))
+
+	// Act
+	result := svc.GetCapabilityTopic("diku")
+
+	// Assert
+	assert.Equal(t, "folio.diku.mgr-tenant-entitlements.capability", result)
+}
+
 func TestPollConsumerGroup_LagDecreases(t *testing.T) {
 	t.Skip("Skipping complex mock scenario - basic flow covered in TestPollConsumerGroup_ZeroLag")
 }
@@ -162,13 +287,22 @@ func TestGetConsumerGroupLag_Success(t *testing.T) {
 	mockExec := new(testhelpers.MockCommandExecutor)
 	svc := New(action, mockExec)
 
+	action.ConfigEnvFolio = "test-env"
 	tenantName := "diku"
 	consumerGroup := "test-env-consumer-group"
 	initialLag := 0
 
-	lagStdout := bytes.NewBufferString("42\n")
+	lagStdout := bytes.NewBufferString(`
+GROUP                    TOPIC                                              PARTITION  CURRENT-OFFSET  LOG-END-OFFSET  LAG  CONSUMER-ID  HOST  CLIENT-ID
+test-env-consumer-group  test-env.diku.mgr-tenant-entitlements.capability   0          10              50              40   consumer-1   /h    client-1
+test-env-consumer-group  test-env.diku.mgr-tenant-entitlements.capability   1          3               5               2    consumer-1   /h    client-1
+test-env-consumer-group  test-env.diku2.mgr-tenant-entitlements.capability  0          1               8               7    consumer-1   /h    client-1
+test-env-consumer-group  test-env.diku.mgr-tenant-entitlements.capability   2          -               4               -    -            -     -
+`)
 	lagStderr := bytes.NewBuffer(nil)
-	mockExec.On("ExecReturnOutput", mock.Anything).Return(*lagStdout, *lagStderr, nil).Once()
+	mockExec.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return cmd.Args[0] == "docker" && strings.HasSuffix(cmd.Args[6], "--describe --group test-env-consumer-group")
+	})).Return(*lagStdout, *lagStderr, nil).Once()
 
 	// Act
 	lag, err := svc.getConsumerGroupLag(tenantName, consumerGroup, initialLag)
@@ -252,6 +386,37 @@ func TestGetConsumerGroupLag_Rebalancing(t *testing.T) {
 	mockExec.AssertExpectations(t)
 }
 
+func TestGetConsumerGroupLag_TransientErrorsWithCustomGroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+	}{
+		{"no active members", "Consumer group 'dev-custom-capability-group' has no active members."},
+		{"rebalancing", "Consumer group 'dev-custom-capability-group' is rebalancing."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			action := testhelpers.NewMockAction()
+			mockExec := new(testhelpers.MockCommandExecutor)
+			svc := New(action, mockExec)
+			svc.RebalanceWait = 1 * time.Millisecond
+
+			lagStdout := bytes.NewBuffer(nil)
+			lagStderr := bytes.NewBufferString(tt.stderr)
+			mockExec.On("ExecReturnOutput", mock.Anything).Return(*lagStdout, *lagStderr, nil).Once()
+
+			// Act
+			lag, err := svc.getConsumerGroupLag("diku", "dev-custom-capability-group", 10)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, 10, lag)
+			mockExec.AssertExpectations(t)
+		})
+	}
+}
+
 func TestGetConsumerGroupLag_TimeoutException(t *testing.T) {
 	// Arrange
 	action := testhelpers.NewMockAction()
@@ -320,6 +485,107 @@ func TestGetConsumerGroupLag_InvalidLagValue(t *testing.T) {
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, 0, lag) // GetKafkaConsumerLagFromLogLine returns "0" for invalid input, strconv.Atoi succeeds
+	assert.Equal(t, 0, lag) // output without a describe header yields no partitions and thus no lag
 	mockExec.AssertExpectations(t)
 }
+
+func TestDescribeConsumerGroup_DockerExec(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	mockExec := new(testhelpers.MockCommandExecutor)
+	svc := New(action, mockExec)
+
+	stdout := bytes.NewBufferString(`
+GROUP          TOPIC                  PARTITION  CURRENT-OFFSET  LOG-END-OFFSET  LAG  CONSUMER-ID  HOST         CLIENT-ID
+capability-grp folio.diku.capability  1          -               5               -    -            -            -
+capability-grp folio.diku.capability  0          10              12              2    consumer-1   /172.18.0.5  client-1
+`)
+	stderr := bytes.NewBuffer(nil)
+
+	mockExec.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return cmd.Args[0] == "docker" && cmd.Args[3] == "kafka-tools" &&
+			strings.Contains(cmd.Args[6], "kafka-consumer-groups.sh --bootstrap-server kafka.eureka:9092 --describe --group capability-grp")
+	})).Return(*stdout, *stderr, nil).Once()
+
+	// Act
+	partitions, err := svc.DescribeConsumerGroup("capability-grp", false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, partitions, 2)
+	assert.Equal(t, 0, partitions[0].Partition)
+	assert.Equal(t, int64(10), *partitions[0].CurrentOffset)
+	assert.Equal(t, int64(12), *partitions[0].LogEndOffset)
+	assert.Equal(t, int64(2), *partitions[0].Lag)
+	assert.Equal(t, "consumer-1", partitions[0].ConsumerID)
+	assert.Equal(t, "client-1", partitions[0].ClientID)
+	assert.Equal(t, 1, partitions[1].Partition)
+	assert.Nil(t, partitions[1].CurrentOffset)
+	assert.Equal(t, int64(5), *partitions[1].LogEndOffset)
+	assert.Empty(t, partitions[1].ConsumerID)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDescribeConsumerGroup_NativeNoActiveMembers(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	mockExec := new(testhelpers.MockCommandExecutor)
+	svc := New(action, mockExec)
+
+	stdout := bytes.NewBufferString("GROUP TOPIC PARTITION CURRENT-OFFSET LOG-END-OFFSET LAG CONSUMER-ID HOST CLIENT-ID\ngrp topic 0 3 3 0 - - -\n")
+	stderr := bytes.NewBufferString("Consumer group 'grp' has no active members.")
+
+	mockExec.On("ExecReturnOutput", mock.MatchedBy(func(cmd *exec.Cmd) bool {
+		return strings.HasSuffix(cmd.Args[0], "kafka-consumer-groups.sh") &&
+			strings.Join(cmd.Args[1:], " ") == "--bootstrap-server localhost:29092 --describe --group grp"
+	})).Return(*stdout, *stderr, nil).Once()
+
+	// Act
+	partitions, err := svc.DescribeConsumerGroup("grp", true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, partitions, 1)
+	assert.Equal(t, int64(0), *partitions[0].Lag)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDescribeConsumerGroup_StderrFails(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	mockExec := new(testhelpers.MockCommandExecutor)
+	svc := New(action, mockExec)
+
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBufferString("Error: Consumer group 'grp' does not exist.")
+	mockExec.On("ExecReturnOutput", mock.Anything).Return(*stdout, *stderr, nil).Once()
+
+	// Act
+	partitions, err := svc.DescribeConsumerGroup("grp", false)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+	assert.Nil(t, partitions)
+}
+
+func TestParseConsumerGroupDescribe_IgnoresNoiseAndMultipleBlocks(t *testing.T) {
+	// Arrange
+	output := `Consumer group 'grp' has no active members.
+
+GROUP TOPIC PARTITION CURRENT-OFFSET LOG-END-OFFSET LAG CONSUMER-ID HOST CLIENT-ID
+grp   b     0         1              1              0   -           -    -
+
+GROUP TOPIC PARTITION CURRENT-OFFSET LOG-END-OFFSET LAG CONSUMER-ID HOST CLIENT-ID
+grp   a     0         4              9              5   -           -    -
+`
+
+	// Act
+	partitions := parseConsumerGroupDescribe(output)
+
+	// Assert
+	assert.Len(t, partitions, 2)
+	assert.Equal(t, "a", partitions[0].Topic)
+	assert.Equal(t, int64(5), *partitions[0].Lag)
+	assert.Equal(t, "b", partitions[1].Topic)
+}
@@ -28,9 +28,11 @@ type KeycloakProcessor interface {
 // KeycloakAdminManager defines the interface for Keycloak admin operations
 type KeycloakAdminManager interface {
 	GetAccessToken(tenantName string) (string, error)
+	VerifyUserLogin(tenantName string, username string, password string) error
 	GetMasterAccessToken(grantType constant.KeycloakGrantType) (string, error)
 	UpdateRealmAccessTokenSettings(tenantName string, lifespan int) error
 	UpdatePublicClientSettings(tenantName string, url string) error
+	GetRealmReport(tenantName string) (*models.KeycloakRealmReport, error)
 }
 
 // KeycloakSvc provides functionality for Keycloak operations including user and role management
@@ -50,29 +52,49 @@ func New(action *action.Action,
 }
 
 func (ks *KeycloakSvc) GetAccessToken(tenantName string) (string, error) {
-	client, err := ks.VaultClient.Create()
+	clientID, secrets, err := ks.getServiceClientSecrets(tenantName)
 	if err != nil {
 		return "", err
 	}
+	systemUser := fmt.Sprintf("%s-system-user", tenantName)
+
+	return ks.getPasswordGrantAccessToken(tenantName, clientID, helpers.GetString(secrets, clientID), systemUser, helpers.GetString(secrets, systemUser))
+}
+
+// VerifyUserLogin logs a user in with the password grant of the tenant service client, the access token is discarded
+func (ks *KeycloakSvc) VerifyUserLogin(tenantName string, username string, password string) error {
+	clientID, secrets, err := ks.getServiceClientSecrets(tenantName)
+	if err != nil {
+		return err
+	}
+	_, err = ks.getPasswordGrantAccessToken(tenantName, clientID, helpers.GetString(secrets, clientID), username, password)
+
+	return err
+}
+
+func (ks *KeycloakSvc) getServiceClientSecrets(tenantName string) (string, map[string]any, error) {
+	client, err := ks.VaultClient.Create()
+	if err != nil {
+		return "", nil, err
+	}
 
 	secrets, err := ks.VaultClient.GetSecretKey(context.Background(), client, ks.Action.VaultRootToken, fmt.Sprintf("folio/%s", tenantName))
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	clientID := action.GetConfigEnv("KC_SERVICE_CLIENT_ID", ks.Action.ConfigGlobalEnv)
-	clientSecret := helpers.GetString(secrets, clientID)
-	systemUser := fmt.Sprintf("%s-system-user", tenantName)
-	systemUserPassword := helpers.GetString(secrets, systemUser)
+	return action.GetConfigEnv("KC_SERVICE_CLIENT_ID", ks.Action.ConfigGlobalEnv), secrets, nil
+}
 
+func (ks *KeycloakSvc) getPasswordGrantAccessToken(tenantName, clientID, clientSecret, username, password string) (string, error) {
 	formData := url.Values{}
 	formData.Set("grant_type", constant.Password)
 	formData.Set("client_id", clientID)
 	formData.Set("client_secret", clientSecret)
-	formData.Set("username", systemUser)
-	formData.Set("password", systemUserPassword)
+	formData.Set("username", username)
+	formData.Set("password", password)
 
-	requestURL := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", constant.KeycloakHTTP, tenantName)
+	requestURL := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", ks.Action.GetKeycloakURL(), tenantName)
 	headers := helpers.ApplicationFormURLEncodedHeaders()
 
 	var tokenData map[string]any
@@ -100,7 +122,7 @@ func (ks *KeycloakSvc) GetMasterAccessToken(grantType constant.KeycloakGrantType
 		formData.Set("username", constant.KeycloakAdminUsername)
 		formData.Set("password", constant.KeycloakAdminPassword)
 	}
-	requestURL := fmt.Sprintf("%s/realms/master/protocol/openid-connect/token", constant.KeycloakHTTP)
+	requestURL := fmt.Sprintf("%s/realms/master/protocol/openid-connect/token", ks.Action.GetKeycloakURL())
 	headers := helpers.ApplicationFormURLEncodedHeaders()
 
 	var tokenData map[string]any
@@ -122,7 +144,7 @@ func (ks *KeycloakSvc) UpdateRealmAccessTokenSettings(tenantName string, lifespa
 		return err
 	}
 
-	requestURL := fmt.Sprintf("%s/admin/realms/%s", constant.KeycloakHTTP, tenantName)
+	requestURL := fmt.Sprintf("%s/admin/realms/%s", ks.Action.GetKeycloakURL(), tenantName)
 	headers, err := helpers.SecureApplicationJSONHeaders(ks.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
@@ -137,7 +159,7 @@ func (ks *KeycloakSvc) UpdateRealmAccessTokenSettings(tenantName string, lifespa
 
 func (ks *KeycloakSvc) UpdatePublicClientSettings(tenantName string, url string) error {
 	clientID := fmt.Sprintf("%s%s", tenantName, action.GetConfigEnv("KC_LOGIN_CLIENT_SUFFIX", ks.Action.ConfigGlobalEnv))
-	getRequestURL := fmt.Sprintf("%s/admin/realms/%s/clients?clientId=%s", constant.KeycloakHTTP, tenantName, clientID)
+	getRequestURL := fmt.Sprintf("%s/admin/realms/%s/clients?clientId=%s", ks.Action.GetKeycloakURL(), tenantName, clientID)
 	headers, err := helpers.SecureApplicationJSONHeaders(ks.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
@@ -169,7 +191,7 @@ func (ks *KeycloakSvc) UpdatePublicClientSettings(tenantName string, url string)
 		return err
 	}
 
-	putRequestURL := fmt.Sprintf("%s/admin/realms/%s/clients/%s", constant.KeycloakHTTP, tenantName, clientUUID)
+	putRequestURL := fmt.Sprintf("%s/admin/realms/%s/clients/%s", ks.Action.GetKeycloakURL(), tenantName, clientUUID)
 	if err := ks.HTTPClient.PutReturnNoContent(putRequestURL, payload, headers); err != nil {
 		return err
 	}
@@ -177,3 +199,48 @@ func (ks *KeycloakSvc) UpdatePublicClientSettings(tenantName string, url string)
 
 	return nil
 }
+
+// GetRealmReport counts users, clients and realm roles of a tenant realm along with its stored login events
+func (ks *KeycloakSvc) GetRealmReport(tenantName string) (*models.KeycloakRealmReport, error) {
+	headers, err := helpers.SecureApplicationJSONHeaders(ks.Action.KeycloakMasterAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	realmURL := fmt.Sprintf("%s/admin/realms/%s", ks.Action.GetKeycloakURL(), tenantName)
+	report := &models.KeycloakRealmReport{Realm: tenantName}
+
+	if err := ks.HTTPClient.GetRetryReturnStruct(realmURL+"/users/count", headers, &report.Users); err != nil {
+		return nil, err
+	}
+
+	var clients []map[string]any
+	if err := ks.HTTPClient.GetRetryReturnStruct(realmURL+"/clients", headers, &clients); err != nil {
+		return nil, err
+	}
+	report.Clients = len(clients)
+
+	var roles []map[string]any
+	if err := ks.HTTPClient.GetRetryReturnStruct(realmURL+"/roles", headers, &roles); err != nil {
+		return nil, err
+	}
+	report.Roles = len(roles)
+
+	var events []map[string]any
+	eventsURL := fmt.Sprintf("%s/events?type=LOGIN&type=LOGIN_ERROR&max=%d", realmURL, constant.KeycloakReportMaxEvents)
+	if err := ks.HTTPClient.GetRetryReturnStruct(eventsURL, headers, &events); err != nil {
+		slog.Warn(ks.Action.Name, "text", "Login events are unavailable", "realm", tenantName, "error", err)
+		return report, nil
+	}
+	var logins, loginErrors int
+	for _, event := range events {
+		switch helpers.GetString(event, "type") {
+		case "LOGIN":
+			logins++
+		case "LOGIN_ERROR":
+			loginErrors++
+		}
+	}
+	report.LoginEvents, report.LoginErrors = &logins, &loginErrors
+
+	return report, nil
+}
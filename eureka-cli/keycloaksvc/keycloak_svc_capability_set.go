@@ -1,11 +1,16 @@
 package keycloaksvc
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
@@ -16,75 +21,110 @@ import (
 
 // KeycloakCapabilitySetManager defines the interface for Keycloak capability set management operations
 type KeycloakCapabilitySetManager interface {
-	GetCapabilitySets(headers map[string]string) ([]any, error)
-	GetCapabilitySetsByName(headers map[string]string, capabilityName string) ([]any, error)
+	GetCapabilitySets(headers map[string]string) ([]models.KeycloakCapabilitySet, error)
+	GetCapabilitySetsByName(headers map[string]string, capabilityName string) ([]models.KeycloakCapabilitySet, error)
+	GetCapabilitySetsByApplication(tenantName string, applicationID string, offset int, limit int) (*models.KeycloakCapabilitySetsResponse, error)
 	HasCapabilitySets(tenantName string) (bool, error)
 	CountCapabilitySets(tenantName string) (int, error)
-	AttachCapabilitySetsToRoles(tenantName string) error
-	DetachCapabilitySetsFromRoles(tenantName string) error
+	GetUnusedCapabilitySets(tenantName string) (*models.UnusedCapabilitySetsReport, error)
+	AttachCapabilitySetsToRoles(tenantName string) ([]models.RoleCapabilitySetsAttachResult, error)
+	DetachCapabilitySetsFromRoles(tenantName string) (int, error)
+	DetachNamedCapabilitySetsFromRoles(tenantName string, roleFilter string, capabilitySetNames []string) error
 }
 
-func (ks *KeycloakSvc) GetCapabilitySets(headers map[string]string) ([]any, error) {
-	var capabilitySets []any
+func (ks *KeycloakSvc) GetCapabilitySets(headers map[string]string) ([]models.KeycloakCapabilitySet, error) {
 	applications, err := ks.ManagementSvc.GetApplications()
 	if err != nil {
 		return nil, err
 	}
 
+	var (
+		mu             sync.Mutex
+		wg             sync.WaitGroup
+		firstErr       error
+		capabilitySets []models.KeycloakCapabilitySet
+		seenIDs        = make(map[string]struct{})
+		semaphore      = make(chan struct{}, ks.getCapabilityConcurrency())
+	)
 	for _, descriptor := range applications.ApplicationDescriptors {
 		applicationID := helpers.GetString(descriptor, "id")
-		requestURL := ks.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/capability-sets?query=applicationId==%s&offset=0&limit=10000", applicationID))
+		wg.Add(1)
+		go func(innerApplicationID string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
 
-		var decodedResponse models.KeycloakCapabilitySetsResponse
-		if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
-			return nil, err
-		}
-		if len(decodedResponse.CapabilitySets) == 0 {
-			continue
-		}
-		for _, cs := range decodedResponse.CapabilitySets {
-			capabilitySets = append(capabilitySets, map[string]any{
-				"id":            cs.ID,
-				"name":          cs.Name,
-				"description":   cs.Description,
-				"applicationId": cs.ApplicationID,
-				"resource":      cs.Resource,
-				"action":        cs.Action,
-			})
-		}
+			decodedResponse, err := ks.getApplicationCapabilitySets(headers, innerApplicationID, 0, 10000)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for _, capabilitySet := range decodedResponse.CapabilitySets {
+				if _, exists := seenIDs[capabilitySet.ID]; exists {
+					continue
+				}
+				seenIDs[capabilitySet.ID] = struct{}{}
+				capabilitySets = append(capabilitySets, capabilitySet)
+			}
+		}(applicationID)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
 	}
 
 	return capabilitySets, nil
 }
 
-func (ks *KeycloakSvc) GetCapabilitySetsByName(headers map[string]string, capabilityName string) ([]any, error) {
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/capability-sets?query=name==%s&limit=1", capabilityName))
+func (ks *KeycloakSvc) GetCapabilitySetsByApplication(tenantName string, applicationID string, offset int, limit int) (*models.KeycloakCapabilitySetsResponse, error) {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return ks.getApplicationCapabilitySets(headers, applicationID, offset, limit)
+}
+
+func (ks *KeycloakSvc) getApplicationCapabilitySets(headers map[string]string, applicationID string, offset int, limit int) (*models.KeycloakCapabilitySetsResponse, error) {
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/capability-sets?query=applicationId==%s&offset=%d&limit=%d", applicationID, offset, limit))
 
 	var decodedResponse models.KeycloakCapabilitySetsResponse
 	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
 		return nil, err
 	}
-	if len(decodedResponse.CapabilitySets) == 0 {
-		return nil, nil
+
+	return &decodedResponse, nil
+}
+
+func (ks *KeycloakSvc) getCapabilityConcurrency() int {
+	if ks.Action.Param != nil && ks.Action.Param.CapabilityConcurrency > 0 {
+		return ks.Action.Param.CapabilityConcurrency
 	}
 
-	result := make([]any, len(decodedResponse.CapabilitySets))
-	for i, cs := range decodedResponse.CapabilitySets {
-		result[i] = map[string]any{
-			"id":            cs.ID,
-			"name":          cs.Name,
-			"description":   cs.Description,
-			"applicationId": cs.ApplicationID,
-			"resource":      cs.Resource,
-			"action":        cs.Action,
-		}
+	return constant.CapabilitySetsConcurrency
+}
+
+func (ks *KeycloakSvc) GetCapabilitySetsByName(headers map[string]string, capabilityName string) ([]models.KeycloakCapabilitySet, error) {
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/capability-sets?query=name==%s&limit=1", capabilityName))
+
+	var decodedResponse models.KeycloakCapabilitySetsResponse
+	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
+		return nil, err
+	}
+	if len(decodedResponse.CapabilitySets) == 0 {
+		return nil, nil
 	}
 
-	return result, nil
+	return decodedResponse.CapabilitySets, nil
 }
 
 func (ks *KeycloakSvc) HasCapabilitySets(tenantName string) (bool, error) {
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
 		return false, err
 	}
@@ -96,7 +136,7 @@ func (ks *KeycloakSvc) HasCapabilitySets(tenantName string) (bool, error) {
 }
 
 func (ks *KeycloakSvc) CountCapabilitySets(tenantName string) (int, error) {
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
 		return 0, err
 	}
@@ -108,26 +148,80 @@ func (ks *KeycloakSvc) CountCapabilitySets(tenantName string) (int, error) {
 	return len(sets), nil
 }
 
-func (ks *KeycloakSvc) AttachCapabilitySetsToRoles(tenantName string) error {
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+// GetUnusedCapabilitySets diffs the capability sets of all applications against the sets attached across all roles of a tenant
+func (ks *KeycloakSvc) GetUnusedCapabilitySets(tenantName string) (*models.UnusedCapabilitySetsReport, error) {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	capabilitySets, err := ks.GetCapabilitySets(headers)
+	if err != nil {
+		return nil, err
+	}
 	roles, err := ks.GetRoles(headers)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	attachedIDs := make(map[string]struct{})
+	for _, value := range roles {
+		entry := value.(map[string]any)
+		roleCapabilitySets, err := ks.GetRoleCapabilitySets(helpers.GetString(entry, "id"), headers)
+		if err != nil {
+			return nil, err
+		}
+		for _, capabilitySet := range roleCapabilitySets {
+			attachedIDs[capabilitySet.ID] = struct{}{}
+		}
+	}
+
+	report := &models.UnusedCapabilitySetsReport{
+		Tenant:         tenantName,
+		Total:          len(capabilitySets),
+		Roles:          len(roles),
+		CapabilitySets: []models.KeycloakCapabilitySet{},
+	}
+	for _, capabilitySet := range capabilitySets {
+		if _, attached := attachedIDs[capabilitySet.ID]; attached {
+			report.Attached++
+			continue
+		}
+		report.CapabilitySets = append(report.CapabilitySets, capabilitySet)
+	}
+	slices.SortFunc(report.CapabilitySets, func(a, b models.KeycloakCapabilitySet) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	slog.Info(ks.Action.Name, "text", "Found unused capability sets", "tenant", tenantName, "count", len(report.CapabilitySets), "total", report.Total)
+
+	return report, nil
+}
+
+func (ks *KeycloakSvc) AttachCapabilitySetsToRoles(tenantName string) ([]models.RoleCapabilitySetsAttachResult, error) {
+	return ks.attachCapabilitySetsToRoles(tenantName, "")
+}
+
+func (ks *KeycloakSvc) attachCapabilitySetsToRoles(tenantName string, roleFilter string) ([]models.RoleCapabilitySetsAttachResult, error) {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := ks.GetRoles(headers)
+	if err != nil {
+		return nil, err
 	}
 	if len(roles) == 0 {
 		slog.Warn(ks.Action.Name, "text", "Found no roles with capability sets", "tenant", tenantName)
-		return nil
+		return nil, nil
 	}
 
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, "/roles/capability-sets")
+	var results []models.RoleCapabilitySetsAttachResult
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), "/roles/capability-sets")
 	for _, roleValue := range roles {
 		entry := roleValue.(map[string]any)
 		roleName := ks.Action.Caser.String(helpers.GetString(entry, "name"))
-		if ks.Action.ConfigRoles[roleName] == nil {
+		if ks.Action.ConfigRoles[roleName] == nil || (roleFilter != "" && roleName != roleFilter) {
 			continue
 		}
 
@@ -137,127 +231,269 @@ func (ks *KeycloakSvc) AttachCapabilitySetsToRoles(tenantName string) error {
 		}
 
 		rolesCapabilitySets := helpers.GetAnySlice(rolesMapConfig, field.RolesCapabilitySetsEntry)
-		capabilitySets, err := ks.populateCapabilitySets(headers, rolesCapabilitySets)
+		capabilitySets, capabilitySetNames, unresolved, err := ks.populateRoleCapabilitySets(headers, roleName, tenantName, rolesCapabilitySets)
 		if err != nil {
-			return err
+			return results, err
 		}
+		result := models.RoleCapabilitySetsAttachResult{RoleName: roleName, Tenant: tenantName, Unresolved: unresolved}
 		if len(capabilitySets) == 0 {
 			slog.Warn(ks.Action.Name, "text", "No capability sets were attached", "role", roleName, "tenant", tenantName)
+			results = append(results, result)
 			continue
 		}
 
-		alreadyAttached, err := ks.getRoleCapabilitySetIDs(helpers.GetString(entry, "id"), headers)
+		roleID := helpers.GetString(entry, "id")
+		alreadyAttached, err := ks.getRoleCapabilitySetIDs(roleID, headers)
 		if err != nil {
-			return err
+			return results, err
 		}
-		if len(alreadyAttached) > 0 {
-			attachedSet := make(map[string]struct{}, len(alreadyAttached))
-			for _, id := range alreadyAttached {
-				attachedSet[id] = struct{}{}
-			}
-			var delta []string
-			for _, id := range capabilitySets {
-				if _, exists := attachedSet[id]; !exists {
-					delta = append(delta, id)
-				}
+		missing, extra := helpers.DiffStrings(capabilitySets, alreadyAttached)
+		if ks.Action.Param != nil && ks.Action.Param.Strict && len(extra) > 0 {
+			ks.logCapabilitySetNames(roleName, tenantName, capabilitySets, capabilitySetNames)
+			if err := ks.replaceRoleCapabilitySets(roleID, capabilitySets, headers); err != nil {
+				return results, err
 			}
-			capabilitySets = delta
+			slog.Info(ks.Action.Name, "text", "Replaced capability sets", "added", len(missing), "removed", len(extra), "role", roleName, "tenant", tenantName)
+			result.Attached = len(missing)
+			results = append(results, result)
+			continue
 		}
+		capabilitySets = missing
 		if len(capabilitySets) == 0 {
 			slog.Info(ks.Action.Name, "text", "All capability sets already attached, skipping", "role", roleName, "tenant", tenantName)
+			results = append(results, result)
 			continue
 		}
 
-		batchSize := 250
-		for lowerBound := 0; lowerBound < len(capabilitySets); lowerBound += batchSize {
-			upperBound := min(lowerBound+batchSize, len(capabilitySets))
+		for lowerBound := 0; lowerBound < len(capabilitySets); lowerBound += constant.CapabilitySetsBatchSize {
+			upperBound := min(lowerBound+constant.CapabilitySetsBatchSize, len(capabilitySets))
 			batchCapabilitySetIDs := capabilitySets[lowerBound:upperBound]
 			slog.Info(ks.Action.Name, "text", "Attaching capability sets", "start", lowerBound, "end", upperBound, "total", len(capabilitySets), "role", roleName, "tenant", tenantName)
+			ks.logCapabilitySetNames(roleName, tenantName, batchCapabilitySetIDs, capabilitySetNames)
 
-			payload, err := json.Marshal(map[string]any{
-				"roleId":           helpers.GetString(entry, "id"),
-				"capabilitySetIds": batchCapabilitySetIDs,
-			})
-			if err != nil {
-				return err
-			}
-			if err := ks.HTTPClient.PostRetryReturnNoContent(requestURL, payload, headers); err != nil {
-				return err
+			if err := ks.postRoleCapabilitySets(requestURL, roleID, batchCapabilitySetIDs, headers); err != nil {
+				return results, err
 			}
+			result.Attached += len(batchCapabilitySetIDs)
 		}
 		slog.Info(ks.Action.Name, "text", "Attached capability sets", "count", len(capabilitySets), "role", roleName, "tenant", tenantName)
+		results = append(results, result)
 	}
 
-	return nil
+	return results, nil
 }
 
-func (ks *KeycloakSvc) populateCapabilitySets(headers map[string]string, rolesCapabilitySets []any) ([]string, error) {
+// populateRoleCapabilitySets repeats resolving the capability sets of a role that resolved none up to --attachRetries
+// times, since the capability sets of newly entitled applications may still be materializing
+func (ks *KeycloakSvc) populateRoleCapabilitySets(headers map[string]string, roleName, tenantName string, rolesCapabilitySets []any) (capabilitySets []string, capabilitySetNames map[string]string, unresolved []string, err error) {
+	capabilitySets, capabilitySetNames, unresolved, err = ks.populateCapabilitySets(headers, rolesCapabilitySets)
+	if err != nil || len(rolesCapabilitySets) == 0 || ks.Action.Param == nil {
+		return capabilitySets, capabilitySetNames, unresolved, err
+	}
+
+	retryWait := helpers.DefaultDuration(ks.Action.Param.AttachRetryWait, constant.AttachCapabilitySetsRetryWait)
+	for attempt := 1; len(capabilitySets) == 0 && attempt <= ks.Action.Param.AttachRetries; attempt++ {
+		slog.Info(ks.Action.Name, "text", "Retrying to resolve capability sets", "attempt", attempt, "attempts", ks.Action.Param.AttachRetries, "wait", retryWait, "role", roleName, "tenant", tenantName)
+		time.Sleep(retryWait)
+		capabilitySets, capabilitySetNames, unresolved, err = ks.populateCapabilitySets(headers, rolesCapabilitySets)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return capabilitySets, capabilitySetNames, unresolved, nil
+}
+
+func (ks *KeycloakSvc) populateCapabilitySets(headers map[string]string, rolesCapabilitySets []any) (capabilitySets []string, capabilitySetNames map[string]string, unresolved []string, err error) {
+	capabilitySets = []string{}
+	capabilitySetNames = map[string]string{}
 	if len(rolesCapabilitySets) == 0 {
-		return []string{}, nil
+		return capabilitySets, capabilitySetNames, nil, nil
 	}
 
-	if len(rolesCapabilitySets) == 1 && !slices.Contains(rolesCapabilitySets, "all") {
-		var capabilitySets = []string{}
+	scopedApplicationIDs, capabilitySetNamesOnly := splitCapabilitySetScopes(rolesCapabilitySets)
+	if len(scopedApplicationIDs) > 0 && !slices.Contains(rolesCapabilitySets, any(constant.AllCapabilitySets)) {
+		return ks.populateScopedCapabilitySets(headers, scopedApplicationIDs, capabilitySetNamesOnly)
+	}
+
+	if len(rolesCapabilitySets) == 1 && !slices.Contains(rolesCapabilitySets, any(constant.AllCapabilitySets)) {
 		for _, capabilitySetName := range rolesCapabilitySets {
 			capabilitySetsFound, err := ks.GetCapabilitySetsByName(headers, capabilitySetName.(string))
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
+			}
+			if len(capabilitySetsFound) == 0 {
+				unresolved = append(unresolved, capabilitySetName.(string))
 			}
-			for _, value := range capabilitySetsFound {
-				rawCapabilitySets := value.(map[string]any)
-				capabilitySets = append(capabilitySets, helpers.GetString(rawCapabilitySets, "id"))
+			for _, capabilitySet := range capabilitySetsFound {
+				capabilitySets = append(capabilitySets, capabilitySet.ID)
+				capabilitySetNames[capabilitySet.ID] = capabilitySet.Name
 			}
 		}
-		return capabilitySets, nil
+		return capabilitySets, capabilitySetNames, unresolved, nil
 	}
 
-	var capabilitySets = []string{}
 	allCapabilitySets, err := ks.GetCapabilitySets(headers)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	for _, value := range allCapabilitySets {
-		rawCapabilitySets := value.(map[string]any)
-		capabilitySets = append(capabilitySets, helpers.GetString(rawCapabilitySets, "id"))
+	for _, capabilitySet := range allCapabilitySets {
+		capabilitySets = append(capabilitySets, capabilitySet.ID)
+		capabilitySetNames[capabilitySet.ID] = capabilitySet.Name
 	}
 
-	return capabilitySets, nil
+	return capabilitySets, capabilitySetNames, nil, nil
 }
 
-func (ks *KeycloakSvc) getRoleCapabilitySetIDs(roleID string, headers map[string]string) ([]string, error) {
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/roles/%s/capability-sets?limit=10000", roleID))
+// populateScopedCapabilitySets collects all capability sets of the scoped applications together with the
+// capability sets listed by name, a scope without any capability set is reported as unresolved
+func (ks *KeycloakSvc) populateScopedCapabilitySets(headers map[string]string, applicationIDs []string, names []string) (capabilitySets []string, capabilitySetNames map[string]string, unresolved []string, err error) {
+	capabilitySets = []string{}
+	capabilitySetNames = map[string]string{}
+	addCapabilitySet := func(capabilitySet models.KeycloakCapabilitySet) {
+		if _, exists := capabilitySetNames[capabilitySet.ID]; exists {
+			return
+		}
+		capabilitySets = append(capabilitySets, capabilitySet.ID)
+		capabilitySetNames[capabilitySet.ID] = capabilitySet.Name
+	}
 
-	var decodedResponse models.KeycloakCapabilitySetsResponse
-	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
-		if errors.Is(err, apperrors.ErrHTTP404NotFound) {
-			return nil, nil
+	for _, applicationID := range applicationIDs {
+		decodedResponse, err := ks.getApplicationCapabilitySets(headers, applicationID, 0, 10000)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(decodedResponse.CapabilitySets) == 0 {
+			unresolved = append(unresolved, constant.AllCapabilitySetsScopePrefix+applicationID)
+			continue
+		}
+		for _, capabilitySet := range decodedResponse.CapabilitySets {
+			addCapabilitySet(capabilitySet)
+		}
+	}
+	for _, name := range names {
+		capabilitySetsFound, err := ks.GetCapabilitySetsByName(headers, name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(capabilitySetsFound) == 0 {
+			unresolved = append(unresolved, name)
+		}
+		for _, capabilitySet := range capabilitySetsFound {
+			addCapabilitySet(capabilitySet)
 		}
+	}
+
+	return capabilitySets, capabilitySetNames, unresolved, nil
+}
+
+// splitCapabilitySetScopes separates the application ids of the all:<applicationId> entries from the other entries
+func splitCapabilitySetScopes(rolesCapabilitySets []any) (applicationIDs []string, names []string) {
+	for _, value := range rolesCapabilitySets {
+		entry, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if applicationID, found := strings.CutPrefix(entry, constant.AllCapabilitySetsScopePrefix); found {
+			if applicationID = strings.TrimSpace(applicationID); applicationID != "" && !slices.Contains(applicationIDs, applicationID) {
+				applicationIDs = append(applicationIDs, applicationID)
+			}
+			continue
+		}
+		names = append(names, entry)
+	}
+
+	return applicationIDs, names
+}
+
+// logCapabilitySetNames lists the names of the capability sets being attached to a role, only when debug is enabled
+func (ks *KeycloakSvc) logCapabilitySetNames(roleName, tenantName string, capabilitySetIDs []string, capabilitySetNames map[string]string) {
+	if !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+
+	names := make([]string, 0, len(capabilitySetIDs))
+	for _, capabilitySetID := range capabilitySetIDs {
+		name, ok := capabilitySetNames[capabilitySetID]
+		if !ok || name == "" {
+			name = capabilitySetID
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	slog.Debug(ks.Action.Name, "text", "Attaching capability set names", "role", roleName, "tenant", tenantName, "count", len(names), "names", strings.Join(names, ","))
+}
+
+func (ks *KeycloakSvc) getRoleCapabilitySetIDs(roleID string, headers map[string]string) ([]string, error) {
+	capabilitySets, err := ks.GetRoleCapabilitySets(roleID, headers)
+	if err != nil {
 		return nil, err
 	}
 
-	ids := make([]string, 0, len(decodedResponse.CapabilitySets))
-	for _, cs := range decodedResponse.CapabilitySets {
+	ids := make([]string, 0, len(capabilitySets))
+	for _, cs := range capabilitySets {
 		ids = append(ids, cs.ID)
 	}
 
 	return ids, nil
 }
 
-func (ks *KeycloakSvc) DetachCapabilitySetsFromRoles(tenantName string) error {
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+// replaceRoleCapabilitySets puts the first batch of capability sets onto a role, replacing the attached ones,
+// and attaches the remaining batches afterwards so that no single request exceeds the batch size
+func (ks *KeycloakSvc) replaceRoleCapabilitySets(roleID string, capabilitySetIDs []string, headers map[string]string) error {
+	upperBound := min(constant.CapabilitySetsBatchSize, len(capabilitySetIDs))
+	payload, err := json.Marshal(map[string]any{
+		"capabilitySetIds": capabilitySetIDs[:upperBound],
+	})
 	if err != nil {
 		return err
 	}
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles/%s/capability-sets", roleID))
+	if err := ks.HTTPClient.PutReturnNoContent(requestURL, payload, headers); err != nil {
+		return err
+	}
 
-	roles, err := ks.GetRoles(headers)
+	attachURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), "/roles/capability-sets")
+	for lowerBound := upperBound; lowerBound < len(capabilitySetIDs); lowerBound += constant.CapabilitySetsBatchSize {
+		upperBound = min(lowerBound+constant.CapabilitySetsBatchSize, len(capabilitySetIDs))
+		if err := ks.postRoleCapabilitySets(attachURL, roleID, capabilitySetIDs[lowerBound:upperBound], headers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ks *KeycloakSvc) postRoleCapabilitySets(requestURL string, roleID string, capabilitySetIDs []string, headers map[string]string) error {
+	payload, err := json.Marshal(map[string]any{
+		"roleId":           roleID,
+		"capabilitySetIds": capabilitySetIDs,
+	})
 	if err != nil {
 		return err
 	}
+
+	return ks.HTTPClient.PostRetryReturnNoContent(requestURL, payload, headers)
+}
+
+// DetachCapabilitySetsFromRoles detaches all capability sets from the configured roles of a tenant
+// and returns from how many roles they were detached
+func (ks *KeycloakSvc) DetachCapabilitySetsFromRoles(tenantName string) (int, error) {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return 0, err
+	}
+
+	roles, err := ks.GetRoles(headers)
+	if err != nil {
+		return 0, err
+	}
 	if len(roles) == 0 {
 		slog.Warn(ks.Action.Name, "text", "Found no roles with capability sets", "tenant", tenantName)
-		return nil
+		return 0, nil
 	}
 
+	var detached int
 	for _, value := range roles {
 		entry := value.(map[string]any)
 		roleName := ks.Action.Caser.String(helpers.GetString(entry, "name"))
@@ -265,16 +501,85 @@ func (ks *KeycloakSvc) DetachCapabilitySetsFromRoles(tenantName string) error {
 			continue
 		}
 
-		requestURL := ks.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/roles/%s/capability-sets", helpers.GetString(entry, "id")))
+		requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles/%s/capability-sets", helpers.GetString(entry, "id")))
 		if err := ks.HTTPClient.Delete(requestURL, headers); err != nil {
 			if errors.Is(err, apperrors.ErrHTTP404NotFound) {
 				slog.Debug(ks.Action.Name, "text", "No capability sets to detach (already detached or not found)", "role", roleName, "tenant", tenantName)
 				continue
 			}
-			return err
+			return detached, err
 		}
+		detached++
 		slog.Info(ks.Action.Name, "text", "Detached capability sets", "role", roleName, "tenant", tenantName)
 	}
 
+	return detached, nil
+}
+
+// DetachNamedCapabilitySetsFromRoles detaches only the named capability sets from the configured roles of a tenant,
+// optionally only from a single role. The roles API has no per-set delete, so the remaining sets are put back
+// onto the role, and a role left without any set has all of its sets deleted instead
+func (ks *KeycloakSvc) DetachNamedCapabilitySetsFromRoles(tenantName string, roleFilter string, capabilitySetNames []string) error {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return err
+	}
+
+	detachIDs := make(map[string]string)
+	for _, capabilitySetName := range capabilitySetNames {
+		capabilitySetsFound, err := ks.GetCapabilitySetsByName(headers, capabilitySetName)
+		if err != nil {
+			return err
+		}
+		if len(capabilitySetsFound) == 0 {
+			slog.Warn(ks.Action.Name, "text", "Capability set not found, skipping", "capabilitySet", capabilitySetName, "tenant", tenantName)
+			continue
+		}
+		for _, capabilitySet := range capabilitySetsFound {
+			detachIDs[capabilitySet.ID] = capabilitySet.Name
+		}
+	}
+	if len(detachIDs) == 0 {
+		return nil
+	}
+
+	roles, err := ks.GetRoles(headers)
+	if err != nil {
+		return err
+	}
+	for _, value := range roles {
+		entry := value.(map[string]any)
+		roleName := ks.Action.Caser.String(helpers.GetString(entry, "name"))
+		if ks.Action.ConfigRoles[roleName] == nil || (roleFilter != "" && roleName != roleFilter) {
+			continue
+		}
+
+		roleID := helpers.GetString(entry, "id")
+		attached, err := ks.getRoleCapabilitySetIDs(roleID, headers)
+		if err != nil {
+			return err
+		}
+		remaining := slices.DeleteFunc(slices.Clone(attached), func(capabilitySetID string) bool {
+			_, detach := detachIDs[capabilitySetID]
+			return detach
+		})
+		detached := len(attached) - len(remaining)
+		if detached == 0 {
+			slog.Info(ks.Action.Name, "text", "No matching capability sets attached, skipping", "role", roleName, "tenant", tenantName)
+			continue
+		}
+
+		if len(remaining) == 0 {
+			requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles/%s/capability-sets", roleID))
+			err = ks.HTTPClient.Delete(requestURL, headers)
+		} else {
+			err = ks.replaceRoleCapabilitySets(roleID, remaining, headers)
+		}
+		if err != nil {
+			return err
+		}
+		slog.Info(ks.Action.Name, "text", "Detached capability sets", "count", detached, "remaining", len(remaining), "role", roleName, "tenant", tenantName)
+	}
+
 	return nil
 }
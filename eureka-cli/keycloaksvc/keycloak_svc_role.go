@@ -2,11 +2,13 @@ package keycloaksvc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 
-	"github.com/folio-org/eureka-setup/eureka-cli/constant"
-	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
 )
@@ -15,12 +17,16 @@ import (
 type KeycloakRoleManager interface {
 	GetRoles(headers map[string]string) ([]any, error)
 	GetRoleByName(roleName string, headers map[string]string) (map[string]any, error)
+	DescribeRole(tenantName string, roleName string) (*models.KeycloakRoleDetail, error)
+	GetRoleCapabilitySets(roleID string, headers map[string]string) ([]models.KeycloakCapabilitySet, error)
+	ExportRoleMappings(tenantName string) (*models.KeycloakRoleMappings, error)
 	CreateRoles(configTenant string) error
-	RemoveRoles(tenantName string) error
+	RemoveRoles(tenantName string) (int, error)
+	RecreateRole(tenantName string, roleName string) (*models.RoleCapabilitySetsAttachResult, error)
 }
 
 func (ks *KeycloakSvc) GetRoles(headers map[string]string) ([]any, error) {
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, "/roles?offset=0&limit=10000")
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), "/roles?offset=0&limit=10000")
 
 	var decodedResponse models.KeycloakRolesResponse
 	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
@@ -43,7 +49,7 @@ func (ks *KeycloakSvc) GetRoles(headers map[string]string) ([]any, error) {
 }
 
 func (ks *KeycloakSvc) GetRoleByName(roleName string, headers map[string]string) (map[string]any, error) {
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/roles?query=name==%s&limit=1", roleName))
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles?query=name==%s&limit=1", roleName))
 
 	var decodedResponse models.KeycloakRolesResponse
 	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
@@ -53,7 +59,7 @@ func (ks *KeycloakSvc) GetRoleByName(roleName string, headers map[string]string)
 		return nil, nil
 	}
 	if len(decodedResponse.Roles) != 1 {
-		return nil, errors.RoleNotFound(roleName)
+		return nil, apperrors.RoleNotFound(roleName)
 	}
 
 	return map[string]any{
@@ -63,19 +69,111 @@ func (ks *KeycloakSvc) GetRoleByName(roleName string, headers map[string]string)
 	}, nil
 }
 
+func (ks *KeycloakSvc) DescribeRole(tenantName string, roleName string) (*models.KeycloakRoleDetail, error) {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := ks.GetRoleByName(ks.Action.Caser.String(roleName), headers)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, apperrors.RoleNotFound(roleName)
+	}
+	roleID := helpers.GetString(role, "id")
+
+	capabilitySets, err := ks.GetRoleCapabilitySets(roleID, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var capabilitiesResponse models.KeycloakCapabilitiesResponse
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles/%s/capabilities?limit=10000", roleID))
+	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &capabilitiesResponse); err != nil && !errors.Is(err, apperrors.ErrHTTP404NotFound) {
+		return nil, err
+	}
+
+	return &models.KeycloakRoleDetail{
+		Role: models.KeycloakRole{
+			ID:          roleID,
+			Name:        helpers.GetString(role, "name"),
+			Description: helpers.GetString(role, "description"),
+		},
+		CapabilitySets: capabilitySets,
+		Capabilities:   capabilitiesResponse.Capabilities,
+	}, nil
+}
+
+func (ks *KeycloakSvc) GetRoleCapabilitySets(roleID string, headers map[string]string) ([]models.KeycloakCapabilitySet, error) {
+	var decodedResponse models.KeycloakCapabilitySetsResponse
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles/%s/capability-sets?limit=10000", roleID))
+	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil && !errors.Is(err, apperrors.ErrHTTP404NotFound) {
+		return nil, err
+	}
+
+	return decodedResponse.CapabilitySets, nil
+}
+
+func (ks *KeycloakSvc) ExportRoleMappings(tenantName string) (*models.KeycloakRoleMappings, error) {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := ks.GetRoles(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	roleMappings := &models.KeycloakRoleMappings{Roles: make(map[string]models.KeycloakRoleMapping, len(roles))}
+	for _, value := range roles {
+		entry := value.(map[string]any)
+		roleName := helpers.GetString(entry, "name")
+
+		capabilitySets, err := ks.GetRoleCapabilitySets(helpers.GetString(entry, "id"), headers)
+		if err != nil {
+			return nil, err
+		}
+
+		capabilitySetNames := make([]string, 0, len(capabilitySets))
+		for _, capabilitySet := range capabilitySets {
+			capabilitySetNames = append(capabilitySetNames, capabilitySet.Name)
+		}
+		sort.Strings(capabilitySetNames)
+
+		roleMappings.Roles[roleName] = models.KeycloakRoleMapping{Tenant: tenantName, CapabilitySets: capabilitySetNames}
+		slog.Info(ks.Action.Name, "text", "Exported role mapping", "role", roleName, "tenant", tenantName, "count", len(capabilitySetNames))
+	}
+
+	return roleMappings, nil
+}
+
 func (ks *KeycloakSvc) CreateRoles(configTenant string) error {
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, "/roles")
+	return ks.createRoles(configTenant, "")
+}
+
+func (ks *KeycloakSvc) createRoles(configTenant string, roleFilter string) error {
+	if err := ks.Action.ValidateRoleTenants(); err != nil {
+		return err
+	}
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), "/roles")
 	roleNames := helpers.SortedMapKeys(ks.Action.ConfigRoles)
 
+	var compositeRoles []string
 	for _, role := range roleNames {
 		value := ks.Action.ConfigRoles[role]
 		entry := value.(map[string]any)
 		tenantName := helpers.GetString(entry, "tenant")
-		if configTenant != tenantName {
+		if configTenant != tenantName || (roleFilter != "" && role != roleFilter) {
 			continue
 		}
+		if len(helpers.GetStringSlice(entry, field.RolesCompositesEntry)) > 0 {
+			compositeRoles = append(compositeRoles, role)
+		}
 
-		headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+		headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 		if err != nil {
 			return err
 		}
@@ -91,44 +189,142 @@ func (ks *KeycloakSvc) CreateRoles(configTenant string) error {
 
 		payload, err := json.Marshal(map[string]string{
 			"name":        ks.Action.Caser.String(role),
-			"description": "Default",
+			"description": ks.Action.GetDescription(entry),
 		})
 		if err != nil {
 			return err
 		}
 		if err := ks.HTTPClient.PostReturnNoContent(requestURL, payload, headers); err != nil {
+			if ks.Action.IsIgnorableConflict(err) {
+				slog.Info(ks.Action.Name, "text", "Role already exists", "role", role, "tenant", tenantName)
+				continue
+			}
 			return err
 		}
 		slog.Info(ks.Action.Name, "text", "Created role", "role", role, "tenant", tenantName)
 	}
+	if len(compositeRoles) == 0 {
+		return nil
+	}
 
-	return nil
+	return ks.createRoleComposites(configTenant, compositeRoles)
 }
 
-func (ks *KeycloakSvc) RemoveRoles(tenantName string) error {
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+func (ks *KeycloakSvc) createRoleComposites(tenantName string, compositeRoles []string) error {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
 		return err
 	}
-
 	roles, err := ks.GetRoles(headers)
 	if err != nil {
 		return err
 	}
+	roleIDs := make(map[string]string, len(roles))
+	for _, value := range roles {
+		entry := value.(map[string]any)
+		roleIDs[ks.Action.Caser.String(helpers.GetString(entry, "name"))] = helpers.GetString(entry, "id")
+	}
+
+	adminHeaders, err := helpers.SecureApplicationJSONHeaders(ks.Action.KeycloakMasterAccessToken)
+	if err != nil {
+		return err
+	}
+	for _, role := range compositeRoles {
+		roleID, ok := roleIDs[ks.Action.Caser.String(role)]
+		if !ok {
+			slog.Warn(ks.Action.Name, "text", "Role not found, skipping composites", "role", role, "tenant", tenantName)
+			continue
+		}
+
+		entry := ks.Action.ConfigRoles[role].(map[string]any)
+		var composites []map[string]string
+		for _, compositeName := range helpers.GetStringSlice(entry, field.RolesCompositesEntry) {
+			compositeID, ok := roleIDs[ks.Action.Caser.String(compositeName)]
+			if !ok {
+				slog.Warn(ks.Action.Name, "text", "Skipping unknown composite role", "role", role, "composite", compositeName, "tenant", tenantName)
+				continue
+			}
+			if compositeID == roleID {
+				slog.Warn(ks.Action.Name, "text", "Skipping self-referencing composite role", "role", role, "tenant", tenantName)
+				continue
+			}
+			composites = append(composites, map[string]string{"id": compositeID})
+		}
+		if len(composites) == 0 {
+			continue
+		}
+
+		payload, err := json.Marshal(composites)
+		if err != nil {
+			return err
+		}
+		requestURL := fmt.Sprintf("%s/admin/realms/%s/roles-by-id/%s/composites", ks.Action.GetKeycloakURL(), tenantName, roleID)
+		if err := ks.HTTPClient.PostReturnNoContent(requestURL, payload, adminHeaders); err != nil {
+			return err
+		}
+		slog.Info(ks.Action.Name, "text", "Added composite roles", "role", role, "count", len(composites), "tenant", tenantName)
+	}
+
+	return nil
+}
+
+// RemoveRoles removes the configured roles of a tenant and returns how many were removed
+func (ks *KeycloakSvc) RemoveRoles(tenantName string) (int, error) {
+	return ks.removeRoles(tenantName, "")
+}
+
+func (ks *KeycloakSvc) removeRoles(tenantName string, roleFilter string) (int, error) {
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return 0, err
+	}
 
+	roles, err := ks.GetRoles(headers)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
 	for _, value := range roles {
 		entry := value.(map[string]any)
 		roleName := ks.Action.Caser.String(helpers.GetString(entry, "name"))
-		if ks.Action.ConfigRoles[roleName] == nil {
+		if ks.Action.ConfigRoles[roleName] == nil || (roleFilter != "" && roleName != roleFilter) {
 			continue
 		}
 
-		requestURL := ks.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/roles/%s", helpers.GetString(entry, "id")))
+		requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles/%s", helpers.GetString(entry, "id")))
 		if err := ks.HTTPClient.Delete(requestURL, headers); err != nil {
-			return err
+			return removed, err
 		}
+		removed++
 		slog.Info(ks.Action.Name, "text", "Removed role", "role", roleName, "tenant", tenantName)
 	}
 
-	return nil
+	return removed, nil
+}
+
+// RecreateRole removes a single configured role of a tenant, creates it anew from the config
+// and re-attaches its configured capability sets
+func (ks *KeycloakSvc) RecreateRole(tenantName string, roleName string) (*models.RoleCapabilitySetsAttachResult, error) {
+	roleName = ks.Action.Caser.String(roleName)
+	entry, ok := ks.Action.ConfigRoles[roleName].(map[string]any)
+	if !ok || helpers.GetString(entry, field.RolesTenantEntry) != tenantName {
+		return nil, apperrors.RoleNotConfigured(roleName, tenantName)
+	}
+
+	if _, err := ks.removeRoles(tenantName, roleName); err != nil {
+		return nil, err
+	}
+	if err := ks.createRoles(tenantName, roleName); err != nil {
+		return nil, err
+	}
+	results, err := ks.attachCapabilitySetsToRoles(tenantName, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return &models.RoleCapabilitySetsAttachResult{RoleName: roleName, Tenant: tenantName}, nil
+	}
+
+	return &results[0], nil
 }
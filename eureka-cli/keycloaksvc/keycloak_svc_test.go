@@ -1,13 +1,17 @@
 package keycloaksvc_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
@@ -17,6 +21,7 @@ import (
 	vault "github.com/hashicorp/vault-client-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockVaultClient is a mock for vaultclient.VaultClientRunner
@@ -53,14 +58,25 @@ func (m *MockManagementSvc) GetTenants(consortiumName string, tenantType constan
 	return args.Get(0).([]any), args.Error(1)
 }
 
+func (m *MockManagementSvc) GetConfigTenants(consortiumName string, tenantType constant.TenantType) []any {
+	args := m.Called(consortiumName, tenantType)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]any)
+}
+
 func (m *MockManagementSvc) CreateTenants() error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockManagementSvc) RemoveTenants(consortiumName string, tenantType constant.TenantType) error {
-	args := m.Called(consortiumName, tenantType)
-	return args.Error(0)
+func (m *MockManagementSvc) RemoveTenants(consortiumName string, tenantType constant.TenantType, purgeKafkaTopics bool) ([]models.TenantRemovalResult, error) {
+	args := m.Called(consortiumName, tenantType, purgeKafkaTopics)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TenantRemovalResult), args.Error(1)
 }
 
 func (m *MockManagementSvc) GetApplications() (models.ApplicationsResponse, error) {
@@ -79,6 +95,46 @@ func (m *MockManagementSvc) GetLatestApplication() (map[string]any, error) {
 	return args.Get(0).(map[string]any), args.Error(1)
 }
 
+func (m *MockManagementSvc) GetApplication(applicationID string) (map[string]any, error) {
+	args := m.Called(applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]any), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetApplicationDescriptor(applicationID string) (*models.ApplicationDescriptor, error) {
+	args := m.Called(applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplicationDescriptor), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetApplicationDependencies(applicationID string) (*models.ApplicationDependencyGraph, error) {
+	args := m.Called(applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplicationDependencyGraph), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetRegistryDependencies(extract *models.RegistryExtract) (*models.ApplicationDependencyGraph, error) {
+	args := m.Called(extract)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ApplicationDependencyGraph), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetApplicationModules(applicationID string) ([]models.ApplicationModule, error) {
+	args := m.Called(applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ApplicationModule), args.Error(1)
+}
+
 func (m *MockManagementSvc) CreateApplication(extract *models.RegistryExtract) error {
 	args := m.Called(extract)
 	return args.Error(0)
@@ -94,9 +150,13 @@ func (m *MockManagementSvc) RemoveApplication(applicationID string) error {
 	return args.Error(0)
 }
 
-func (m *MockManagementSvc) RemoveApplications(applicationName, ignoreAppID string) error {
+func (m *MockManagementSvc) RemoveApplications(applicationName, ignoreAppID string, applicationIDs ...string) (int, error) {
+	if len(applicationIDs) > 0 {
+		args := m.Called(applicationName, ignoreAppID, applicationIDs)
+		return args.Int(0), args.Error(1)
+	}
 	args := m.Called(applicationName, ignoreAppID)
-	return args.Error(0)
+	return args.Int(0), args.Error(1)
 }
 
 func (m *MockManagementSvc) GetModuleDiscovery(name string) (models.ModuleDiscoveryResponse, error) {
@@ -104,6 +164,19 @@ func (m *MockManagementSvc) GetModuleDiscovery(name string) (models.ModuleDiscov
 	return args.Get(0).(models.ModuleDiscoveryResponse), args.Error(1)
 }
 
+func (m *MockManagementSvc) GetAllModuleDiscovery() ([]models.ModuleDiscovery, error) {
+	args := m.Called()
+	return args.Get(0).([]models.ModuleDiscovery), args.Error(1)
+}
+
+func (m *MockManagementSvc) GetAllRawModuleDiscovery() ([]json.RawMessage, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]json.RawMessage), args.Error(1)
+}
+
 func (m *MockManagementSvc) CreateNewModuleDiscovery(newDiscoveryModules []map[string]string) error {
 	args := m.Called(newDiscoveryModules)
 	return args.Error(0)
@@ -114,6 +187,21 @@ func (m *MockManagementSvc) UpdateModuleDiscovery(id string, restore bool, priva
 	return args.Error(0)
 }
 
+func (m *MockManagementSvc) BatchUpdateModuleDiscovery(discovery []models.ModuleDiscovery) error {
+	args := m.Called(discovery)
+	return args.Error(0)
+}
+
+func (m *MockManagementSvc) BatchUpdateRawModuleDiscovery(discovery []json.RawMessage) error {
+	args := m.Called(discovery)
+	return args.Error(0)
+}
+
+func (m *MockManagementSvc) RestoreModuleDiscovery(ids []string, privatePort int) error {
+	args := m.Called(ids, privatePort)
+	return args.Error(0)
+}
+
 func (m *MockManagementSvc) GetTenantEntitlements(tenantName string, includeModules bool) (models.TenantEntitlementResponse, error) {
 	args := m.Called(tenantName, includeModules)
 	return args.Get(0).(models.TenantEntitlementResponse), args.Error(1)
@@ -124,13 +212,26 @@ func (m *MockManagementSvc) CreateTenantEntitlement(consortiumName string, tenan
 	return args.Error(0)
 }
 
+func (m *MockManagementSvc) EntitleTenants(consortiumName string, tenantType constant.TenantType, applicationID string, concurrency int) ([]models.TenantEntitlementResult, error) {
+	args := m.Called(consortiumName, tenantType, applicationID, concurrency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TenantEntitlementResult), args.Error(1)
+}
+
 func (m *MockManagementSvc) UpgradeTenantEntitlement(consortiumName string, tenantType constant.TenantType, newApplicationID string) error {
 	args := m.Called(consortiumName, tenantType, newApplicationID)
 	return args.Error(0)
 }
 
-func (m *MockManagementSvc) RemoveTenantEntitlements(consortiumName string, tenantType constant.TenantType, purgeSchemas bool) error {
-	args := m.Called(consortiumName, tenantType, purgeSchemas)
+func (m *MockManagementSvc) RemoveTenantEntitlements(consortiumName string, tenantType constant.TenantType, purgeSchemas bool, applicationIDs ...string) (int, error) {
+	args := m.Called(consortiumName, tenantType, purgeSchemas, applicationIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockManagementSvc) WaitForTenantEntitlements(timeout time.Duration) error {
+	args := m.Called(timeout)
 	return args.Error(0)
 }
 
@@ -624,6 +725,91 @@ func TestUpdatePublicClientSettings_HeaderCreationError(t *testing.T) {
 	mockHTTP.AssertNotCalled(t, "PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
 
+// ==================== GetRealmReport Tests ====================
+
+func mockRealmReportEndpoint(mockHTTP *testhelpers.MockHTTPClient, suffix string, fill func(target any)) *mock.Call {
+	return mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/admin/realms/test-tenant"+suffix)
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) { fill(args.Get(2)) })
+}
+
+func TestGetRealmReport_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-master-token"
+	action.ConfigKeycloakURL = "http://localhost:8080/"
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct", "http://localhost:8080/admin/realms/test-tenant/users/count", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*int) = 12 }).
+		Return(nil)
+	mockRealmReportEndpoint(mockHTTP, "/clients", func(target any) {
+		*target.(*[]map[string]any) = []map[string]any{{"clientId": "a"}, {"clientId": "b"}}
+	}).Return(nil)
+	mockRealmReportEndpoint(mockHTTP, "/roles", func(target any) {
+		*target.(*[]map[string]any) = []map[string]any{{"name": "admin"}}
+	}).Return(nil)
+	mockRealmReportEndpoint(mockHTTP, "/events", func(target any) {
+		*target.(*[]map[string]any) = []map[string]any{{"type": "LOGIN"}, {"type": "LOGIN"}, {"type": "LOGIN_ERROR"}}
+	}).Return(nil)
+
+	// Act
+	report, err := svc.GetRealmReport("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 12, report.Users)
+	assert.Equal(t, 2, report.Clients)
+	assert.Equal(t, 1, report.Roles)
+	assert.Equal(t, 2, *report.LoginEvents)
+	assert.Equal(t, 1, *report.LoginErrors)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestGetRealmReport_EventsUnavailable(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-master-token"
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockRealmReportEndpoint(mockHTTP, "/users/count", func(target any) { *target.(*int) = 3 }).Return(nil)
+	mockRealmReportEndpoint(mockHTTP, "/clients", func(target any) {}).Return(nil)
+	mockRealmReportEndpoint(mockHTTP, "/roles", func(target any) {}).Return(nil)
+	mockRealmReportEndpoint(mockHTTP, "/events", func(target any) {}).Return(errors.New("forbidden"))
+
+	// Act
+	report, err := svc.GetRealmReport("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Users)
+	assert.Nil(t, report.LoginEvents)
+	assert.Nil(t, report.LoginErrors)
+}
+
+func TestGetRealmReport_UsersCountError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-master-token"
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockRealmReportEndpoint(mockHTTP, "/users/count", func(target any) {}).Return(assert.AnError)
+
+	// Act
+	report, err := svc.GetRealmReport("test-tenant")
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, report)
+}
+
 // ==================== GetAccessToken Tests ====================
 
 func TestGetAccessToken_Success(t *testing.T) {
@@ -823,6 +1009,63 @@ func TestGetAccessToken_NoToken(t *testing.T) {
 	assert.Empty(t, token)
 }
 
+// ==================== VerifyUserLogin Tests ====================
+
+func newVerifyUserLoginSvc() (*keycloaksvc.KeycloakSvc, *testhelpers.MockHTTPClient) {
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.VaultRootToken = "root-token"
+	action.ConfigGlobalEnv = map[string]string{"kc_service_client_id": "test-client-id"}
+	mockVault := &MockVaultClient{}
+	vaultClient := &vault.Client{}
+	mockVault.On("Create").Return(vaultClient, nil)
+	mockVault.On("GetSecretKey", mock.Anything, vaultClient, "root-token", "folio/test-tenant").
+		Return(map[string]any{"test-client-id": "client-secret-123"}, nil)
+
+	return keycloaksvc.New(action, mockHTTP, mockVault, &MockManagementSvc{}), mockHTTP
+}
+
+func TestVerifyUserLogin_Success(t *testing.T) {
+	// Arrange
+	svc, mockHTTP := newVerifyUserLoginSvc()
+	mockHTTP.On("PostFormDataReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/realms/test-tenant/protocol/openid-connect/token")
+		}),
+		mock.MatchedBy(func(formData url.Values) bool {
+			return formData.Get("client_id") == "test-client-id" && formData.Get("client_secret") == "client-secret-123" &&
+				formData.Get("username") == "diku_admin" && formData.Get("password") == "admin"
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(3).(*map[string]any)
+			*target = map[string]any{"access_token": "user-access-token"}
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.VerifyUserLogin("test-tenant", "diku_admin", "admin")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestVerifyUserLogin_InvalidCredentials(t *testing.T) {
+	// Arrange
+	svc, mockHTTP := newVerifyUserLoginSvc()
+	mockHTTP.On("PostFormDataReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(apperrors.RequestFailed(401, "POST", "http://keycloak.eureka:8080/realms/test-tenant/protocol/openid-connect/token"))
+
+	// Act
+	err := svc.VerifyUserLogin("test-tenant", "diku_admin", "wrong")
+
+	// Assert
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "wrong")
+}
+
 // ==================== Role Tests ====================
 
 func TestGetRoles_Success(t *testing.T) {
@@ -1024,6 +1267,9 @@ func TestCreateRoles_Success(t *testing.T) {
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
 			"tenant": "test-tenant",
@@ -1064,10 +1310,44 @@ func TestCreateRoles_Success(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
+func TestCreateRoles_WithTag_AppendsTagToDescription(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.Param.Tag = "run-42"
+	action.ConfigTenants = map[string]any{"test-tenant": nil}
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{"tenant": "test-tenant", "description": "Admin role"},
+	}
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnNoContent",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]string
+			_ = json.Unmarshal(payload, &data)
+			return data["description"] == "Admin role [run-42]"
+		}),
+		mock.Anything).
+		Return(nil).Once()
+
+	// Act
+	err := svc.CreateRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
 func TestCreateRoles_SkipsDifferentTenant(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
+	action.ConfigTenants = map[string]any{
+		"other-tenant": nil,
+	}
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
 			"tenant": "other-tenant",
@@ -1090,6 +1370,9 @@ func TestCreateRoles_HTTPError(t *testing.T) {
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
 			"tenant": "test-tenant",
@@ -1123,35 +1406,49 @@ func TestCreateRoles_HTTPError(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestCreateRoles_HeaderCreationError(t *testing.T) {
+func TestCreateRoles_ConflictIgnored(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	action.KeycloakAccessToken = "" // Empty token
+	action.Param.IgnoreExisting = true
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
 			"tenant": "test-tenant",
 		},
 	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything).
+		Return(apperrors.RequestFailed(http.StatusConflict, http.MethodPost, "/roles"))
 
 	// Act
 	err := svc.CreateRoles("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
-	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestRemoveRoles_Success(t *testing.T) {
+func TestCreateRoles_Composites(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.KeycloakMasterAccessToken = "master-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
+			"tenant":     "test-tenant",
+			"composites": []any{"user", "admin", "missing"},
+		},
+		"user": map[string]any{
 			"tenant": "test-tenant",
 		},
 	}
@@ -1159,58 +1456,195 @@ func TestRemoveRoles_Success(t *testing.T) {
 	mockMgmt := &MockManagementSvc{}
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin", Description: "Admin role"},
-		},
-	}
-
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?query=name==")
+		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			target.Roles = []models.KeycloakRole{{ID: "existing", Name: "existing"}}
 		}).
 		Return(nil)
 
-	mockHTTP.On("Delete",
+	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/role-1")
+			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
 		}),
+		mock.Anything,
 		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			target.Roles = []models.KeycloakRole{{ID: "role-1", Name: "Admin"}, {ID: "role-2", Name: "User"}}
+		}).
 		Return(nil)
 
+	mockHTTP.On("PostReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.HasSuffix(urlStr, "/admin/realms/test-tenant/roles-by-id/role-1/composites")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data []map[string]string
+			_ = json.Unmarshal(payload, &data)
+			return len(data) == 1 && data[0]["id"] == "role-2"
+		}),
+		mock.Anything).
+		Return(nil).Once()
+
 	// Act
-	err := svc.RemoveRoles("test-tenant")
+	err := svc.CreateRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestRemoveRoles_GetRolesError(t *testing.T) {
+func TestCreateRoles_CompositesPostError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.KeycloakMasterAccessToken = "master-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant":     "test-tenant",
+			"composites": []any{"user"},
+		},
+		"user": map[string]any{
+			"tenant": "test-tenant",
+		},
+	}
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
-	expectedError := errors.New("Get roles failed")
 	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?query=name==")
+		}),
 		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			target.Roles = []models.KeycloakRole{{ID: "existing", Name: "existing"}}
+		}).
+		Return(nil)
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+		}),
 		mock.Anything,
 		mock.Anything).
-		Return(expectedError)
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			target.Roles = []models.KeycloakRole{{ID: "role-1", Name: "admin"}, {ID: "role-2", Name: "user"}}
+		}).
+		Return(nil)
+
+	expectedError := errors.New("HTTP POST failed")
+	mockHTTP.On("PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
 
 	// Act
-	err := svc.RemoveRoles("test-tenant")
+	err := svc.CreateRoles("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, expectedError)
+}
+
+func TestCreateRoles_HeaderCreationError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "" // Empty token
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant": "test-tenant",
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	// Act
+	err := svc.CreateRoles("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveRoles_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant": "test-tenant",
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "admin", Description: "Admin role"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/role-1")
+		}),
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	removed, err := svc.RemoveRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestRemoveRoles_GetRolesError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	expectedError := errors.New("Get roles failed")
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	_, err := svc.RemoveRoles("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
 	mockHTTP.AssertExpectations(t)
 }
@@ -1225,7 +1659,7 @@ func TestRemoveRoles_HeaderCreationError(t *testing.T) {
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
 	// Act
-	err := svc.RemoveRoles("test-tenant")
+	_, err := svc.RemoveRoles("test-tenant")
 
 	// Assert
 	assert.Error(t, err)
@@ -1233,6 +1667,97 @@ func TestRemoveRoles_HeaderCreationError(t *testing.T) {
 	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 }
 
+func TestRecreateRole_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant": "test-tenant",
+		},
+		"user": map[string]any{
+			"tenant": "test-tenant",
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesURL := mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/roles?offset=0")
+	})
+	mockHTTP.On("GetRetryReturnStruct", rolesURL, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}, {ID: "role-2", Name: "user"}}}
+		}).
+		Return(nil).Once()
+	mockHTTP.On("GetRetryReturnStruct", rolesURL, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-3", Name: "admin"}, {ID: "role-2", Name: "user"}}}
+		}).
+		Return(nil).Once()
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?query=name==admin")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil).Once()
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.HasSuffix(urlStr, "/roles/role-1")
+		}),
+		mock.Anything).
+		Return(nil).Once()
+	mockHTTP.On("PostReturnNoContent",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]string
+			_ = json.Unmarshal(payload, &data)
+			return data["name"] == "admin"
+		}),
+		mock.Anything).
+		Return(nil).Once()
+
+	// Act
+	result, err := svc.RecreateRole("test-tenant", "Admin")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "admin", result.RoleName)
+	assert.Equal(t, "test-tenant", result.Tenant)
+	assert.Equal(t, 0, result.Attached)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestRecreateRole_NotConfiguredForTenant(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant": "other-tenant",
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	// Act
+	result, err := svc.RecreateRole("test-tenant", "admin")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
 // ==================== User Tests ====================
 
 func TestGetUsers_Success(t *testing.T) {
@@ -1258,7 +1783,7 @@ func TestGetUsers_Success(t *testing.T) {
 
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/users?offset=0&limit=10000")
+			return strings.Contains(urlStr, "/users?offset=0&limit=500")
 		}),
 		mock.MatchedBy(func(headers map[string]string) bool {
 			return headers[constant.OkapiTenantHeader] == "test-tenant" &&
@@ -1284,6 +1809,79 @@ func TestGetUsers_Success(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
+func TestGetUsers_Paginated(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	newPage := func(offset, count int) []models.KeycloakUser {
+		users := make([]models.KeycloakUser, count)
+		for i := range users {
+			users[i] = models.KeycloakUser{ID: fmt.Sprintf("user-%d", offset+i), Username: fmt.Sprintf("user%d", offset+i)}
+		}
+		return users
+	}
+	for offset, count := range map[int]int{0: constant.UsersPageSize, constant.UsersPageSize: constant.UsersPageSize, 2 * constant.UsersPageSize: 3} {
+		mockHTTP.On("GetRetryReturnStruct",
+			mock.MatchedBy(func(urlStr string) bool {
+				return strings.HasSuffix(urlStr, fmt.Sprintf("/users?offset=%d&limit=%d", offset, constant.UsersPageSize))
+			}),
+			mock.Anything,
+			mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*models.KeycloakUsersResponse).Users = newPage(offset, count)
+			}).
+			Return(nil).Once()
+	}
+
+	// Act
+	users, err := svc.GetUsers("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, users, 2*constant.UsersPageSize+3)
+	assert.Equal(t, "user-0", users[0].(map[string]any)["id"])
+	assert.Equal(t, fmt.Sprintf("user-%d", 2*constant.UsersPageSize+2), users[len(users)-1].(map[string]any)["id"])
+	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNumberOfCalls(t, "GetRetryReturnStruct", 3)
+}
+
+func TestGetUsers_WithTenantHeaders(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{
+			"headers": map[string]any{"x-api-key": "secret-key"},
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.MatchedBy(func(headers map[string]string) bool {
+			return headers["x-api-key"] == "secret-key" &&
+				headers[constant.OkapiTenantHeader] == "test-tenant" &&
+				headers[constant.OkapiTokenHeader] == "test-token"
+		}),
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	_, err := svc.GetUsers("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
 func TestGetUsers_EmptyResponse(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
@@ -1345,6 +1943,9 @@ func TestCreateUsers_Success(t *testing.T) {
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
 	action.ConfigUsers = map[string]any{
 		"testuser": map[string]any{
 			"tenant":     "test-tenant",
@@ -1419,7 +2020,7 @@ func TestCreateUsers_Success(t *testing.T) {
 		}).
 		Return(nil)
 
-	mockHTTP.On("PostReturnNoContent",
+	mockHTTP.On("PostRetryReturnNoContent",
 		mock.MatchedBy(func(urlStr string) bool {
 			return strings.Contains(urlStr, "/roles/users")
 		}),
@@ -1439,44 +2040,277 @@ func TestCreateUsers_Success(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestCreateUsers_SkipsDifferentTenant(t *testing.T) {
+func TestCreateUsers_UserFields(t *testing.T) {
+	tests := []struct {
+		name                  string
+		entry                 map[string]any
+		expectedEmail         string
+		expectedType          string
+		expectedContactTypeID string
+	}{
+		{
+			name:                  "TestCreateUsers_UserFields_Defaults",
+			entry:                 map[string]any{"tenant": "test-tenant"},
+			expectedEmail:         "test-tenant_testuser@test.org",
+			expectedType:          constant.StaffUserType,
+			expectedContactTypeID: constant.EmailContactType,
+		},
+		{
+			name: "TestCreateUsers_UserFields_Configured",
+			entry: map[string]any{
+				"tenant":                    "test-tenant",
+				"email":                     "jane.doe@example.org",
+				"type":                      "patron",
+				"preferred-contact-type-id": constant.MailContactType,
+			},
+			expectedEmail:         "jane.doe@example.org",
+			expectedType:          "patron",
+			expectedContactTypeID: constant.MailContactType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockHTTP := &testhelpers.MockHTTPClient{}
+			action := testhelpers.NewMockAction()
+			action.KeycloakAccessToken = "test-token"
+			action.ConfigTenants = map[string]any{"test-tenant": nil}
+			action.ConfigUsers = map[string]any{"testuser": tt.entry}
+			svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+			mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockHTTP.On("PostReturnStruct",
+				mock.Anything,
+				mock.MatchedBy(func(payload []byte) bool {
+					var user models.KeycloakUser
+					_ = json.Unmarshal(payload, &user)
+					return user.Type == tt.expectedType &&
+						user.Personal["email"] == tt.expectedEmail &&
+						user.Personal["preferredContactTypeId"] == tt.expectedContactTypeID
+				}),
+				mock.Anything,
+				mock.Anything).
+				Run(func(args mock.Arguments) {
+					*args.Get(3).(*map[string]any) = map[string]any{"id": "user-1"}
+				}).
+				Return(nil)
+			mockHTTP.On("PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			// Act
+			err := svc.CreateUsers("test-tenant")
+
+			// Assert
+			assert.NoError(t, err)
+			mockHTTP.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateUsers_WithTag_AddsUserTag(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	action.ConfigUsers = map[string]any{
-		"testuser": map[string]any{
-			"tenant": "other-tenant",
-		},
-	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+	action.KeycloakAccessToken = "test-token"
+	action.Param.Tag = "run-42"
+	action.ConfigTenants = map[string]any{"test-tenant": nil}
+	action.ConfigUsers = map[string]any{"testuser": map[string]any{"tenant": "test-tenant"}}
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			tags, ok := data["tags"].(map[string]any)
+			return ok && assert.ObjectsAreEqual([]any{"run-42"}, tags["tagList"])
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(3).(*map[string]any) = map[string]any{"id": "user-1"}
+		}).
+		Return(nil)
+	mockHTTP.On("PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
 	err := svc.CreateUsers("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
-	mockHTTP.AssertNotCalled(t, "PostReturnStruct")
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestCreateUsers_HeaderCreationError(t *testing.T) {
+func TestCreateUsers_ConflictIgnored(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	action.KeycloakAccessToken = "" // Empty token
+	action.Param.IgnoreExisting = true
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
 	action.ConfigUsers = map[string]any{
 		"testuser": map[string]any{
-			"tenant":     "test-tenant",
-			"password":   "pass123",
-			"first-name": "Test",
-			"last-name":  "User",
-			"roles":      []any{"admin"},
+			"tenant":   "test-tenant",
+			"password": "pass123",
+			"roles":    []any{"admin"},
 		},
 	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(apperrors.RequestFailed(http.StatusConflict, http.MethodPost, "/users-keycloak/users"))
+
+	// Act
+	err := svc.CreateUsers("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateUsers_RoleAttachmentFailureContinuesWithOtherUsers(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
+	action.ConfigUsers = map[string]any{
+		"user-a": map[string]any{"tenant": "test-tenant", "roles": []any{"admin"}},
+		"user-b": map[string]any{"tenant": "test-tenant", "roles": []any{"admin"}},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/users?query=username==")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/users-keycloak/users")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			return strings.Contains(string(payload), `"username":"user-a"`)
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(3).(*map[string]any) = map[string]any{"id": "user-a-id"}
+		}).
+		Return(nil)
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/users-keycloak/users")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			return strings.Contains(string(payload), `"username":"user-b"`)
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(3).(*map[string]any) = map[string]any{"id": "user-b-id"}
+		}).
+		Return(nil)
+	mockHTTP.On("PostReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/authn/credentials")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?query=name==admin")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*models.KeycloakRolesResponse) = models.KeycloakRolesResponse{
+				Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}},
+			}
+		}).
+		Return(nil)
+	mockHTTP.On("PostRetryReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/users")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			return strings.Contains(string(payload), "user-a-id")
+		}),
+		mock.Anything).
+		Return(errors.New("gateway timeout"))
+	mockHTTP.On("PostRetryReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/users")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			return strings.Contains(string(payload), "user-b-id")
+		}),
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	err := svc.CreateUsers("test-tenant")
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrRoleAttachmentFailed)
+	assert.Contains(t, err.Error(), "user-a")
+	assert.NotContains(t, err.Error(), "user-b")
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateUsers_SkipsDifferentTenant(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.ConfigTenants = map[string]any{
+		"other-tenant": nil,
+	}
+	action.ConfigUsers = map[string]any{
+		"testuser": map[string]any{
+			"tenant": "other-tenant",
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	// Act
+	err := svc.CreateUsers("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct")
+}
+
+func TestCreateUsers_HeaderCreationError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "" // Empty token
+	action.ConfigUsers = map[string]any{
+		"testuser": map[string]any{
+			"tenant":     "test-tenant",
+			"password":   "pass123",
+			"first-name": "Test",
+			"last-name":  "User",
+			"roles":      []any{"admin"},
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
 	// Act
 	err := svc.CreateUsers("test-tenant")
@@ -1549,7 +2383,7 @@ func TestRemoveUsers_Success(t *testing.T) {
 		Return(nil)
 
 	// Act
-	err := svc.RemoveUsers("test-tenant")
+	_, err := svc.RemoveUsers("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
@@ -1573,7 +2407,7 @@ func TestRemoveUsers_GetUsersError(t *testing.T) {
 		Return(expectedError)
 
 	// Act
-	err := svc.RemoveUsers("test-tenant")
+	_, err := svc.RemoveUsers("test-tenant")
 
 	// Assert
 	assert.Error(t, err)
@@ -1591,7 +2425,7 @@ func TestRemoveUsers_HeaderCreationError(t *testing.T) {
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
 	// Act
-	err := svc.RemoveUsers("test-tenant")
+	_, err := svc.RemoveUsers("test-tenant")
 
 	// Assert
 	assert.Error(t, err)
@@ -1609,7 +2443,7 @@ func TestRemoveUsers_BlankTenantName(t *testing.T) {
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
 	// Act
-	err := svc.RemoveUsers("") // Empty tenant
+	_, err := svc.RemoveUsers("") // Empty tenant
 
 	// Assert
 	assert.Error(t, err)
@@ -1617,235 +2451,463 @@ func TestRemoveUsers_BlankTenantName(t *testing.T) {
 	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 }
 
-// ==================== Capability Set Tests ====================
-
-func TestGetCapabilitySets_Success(t *testing.T) {
+func TestDescribeRole_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	applications := models.ApplicationsResponse{
-		ApplicationDescriptors: []map[string]any{
-			{"id": "app-1"},
-		},
-	}
-
-	mockMgmt.On("GetApplications").Return(applications, nil)
-
-	capSetsResponse := models.KeycloakCapabilitySetsResponse{
-		CapabilitySets: []models.KeycloakCapabilitySet{
-			{
-				ID:            "cap-1",
-				Name:          "users.read",
-				ApplicationID: "app-1",
-				Resource:      "users",
-				Action:        "read",
-			},
-		},
-	}
+	action.KeycloakAccessToken = "test-token"
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
-		}),
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles?query=name==admin") }),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin", Description: "Default"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-1/capability-sets") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
-			*target = capSetsResponse
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{{ID: "set-1", Name: "users_item.view"}}}
 		}).
 		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-1/capabilities") }),
+		mock.Anything,
+		mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
 
 	// Act
-	capSets, err := svc.GetCapabilitySets(map[string]string{})
+	result, err := svc.DescribeRole("test-tenant", "Admin")
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Len(t, capSets, 1)
-	capSet := capSets[0].(map[string]any)
-	assert.Equal(t, "cap-1", capSet["id"])
-	assert.Equal(t, "users.read", capSet["name"])
-	mockMgmt.AssertExpectations(t)
+	assert.Equal(t, "role-1", result.Role.ID)
+	assert.Equal(t, "admin", result.Role.Name)
+	assert.Len(t, result.CapabilitySets, 1)
+	assert.Equal(t, "users_item.view", result.CapabilitySets[0].Name)
+	assert.Empty(t, result.Capabilities)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestGetCapabilitySets_GetApplicationsError(t *testing.T) {
+func TestExportRoleMappings_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+	action.KeycloakAccessToken = "test-token"
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
-	expectedError := errors.New("Get applications failed")
-	mockMgmt.On("GetApplications").Return(nil, expectedError)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles?offset=0") }),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}, {ID: "role-2", Name: "empty"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-1/capability-sets") }),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{
+				{ID: "set-2", Name: "users_item.view"},
+				{ID: "set-1", Name: "inventory_item.view"},
+			}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-2/capability-sets") }),
+		mock.Anything,
+		mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
 
 	// Act
-	capSets, err := svc.GetCapabilitySets(map[string]string{})
+	result, err := svc.ExportRoleMappings("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	assert.Nil(t, capSets)
-	mockMgmt.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, models.KeycloakRoleMapping{Tenant: "test-tenant", CapabilitySets: []string{"inventory_item.view", "users_item.view"}}, result.Roles["admin"])
+	assert.Equal(t, models.KeycloakRoleMapping{Tenant: "test-tenant", CapabilitySets: []string{}}, result.Roles["empty"])
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestHasCapabilitySets_ReturnsFalse_WhenEmpty(t *testing.T) {
+func TestGetUnusedCapabilitySets_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
-	act := testhelpers.NewMockAction()
-	act.KeycloakAccessToken = "token"
-	mockVault := &MockVaultClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, mockMgmt)
 
 	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
-	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{
+				{ID: "set-1", Name: "users_item.view", ApplicationID: "app-1"},
+				{ID: "set-2", Name: "users_item.edit", ApplicationID: "app-1"},
+				{ID: "set-3", Name: "inventory_item.view", ApplicationID: "app-1"},
+			}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles?offset=0") }),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}, {ID: "role-2", Name: "empty"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-1/capability-sets") }),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{{ID: "set-1", Name: "users_item.view"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-2/capability-sets") }),
+		mock.Anything,
+		mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
 
 	// Act
-	has, err := svc.HasCapabilitySets("test-tenant")
+	result, err := svc.GetUnusedCapabilitySets("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
-	assert.False(t, has)
+	assert.Equal(t, "test-tenant", result.Tenant)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 1, result.Attached)
+	assert.Equal(t, 2, result.Roles)
+	assert.Equal(t, []models.KeycloakCapabilitySet{
+		{ID: "set-3", Name: "inventory_item.view", ApplicationID: "app-1"},
+		{ID: "set-2", Name: "users_item.edit", ApplicationID: "app-1"},
+	}, result.CapabilitySets)
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestHasCapabilitySets_ReturnsTrue_WhenNonEmpty(t *testing.T) {
+func TestGetUnusedCapabilitySets_GetCapabilitySetsError(t *testing.T) {
 	// Arrange
-	mockHTTP := &testhelpers.MockHTTPClient{}
-	act := testhelpers.NewMockAction()
-	act.KeycloakAccessToken = "token"
-	mockVault := &MockVaultClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, &testhelpers.MockHTTPClient{}, &MockVaultClient{}, mockMgmt)
 
-	capSetsResponse := models.KeycloakCapabilitySetsResponse{
-		CapabilitySets: []models.KeycloakCapabilitySet{{ID: "cap-1", Name: "users.read", ApplicationID: "app-1"}},
-	}
-	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
-	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
-		Run(func(args mock.Arguments) {
-			*args.Get(2).(*models.KeycloakCapabilitySetsResponse) = capSetsResponse
-		}).
-		Return(nil)
+	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{}, assert.AnError)
 
 	// Act
-	has, err := svc.HasCapabilitySets("test-tenant")
+	result, err := svc.GetUnusedCapabilitySets("test-tenant")
 
 	// Assert
-	assert.NoError(t, err)
-	assert.True(t, has)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
 }
 
-func TestHasCapabilitySets_HeadersError(t *testing.T) {
+func TestDescribeRole_RoleNotFound(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
-	act := testhelpers.NewMockAction()
-	// KeycloakAccessToken left empty — SecureOkapiTenantApplicationJSONHeaders returns error
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	result, err := svc.DescribeRole("test-tenant", "missing")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	mockHTTP.AssertNumberOfCalls(t, "GetRetryReturnStruct", 1)
+}
+
+func TestDetachAllUserRoles_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	usersResponse := models.KeycloakUsersResponse{
+		Users: []models.KeycloakUser{
+			{ID: "user-1", Username: "testuser", Active: true},
+			{ID: "user-2", Username: "otheruser", Active: true},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakUsersResponse)
+			*target = usersResponse
+		}).
+		Return(nil)
+
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/users/user-1")
+		}),
+		mock.Anything).
+		Return(nil)
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/users/user-2")
+		}),
+		mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
 
 	// Act
-	has, err := svc.HasCapabilitySets("test-tenant")
+	err := svc.DetachAllUserRoles("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
-	assert.False(t, has)
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "Delete", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/users-keycloak/users")
+	}), mock.Anything)
 }
 
-func TestHasCapabilitySets_GetCapabilitySetsError(t *testing.T) {
+func TestDetachAllUserRoles_DeleteError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
-	act := testhelpers.NewMockAction()
-	act.KeycloakAccessToken = "token"
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
-	expectedError := errors.New("get applications failed")
-	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{}, expectedError)
+	usersResponse := models.KeycloakUsersResponse{
+		Users: []models.KeycloakUser{
+			{ID: "user-1", Username: "testuser", Active: true},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakUsersResponse)
+			*target = usersResponse
+		}).
+		Return(nil)
+
+	expectedError := errors.New("delete failed")
+	mockHTTP.On("Delete", mock.Anything, mock.Anything).Return(expectedError)
 
 	// Act
-	has, err := svc.HasCapabilitySets("test-tenant")
+	err := svc.DetachAllUserRoles("test-tenant")
 
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
-	assert.False(t, has)
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestCountCapabilitySets_ReturnsCount(t *testing.T) {
+// ==================== Capability Set Tests ====================
+
+func TestGetCapabilitySets_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
-	act := testhelpers.NewMockAction()
-	act.KeycloakAccessToken = "token"
+	action := testhelpers.NewMockAction()
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	applications := models.ApplicationsResponse{
+		ApplicationDescriptors: []map[string]any{
+			{"id": "app-1"},
+		},
+	}
+
+	mockMgmt.On("GetApplications").Return(applications, nil)
 
 	capSetsResponse := models.KeycloakCapabilitySetsResponse{
 		CapabilitySets: []models.KeycloakCapabilitySet{
-			{ID: "cap-1", Name: "users.read", ApplicationID: "app-1"},
-			{ID: "cap-2", Name: "users.write", ApplicationID: "app-1"},
+			{
+				ID:            "cap-1",
+				Name:          "users.read",
+				ApplicationID: "app-1",
+				Resource:      "users",
+				Action:        "read",
+			},
 		},
 	}
-	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
-	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
+		}),
+		mock.Anything,
+		mock.Anything).
 		Run(func(args mock.Arguments) {
-			*args.Get(2).(*models.KeycloakCapabilitySetsResponse) = capSetsResponse
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = capSetsResponse
 		}).
 		Return(nil)
 
 	// Act
-	count, err := svc.CountCapabilitySets("test-tenant")
+	capSets, err := svc.GetCapabilitySets(map[string]string{})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, 2, count)
+	assert.Len(t, capSets, 1)
+	assert.Equal(t, "cap-1", capSets[0].ID)
+	assert.Equal(t, "users.read", capSets[0].Name)
+	assert.Equal(t, "app-1", capSets[0].ApplicationID)
+	mockMgmt.AssertExpectations(t)
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestCountCapabilitySets_ReturnsZero_WhenEmpty(t *testing.T) {
+func TestGetCapabilitySets_MultipleApplicationsDeduplicated(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
-	act := testhelpers.NewMockAction()
-	act.KeycloakAccessToken = "token"
-	mockVault := &MockVaultClient{}
+	action := testhelpers.NewMockAction()
+	action.Param.CapabilityConcurrency = 2
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, mockMgmt)
 
-	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
-	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	applications := models.ApplicationsResponse{
+		ApplicationDescriptors: []map[string]any{{"id": "app-1"}, {"id": "app-2"}, {"id": "app-3"}},
+	}
+	mockMgmt.On("GetApplications").Return(applications, nil)
+
+	responses := map[string][]models.KeycloakCapabilitySet{
+		"app-1": {{ID: "cap-1", Name: "users.read"}, {ID: "cap-shared", Name: "shared.read"}},
+		"app-2": {{ID: "cap-2", Name: "orders.read"}, {ID: "cap-shared", Name: "shared.read"}},
+		"app-3": {},
+	}
+	for appID, capabilitySets := range responses {
+		mockHTTP.On("GetRetryReturnStruct",
+			mock.MatchedBy(func(urlStr string) bool {
+				return strings.Contains(urlStr, "applicationId=="+appID+"&")
+			}),
+			mock.Anything,
+			mock.Anything).
+			Run(func(args mock.Arguments) {
+				target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+				target.CapabilitySets = capabilitySets
+			}).
+			Return(nil)
+	}
 
 	// Act
-	count, err := svc.CountCapabilitySets("test-tenant")
+	capSets, err := svc.GetCapabilitySets(map[string]string{})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, 0, count)
+	var ids []string
+	for _, capSet := range capSets {
+		ids = append(ids, capSet.ID)
+	}
+	assert.ElementsMatch(t, []string{"cap-1", "cap-2", "cap-shared"}, ids)
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestCountCapabilitySets_HeadersError(t *testing.T) {
+func TestGetCapabilitySets_ApplicationQueryError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
-	act := testhelpers.NewMockAction()
-	// KeycloakAccessToken empty → headers error
+	action := testhelpers.NewMockAction()
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, mockMgmt)
+
+	applications := models.ApplicationsResponse{
+		ApplicationDescriptors: []map[string]any{{"id": "app-1"}, {"id": "app-2"}},
+	}
+	mockMgmt.On("GetApplications").Return(applications, nil)
+	expectedError := errors.New("gateway unavailable")
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "applicationId==app-1&") }),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "applicationId==app-2&") }),
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	capSets, err := svc.GetCapabilitySets(map[string]string{})
+
+	// Assert
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, capSets)
+}
+
+func TestGetCapabilitySetsByApplication_Paginated(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.HasSuffix(urlStr, "/capability-sets?query=applicationId==app-1.0.0&offset=20&limit=10")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-21"}}
+			target.TotalCount = 21
+		}).
+		Return(nil)
+
+	// Act
+	result, err := svc.GetCapabilitySetsByApplication("test-tenant", "app-1.0.0", 20, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 21, result.TotalCount)
+	assert.Equal(t, "cap-21", result.CapabilitySets[0].ID)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestGetCapabilitySets_GetApplicationsError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	expectedError := errors.New("Get applications failed")
+	mockMgmt.On("GetApplications").Return(nil, expectedError)
 
 	// Act
-	count, err := svc.CountCapabilitySets("test-tenant")
+	capSets, err := svc.GetCapabilitySets(map[string]string{})
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, 0, count)
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, capSets)
+	mockMgmt.AssertExpectations(t)
 }
 
-func TestCountCapabilitySets_GetCapabilitySetsError(t *testing.T) {
+func TestHasCapabilitySets_ReturnsFalse_WhenEmpty(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	act := testhelpers.NewMockAction()
@@ -1854,38 +2916,279 @@ func TestCountCapabilitySets_GetCapabilitySetsError(t *testing.T) {
 	mockMgmt := &MockManagementSvc{}
 	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
 
-	expectedError := errors.New("get applications failed")
-	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{}, expectedError)
+	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
-	count, err := svc.CountCapabilitySets("test-tenant")
+	has, err := svc.HasCapabilitySets("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	assert.Equal(t, 0, count)
+	assert.NoError(t, err)
+	assert.False(t, has)
 }
 
-func TestGetCapabilitySetsByName_Success(t *testing.T) {
+func TestHasCapabilitySets_ReturnsTrue_WhenNonEmpty(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
-	action := testhelpers.NewMockAction()
+	act := testhelpers.NewMockAction()
+	act.KeycloakAccessToken = "token"
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
 
 	capSetsResponse := models.KeycloakCapabilitySetsResponse{
-		CapabilitySets: []models.KeycloakCapabilitySet{
-			{
-				ID:            "cap-1",
-				Name:          "users.read",
-				ApplicationID: "app-1",
-			},
-		},
+		CapabilitySets: []models.KeycloakCapabilitySet{{ID: "cap-1", Name: "users.read", ApplicationID: "app-1"}},
 	}
+	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*models.KeycloakCapabilitySetsResponse) = capSetsResponse
+		}).
+		Return(nil)
 
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
+	// Act
+	has, err := svc.HasCapabilitySets("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestHasCapabilitySets_HeadersError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	act := testhelpers.NewMockAction()
+	// KeycloakAccessToken left empty — SecureOkapiTenantApplicationJSONHeaders returns error
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+
+	// Act
+	has, err := svc.HasCapabilitySets("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, has)
+}
+
+func TestHasCapabilitySets_GetCapabilitySetsError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	act := testhelpers.NewMockAction()
+	act.KeycloakAccessToken = "token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+
+	expectedError := errors.New("get applications failed")
+	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{}, expectedError)
+
+	// Act
+	has, err := svc.HasCapabilitySets("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	assert.False(t, has)
+}
+
+func TestCountCapabilitySets_ReturnsCount(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	act := testhelpers.NewMockAction()
+	act.KeycloakAccessToken = "token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+
+	capSetsResponse := models.KeycloakCapabilitySetsResponse{
+		CapabilitySets: []models.KeycloakCapabilitySet{
+			{ID: "cap-1", Name: "users.read", ApplicationID: "app-1"},
+			{ID: "cap-2", Name: "users.write", ApplicationID: "app-1"},
+		},
+	}
+	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*models.KeycloakCapabilitySetsResponse) = capSetsResponse
+		}).
+		Return(nil)
+
+	// Act
+	count, err := svc.CountCapabilitySets("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestCountCapabilitySets_ReturnsZero_WhenEmpty(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	act := testhelpers.NewMockAction()
+	act.KeycloakAccessToken = "token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+
+	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	count, err := svc.CountCapabilitySets("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestCountCapabilitySets_HeadersError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	act := testhelpers.NewMockAction()
+	// KeycloakAccessToken empty → headers error
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+
+	// Act
+	count, err := svc.CountCapabilitySets("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestCountCapabilitySets_GetCapabilitySetsError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	act := testhelpers.NewMockAction()
+	act.KeycloakAccessToken = "token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(act, mockHTTP, mockVault, mockMgmt)
+
+	expectedError := errors.New("get applications failed")
+	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{}, expectedError)
+
+	// Act
+	count, err := svc.CountCapabilitySets("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestGetCapabilitySetsByName_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	capSetsResponse := models.KeycloakCapabilitySetsResponse{
+		CapabilitySets: []models.KeycloakCapabilitySet{
+			{
+				ID:            "cap-1",
+				Name:          "users.read",
+				ApplicationID: "app-1",
+			},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/capability-sets?query=name==users.read")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = capSetsResponse
+		}).
+		Return(nil)
+
+	// Act
+	capSets, err := svc.GetCapabilitySetsByName(map[string]string{}, "users.read")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, capSets, 1)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestGetCapabilitySetsByName_EmptyResponse(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	capSetsResponse := models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{}}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = capSetsResponse
+		}).
+		Return(nil)
+
+	// Act
+	capSets, err := svc.GetCapabilitySetsByName(map[string]string{}, "nonexistent")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, capSets)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestAttachCapabilitySetsToRoles_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant":          "test-tenant",
+			"capability-sets": []any{"users.read"},
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "admin"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	capSetsResponse := models.KeycloakCapabilitySetsResponse{
+		CapabilitySets: []models.KeycloakCapabilitySet{
+			{ID: "cap-1", Name: "users.read"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
 			return strings.Contains(urlStr, "/capability-sets?query=name==users.read")
 		}),
 		mock.Anything,
@@ -1896,45 +3199,691 @@ func TestGetCapabilitySetsByName_Success(t *testing.T) {
 		}).
 		Return(nil)
 
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/capability-sets?limit=10000")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	mockHTTP.On("PostRetryReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/capability-sets")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	results, err := svc.AttachCapabilitySetsToRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.RoleCapabilitySetsAttachResult{{RoleName: "admin", Tenant: "test-tenant", Attached: 1}}, results)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestAttachCapabilitySetsToRoles_NoRoles(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{Roles: []models.KeycloakRole{}}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	// Act
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestAttachCapabilitySetsToRoles_GetRolesError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	expectedError := errors.New("Get roles failed")
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestAttachCapabilitySetsToRoles_HeaderCreationError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "" // Empty token
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	// Act
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDetachCapabilitySetsFromRoles_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "admin"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/role-1/capability-sets")
+		}),
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	_, err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestDetachCapabilitySetsFromRoles_NoRoles(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{Roles: []models.KeycloakRole{}}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	// Act
+	_, err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestDetachCapabilitySetsFromRoles_HeaderCreationError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "" // Empty token
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	// Act
+	_, err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDetachCapabilitySetsFromRoles_GetRolesError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(errors.New("get roles failed"))
+
+	// Act
+	_, err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "get roles failed")
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDetachCapabilitySetsFromRoles_NotFoundIgnored(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "admin"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/role-1/capability-sets")
+		}),
+		mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
+
+	// Act
+	_, err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+
+	// Assert
+	// 404 errors should be logged but not returned as errors
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestDetachCapabilitySetsFromRoles_DeleteError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "admin"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/role-1/capability-sets")
+		}),
+		mock.Anything).
+		Return(errors.New("delete failed"))
+
+	// Act
+	_, err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "delete failed")
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestDetachCapabilitySetsFromRoles_RoleNotInConfig(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "user"}, // Not in config
+			{ID: "role-2", Name: "admin"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	// Only admin role should be deleted, not user role
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/role-2/capability-sets")
+		}),
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	_, err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	// Verify Delete was NOT called for role-1
+	mockHTTP.AssertNotCalled(t, "Delete",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/role-1/capability-sets")
+		}),
+		mock.Anything)
+}
+
+func newDetachNamedCapabilitySetsSvc(attachedIDs []string) (*keycloaksvc.KeycloakSvc, *testhelpers.MockHTTPClient) {
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{"admin": map[string]any{}, "viewer": map[string]any{}}
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/capability-sets?query=name==users.edit")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{{ID: "cap-2", Name: "users.edit"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/capability-sets?query=name==users.missing")
+	}), mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}, {ID: "role-2", Name: "viewer"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/roles/role-1/capability-sets?limit=10000")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			for _, id := range attachedIDs {
+				target.CapabilitySets = append(target.CapabilitySets, models.KeycloakCapabilitySet{ID: id})
+			}
+		}).
+		Return(nil)
+
+	return keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{}), mockHTTP
+}
+
+func TestDetachNamedCapabilitySetsFromRoles_PutsRemainingSets(t *testing.T) {
+	// Arrange
+	svc, mockHTTP := newDetachNamedCapabilitySetsSvc([]string{"cap-1", "cap-2", "cap-3"})
+	mockHTTP.On("PutReturnNoContent", mock.MatchedBy(func(urlStr string) bool {
+		return strings.HasSuffix(urlStr, "/roles/role-1/capability-sets")
+	}), []byte(`{"capabilitySetIds":["cap-1","cap-3"]}`), mock.Anything).Return(nil)
+
+	// Act
+	err := svc.DetachNamedCapabilitySetsFromRoles("test-tenant", "admin", []string{"users.edit", "users.missing"})
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDetachNamedCapabilitySetsFromRoles_DeletesWhenNoSetRemains(t *testing.T) {
+	// Arrange
+	svc, mockHTTP := newDetachNamedCapabilitySetsSvc([]string{"cap-2"})
+	mockHTTP.On("Delete", mock.MatchedBy(func(urlStr string) bool {
+		return strings.HasSuffix(urlStr, "/roles/role-1/capability-sets")
+	}), mock.Anything).Return(nil)
+
+	// Act
+	err := svc.DetachNamedCapabilitySetsFromRoles("test-tenant", "admin", []string{"users.edit"})
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDetachNamedCapabilitySetsFromRoles_SkipsUnknownCapabilitySets(t *testing.T) {
+	// Arrange
+	svc, mockHTTP := newDetachNamedCapabilitySetsSvc([]string{"cap-2"})
+
+	// Act
+	err := svc.DetachNamedCapabilitySetsFromRoles("test-tenant", "", []string{"users.missing"})
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertNotCalled(t, "PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAttachCapabilitySetsToRoles_WithAllCapabilitySets(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant":          "test-tenant",
+			"capability-sets": []any{"all"},
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "admin"},
+		},
+	}
+
+	applicationsResponse := models.ApplicationsResponse{
+		ApplicationDescriptors: []map[string]any{
+			{"id": "app-1"},
+		},
+	}
+
+	capSetsResponse := models.KeycloakCapabilitySetsResponse{
+		CapabilitySets: []models.KeycloakCapabilitySet{
+			{ID: "cap-1", Name: "users.read"},
+			{ID: "cap-2", Name: "users.write"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
+
+	mockMgmt.On("GetApplications").Return(applicationsResponse, nil)
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = capSetsResponse
+		}).
+		Return(nil)
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/capability-sets?limit=10000")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	mockHTTP.On("PostRetryReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/capability-sets")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	mockMgmt.AssertExpectations(t)
+}
+
+func newScopedCapabilitySetsSvc(capabilitySets []any) (*keycloaksvc.KeycloakSvc, *testhelpers.MockHTTPClient, *MockManagementSvc) {
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant":          "test-tenant",
+			"capability-sets": capabilitySets,
+		},
+	}
+	mockMgmt := &MockManagementSvc{}
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-inventory-1.0.0")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{
+				{ID: "cap-1", Name: "inventory.all"},
+				{ID: "cap-2", Name: "inventory.view"},
+			}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-missing-1.0.0")
+	}), mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/capability-sets?query=name==users.view")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{{ID: "cap-3", Name: "users.view"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/capability-sets?limit=10000")
+	}), mock.Anything, mock.Anything).Return(nil)
+
+	return keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, mockMgmt), mockHTTP, mockMgmt
+}
+
+func TestAttachCapabilitySetsToRoles_WithApplicationScopedCapabilitySets(t *testing.T) {
+	// Arrange
+	svc, mockHTTP, mockMgmt := newScopedCapabilitySetsSvc([]any{"all:app-inventory-1.0.0", "users.view"})
+	var attachedIDs []string
+	mockHTTP.On("PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			var payload struct {
+				CapabilitySetIDs []string `json:"capabilitySetIds"`
+			}
+			_ = json.Unmarshal(args.Get(1).([]byte), &payload)
+			attachedIDs = append(attachedIDs, payload.CapabilitySetIDs...)
+		}).
+		Return(nil)
+
 	// Act
-	capSets, err := svc.GetCapabilitySetsByName(map[string]string{}, "users.read")
+	results, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Len(t, capSets, 1)
-	mockHTTP.AssertExpectations(t)
+	assert.Equal(t, []string{"cap-1", "cap-2", "cap-3"}, attachedIDs)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.Equal(t, 3, results[0].Attached)
+	assert.Empty(t, results[0].Unresolved)
+	mockMgmt.AssertNotCalled(t, "GetApplications")
 }
 
-func TestGetCapabilitySetsByName_EmptyResponse(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_ReportsEmptyApplicationScopeAsUnresolved(t *testing.T) {
 	// Arrange
+	svc, mockHTTP, _ := newScopedCapabilitySetsSvc([]any{"all:app-inventory-1.0.0", "all:app-missing-1.0.0"})
+	mockHTTP.On("PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	results, err := svc.AttachCapabilitySetsToRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.Equal(t, 2, results[0].Attached)
+	assert.Equal(t, []string{"all:app-missing-1.0.0"}, results[0].Unresolved)
+}
+
+func newAttachAllCapabilitySetsSvc() (*keycloaksvc.KeycloakSvc, *testhelpers.MockHTTPClient) {
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	mockVault := &MockVaultClient{}
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant":          "test-tenant",
+			"capability-sets": []any{"all"},
+		},
+	}
 	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	capSetsResponse := models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{}}
-
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
-		mock.Anything,
-		mock.Anything).
+	mockMgmt.On("GetApplications").Return(models.ApplicationsResponse{ApplicationDescriptors: []map[string]any{{"id": "app-1"}}}, nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
+	}), mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
-			*target = capSetsResponse
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{
+				{ID: "cap-2", Name: "users.write"},
+				{ID: "cap-1", Name: "users.read"},
+			}}
 		}).
 		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/capability-sets?limit=10000")
+	}), mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, mockMgmt), mockHTTP
+}
+
+func TestAttachCapabilitySetsToRoles_LogsCapabilitySetNamesWithDebug(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(defaultLogger)
+	svc, _ := newAttachAllCapabilitySetsSvc()
 
 	// Act
-	capSets, err := svc.GetCapabilitySetsByName(map[string]string{}, "nonexistent")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Nil(t, capSets)
-	mockHTTP.AssertExpectations(t)
+	assert.Contains(t, buf.String(), "Attaching capability set names")
+	assert.Contains(t, buf.String(), "names=users.read,users.write")
 }
 
-func TestAttachCapabilitySetsToRoles_Success(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_OmitsCapabilitySetNamesWithoutDebug(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	defer slog.SetDefault(defaultLogger)
+	svc, _ := newAttachAllCapabilitySetsSvc()
+
+	// Act
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "Attached capability sets")
+	assert.NotContains(t, buf.String(), "users.read")
+}
+
+func TestAttachCapabilitySetsToRoles_LargeBatchSplitting(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
@@ -1942,7 +3891,7 @@ func TestAttachCapabilitySetsToRoles_Success(t *testing.T) {
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
 			"tenant":          "test-tenant",
-			"capability-sets": []any{"users.read"},
+			"capability-sets": []any{"all"},
 		},
 	}
 	mockVault := &MockVaultClient{}
@@ -1955,6 +3904,22 @@ func TestAttachCapabilitySetsToRoles_Success(t *testing.T) {
 		},
 	}
 
+	applicationsResponse := models.ApplicationsResponse{
+		ApplicationDescriptors: []map[string]any{
+			{"id": "app-1"},
+		},
+	}
+
+	// Create 300 capability sets to test batch splitting (batch size is 250)
+	capSets := make([]models.KeycloakCapabilitySet, 300)
+	for i := 0; i < 300; i++ {
+		capSets[i] = models.KeycloakCapabilitySet{
+			ID:   fmt.Sprintf("cap-%d", i),
+			Name: fmt.Sprintf("capability-%d", i),
+		}
+	}
+	capSetsResponse := models.KeycloakCapabilitySetsResponse{CapabilitySets: capSets}
+
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
 			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
@@ -1967,15 +3932,11 @@ func TestAttachCapabilitySetsToRoles_Success(t *testing.T) {
 		}).
 		Return(nil)
 
-	capSetsResponse := models.KeycloakCapabilitySetsResponse{
-		CapabilitySets: []models.KeycloakCapabilitySet{
-			{ID: "cap-1", Name: "users.read"},
-		},
-	}
+	mockMgmt.On("GetApplications").Return(applicationsResponse, nil)
 
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?query=name==users.read")
+			return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
 		}),
 		mock.Anything,
 		mock.Anything).
@@ -1985,6 +3946,7 @@ func TestAttachCapabilitySetsToRoles_Success(t *testing.T) {
 		}).
 		Return(nil)
 
+	// Expect 2 batches: 250 + 50
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
 			return strings.Contains(urlStr, "/capability-sets?limit=10000")
@@ -1999,29 +3961,42 @@ func TestAttachCapabilitySetsToRoles_Success(t *testing.T) {
 		}),
 		mock.Anything,
 		mock.Anything).
-		Return(nil)
+		Return(nil).Times(2)
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
+	mockMgmt.AssertExpectations(t)
 }
 
-func TestAttachCapabilitySetsToRoles_NoRoles(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_SkipsDifferentTenant(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant":          "different-tenant",
+			"capability-sets": []any{"users.read"},
+		},
+	}
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
-	rolesResponse := models.KeycloakRolesResponse{Roles: []models.KeycloakRole{}}
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "admin"},
+		},
+	}
 
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
@@ -2031,63 +4006,60 @@ func TestAttachCapabilitySetsToRoles_NoRoles(t *testing.T) {
 		Return(nil)
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
+	// Should not call GetCapabilitySetsByName or PostRetryReturnNoContent
 }
 
-func TestAttachCapabilitySetsToRoles_GetRolesError(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_SkipsRoleNotInConfig(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.ConfigRoles = map[string]any{}
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
-	expectedError := errors.New("Get roles failed")
+	rolesResponse := models.KeycloakRolesResponse{
+		Roles: []models.KeycloakRole{
+			{ID: "role-1", Name: "admin"},
+		},
+	}
+
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+		}),
 		mock.Anything,
 		mock.Anything).
-		Return(expectedError)
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakRolesResponse)
+			*target = rolesResponse
+		}).
+		Return(nil)
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestAttachCapabilitySetsToRoles_HeaderCreationError(t *testing.T) {
-	// Arrange
-	mockHTTP := &testhelpers.MockHTTPClient{}
-	action := testhelpers.NewMockAction()
-	action.KeycloakAccessToken = "" // Empty token
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
-
-	// Assert
-	assert.Error(t, err)
-	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
-	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
-}
-
-func TestDetachCapabilitySetsFromRoles_Success(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_NoCapabilitySetsFound(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
 	action.ConfigRoles = map[string]any{
-		"admin": map[string]any{},
+		"admin": map[string]any{
+			"tenant":          "test-tenant",
+			"capability-sets": []any{"nonexistent"},
+		},
 	}
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
@@ -2099,8 +4071,12 @@ func TestDetachCapabilitySetsFromRoles_Success(t *testing.T) {
 		},
 	}
 
+	capSetsResponse := models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{}}
+
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
@@ -2109,187 +4085,140 @@ func TestDetachCapabilitySetsFromRoles_Success(t *testing.T) {
 		}).
 		Return(nil)
 
-	mockHTTP.On("Delete",
+	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/role-1/capability-sets")
+			return strings.Contains(urlStr, "/capability-sets?query=name==nonexistent")
 		}),
+		mock.Anything,
 		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = capSetsResponse
+		}).
 		Return(nil)
 
 	// Act
-	err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+	results, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 0, results[0].Attached)
+	assert.Equal(t, []string{"nonexistent"}, results[0].Unresolved)
 	mockHTTP.AssertExpectations(t)
+	// Should not call PostRetryReturnNoContent since no capability sets were found
 }
 
-func TestDetachCapabilitySetsFromRoles_NoRoles(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_RetriesRoleWithoutCapabilitySets(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	rolesResponse := models.KeycloakRolesResponse{Roles: []models.KeycloakRole{}}
+	action.Param.AttachRetries = 2
+	action.Param.AttachRetryWait = time.Millisecond
+	action.ConfigRoles = map[string]any{
+		"admin": map[string]any{
+			"tenant":          "test-tenant",
+			"capability-sets": []any{"users.read"},
+		},
+	}
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles?offset=0&limit=10000") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}}}
 		}).
 		Return(nil)
-
-	// Act
-	err := svc.DetachCapabilitySetsFromRoles("test-tenant")
-
-	// Assert
-	assert.NoError(t, err)
-	mockHTTP.AssertExpectations(t)
-}
-
-func TestDetachCapabilitySetsFromRoles_HeaderCreationError(t *testing.T) {
-	// Arrange
-	mockHTTP := &testhelpers.MockHTTPClient{}
-	action := testhelpers.NewMockAction()
-	action.KeycloakAccessToken = "" // Empty token
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	// Act
-	err := svc.DetachCapabilitySetsFromRoles("test-tenant")
-
-	// Assert
-	assert.Error(t, err)
-	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
-	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
-}
-
-func TestDetachCapabilitySetsFromRoles_GetRolesError(t *testing.T) {
-	// Arrange
-	mockHTTP := &testhelpers.MockHTTPClient{}
-	action := testhelpers.NewMockAction()
-	action.KeycloakAccessToken = "test-token"
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/capability-sets?query=name==users.read") }),
 		mock.Anything,
 		mock.Anything).
-		Return(errors.New("get roles failed"))
-
-	// Act
-	err := svc.DetachCapabilitySetsFromRoles("test-tenant")
-
-	// Assert
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "get roles failed")
-	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
-}
-
-func TestDetachCapabilitySetsFromRoles_NotFoundIgnored(t *testing.T) {
-	// Arrange
-	mockHTTP := &testhelpers.MockHTTPClient{}
-	action := testhelpers.NewMockAction()
-	action.KeycloakAccessToken = "test-token"
-	action.ConfigRoles = map[string]any{
-		"admin": map[string]any{},
-	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin"},
-		},
-	}
-
+		Return(nil).Once()
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/capability-sets?query=name==users.read") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{{ID: "cap-1", Name: "users.read"}}}
 		}).
+		Return(nil).Once()
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-1/capability-sets") }),
+		mock.Anything,
+		mock.Anything).
 		Return(nil)
-
-	mockHTTP.On("Delete",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/role-1/capability-sets")
-		}),
+	mockHTTP.On("PostRetryReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/capability-sets") }),
+		mock.Anything,
 		mock.Anything).
-		Return(apperrors.ErrHTTP404NotFound)
+		Return(nil)
 
 	// Act
-	err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+	results, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
-	// 404 errors should be logged but not returned as errors
 	assert.NoError(t, err)
+	assert.Equal(t, []models.RoleCapabilitySetsAttachResult{{RoleName: "admin", Tenant: "test-tenant", Attached: 1}}, results)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestDetachCapabilitySetsFromRoles_DeleteError(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_StopsRetryingAfterAttempts(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.Param.AttachRetries = 2
+	action.Param.AttachRetryWait = time.Millisecond
 	action.ConfigRoles = map[string]any{
-		"admin": map[string]any{},
-	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin"},
+		"admin": map[string]any{
+			"tenant":          "test-tenant",
+			"capability-sets": []any{"users.read"},
 		},
 	}
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles?offset=0&limit=10000") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			*target = models.KeycloakRolesResponse{Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}}}
 		}).
 		Return(nil)
-
-	mockHTTP.On("Delete",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/role-1/capability-sets")
-		}),
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/capability-sets?query=name==users.read") }),
+		mock.Anything,
 		mock.Anything).
-		Return(errors.New("delete failed"))
+		Return(nil)
 
 	// Act
-	err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+	results, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "delete failed")
-	mockHTTP.AssertExpectations(t)
+	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 0, results[0].Attached)
+	assert.Equal(t, []string{"users.read"}, results[0].Unresolved)
+	mockHTTP.AssertNumberOfCalls(t, "GetRetryReturnStruct", 4)
+	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestDetachCapabilitySetsFromRoles_RoleNotInConfig(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_PopulateCapabilitySetsError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
 	action.ConfigRoles = map[string]any{
-		"admin": map[string]any{},
+		"admin": map[string]any{
+			"tenant":          "test-tenant",
+			"capability-sets": []any{"users.read"},
+		},
 	}
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
@@ -2297,13 +4226,15 @@ func TestDetachCapabilitySetsFromRoles_RoleNotInConfig(t *testing.T) {
 
 	rolesResponse := models.KeycloakRolesResponse{
 		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "user"}, // Not in config
-			{ID: "role-2", Name: "admin"},
+			{ID: "role-1", Name: "admin"},
 		},
 	}
 
+	expectedError := errors.New("HTTP error")
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
@@ -2312,29 +4243,24 @@ func TestDetachCapabilitySetsFromRoles_RoleNotInConfig(t *testing.T) {
 		}).
 		Return(nil)
 
-	// Only admin role should be deleted, not user role
-	mockHTTP.On("Delete",
+	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/role-2/capability-sets")
+			return strings.Contains(urlStr, "/capability-sets?query=name==users.read")
 		}),
+		mock.Anything,
 		mock.Anything).
-		Return(nil)
+		Return(expectedError)
 
 	// Act
-	err := svc.DetachCapabilitySetsFromRoles("test-tenant")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
-	assert.NoError(t, err)
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
 	mockHTTP.AssertExpectations(t)
-	// Verify Delete was NOT called for role-1
-	mockHTTP.AssertNotCalled(t, "Delete",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/role-1/capability-sets")
-		}),
-		mock.Anything)
 }
 
-func TestAttachCapabilitySetsToRoles_WithAllCapabilitySets(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_PostError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
@@ -2342,7 +4268,7 @@ func TestAttachCapabilitySetsToRoles_WithAllCapabilitySets(t *testing.T) {
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
 			"tenant":          "test-tenant",
-			"capability-sets": []any{"all"},
+			"capability-sets": []any{"users.read"},
 		},
 	}
 	mockVault := &MockVaultClient{}
@@ -2355,19 +4281,13 @@ func TestAttachCapabilitySetsToRoles_WithAllCapabilitySets(t *testing.T) {
 		},
 	}
 
-	applicationsResponse := models.ApplicationsResponse{
-		ApplicationDescriptors: []map[string]any{
-			{"id": "app-1"},
-		},
-	}
-
 	capSetsResponse := models.KeycloakCapabilitySetsResponse{
 		CapabilitySets: []models.KeycloakCapabilitySet{
 			{ID: "cap-1", Name: "users.read"},
-			{ID: "cap-2", Name: "users.write"},
 		},
 	}
 
+	expectedError := errors.New("Post failed")
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
 			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
@@ -2380,11 +4300,9 @@ func TestAttachCapabilitySetsToRoles_WithAllCapabilitySets(t *testing.T) {
 		}).
 		Return(nil)
 
-	mockMgmt.On("GetApplications").Return(applicationsResponse, nil)
-
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
+			return strings.Contains(urlStr, "/capability-sets?query=name==users.read")
 		}),
 		mock.Anything,
 		mock.Anything).
@@ -2403,246 +4321,314 @@ func TestAttachCapabilitySetsToRoles_WithAllCapabilitySets(t *testing.T) {
 		Return(nil)
 
 	mockHTTP.On("PostRetryReturnNoContent",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/capability-sets")
-		}),
+		mock.Anything,
 		mock.Anything,
 		mock.Anything).
-		Return(nil)
+		Return(expectedError)
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
-	assert.NoError(t, err)
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
 	mockHTTP.AssertExpectations(t)
-	mockMgmt.AssertExpectations(t)
 }
 
-func TestAttachCapabilitySetsToRoles_LargeBatchSplitting(t *testing.T) {
+// ==================== Idempotency Tests ====================
+
+func TestCreateRoles_RoleAlreadyExists_Skipped(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
-			"tenant":          "test-tenant",
-			"capability-sets": []any{"all"},
+			"tenant": "test-tenant",
 		},
 	}
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin"},
-		},
-	}
-
-	applicationsResponse := models.ApplicationsResponse{
-		ApplicationDescriptors: []map[string]any{
-			{"id": "app-1"},
-		},
-	}
-
-	// Create 300 capability sets to test batch splitting (batch size is 250)
-	capSets := make([]models.KeycloakCapabilitySet, 300)
-	for i := 0; i < 300; i++ {
-		capSets[i] = models.KeycloakCapabilitySet{
-			ID:   fmt.Sprintf("cap-%d", i),
-			Name: fmt.Sprintf("capability-%d", i),
-		}
-	}
-	capSetsResponse := models.KeycloakCapabilitySetsResponse{CapabilitySets: capSets}
-
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+			return strings.Contains(urlStr, "/roles?query=name==")
 		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			target.Roles = []models.KeycloakRole{{ID: "role-1", Name: "admin"}}
+			target.TotalCount = 1
 		}).
 		Return(nil)
 
-	mockMgmt.On("GetApplications").Return(applicationsResponse, nil)
+	// Act
+	err := svc.CreateRoles("test-tenant")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateUsers_UserAlreadyExists_Skipped(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
+	action.ConfigUsers = map[string]any{
+		"testuser": map[string]any{
+			"tenant":     "test-tenant",
+			"password":   "pass123",
+			"first-name": "Test",
+			"last-name":  "User",
+		},
+	}
+	mockVault := &MockVaultClient{}
+	mockMgmt := &MockManagementSvc{}
+	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
+			return strings.Contains(urlStr, "/users?query=username==")
 		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
-			*target = capSetsResponse
+			target := args.Get(2).(*models.KeycloakUsersResponse)
+			target.Users = []models.KeycloakUser{{ID: "user-1", Username: "testuser", Active: true}}
 		}).
 		Return(nil)
 
-	// Expect 2 batches: 250 + 50
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?limit=10000")
-		}),
-		mock.Anything,
-		mock.Anything).
-		Return(nil)
-
-	mockHTTP.On("PostRetryReturnNoContent",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/capability-sets")
-		}),
-		mock.Anything,
-		mock.Anything).
-		Return(nil).Times(2)
-
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	err := svc.CreateUsers("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
-	mockHTTP.AssertExpectations(t)
-	mockMgmt.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestAttachCapabilitySetsToRoles_SkipsDifferentTenant(t *testing.T) {
+func TestCreateUsers_UserAlreadyExists_UpdateExisting_KeepsUnconfiguredFields(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
-	action.ConfigRoles = map[string]any{
-		"admin": map[string]any{
-			"tenant":          "different-tenant",
-			"capability-sets": []any{"users.read"},
+	action.Param.UpdateExisting = true
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
+	action.ConfigUsers = map[string]any{
+		"testuser": map[string]any{
+			"tenant":     "test-tenant",
+			"password":   "pass123",
+			"first-name": "Renamed",
+			"last-name":  "User",
 		},
 	}
 	mockVault := &MockVaultClient{}
 	mockMgmt := &MockManagementSvc{}
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin"},
-		},
-	}
-
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
+			return strings.Contains(urlStr, "/users?query=username==")
 		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			target := args.Get(2).(*models.KeycloakUsersResponse)
+			target.Users = []models.KeycloakUser{{ID: "user-1", Username: "testuser", Active: true}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.HasSuffix(urlStr, "/users-keycloak/users/user-1")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*map[string]any)
+			*target = map[string]any{
+				"id":             "user-1",
+				"username":       "testuser",
+				"active":         true,
+				"type":           "staff",
+				"barcode":        "123456",
+				"patronGroup":    "group-1",
+				"departments":    []any{"department-1"},
+				"customFields":   map[string]any{"nickname": "tester"},
+				"expirationDate": "2030-01-01T00:00:00.000+00:00",
+				"personal": map[string]any{
+					"firstName":  "Test",
+					"lastName":   "User",
+					"email":      "test@example.org",
+					"middleName": "Middle",
+				},
+			}
 		}).
 		Return(nil)
+	mockHTTP.On("PutReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.HasSuffix(urlStr, "/users-keycloak/users/user-1")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var user map[string]any
+			_ = json.Unmarshal(payload, &user)
+			return assert.ObjectsAreEqual(map[string]any{
+				"id":             "user-1",
+				"username":       "testuser",
+				"active":         true,
+				"type":           "staff",
+				"barcode":        "123456",
+				"patronGroup":    "group-1",
+				"departments":    []any{"department-1"},
+				"customFields":   map[string]any{"nickname": "tester"},
+				"expirationDate": "2030-01-01T00:00:00.000+00:00",
+				"personal": map[string]any{
+					"firstName":  "Renamed",
+					"lastName":   "User",
+					"email":      "test@example.org",
+					"middleName": "Middle",
+				},
+			}, user)
+		}),
+		mock.Anything).
+		Return(nil)
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	err := svc.CreateUsers("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
-	// Should not call GetCapabilitySetsByName or PostRetryReturnNoContent
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestAttachCapabilitySetsToRoles_SkipsRoleNotInConfig(t *testing.T) {
+func TestCreateUsers_UserAlreadyExists_AttachesOnlyMissingRoles(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
-	action.ConfigRoles = map[string]any{}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin"},
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
+	}
+	action.ConfigUsers = map[string]any{
+		"testuser": map[string]any{
+			"tenant":   "test-tenant",
+			"password": "pass123",
+			"roles":    []any{"admin", "viewer"},
 		},
 	}
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
-		}),
-		mock.Anything,
-		mock.Anything).
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/users?query=username==testuser")
+	}), mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			target := args.Get(2).(*models.KeycloakUsersResponse)
+			target.Users = []models.KeycloakUser{{ID: "user-1", Username: "testuser", Active: true}}
+		}).
+		Return(nil)
+	for roleName, roleID := range map[string]string{"admin": "role-1", "viewer": "role-2"} {
+		mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles?query=name=="+roleName)
+		}), mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				target := args.Get(2).(*models.KeycloakRolesResponse)
+				target.Roles = []models.KeycloakRole{{ID: roleID, Name: roleName}}
+			}).
+			Return(nil)
+	}
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.HasSuffix(urlStr, "/roles/users/user-1")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakUserRolesResponse)
+			target.UserRoles = []models.KeycloakUserRole{{UserID: "user-1", RoleID: "role-1"}}
 		}).
 		Return(nil)
 
+	var payload models.KeycloakUserRoleRequest
+	mockHTTP.On("PostRetryReturnNoContent", mock.MatchedBy(func(urlStr string) bool {
+		return strings.HasSuffix(urlStr, "/roles/users")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal(args.Get(1).([]byte), &payload)
+		}).
+		Return(nil).Once()
+
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	err := svc.CreateUsers("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, models.KeycloakUserRoleRequest{UserID: "user-1", RoleIDs: []string{"role-2"}}, payload)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestAttachCapabilitySetsToRoles_NoCapabilitySetsFound(t *testing.T) {
+func TestCreateUsers_UserAlreadyExists_RolesUpToDate(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
-	action.ConfigRoles = map[string]any{
-		"admin": map[string]any{
-			"tenant":          "test-tenant",
-			"capability-sets": []any{"nonexistent"},
-		},
+	action.ConfigTenants = map[string]any{
+		"test-tenant": nil,
 	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin"},
+	action.ConfigUsers = map[string]any{
+		"testuser": map[string]any{
+			"tenant":   "test-tenant",
+			"password": "pass123",
+			"roles":    []any{"admin"},
 		},
 	}
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
-	capSetsResponse := models.KeycloakCapabilitySetsResponse{CapabilitySets: []models.KeycloakCapabilitySet{}}
-
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
-		}),
-		mock.Anything,
-		mock.Anything).
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/users?query=username==testuser")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakUsersResponse)
+			target.Users = []models.KeycloakUser{{ID: "user-1", Username: "testuser", Active: true}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.Contains(urlStr, "/roles?query=name==admin")
+	}), mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			target.Roles = []models.KeycloakRole{{ID: "role-1", Name: "admin"}}
 		}).
 		Return(nil)
-
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?query=name==nonexistent")
-		}),
-		mock.Anything,
-		mock.Anything).
+	mockHTTP.On("GetRetryReturnStruct", mock.MatchedBy(func(urlStr string) bool {
+		return strings.HasSuffix(urlStr, "/roles/users/user-1")
+	}), mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
-			*target = capSetsResponse
+			target := args.Get(2).(*models.KeycloakUserRolesResponse)
+			target.UserRoles = []models.KeycloakUserRole{{UserID: "user-1", RoleID: "role-1"}}
 		}).
 		Return(nil)
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	err := svc.CreateUsers("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
-	mockHTTP.AssertExpectations(t)
-	// Should not call PostRetryReturnNoContent since no capability sets were found
+	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestAttachCapabilitySetsToRoles_PopulateCapabilitySetsError(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_AllAlreadyAttached_Skipped(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
@@ -2658,12 +4644,12 @@ func TestAttachCapabilitySetsToRoles_PopulateCapabilitySetsError(t *testing.T) {
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
 	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin"},
-		},
+		Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}},
+	}
+	capSetsResponse := models.KeycloakCapabilitySetsResponse{
+		CapabilitySets: []models.KeycloakCapabilitySet{{ID: "cap-1", Name: "users.read"}},
 	}
 
-	expectedError := errors.New("HTTP error")
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
 			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
@@ -2682,18 +4668,37 @@ func TestAttachCapabilitySetsToRoles_PopulateCapabilitySetsError(t *testing.T) {
 		}),
 		mock.Anything,
 		mock.Anything).
-		Return(expectedError)
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			*target = capSetsResponse
+		}).
+		Return(nil)
+
+	// cap-1 already attached — delta is empty
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/capability-sets?limit=10000")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-1"}}
+		}).
+		Return(nil)
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	results, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	mockHTTP.AssertExpectations(t)
+	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 0, results[0].Attached)
+	assert.Empty(t, results[0].Unresolved)
+	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestAttachCapabilitySetsToRoles_PostError(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_PartiallyAttached_PostsDelta(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
@@ -2709,18 +4714,16 @@ func TestAttachCapabilitySetsToRoles_PostError(t *testing.T) {
 	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
 
 	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{
-			{ID: "role-1", Name: "admin"},
-		},
+		Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}},
 	}
-
+	// GetCapabilitySetsByName returns two entries for the single set name
 	capSetsResponse := models.KeycloakCapabilitySetsResponse{
 		CapabilitySets: []models.KeycloakCapabilitySet{
 			{ID: "cap-1", Name: "users.read"},
+			{ID: "cap-2", Name: "users.write"},
 		},
 	}
 
-	expectedError := errors.New("Post failed")
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
 			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
@@ -2745,172 +4748,178 @@ func TestAttachCapabilitySetsToRoles_PostError(t *testing.T) {
 		}).
 		Return(nil)
 
+	// Only cap-1 already attached; cap-2 is the delta
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
 			return strings.Contains(urlStr, "/capability-sets?limit=10000")
 		}),
 		mock.Anything,
 		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-1"}}
+		}).
 		Return(nil)
 
 	mockHTTP.On("PostRetryReturnNoContent",
-		mock.Anything,
-		mock.Anything,
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.Contains(urlStr, "/roles/capability-sets")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			ids, ok := data["capabilitySetIds"].([]any)
+			return ok && len(ids) == 1 && ids[0] == "cap-2"
+		}),
 		mock.Anything).
-		Return(expectedError)
+		Return(nil)
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	results, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results[0].Attached)
 	mockHTTP.AssertExpectations(t)
 }
 
-// ==================== Idempotency Tests ====================
-
-func TestCreateRoles_RoleAlreadyExists_Skipped(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_Strict_ReplacesExtras(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.Param.Strict = true
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
-			"tenant": "test-tenant",
+			"tenant":          "test-tenant",
+			"capability-sets": []any{"users.read"},
 		},
 	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles?query=name==")
-		}),
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles?offset=0&limit=10000") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakRolesResponse)
 			target.Roles = []models.KeycloakRole{{ID: "role-1", Name: "admin"}}
-			target.TotalCount = 1
 		}).
 		Return(nil)
-
-	// Act
-	err := svc.CreateRoles("test-tenant")
-
-	// Assert
-	assert.NoError(t, err)
-	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
-}
-
-func TestCreateUsers_UserAlreadyExists_Skipped(t *testing.T) {
-	// Arrange
-	mockHTTP := &testhelpers.MockHTTPClient{}
-	action := testhelpers.NewMockAction()
-	action.KeycloakAccessToken = "test-token"
-	action.ConfigUsers = map[string]any{
-		"testuser": map[string]any{
-			"tenant":     "test-tenant",
-			"password":   "pass123",
-			"first-name": "Test",
-			"last-name":  "User",
-		},
-	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/users?query=username==")
-		}),
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/capability-sets?query=name==users.read") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.KeycloakUsersResponse)
-			target.Users = []models.KeycloakUser{{ID: "user-1", Username: "testuser", Active: true}}
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-1", Name: "users.read"}}
+		}).
+		Return(nil)
+	// cap-stale is attached but no longer configured
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-1/capability-sets?limit=10000") }),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
+			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-1"}, {ID: "cap-stale"}}
 		}).
 		Return(nil)
+	mockHTTP.On("PutReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool { return strings.HasSuffix(urlStr, "/roles/role-1/capability-sets") }),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string][]string
+			_ = json.Unmarshal(payload, &data)
+			return assert.ObjectsAreEqual([]string{"cap-1"}, data["capabilitySetIds"])
+		}),
+		mock.Anything).
+		Return(nil)
 
 	// Act
-	err := svc.CreateUsers("test-tenant")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
-	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
-	mockHTTP.AssertNotCalled(t, "PostReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestAttachCapabilitySetsToRoles_AllAlreadyAttached_Skipped(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_Strict_ReplacesInBatches(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakAccessToken = "test-token"
+	action.Param.Strict = true
 	action.ConfigRoles = map[string]any{
 		"admin": map[string]any{
 			"tenant":          "test-tenant",
-			"capability-sets": []any{"users.read"},
+			"capability-sets": []any{"all:app-1"},
 		},
 	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}},
-	}
-	capSetsResponse := models.KeycloakCapabilitySetsResponse{
-		CapabilitySets: []models.KeycloakCapabilitySet{{ID: "cap-1", Name: "users.read"}},
+	capabilitySets := make([]models.KeycloakCapabilitySet, 0, constant.CapabilitySetsBatchSize+1)
+	for i := range constant.CapabilitySetsBatchSize + 1 {
+		capabilitySets = append(capabilitySets, models.KeycloakCapabilitySet{ID: fmt.Sprintf("cap-%d", i)})
 	}
-
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
-		}),
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles?offset=0&limit=10000") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			target.Roles = []models.KeycloakRole{{ID: "role-1", Name: "admin"}}
 		}).
 		Return(nil)
-
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?query=name==users.read")
+			return strings.Contains(urlStr, "/capability-sets?query=applicationId==app-1")
 		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
-			*target = capSetsResponse
+			target.CapabilitySets = capabilitySets
 		}).
 		Return(nil)
-
-	// cap-1 already attached — delta is empty
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?limit=10000")
-		}),
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-1/capability-sets?limit=10000") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
-			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-1"}}
+			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-stale"}}
 		}).
 		Return(nil)
+	mockHTTP.On("PutReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool { return strings.HasSuffix(urlStr, "/roles/role-1/capability-sets") }),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string][]string
+			_ = json.Unmarshal(payload, &data)
+			return len(data["capabilitySetIds"]) == constant.CapabilitySetsBatchSize
+		}),
+		mock.Anything).
+		Return(nil).Once()
+	mockHTTP.On("PostRetryReturnNoContent",
+		mock.MatchedBy(func(urlStr string) bool { return strings.HasSuffix(urlStr, "/roles/capability-sets") }),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			return data["roleId"] == "role-1" && assert.ObjectsAreEqual([]any{fmt.Sprintf("cap-%d", constant.CapabilitySetsBatchSize)}, data["capabilitySetIds"])
+		}),
+		mock.Anything).
+		Return(nil).Once()
 
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
-	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestAttachCapabilitySetsToRoles_PartiallyAttached_PostsDelta(t *testing.T) {
+func TestAttachCapabilitySetsToRoles_NonStrict_KeepsExtras(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
@@ -2921,75 +4930,42 @@ func TestAttachCapabilitySetsToRoles_PartiallyAttached_PostsDelta(t *testing.T)
 			"capability-sets": []any{"users.read"},
 		},
 	}
-	mockVault := &MockVaultClient{}
-	mockMgmt := &MockManagementSvc{}
-	svc := keycloaksvc.New(action, mockHTTP, mockVault, mockMgmt)
-
-	rolesResponse := models.KeycloakRolesResponse{
-		Roles: []models.KeycloakRole{{ID: "role-1", Name: "admin"}},
-	}
-	// GetCapabilitySetsByName returns two entries for the single set name
-	capSetsResponse := models.KeycloakCapabilitySetsResponse{
-		CapabilitySets: []models.KeycloakCapabilitySet{
-			{ID: "cap-1", Name: "users.read"},
-			{ID: "cap-2", Name: "users.write"},
-		},
-	}
+	svc := keycloaksvc.New(action, mockHTTP, &MockVaultClient{}, &MockManagementSvc{})
 
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles?offset=0&limit=10000")
-		}),
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles?offset=0&limit=10000") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakRolesResponse)
-			*target = rolesResponse
+			target.Roles = []models.KeycloakRole{{ID: "role-1", Name: "admin"}}
 		}).
 		Return(nil)
-
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?query=name==users.read")
-		}),
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/capability-sets?query=name==users.read") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
-			*target = capSetsResponse
+			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-1", Name: "users.read"}}
 		}).
 		Return(nil)
-
-	// Only cap-1 already attached; cap-2 is the delta
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/capability-sets?limit=10000")
-		}),
+		mock.MatchedBy(func(urlStr string) bool { return strings.Contains(urlStr, "/roles/role-1/capability-sets?limit=10000") }),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.KeycloakCapabilitySetsResponse)
-			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-1"}}
+			target.CapabilitySets = []models.KeycloakCapabilitySet{{ID: "cap-1"}, {ID: "cap-stale"}}
 		}).
 		Return(nil)
 
-	mockHTTP.On("PostRetryReturnNoContent",
-		mock.MatchedBy(func(urlStr string) bool {
-			return strings.Contains(urlStr, "/roles/capability-sets")
-		}),
-		mock.MatchedBy(func(payload []byte) bool {
-			var data map[string]any
-			_ = json.Unmarshal(payload, &data)
-			ids, ok := data["capabilitySetIds"].([]any)
-			return ok && len(ids) == 1 && ids[0] == "cap-2"
-		}),
-		mock.Anything).
-		Return(nil)
-
 	// Act
-	err := svc.AttachCapabilitySetsToRoles("test-tenant")
+	_, err := svc.AttachCapabilitySetsToRoles("test-tenant")
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostRetryReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
@@ -2,10 +2,14 @@ package keycloaksvc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
 )
@@ -14,32 +18,36 @@ import (
 type KeycloakUserManager interface {
 	GetUsers(tenantName string) ([]any, error)
 	CreateUsers(configTenant string) error
-	RemoveUsers(tenantName string) error
+	RemoveUsers(tenantName string) (int, error)
+	DetachAllUserRoles(tenantName string) error
 }
 
+// GetUsers returns all users of a tenant, the users are fetched page by page until a page is not full
 func (ks *KeycloakSvc) GetUsers(tenantName string) ([]any, error) {
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, "/users?offset=0&limit=10000")
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
 		return nil, err
 	}
 
-	var decodedResponse models.KeycloakUsersResponse
-	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
-		return nil, err
-	}
-	if len(decodedResponse.Users) == 0 {
-		return nil, nil
-	}
+	var result []any
+	for offset := 0; ; offset += constant.UsersPageSize {
+		requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/users?offset=%d&limit=%d", offset, constant.UsersPageSize))
 
-	result := make([]any, len(decodedResponse.Users))
-	for i, user := range decodedResponse.Users {
-		result[i] = map[string]any{
-			"id":       user.ID,
-			"username": user.Username,
-			"active":   user.Active,
-			"type":     user.Type,
-			"personal": user.Personal,
+		var decodedResponse models.KeycloakUsersResponse
+		if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
+			return nil, err
+		}
+		for _, user := range decodedResponse.Users {
+			result = append(result, map[string]any{
+				"id":       user.ID,
+				"username": user.Username,
+				"active":   user.Active,
+				"type":     user.Type,
+				"personal": user.Personal,
+			})
+		}
+		if len(decodedResponse.Users) < constant.UsersPageSize {
+			break
 		}
 	}
 
@@ -47,8 +55,12 @@ func (ks *KeycloakSvc) GetUsers(tenantName string) ([]any, error) {
 }
 
 func (ks *KeycloakSvc) CreateUsers(configTenant string) error {
+	if err := ks.Action.ValidateUserTenants(); err != nil {
+		return err
+	}
 	usernames := helpers.SortedMapKeys(ks.Action.ConfigUsers)
 
+	var usersWithoutRoles []string
 	for _, username := range usernames {
 		value := ks.Action.ConfigUsers[username]
 		entry := value.(map[string]any)
@@ -61,35 +73,52 @@ func (ks *KeycloakSvc) CreateUsers(configTenant string) error {
 		if err != nil {
 			return err
 		}
-		if existingUser != nil {
-			slog.Info(ks.Action.Name, "text", "User already exists, skipping", "username", username, "tenant", tenantName)
-			continue
-		}
 
-		createdUser, err := ks.createUser(tenantName, username, entry)
-		if err != nil {
-			return err
-		}
+		userID := helpers.GetString(existingUser, "id")
+		if existingUser != nil {
+			slog.Info(ks.Action.Name, "text", "User already exists, reconciling its roles", "username", username, "tenant", tenantName)
+			if ks.Action.Param != nil && ks.Action.Param.UpdateExisting {
+				if err := ks.updateUser(tenantName, userID, username, entry); err != nil {
+					return err
+				}
+			}
+		} else {
+			createdUser, err := ks.createUser(tenantName, username, entry)
+			if err != nil {
+				if ks.Action.IsIgnorableConflict(err) {
+					slog.Info(ks.Action.Name, "text", "User already exists", "username", username, "tenant", tenantName)
+					continue
+				}
+				return err
+			}
 
-		userID := helpers.GetString(createdUser, "id")
-		if err := ks.attachUserPassword(tenantName, userID, username, entry); err != nil {
-			return err
+			userID = helpers.GetString(createdUser, "id")
+			if err := ks.attachUserPassword(tenantName, userID, username, entry); err != nil {
+				return err
+			}
 		}
 
 		userRoles := helpers.GetAnySlice(entry, "roles")
 		if len(userRoles) > 0 {
-			if err := ks.attachUserRoles(tenantName, userID, username, userRoles); err != nil {
-				return err
+			if err := ks.attachUserRoles(tenantName, userID, username, userRoles, existingUser != nil); err != nil {
+				if !errors.Is(err, apperrors.ErrRoleAttachmentFailed) {
+					return err
+				}
+				slog.Warn(ks.Action.Name, "text", "User is left without its roles", "username", username, "tenant", tenantName, "error", err)
+				usersWithoutRoles = append(usersWithoutRoles, username)
 			}
 		}
 	}
+	if len(usersWithoutRoles) > 0 {
+		return apperrors.UsersWithoutRoles(configTenant, usersWithoutRoles)
+	}
 
 	return nil
 }
 
 func (ks *KeycloakSvc) getUserByUsername(tenantName, username string) (map[string]any, error) {
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/users?query=username==%s&limit=1", username))
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/users?query=username==%s&limit=1", username))
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
 		return nil, err
 	}
@@ -108,23 +137,34 @@ func (ks *KeycloakSvc) getUserByUsername(tenantName, username string) (map[strin
 	}, nil
 }
 
-func (ks *KeycloakSvc) createUser(tenantName string, username string, entry map[string]any) (map[string]any, error) {
-	payload, err := json.Marshal(map[string]any{
+// newUserPayload builds a user from its config entry, the email, type and preferred contact type
+// fall back to a per-tenant test address, a staff user and the email contact type, a tag is added to the user tags
+func newUserPayload(tenantName string, username string, entry map[string]any, tag string) map[string]any {
+	user := map[string]any{
 		"username": username,
 		"active":   true,
-		"type":     "staff",
+		"type":     helpers.GetStringOrDefault(entry, field.UsersTypeEntry, constant.StaffUserType),
 		"personal": map[string]any{
 			"firstName":              helpers.GetString(entry, "first-name"),
 			"lastName":               helpers.GetString(entry, "last-name"),
-			"email":                  fmt.Sprintf("%s_%s@test.org", tenantName, username),
-			"preferredContactTypeId": "002",
+			"email":                  helpers.GetStringOrDefault(entry, field.UsersEmailEntry, fmt.Sprintf("%s_%s@test.org", tenantName, username)),
+			"preferredContactTypeId": helpers.GetStringOrDefault(entry, field.UsersPreferredContactTypeIDEntry, constant.EmailContactType),
 		},
-	})
+	}
+	if tag != "" {
+		user["tags"] = map[string]any{"tagList": []string{tag}}
+	}
+
+	return user
+}
+
+func (ks *KeycloakSvc) createUser(tenantName string, username string, entry map[string]any) (map[string]any, error) {
+	payload, err := json.Marshal(newUserPayload(tenantName, username, entry, ks.Action.GetTag()))
 	if err != nil {
 		return nil, err
 	}
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, "/users-keycloak/users")
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), "/users-keycloak/users")
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +178,74 @@ func (ks *KeycloakSvc) createUser(tenantName string, username string, entry map[
 	return decodedResponse, nil
 }
 
+// updateUser merges the configured fields into the existing user before putting it back, so that the fields
+// missing from the config, e.g. the patron group, barcode or custom fields, and the password are kept
+func (ks *KeycloakSvc) updateUser(tenantName, userID, username string, entry map[string]any) error {
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/users-keycloak/users/%s", userID))
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return err
+	}
+
+	var user map[string]any
+	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &user); err != nil {
+		return err
+	}
+	if user == nil {
+		user = map[string]any{"id": userID, "username": username}
+	}
+	mergeUserPayload(user, entry, ks.Action.GetTag())
+
+	payload, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+	if err := ks.HTTPClient.PutReturnNoContent(requestURL, payload, headers); err != nil {
+		return err
+	}
+	slog.Info(ks.Action.Name, "text", "Updated user", "username", username, "tenant", tenantName)
+
+	return nil
+}
+
+// mergeUserPayload sets only the fields present in the config entry of a user onto an existing user, unlike
+// newUserPayload no defaults are applied and the tag is appended to the existing tags
+func mergeUserPayload(user map[string]any, entry map[string]any, tag string) {
+	if userType := helpers.GetString(entry, field.UsersTypeEntry); userType != "" {
+		user["type"] = userType
+	}
+
+	personal, ok := user["personal"].(map[string]any)
+	if !ok {
+		personal = map[string]any{}
+		user["personal"] = personal
+	}
+	personalFields := map[string]string{
+		field.UsersFirstNameEntry:              "firstName",
+		field.UsersLastNameEntry:               "lastName",
+		field.UsersEmailEntry:                  "email",
+		field.UsersPreferredContactTypeIDEntry: "preferredContactTypeId",
+	}
+	for entryKey, personalKey := range personalFields {
+		if value := helpers.GetString(entry, entryKey); value != "" {
+			personal[personalKey] = value
+		}
+	}
+
+	if tag == "" {
+		return
+	}
+	tags, ok := user["tags"].(map[string]any)
+	if !ok {
+		tags = map[string]any{}
+		user["tags"] = tags
+	}
+	tagList := helpers.GetAnySlice(tags, "tagList")
+	if !slices.Contains(tagList, any(tag)) {
+		tags["tagList"] = append(tagList, tag)
+	}
+}
+
 func (ks *KeycloakSvc) attachUserPassword(tenantName, userID, username string, entry map[string]any) error {
 	payload, err := json.Marshal(map[string]any{
 		"userId":   userID,
@@ -148,8 +256,8 @@ func (ks *KeycloakSvc) attachUserPassword(tenantName, userID, username string, e
 		return err
 	}
 
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, "/authn/credentials")
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), "/authn/credentials")
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
 		return err
 	}
@@ -161,9 +269,11 @@ func (ks *KeycloakSvc) attachUserPassword(tenantName, userID, username string, e
 	return nil
 }
 
-func (ks *KeycloakSvc) attachUserRoles(tenantName, userID, username string, userRoles []any) error {
-	requestURL := ks.Action.GetRequestURL(constant.KongPort, "/roles/users")
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+// attachUserRoles assigns the configured roles to a user, with reconcile only the roles the user does not have yet
+// are posted, so a re-run completes a user whose earlier role attachment failed without a conflict
+func (ks *KeycloakSvc) attachUserRoles(tenantName, userID, username string, userRoles []any, reconcile bool) error {
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), "/roles/users")
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
 		return err
 	}
@@ -182,6 +292,19 @@ func (ks *KeycloakSvc) attachUserRoles(tenantName, userID, username string, user
 		}
 		roleIDs = append(roleIDs, roleID)
 	}
+	if reconcile {
+		assignedRoleIDs, err := ks.getUserRoleIDs(userID, headers)
+		if err != nil {
+			return apperrors.RoleAttachmentFailed(username, err)
+		}
+		roleIDs = slices.DeleteFunc(roleIDs, func(roleID string) bool {
+			return slices.Contains(assignedRoleIDs, roleID)
+		})
+		if len(roleIDs) == 0 {
+			slog.Info(ks.Action.Name, "text", "User already has all of its roles", "username", username, "tenant", tenantName)
+			return nil
+		}
+	}
 
 	payload, err := json.Marshal(map[string]any{
 		"userId":  userID,
@@ -190,25 +313,46 @@ func (ks *KeycloakSvc) attachUserRoles(tenantName, userID, username string, user
 	if err != nil {
 		return err
 	}
-	if err := ks.HTTPClient.PostReturnNoContent(requestURL, payload, headers); err != nil {
-		return err
+	if err := ks.HTTPClient.PostRetryReturnNoContent(requestURL, payload, headers); err != nil {
+		return apperrors.RoleAttachmentFailed(username, err)
 	}
 	slog.Info(ks.Action.Name, "text", "Attached roles to user", "username", username, "tenant", tenantName, "count", len(roleIDs))
 
 	return nil
 }
 
-func (ks *KeycloakSvc) RemoveUsers(tenantName string) error {
+func (ks *KeycloakSvc) getUserRoleIDs(userID string, headers map[string]string) ([]string, error) {
+	requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles/users/%s", userID))
+
+	var decodedResponse models.KeycloakUserRolesResponse
+	if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
+		if errors.Is(err, apperrors.ErrHTTP404NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	roleIDs := make([]string, 0, len(decodedResponse.UserRoles))
+	for _, userRole := range decodedResponse.UserRoles {
+		roleIDs = append(roleIDs, userRole.RoleID)
+	}
+
+	return roleIDs, nil
+}
+
+// RemoveUsers removes the configured users of a tenant and returns how many were removed
+func (ks *KeycloakSvc) RemoveUsers(tenantName string) (int, error) {
 	users, err := ks.GetUsers(tenantName)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	var removed int
 	for _, value := range users {
 		entry := value.(map[string]any)
 		username := helpers.GetString(entry, "username")
@@ -216,12 +360,49 @@ func (ks *KeycloakSvc) RemoveUsers(tenantName string) error {
 			continue
 		}
 
-		requestURL := ks.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/users-keycloak/users/%s", helpers.GetString(entry, "id")))
+		requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/users-keycloak/users/%s", helpers.GetString(entry, "id")))
 		if err := ks.HTTPClient.Delete(requestURL, headers); err != nil {
-			return err
+			return removed, err
 		}
+		removed++
 		slog.Info(ks.Action.Name, "text", "Removed user", "username", username, "tenant", tenantName)
 	}
 
+	return removed, nil
+}
+
+func (ks *KeycloakSvc) DetachAllUserRoles(tenantName string) error {
+	users, err := ks.GetUsers(tenantName)
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		slog.Warn(ks.Action.Name, "text", "Found no users to detach roles from", "tenant", tenantName)
+		return nil
+	}
+
+	headers, err := ks.Action.BuildTenantHeaders(tenantName, ks.Action.KeycloakAccessToken)
+	if err != nil {
+		return err
+	}
+
+	var detachedCount int
+	for _, value := range users {
+		entry := value.(map[string]any)
+		username := helpers.GetString(entry, "username")
+
+		requestURL := ks.Action.GetRequestURL(ks.Action.GetGatewayPort(), fmt.Sprintf("/roles/users/%s", helpers.GetString(entry, "id")))
+		if err := ks.HTTPClient.Delete(requestURL, headers); err != nil {
+			if errors.Is(err, apperrors.ErrHTTP404NotFound) {
+				slog.Debug(ks.Action.Name, "text", "No roles to detach from user", "username", username, "tenant", tenantName)
+				continue
+			}
+			return err
+		}
+		detachedCount++
+		slog.Info(ks.Action.Name, "text", "Detached roles from user", "username", username, "tenant", tenantName)
+	}
+	slog.Info(ks.Action.Name, "text", "Detached roles from users", "tenant", tenantName, "count", detachedCount, "total", len(users))
+
 	return nil
 }
@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
-	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/httpclient"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
@@ -16,6 +16,7 @@ import (
 // KongProcessor defines the interface for Kong service operations
 type KongProcessor interface {
 	KongRouteReader
+	KongServiceReader
 	KongRouteReadinessChecker
 }
 
@@ -26,6 +27,11 @@ type KongRouteReader interface {
 	CheckRouteExists(routeID string) (bool, *models.KongRoute, error)
 }
 
+// KongServiceReader defines the interface for Kong service read operations
+type KongServiceReader interface {
+	ListAllServices() ([]models.KongService, error)
+}
+
 // KongSvc provides functionality for Kong API gateway operations
 type KongSvc struct {
 	Action     *action.Action
@@ -49,13 +55,13 @@ func (ks *KongSvc) ListAllRoutes() ([]models.KongRoute, error) {
 	var allRoutes []models.KongRoute
 	path := "/routes"
 	for {
-		requestURL := ks.Action.GetRequestURL(constant.KongAdminPort, path)
-		
+		requestURL := ks.getAdminURL(path)
+
 		var decodedResponse models.KongRoutesResponse
 		if err := ks.HTTPClient.GetRetryReturnStruct(requestURL, nil, &decodedResponse); err != nil {
 			return nil, err
 		}
-		
+
 		allRoutes = append(allRoutes, decodedResponse.Data...)
 		if decodedResponse.Next == "" {
 			break
@@ -66,6 +72,24 @@ func (ks *KongSvc) ListAllRoutes() ([]models.KongRoute, error) {
 	return allRoutes, nil
 }
 
+func (ks *KongSvc) ListAllServices() ([]models.KongService, error) {
+	var allServices []models.KongService
+	path := "/services"
+	for {
+		var decodedResponse models.KongServicesResponse
+		if err := ks.HTTPClient.GetRetryReturnStruct(ks.getAdminURL(path), nil, &decodedResponse); err != nil {
+			return nil, err
+		}
+
+		allServices = append(allServices, decodedResponse.Data...)
+		if decodedResponse.Next == "" {
+			break
+		}
+		path = decodedResponse.Next
+	}
+
+	return allServices, nil
+}
 
 func (ks *KongSvc) FindRouteByExpressions(expressions []string) ([]*models.KongRoute, error) {
 	allRoutes, err := ks.ListAllRoutes()
@@ -84,7 +108,7 @@ func (ks *KongSvc) FindRouteByExpressions(expressions []string) ([]*models.KongR
 }
 
 func (ks *KongSvc) CheckRouteExists(routeID string) (bool, *models.KongRoute, error) {
-	requestURL := ks.Action.GetRequestURL(constant.KongAdminPort, fmt.Sprintf("/routes/%s", routeID))
+	requestURL := ks.getAdminURL(fmt.Sprintf("/routes/%s", routeID))
 	statusCode, err := ks.HTTPClient.Ping(requestURL)
 	if err != nil {
 		return false, nil, err
@@ -104,3 +128,12 @@ func (ks *KongSvc) CheckRouteExists(routeID string) (bool, *models.KongRoute, er
 
 	return true, &decodedResponse, nil
 }
+
+// getAdminURL builds an admin API URL from --kongAdminURL, falling back to the gateway-admin port
+func (ks *KongSvc) getAdminURL(path string) string {
+	if ks.Action.Param != nil && ks.Action.Param.KongAdminURL != "" {
+		return strings.TrimRight(ks.Action.Param.KongAdminURL, "/") + path
+	}
+
+	return ks.Action.GetRequestURL(ks.Action.GetGatewayAdminPort(), path)
+}
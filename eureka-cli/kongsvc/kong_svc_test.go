@@ -327,6 +327,86 @@ func TestListAllRoutes_HTTPError(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
+func TestListAllRoutes_UsesKongAdminURL(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.Param.KongAdminURL = "http://kong.example.org:9001/"
+	svc := kongsvc.New(action, mockHTTP)
+
+	mockHTTP.On("GetRetryReturnStruct", "http://kong.example.org:9001/routes", mock.Anything, mock.Anything).
+		Return(nil)
+
+	// Act
+	routes, err := svc.ListAllRoutes()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, routes)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestListAllServices_PaginatedResponse(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	svc := kongsvc.New(action, mockHTTP)
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.HasSuffix(urlStr, "/services")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KongServicesResponse)
+			*target = models.KongServicesResponse{
+				Data: []models.KongService{{ID: "service-1", Name: "mod-users-19.0.0"}},
+				Next: "/services?offset=abc",
+			}
+		}).
+		Return(nil).Once()
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(urlStr string) bool {
+			return strings.HasSuffix(urlStr, "/services?offset=abc")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.KongServicesResponse)
+			*target = models.KongServicesResponse{Data: []models.KongService{{ID: "service-2", Name: "mod-orders-13.0.0"}}}
+		}).
+		Return(nil).Once()
+
+	// Act
+	services, err := svc.ListAllServices()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.KongService{
+		{ID: "service-1", Name: "mod-users-19.0.0"},
+		{ID: "service-2", Name: "mod-orders-13.0.0"},
+	}, services)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestListAllServices_HTTPError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	svc := kongsvc.New(action, mockHTTP)
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("HTTP request failed"))
+
+	// Act
+	services, err := svc.ListAllServices()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, services)
+}
+
 func TestFindRouteByExpressions_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
@@ -4,10 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/url"
+	"os"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
@@ -29,20 +34,33 @@ type ManagementProcessor interface {
 type ManagementApplicationManager interface {
 	GetApplications() (models.ApplicationsResponse, error)
 	GetLatestApplication() (map[string]any, error)
+	GetApplication(applicationID string) (map[string]any, error)
+	GetApplicationDescriptor(applicationID string) (*models.ApplicationDescriptor, error)
+	GetApplicationDependencies(applicationID string) (*models.ApplicationDependencyGraph, error)
+	GetRegistryDependencies(extract *models.RegistryExtract) (*models.ApplicationDependencyGraph, error)
+	GetApplicationModules(applicationID string) ([]models.ApplicationModule, error)
 	CreateApplication(extract *models.RegistryExtract) error
 	CreateNewApplication(r *models.ApplicationUpgradeRequest) error
 	RemoveApplication(applicationID string) error
-	RemoveApplications(applicationName, ignoreApplicationID string) error
+	RemoveApplications(applicationName, ignoreApplicationID string, applicationIDs ...string) (int, error)
 	GetModuleDiscovery(name string) (models.ModuleDiscoveryResponse, error)
+	GetAllModuleDiscovery() ([]models.ModuleDiscovery, error)
+	GetAllRawModuleDiscovery() ([]json.RawMessage, error)
 	CreateNewModuleDiscovery(newDiscoveryModules []map[string]string) error
 	UpdateModuleDiscovery(id string, restore bool, privatePort int, sidecarURL string) error
+	BatchUpdateModuleDiscovery(discovery []models.ModuleDiscovery) error
+	BatchUpdateRawModuleDiscovery(discovery []json.RawMessage) error
+	RestoreModuleDiscovery(ids []string, privatePort int) error
 }
 
 // ManagementSvc defines the service for management operations including applications and tenants
 type ManagementSvc struct {
-	Action     *action.Action
-	HTTPClient httpclient.HTTPClientRunner
-	TenantSvc  tenantsvc.TenantProcessor
+	Action                 *action.Action
+	HTTPClient             httpclient.HTTPClientRunner
+	TenantSvc              tenantsvc.TenantProcessor
+	EntitlementPollWait    time.Duration
+	EntitlementFlowTimeout time.Duration
+	DryRunWriter           io.Writer
 }
 
 // New creates a new ManagementSvc instance
@@ -51,7 +69,7 @@ func New(action *action.Action, httpClient httpclient.HTTPClientRunner, tenantSv
 }
 
 func (ms *ManagementSvc) GetApplications() (models.ApplicationsResponse, error) {
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, "/applications")
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), "/applications")
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return models.ApplicationsResponse{}, err
@@ -66,7 +84,7 @@ func (ms *ManagementSvc) GetApplications() (models.ApplicationsResponse, error)
 }
 
 func (ms *ManagementSvc) GetLatestApplication() (map[string]any, error) {
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/applications?appName=%s&latest=1&full=true", ms.Action.ConfigApplicationName))
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/applications?appName=%s&latest=1&full=true", ms.Action.ConfigApplicationName))
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return nil, err
@@ -83,8 +101,42 @@ func (ms *ManagementSvc) GetLatestApplication() (map[string]any, error) {
 	return decodedResponse.ApplicationDescriptors[0], nil
 }
 
-func (ms *ManagementSvc) getApplicationByID(id string) (map[string]any, error) {
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/applications/%s", id))
+func (ms *ManagementSvc) GetApplication(applicationID string) (map[string]any, error) {
+	application, err := ms.getApplicationByID(applicationID, true)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, apperrors.ApplicationIDNotFound(applicationID)
+	}
+
+	return application, nil
+}
+
+func (ms *ManagementSvc) GetApplicationDescriptor(applicationID string) (*models.ApplicationDescriptor, error) {
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/applications/%s", applicationID))
+	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var decodedResponse models.ApplicationDescriptor
+	if err := ms.HTTPClient.GetRetryReturnStruct(requestURL, headers, &decodedResponse); err != nil {
+		if errors.Is(err, apperrors.ErrHTTP404NotFound) {
+			return nil, apperrors.ApplicationIDNotFound(applicationID)
+		}
+		return nil, err
+	}
+
+	return &decodedResponse, nil
+}
+
+func (ms *ManagementSvc) getApplicationByID(id string, full bool) (map[string]any, error) {
+	path := fmt.Sprintf("/applications/%s", id)
+	if full {
+		path += "?full=true"
+	}
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), path)
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return nil, err
@@ -101,14 +153,185 @@ func (ms *ManagementSvc) getApplicationByID(id string) (map[string]any, error) {
 	return decodedResponse, nil
 }
 
-func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) error {
-	existing, err := ms.getApplicationByID(ms.Action.ConfigApplicationID)
+func (ms *ManagementSvc) GetApplicationModules(applicationID string) ([]models.ApplicationModule, error) {
+	application, err := ms.getApplicationByID(applicationID, false)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, apperrors.ApplicationIDNotFound(applicationID)
+	}
+
+	var modules []models.ApplicationModule
+	for _, value := range helpers.GetAnySlice(application, "modules") {
+		module, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		modules = append(modules, models.ApplicationModule{
+			ID:      helpers.GetString(module, "id"),
+			Name:    helpers.GetString(module, "name"),
+			Version: helpers.GetString(module, "version"),
+		})
+	}
+
+	return modules, nil
+}
+
+func (ms *ManagementSvc) GetApplicationDependencies(applicationID string) (*models.ApplicationDependencyGraph, error) {
+	application, err := ms.getApplicationByID(applicationID, true)
 	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, apperrors.ApplicationIDNotFound(applicationID)
+	}
+
+	return newApplicationDependencyGraph(applicationID, getApplicationDependencies(application), helpers.GetAnySlice(application, "moduleDescriptors")), nil
+}
+
+// GetRegistryDependencies builds the dependency graph of the configured application from the descriptors of the backend modules
+// in the registry extract that would be registered, so that the graph is available before the application is registered
+func (ms *ManagementSvc) GetRegistryDependencies(extract *models.RegistryExtract) (*models.ApplicationDependencyGraph, error) {
+	if extract.Modules == nil {
+		return nil, apperrors.ApplicationHasNoModules(ms.Action.ConfigApplicationID)
+	}
+
+	var descriptors []any
+	for _, module := range slices.Concat(extract.Modules.FolioModules, extract.Modules.EurekaModules) {
+		if strings.Contains(module.Metadata.Name, constant.ManagementModulePattern) {
+			continue
+		}
+		backendModule, exists := extract.BackendModules[module.Metadata.Name]
+		if !exists || !backendModule.DeployModule {
+			continue
+		}
+		if registrable, _ := ms.Action.IsModuleRegistrable(module.Metadata.Name); !registrable {
+			continue
+		}
+
+		moduleID := module.ID
+		if backendModule.ModuleVersion != nil {
+			moduleID = fmt.Sprintf("%s-%s", module.Metadata.Name, *backendModule.ModuleVersion)
+		}
+		descriptorPath := getLocalDescriptorPath(backendModule, models.FrontendModule{})
+		if err := ms.FetchModuleDescriptor(extract, moduleID, ms.Action.GetModuleURL(moduleID), descriptorPath, descriptorPath != ""); err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, extract.ModuleDescriptors.Get(moduleID))
+	}
+	dependencies := getApplicationDependencies(map[string]any{"dependencies": ms.Action.ConfigApplicationDependencies})
+
+	return newApplicationDependencyGraph(ms.Action.ConfigApplicationID, dependencies, descriptors), nil
+}
+
+// newApplicationDependencyGraph links the interfaces required by the module descriptors to the modules providing them
+func newApplicationDependencyGraph(applicationID string, dependencies []models.ApplicationDependency, descriptors []any) *models.ApplicationDependencyGraph {
+	providers := make(map[string][]string)
+	for _, value := range descriptors {
+		descriptor, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		moduleID := helpers.GetString(descriptor, "id")
+		for _, provided := range getDescriptorInterfaces(descriptor, "provides") {
+			interfaceID := helpers.GetString(provided, "id")
+			providers[interfaceID] = append(providers[interfaceID], moduleID)
+		}
+	}
+
+	graph := &models.ApplicationDependencyGraph{
+		ApplicationID: applicationID,
+		Dependencies:  dependencies,
+	}
+	for _, value := range descriptors {
+		descriptor, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		module := models.ModuleDependencies{ModuleID: helpers.GetString(descriptor, "id")}
+		for _, provided := range getDescriptorInterfaces(descriptor, "provides") {
+			module.Provides = append(module.Provides, models.ProvidedInterface{
+				InterfaceID: helpers.GetString(provided, "id"),
+				Version:     helpers.GetString(provided, "version"),
+			})
+		}
+		for _, key := range []string{"requires", "optional"} {
+			for _, required := range getDescriptorInterfaces(descriptor, key) {
+				interfaceID := helpers.GetString(required, "id")
+				module.Requires = append(module.Requires, models.InterfaceDependency{
+					InterfaceID: interfaceID,
+					Version:     helpers.GetString(required, "version"),
+					Optional:    key == "optional",
+					ProviderIDs: providers[interfaceID],
+				})
+			}
+		}
+		graph.Modules = append(graph.Modules, module)
+	}
+	sort.Slice(graph.Modules, func(i, j int) bool {
+		return graph.Modules[i].ModuleID < graph.Modules[j].ModuleID
+	})
+
+	return graph
+}
+
+func getDescriptorInterfaces(descriptor map[string]any, key string) []map[string]any {
+	var interfaces []map[string]any
+	for _, value := range helpers.GetAnySlice(descriptor, key) {
+		if entry, ok := value.(map[string]any); ok {
+			interfaces = append(interfaces, entry)
+		}
+	}
+
+	return interfaces
+}
+
+// getApplicationDependencies reads the application dependencies that are either
+// stored as a single object, as in the config, or as a list of objects
+func getApplicationDependencies(application map[string]any) []models.ApplicationDependency {
+	var entries []map[string]any
+	switch value := application["dependencies"].(type) {
+	case map[string]any:
+		if len(value) > 0 {
+			entries = append(entries, value)
+		}
+	case []any:
+		for _, item := range value {
+			if entry, ok := item.(map[string]any); ok {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	dependencies := make([]models.ApplicationDependency, 0, len(entries))
+	for _, entry := range entries {
+		dependencies = append(dependencies, models.ApplicationDependency{
+			Name:    helpers.GetString(entry, "name"),
+			Version: helpers.GetString(entry, "version"),
+		})
+	}
+
+	return dependencies
+}
+
+func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) error {
+	if err := ms.Action.ValidateApplicationPlatform(); err != nil {
 		return err
 	}
-	if existing != nil {
-		slog.Info(ms.Action.Name, "text", "Application already exists, skipping", "id", ms.Action.ConfigApplicationID)
-		return nil
+	if extract.Modules == nil {
+		return apperrors.ApplicationHasNoModules(ms.Action.ConfigApplicationID)
+	}
+
+	if !ms.Action.Param.DryRun {
+		existing, err := ms.getApplicationByID(ms.Action.ConfigApplicationID, false)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			slog.Info(ms.Action.Name, "text", "Application already exists, skipping", "id", ms.Action.ConfigApplicationID)
+			return nil
+		}
 	}
 
 	var (
@@ -123,14 +346,16 @@ func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) erro
 		dependencies = ms.Action.ConfigApplicationDependencies
 	}
 
-	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
-	if err != nil {
-		return err
+	registries := []struct {
+		name    string
+		modules []*models.ProxyModule
+	}{
+		{name: constant.FolioRegistry, modules: extract.Modules.FolioModules},
+		{name: constant.EurekaRegistry, modules: extract.Modules.EurekaModules},
 	}
-
-	allModules := [][]*models.ProxyModule{extract.Modules.FolioModules, extract.Modules.EurekaModules}
-	for _, modules := range allModules {
-		for _, module := range modules {
+	for _, registry := range registries {
+		var deployableModules int
+		for _, module := range registry.modules {
 			if strings.Contains(module.Metadata.Name, constant.ManagementModulePattern) {
 				continue
 			}
@@ -140,6 +365,11 @@ func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) erro
 			if (!existsBackend && !existsFrontend) || (existsBackend && !backendModule.DeployModule || existsFrontend && !frontendModule.DeployModule) {
 				continue
 			}
+			if registrable, reason := ms.Action.IsModuleRegistrable(module.Metadata.Name); !registrable {
+				slog.Info(ms.Action.Name, "text", "Skipping module registration", "module", module.Metadata.Name, "reason", reason)
+				continue
+			}
+			deployableModules++
 			if existsBackend && backendModule.ModuleVersion != nil || existsFrontend && frontendModule.ModuleVersion != nil {
 				if backendModule.ModuleVersion != nil {
 					module.Metadata.Version = backendModule.ModuleVersion
@@ -150,16 +380,9 @@ func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) erro
 			}
 
 			moduleDescriptorURL := ms.Action.GetModuleURL(module.ID)
-			isLocalBackendModule := existsBackend && backendModule.LocalDescriptorPath != ""
-			isLocalFrontendModule := existsFrontend && frontendModule.LocalDescriptorPath != ""
-			isLocalModule := isLocalBackendModule || isLocalFrontendModule
+			descriptorPath := getLocalDescriptorPath(backendModule, frontendModule)
+			isLocalModule := descriptorPath != ""
 			if ms.Action.ConfigApplicationFetchDescriptors || isLocalModule {
-				var descriptorPath string
-				if isLocalBackendModule {
-					descriptorPath = backendModule.LocalDescriptorPath
-				} else if isLocalFrontendModule {
-					descriptorPath = frontendModule.LocalDescriptorPath
-				}
 				if err := ms.FetchModuleDescriptor(extract, module.ID, moduleDescriptorURL, descriptorPath, isLocalModule); err != nil {
 					return err
 				}
@@ -172,7 +395,7 @@ func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) erro
 					"version": *module.Metadata.Version,
 				}
 				if ms.Action.ConfigApplicationFetchDescriptors || isLocalModule {
-					backendModuleDescriptors = append(backendModuleDescriptors, extract.ModuleDescriptors[module.ID])
+					backendModuleDescriptors = append(backendModuleDescriptors, extract.ModuleDescriptors.Get(module.ID))
 				} else {
 					newBackendModule["url"] = moduleDescriptorURL
 				}
@@ -192,30 +415,49 @@ func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) erro
 					"version": *module.Metadata.Version,
 				}
 				if ms.Action.ConfigApplicationFetchDescriptors || isLocalModule {
-					frontendModuleDescriptors = append(frontendModuleDescriptors, extract.ModuleDescriptors[module.ID])
+					frontendModuleDescriptors = append(frontendModuleDescriptors, extract.ModuleDescriptors.Get(module.ID))
 				} else {
 					newFrontendModule["url"] = moduleDescriptorURL
 				}
 				frontendModules = append(frontendModules, newFrontendModule)
 			}
 		}
+		if deployableModules == 0 {
+			slog.Warn(ms.Action.Name, "text", "Registry yielded no deployable modules", "registry", registry.name, "modules", len(registry.modules))
+		}
+	}
+	if len(backendModules) == 0 && len(frontendModules) == 0 {
+		return apperrors.ApplicationHasNoModules(ms.Action.ConfigApplicationID)
 	}
 
-	payload1, err := json.Marshal(map[string]any{
+	applicationPayload := map[string]any{
 		"id":                  ms.Action.ConfigApplicationID,
 		"name":                ms.Action.ConfigApplicationName,
 		"version":             ms.Action.ConfigApplicationVersion,
-		"description":         "Default",
+		"description":         ms.Action.GetDescription(nil),
 		"dependencies":        dependencies,
 		"modules":             backendModules,
 		"uiModules":           frontendModules,
 		"moduleDescriptors":   backendModuleDescriptors,
 		"uiModuleDescriptors": frontendModuleDescriptors,
-	})
+	}
+	if ms.Action.ConfigApplicationPlatform != "" {
+		applicationPayload["platform"] = ms.Action.ConfigApplicationPlatform
+	}
+
+	if ms.Action.Param.DryRun {
+		return ms.printApplicationPayloads(applicationPayload, discoveryModules)
+	}
+
+	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
+	if err != nil {
+		return err
+	}
+	payload1, err := json.Marshal(applicationPayload)
 	if err != nil {
 		return err
 	}
-	appRequestURL := ms.Action.GetRequestURL(constant.KongPort, "/applications?check=true")
+	appRequestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), "/applications?check=true")
 
 	var appResponse models.ApplicationDescriptor
 	if err := ms.HTTPClient.PostReturnStruct(appRequestURL, payload1, headers, &appResponse); err != nil {
@@ -223,6 +465,12 @@ func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) erro
 	}
 	slog.Info(ms.Action.Name, "text", "Created application", "id", appResponse.ID, "backendModules", len(backendModules), "frontendModules", len(frontendModules))
 
+	if ms.Action.Param.SkipModuleDiscovery {
+		for _, discoveryModule := range discoveryModules {
+			slog.Info(ms.Action.Name, "text", "Skipped module discovery", "id", discoveryModule["id"], "location", discoveryModule["location"])
+		}
+		return nil
+	}
 	if len(discoveryModules) > 0 {
 		payload2, err := json.Marshal(map[string]any{
 			"discovery": discoveryModules,
@@ -230,7 +478,7 @@ func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) erro
 		if err != nil {
 			return err
 		}
-		discoveryRequestURL := ms.Action.GetRequestURL(constant.KongPort, "/modules/discovery")
+		discoveryRequestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), "/modules/discovery")
 
 		var discoveryResponse models.ModuleDiscoveryResponse
 		if err := ms.HTTPClient.PostReturnStruct(discoveryRequestURL, payload2, headers, &discoveryResponse); err != nil {
@@ -242,6 +490,50 @@ func (ms *ManagementSvc) CreateApplication(extract *models.RegistryExtract) erro
 	return nil
 }
 
+// printApplicationPayloads writes the application and discovery payloads as they would be posted,
+// the discovery payload is omitted when --skipModuleDiscovery is set or no backend module is registered
+func (ms *ManagementSvc) printApplicationPayloads(applicationPayload map[string]any, discoveryModules []map[string]string) error {
+	writer := ms.DryRunWriter
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	writePayload := func(title string, payload any) error {
+		data, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(writer, "# %s\n%s\n", title, data)
+
+		return nil
+	}
+	if err := writePayload("POST /applications?check=true", applicationPayload); err != nil {
+		return err
+	}
+	if ms.Action.Param.SkipModuleDiscovery || len(discoveryModules) == 0 {
+		return nil
+	}
+
+	return writePayload("POST /modules/discovery", map[string]any{"discovery": discoveryModules})
+}
+
+// getLocalDescriptorPath returns the first configured local descriptor, a descriptor-file only replaces
+// the registry descriptor while local-descriptor-path also skips pulling the module image
+func getLocalDescriptorPath(backendModule models.BackendModule, frontendModule models.FrontendModule) string {
+	for _, descriptorPath := range []string{
+		backendModule.LocalDescriptorPath,
+		backendModule.DescriptorFile,
+		frontendModule.LocalDescriptorPath,
+		frontendModule.DescriptorFile,
+	} {
+		if descriptorPath != "" {
+			return descriptorPath
+		}
+	}
+
+	return ""
+}
+
 func (ms *ManagementSvc) FetchModuleDescriptor(extract *models.RegistryExtract, moduleID, moduleDescriptorURL, descriptorPath string, isLocalModule bool) error {
 	if isLocalModule {
 		slog.Info(ms.Action.Name, "text", "Fetching local module descriptor", "module", moduleID)
@@ -250,26 +542,64 @@ func (ms *ManagementSvc) FetchModuleDescriptor(extract *models.RegistryExtract,
 		if err := helpers.ReadJSONFromFile(descriptorPath, &moduleDescriptorData); err != nil {
 			return err
 		}
-		extract.ModuleDescriptors[moduleID] = moduleDescriptorData
+		extract.ModuleDescriptors.Set(moduleID, moduleDescriptorData)
 		slog.Info(ms.Action.Name, "text", "Loaded module descriptor", "module", moduleID)
 
 		return nil
 	}
+	useCache := !ms.Action.Param.NoCache
+	if useCache {
+		if descriptor, ok := ms.readCachedModuleDescriptor(moduleID); ok {
+			extract.ModuleDescriptors.Set(moduleID, descriptor)
+			slog.Info(ms.Action.Name, "text", "Loaded cached module descriptor", "module", moduleID)
+			return nil
+		}
+	}
 	slog.Info(ms.Action.Name, "text", "Fetching module descriptor", "module", moduleID, "url", moduleDescriptorURL)
 
 	var decodedResponse any
 	if err := ms.HTTPClient.GetRetryReturnStruct(moduleDescriptorURL, map[string]string{}, &decodedResponse); err != nil {
 		return err
 	}
-	extract.ModuleDescriptors[moduleID] = decodedResponse
+	extract.ModuleDescriptors.Set(moduleID, decodedResponse)
 	slog.Info(ms.Action.Name, "text", "Loaded module descriptor", "module", moduleID, "url", moduleDescriptorURL)
+	if useCache {
+		ms.writeCachedModuleDescriptor(moduleID, decodedResponse)
+	}
 
 	return nil
 }
 
+func (ms *ManagementSvc) readCachedModuleDescriptor(moduleID string) (any, bool) {
+	cachePath, err := helpers.GetDescriptorCacheFilePath(moduleID)
+	if err != nil || helpers.IsRegularFile(cachePath) != nil {
+		return nil, false
+	}
+
+	var descriptor any
+	if err := helpers.ReadJSONFromFile(cachePath, &descriptor); err != nil {
+		slog.Warn(ms.Action.Name, "text", "Cached module descriptor is unreadable, fetching it", "module", moduleID, "error", err)
+		return nil, false
+	}
+
+	return descriptor, true
+}
+
+// writeCachedModuleDescriptor persists a fetched descriptor for the next runs, a module id pins the version
+// so the descriptor never changes, a failed write only costs a fetch on the next run
+func (ms *ManagementSvc) writeCachedModuleDescriptor(moduleID string, descriptor any) {
+	cachePath, err := helpers.GetDescriptorCacheFilePath(moduleID)
+	if err == nil {
+		err = helpers.WriteJSONToFile(cachePath, descriptor)
+	}
+	if err != nil {
+		slog.Warn(ms.Action.Name, "text", "Module descriptor was not cached", "module", moduleID, "error", err)
+	}
+}
+
 func (ms *ManagementSvc) CreateNewApplication(r *models.ApplicationUpgradeRequest) error {
 	slog.Info(ms.Action.Name, "text", "CREATING NEW APPLICATION", "name", r.ApplicationName, "version", r.NewApplicationVersion)
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, "/applications?check=true")
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), "/applications?check=true")
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
@@ -287,7 +617,7 @@ func (ms *ManagementSvc) CreateNewApplication(r *models.ApplicationUpgradeReques
 		"id":                  r.NewApplicationID,
 		"name":                r.ApplicationName,
 		"version":             r.NewApplicationVersion,
-		"description":         "Default",
+		"description":         ms.Action.GetDescription(nil),
 		"dependencies":        r.NewDependencies,
 		"modules":             r.NewBackendModules,
 		"uiModules":           r.NewFrontendModules,
@@ -308,7 +638,7 @@ func (ms *ManagementSvc) CreateNewApplication(r *models.ApplicationUpgradeReques
 }
 
 func (ms *ManagementSvc) RemoveApplication(applicationID string) error {
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/applications/%s", applicationID))
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/applications/%s", applicationID))
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
@@ -322,40 +652,48 @@ func (ms *ManagementSvc) RemoveApplication(applicationID string) error {
 	return nil
 }
 
-func (ms *ManagementSvc) RemoveApplications(applicationName, ignoreAppID string) error {
+// RemoveApplications removes the applications named applicationName except ignoreAppID, a blank name matches
+// all applications and non-empty applicationIDs restrict the removal to the listed ids, the number of removed
+// applications is returned
+func (ms *ManagementSvc) RemoveApplications(applicationName, ignoreAppID string, applicationIDs ...string) (int, error) {
 	apps, err := ms.GetApplications()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	var removed int
 	for _, entry := range apps.ApplicationDescriptors {
 		name := helpers.GetString(entry, "name")
-		if name != applicationName {
+		if applicationName != "" && name != applicationName {
 			continue
 		}
 		id := helpers.GetString(entry, "id")
 		if id == ignoreAppID {
 			continue
 		}
-		requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/applications/%s", id))
+		if len(applicationIDs) > 0 && !slices.Contains(applicationIDs, id) {
+			continue
+		}
+		requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/applications/%s", id))
 
 		if err := ms.HTTPClient.Delete(requestURL, headers); err != nil {
-			return err
+			return removed, err
 		}
+		removed++
 		slog.Info(ms.Action.Name, "text", "Removed application", "id", id)
 	}
 
-	return nil
+	return removed, nil
 }
 
 func (ms *ManagementSvc) GetModuleDiscovery(name string) (models.ModuleDiscoveryResponse, error) {
 	rawQuery := fmt.Sprintf("(name==%s) sortby version", name)
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/modules/discovery?query=%s", url.QueryEscape(rawQuery)))
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/modules/discovery?query=%s", url.QueryEscape(rawQuery)))
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return models.ModuleDiscoveryResponse{}, err
@@ -377,56 +715,228 @@ func (ms *ManagementSvc) GetModuleDiscovery(name string) (models.ModuleDiscovery
 	return decodedResponse, nil
 }
 
+func (ms *ManagementSvc) GetAllModuleDiscovery() ([]models.ModuleDiscovery, error) {
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), "/modules/discovery?offset=0&limit=10000")
+	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var decodedResponse models.ModuleDiscoveryResponse
+	if err := ms.HTTPClient.GetReturnStruct(requestURL, headers, &decodedResponse); err != nil {
+		return nil, err
+	}
+
+	return decodedResponse.Discovery, nil
+}
+
+// GetAllRawModuleDiscovery returns all module discovery entries exactly as the gateway returns them, fields
+// unknown to the CLI included
+func (ms *ManagementSvc) GetAllRawModuleDiscovery() ([]json.RawMessage, error) {
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), "/modules/discovery?offset=0&limit=10000")
+	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var decodedResponse models.RawModuleDiscoveryResponse
+	if err := ms.HTTPClient.GetReturnStruct(requestURL, headers, &decodedResponse); err != nil {
+		return nil, err
+	}
+
+	return decodedResponse.Discovery, nil
+}
+
 func (ms *ManagementSvc) CreateNewModuleDiscovery(newDiscoveryModules []map[string]string) error {
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, "/modules/discovery")
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
 	}
 
-	payload, err := json.Marshal(map[string]any{
-		"discovery": newDiscoveryModules,
-	})
+	discovery := make([]json.RawMessage, 0, len(newDiscoveryModules))
+	for _, module := range newDiscoveryModules {
+		rawEntry, err := json.Marshal(module)
+		if err != nil {
+			return err
+		}
+		discovery = append(discovery, rawEntry)
+	}
+
+	return ms.postModuleDiscovery(discovery, headers)
+}
+
+func (ms *ManagementSvc) UpdateModuleDiscovery(id string, restore bool, privatePort int, sidecarURL string) error {
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/modules/%s/discovery", id))
+	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
 	}
 
-	var discoveryResponse models.ModuleDiscoveryResponse
-	if err := ms.HTTPClient.PostReturnStruct(requestURL, payload, headers, &discoveryResponse); err != nil {
+	if restore {
+		sidecarURL = ""
+	}
+	entry := newModuleDiscovery(id, privatePort, sidecarURL)
+	if ms.Action.Param == nil || !ms.Action.Param.Force {
+		if ms.isModuleDiscoveryCurrent(id, entry.Name, entry.Location) {
+			slog.Info(ms.Action.Name, "text", "Module discovery already correct, skipping", "module", entry.Name, "location", entry.Location)
+			return nil
+		}
+	}
+
+	payload, err := json.Marshal(entry)
+	if err != nil {
 		return err
 	}
-	slog.Info(ms.Action.Name, "text", "Created module discovery", "count", len(newDiscoveryModules), "totalRecords", discoveryResponse.TotalRecords)
+
+	if err := ms.HTTPClient.PutReturnNoContent(requestURL, payload, headers); err != nil {
+		return err
+	}
+	slog.Info(ms.Action.Name, "text", "Updated module discovery", "module", entry.Name, "location", entry.Location)
 
 	return nil
 }
 
-func (ms *ManagementSvc) UpdateModuleDiscovery(id string, restore bool, privatePort int, sidecarURL string) error {
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/modules/%s/discovery", id))
+// RestoreModuleDiscovery points the discovery of the modules back to their default sidecar locations
+func (ms *ManagementSvc) RestoreModuleDiscovery(ids []string, privatePort int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	discovery := make([]models.ModuleDiscovery, 0, len(ids))
+	for _, id := range ids {
+		discovery = append(discovery, newModuleDiscovery(id, privatePort, ""))
+	}
+
+	return ms.BatchUpdateModuleDiscovery(discovery)
+}
+
+// BatchUpdateModuleDiscovery writes the discovery entries as they are
+func (ms *ManagementSvc) BatchUpdateModuleDiscovery(discovery []models.ModuleDiscovery) error {
+	rawDiscovery := make([]json.RawMessage, 0, len(discovery))
+	for _, entry := range discovery {
+		rawEntry, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		rawDiscovery = append(rawDiscovery, rawEntry)
+	}
+
+	return ms.upsertModuleDiscovery(rawDiscovery)
+}
+
+// BatchUpdateRawModuleDiscovery writes the raw discovery entries as they are, fields unknown to the CLI included
+func (ms *ManagementSvc) BatchUpdateRawModuleDiscovery(discovery []json.RawMessage) error {
+	return ms.upsertModuleDiscovery(discovery)
+}
+
+// upsertModuleDiscovery updates the existing discovery entries that differ one by one and creates the missing ones
+// in a single request, existing entries that already hold all fields of an entry are skipped unless forced
+func (ms *ManagementSvc) upsertModuleDiscovery(discovery []json.RawMessage) error {
+	if len(discovery) == 0 {
+		return nil
+	}
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
 	}
+	existingDiscovery, err := ms.GetAllRawModuleDiscovery()
+	if err != nil {
+		return err
+	}
+	existingByID := make(map[string]map[string]any, len(existingDiscovery))
+	for _, rawEntry := range existingDiscovery {
+		var entry map[string]any
+		if err := json.Unmarshal(rawEntry, &entry); err != nil {
+			return err
+		}
+		existingByID[helpers.GetString(entry, "id")] = entry
+	}
 
-	name := helpers.GetModuleNameFromID(id)
-	if sidecarURL == "" || restore {
-		sidecarURL = helpers.GetSidecarURL(name, privatePort)
+	force := ms.Action.Param != nil && ms.Action.Param.Force
+	var missing []json.RawMessage
+	for _, rawEntry := range discovery {
+		var entry map[string]any
+		if err := json.Unmarshal(rawEntry, &entry); err != nil {
+			return err
+		}
+		id := helpers.GetString(entry, "id")
+		existing, exists := existingByID[id]
+		if exists && containsDiscoveryFields(existing, entry) && !force {
+			slog.Info(ms.Action.Name, "text", "Module discovery already correct, skipping", "id", id, "location", helpers.GetString(entry, "location"))
+			continue
+		}
+		if !exists {
+			missing = append(missing, rawEntry)
+			continue
+		}
+
+		requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/modules/%s/discovery", id))
+		if err := ms.HTTPClient.PutReturnNoContent(requestURL, rawEntry, headers); err != nil {
+			return err
+		}
+		slog.Info(ms.Action.Name, "text", "Updated module discovery", "id", id, "location", helpers.GetString(entry, "location"))
+	}
+	if len(missing) == 0 {
+		return nil
 	}
 
-	version := helpers.GetModuleVersionFromID(id)
-	payload, err := json.Marshal(map[string]any{
-		"id":       id,
-		"name":     name,
-		"version":  version,
-		"location": sidecarURL,
-	})
+	return ms.postModuleDiscovery(missing, headers)
+}
+
+func containsDiscoveryFields(existing map[string]any, entry map[string]any) bool {
+	for key, value := range entry {
+		if !reflect.DeepEqual(existing[key], value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// postModuleDiscovery creates module discovery entries in a single request
+func (ms *ManagementSvc) postModuleDiscovery(discovery []json.RawMessage, headers map[string]string) error {
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), "/modules/discovery")
+	payload, err := json.Marshal(map[string]any{"discovery": discovery})
 	if err != nil {
 		return err
 	}
 
-	if err := ms.HTTPClient.PutReturnNoContent(requestURL, payload, headers); err != nil {
+	var discoveryResponse models.ModuleDiscoveryResponse
+	if err := ms.HTTPClient.PostReturnStruct(requestURL, payload, headers, &discoveryResponse); err != nil {
 		return err
 	}
-	slog.Info(ms.Action.Name, "text", "Updated module discovery", "module", name, "location", sidecarURL)
+	slog.Info(ms.Action.Name, "text", "Created module discovery", "count", len(discovery), "totalRecords", discoveryResponse.TotalRecords)
 
 	return nil
 }
+
+func (ms *ManagementSvc) isModuleDiscoveryCurrent(id string, name string, location string) bool {
+	moduleDiscovery, err := ms.GetModuleDiscovery(name)
+	if err != nil {
+		slog.Warn(ms.Action.Name, "text", "Could not read current module discovery", "module", name, "error", err)
+		return false
+	}
+	for _, discovery := range moduleDiscovery.Discovery {
+		if discovery.ID == id && discovery.Location == location {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newModuleDiscovery derives the discovery entry of a module from its id, an empty sidecar URL
+// falls back to the default sidecar location on the private port
+func newModuleDiscovery(id string, privatePort int, sidecarURL string) models.ModuleDiscovery {
+	name := helpers.GetModuleNameFromID(id)
+	if sidecarURL == "" {
+		sidecarURL = helpers.GetSidecarURL(name, privatePort)
+	}
+
+	return models.ModuleDiscovery{
+		ID:       id,
+		Name:     name,
+		Version:  helpers.GetModuleVersionFromID(id),
+		Location: sidecarURL,
+	}
+}
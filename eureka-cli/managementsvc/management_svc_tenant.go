@@ -2,11 +2,14 @@ package managementsvc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/url"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
@@ -15,8 +18,9 @@ import (
 // ManagementTenantManager defines the interface for tenant management operations
 type ManagementTenantManager interface {
 	GetTenants(consortiumName string, tenantType constant.TenantType) ([]any, error)
+	GetConfigTenants(consortiumName string, tenantType constant.TenantType) []any
 	CreateTenants() error
-	RemoveTenants(consortiumName string, tenantType constant.TenantType) error
+	RemoveTenants(consortiumName string, tenantType constant.TenantType, purgeKafkaTopics bool) ([]models.TenantRemovalResult, error)
 }
 
 func (ms *ManagementSvc) GetTenants(consortiumName string, tenantType constant.TenantType) ([]any, error) {
@@ -26,7 +30,7 @@ func (ms *ManagementSvc) GetTenants(consortiumName string, tenantType constant.T
 	} else {
 		rawQuery = "(cql.allRecords=1) sortby name"
 	}
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/tenants?query=%s", url.QueryEscape(rawQuery)))
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/tenants?query=%s", url.QueryEscape(rawQuery)))
 
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
@@ -55,8 +59,29 @@ func (ms *ManagementSvc) GetTenants(consortiumName string, tenantType constant.T
 	return result, nil
 }
 
+// GetConfigTenants lists the configured tenants of a partition in the same shape as GetTenants, it is used
+// when no master access token is available to query the tenants
+func (ms *ManagementSvc) GetConfigTenants(consortiumName string, tenantType constant.TenantType) []any {
+	partitionDescription := fmt.Sprintf("%s-%s", consortiumName, tenantType)
+
+	var result []any
+	for _, tenantName := range helpers.SortedMapKeys(ms.Action.ConfigTenants) {
+		entry, _ := ms.Action.ConfigTenants[tenantName].(map[string]any)
+		description := ms.GetTenantType(entry)
+		if tenantType != constant.All && description != partitionDescription {
+			continue
+		}
+		result = append(result, map[string]any{
+			"name":        tenantName,
+			"description": description,
+		})
+	}
+
+	return result
+}
+
 func (ms *ManagementSvc) CreateTenants() error {
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, "/tenants")
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), "/tenants")
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
@@ -75,16 +100,17 @@ func (ms *ManagementSvc) CreateTenants() error {
 
 		properties := ms.Action.ConfigTenants[tenantName]
 		entry := properties.(map[string]any)
-		payload, err := json.Marshal(map[string]string{
-			"name":        tenantName,
-			"description": ms.GetTenantType(entry),
-		})
+		payload, err := json.Marshal(newTenantPayload(tenantName, ms.GetTenantType(entry), entry, ms.Action.GetTag()))
 		if err != nil {
 			return err
 		}
 
 		var tenant models.Tenant
 		if err := ms.HTTPClient.PostReturnStruct(requestURL, payload, headers, &tenant); err != nil {
+			if ms.Action.IsIgnorableConflict(err) {
+				slog.Info(ms.Action.Name, "text", "Tenant already exists", "tenant", tenantName)
+				continue
+			}
 			return err
 		}
 		slog.Info(ms.Action.Name, "text", "Created tenant", "tenant", tenant.Name, "id", tenant.ID, "description", tenant.Description)
@@ -93,9 +119,27 @@ func (ms *ManagementSvc) CreateTenants() error {
 	return nil
 }
 
+// newTenantPayload builds the tenant creation body, the description always carries the tenant type because
+// the tenants of a partition are queried by it, so a tag is added to the configured attributes instead
+func newTenantPayload(tenantName string, tenantType string, entry map[string]any, tag string) map[string]any {
+	payload := map[string]any{
+		"name":        tenantName,
+		"description": tenantType,
+	}
+	attributes := maps.Clone(helpers.GetMap(entry, field.TenantsAttributesEntry))
+	if tag != "" {
+		attributes[constant.TagAttribute] = tag
+	}
+	if len(attributes) > 0 {
+		payload["attributes"] = attributes
+	}
+
+	return payload
+}
+
 func (ms *ManagementSvc) getTenantByName(name string) (*models.Tenant, error) {
 	rawQuery := fmt.Sprintf("name==%s", name)
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/tenants?query=%s&limit=1", url.QueryEscape(rawQuery)))
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/tenants?query=%s&limit=1", url.QueryEscape(rawQuery)))
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return nil, err
@@ -126,17 +170,20 @@ func (ms *ManagementSvc) GetTenantType(entry map[string]any) string {
 	return fmt.Sprintf("%s-%s", consortiumName, tenantType)
 }
 
-func (ms *ManagementSvc) RemoveTenants(consortiumName string, tenantType constant.TenantType) error {
+// RemoveTenants removes the configured tenants of a partition, a failed removal is recorded in the results
+// and does not stop the removal of the other tenants, without purgeKafkaTopics the Kafka topics of the tenants are kept
+func (ms *ManagementSvc) RemoveTenants(consortiumName string, tenantType constant.TenantType, purgeKafkaTopics bool) ([]models.TenantRemovalResult, error) {
 	tenants, err := ms.GetTenants(consortiumName, tenantType)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var results []models.TenantRemovalResult
 	for _, value := range tenants {
 		entry := value.(map[string]any)
 		tenantName := helpers.GetString(entry, "name")
@@ -144,12 +191,28 @@ func (ms *ManagementSvc) RemoveTenants(consortiumName string, tenantType constan
 			continue
 		}
 
-		requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/tenants/%s?purgeKafkaTopics=true", helpers.GetString(entry, "id")))
+		result := models.TenantRemovalResult{Tenant: tenantName, Status: models.TenantRemovalRemoved}
+		requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/tenants/%s?purgeKafkaTopics=%t", helpers.GetString(entry, "id"), purgeKafkaTopics))
 		if err := ms.HTTPClient.Delete(requestURL, headers); err != nil {
-			return err
+			slog.Warn(ms.Action.Name, "text", "Failed to remove tenant", "tenant", tenantName, "tenantType", tenantType, "purgeKafkaTopics", purgeKafkaTopics, "error", err)
+			result.Status, result.Error = models.TenantRemovalFailed, err.Error()
+			var httpErr *apperrors.HTTPError
+			if errors.As(err, &httpErr) {
+				result.StatusCode = httpErr.StatusCode
+			}
+		} else {
+			slog.Info(ms.Action.Name, "text", getTenantRemovalText(purgeKafkaTopics), "tenant", tenantName, "tenantType", tenantType, "purgeKafkaTopics", purgeKafkaTopics)
 		}
-		slog.Info(ms.Action.Name, "text", "Removed tenant", "tenant", tenantName, "tenantType", tenantType)
+		results = append(results, result)
 	}
 
-	return nil
+	return results, nil
+}
+
+func getTenantRemovalText(purgeKafkaTopics bool) string {
+	if purgeKafkaTopics {
+		return "Removed tenant and purged its Kafka topics"
+	}
+
+	return "Removed tenant, kept its Kafka topics"
 }
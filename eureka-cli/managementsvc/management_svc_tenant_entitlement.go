@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
 )
@@ -15,12 +20,14 @@ import (
 type ManagementTenantEntitlementManager interface {
 	GetTenantEntitlements(tenantName string, includeModules bool) (models.TenantEntitlementResponse, error)
 	CreateTenantEntitlement(consortiumName string, tenantType constant.TenantType) error
+	EntitleTenants(consortiumName string, tenantType constant.TenantType, applicationID string, concurrency int) ([]models.TenantEntitlementResult, error)
 	UpgradeTenantEntitlement(consortiumName string, tenantType constant.TenantType, newApplicationID string) error
-	RemoveTenantEntitlements(consortiumName string, tenantType constant.TenantType, purgeSchemas bool) error
+	RemoveTenantEntitlements(consortiumName string, tenantType constant.TenantType, purgeSchemas bool, applicationIDs ...string) (int, error)
+	WaitForTenantEntitlements(timeout time.Duration) error
 }
 
 func (ms *ManagementSvc) GetTenantEntitlements(tenantName string, includeModules bool) (models.TenantEntitlementResponse, error) {
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/entitlements?tenant=%s&includeModules=%t", tenantName, includeModules))
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/entitlements?tenant=%s&includeModules=%t", tenantName, includeModules))
 	headers, err := helpers.SecureOkapiApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return models.TenantEntitlementResponse{}, err
@@ -35,17 +42,11 @@ func (ms *ManagementSvc) GetTenantEntitlements(tenantName string, includeModules
 }
 
 func (ms *ManagementSvc) CreateTenantEntitlement(consortiumName string, tenantType constant.TenantType) error {
-	tenantParameters, err := ms.TenantSvc.GetEntitlementTenantParameters(consortiumName)
-	if err != nil {
-		return err
-	}
-
 	tenants, err := ms.GetTenants(consortiumName, tenantType)
 	if err != nil {
 		return nil
 	}
 
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/entitlements?purgeOnRollback=true&ignoreErrors=false&async=false&tenantParameters=%s", tenantParameters))
 	headers, err := helpers.SecureOkapiApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return err
@@ -58,54 +59,188 @@ func (ms *ManagementSvc) CreateTenantEntitlement(consortiumName string, tenantTy
 			continue
 		}
 
-		existingEntitlements, err := ms.GetTenantEntitlements(tenantName, false)
+		requestURL, err := ms.getEntitlementRequestURL("purgeOnRollback=true&ignoreErrors=false&async=true", consortiumName, tenantName)
 		if err != nil {
 			return err
 		}
-		alreadyEntitled := false
-		for _, e := range existingEntitlements.Entitlements {
-			if e.ApplicationID == ms.Action.ConfigApplicationID {
-				alreadyEntitled = true
-				break
-			}
+
+		if _, _, err := ms.entitleTenant(requestURL, headers, entry, ms.Action.GetEntitlementApplicationIDs()); err != nil {
+			return err
 		}
-		if alreadyEntitled {
-			slog.Info(ms.Action.Name, "text", "Tenant entitlement already exists, skipping", "tenant", tenantName)
+	}
+
+	return nil
+}
+
+// EntitleTenants entitles the configured tenants of a partition to an application, up to concurrency tenants at a time,
+// a failed entitlement is recorded in the results and does not stop the entitlement of the other tenants,
+// the configured application is entitled together with the other applications of its entitlement
+func (ms *ManagementSvc) EntitleTenants(consortiumName string, tenantType constant.TenantType, applicationID string, concurrency int) ([]models.TenantEntitlementResult, error) {
+	applicationIDs := []string{applicationID}
+	if applicationID == ms.Action.ConfigApplicationID {
+		applicationIDs = ms.Action.GetEntitlementApplicationIDs()
+	}
+
+	tenants, err := ms.GetTenants(consortiumName, tenantType)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := helpers.SecureOkapiApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURLs := make(map[string]string)
+	for _, value := range tenants {
+		tenantName := helpers.GetString(value.(map[string]any), "name")
+		if !helpers.HasTenant(tenantName, ms.Action.ConfigTenants) {
 			continue
 		}
 
-		payload, err := json.Marshal(map[string]any{
-			"tenantId":     helpers.GetString(entry, "id"),
-			"applications": []string{ms.Action.ConfigApplicationID},
-		})
+		requestURL, err := ms.getEntitlementRequestURL("purgeOnRollback=true&ignoreErrors=false&async=true", consortiumName, tenantName)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		requestURLs[tenantName] = requestURL
+	}
 
-		var decodedResponse models.TenantEntitlementResponse
-		if err := ms.HTTPClient.PostReturnStruct(requestURL, payload, headers, &decodedResponse); err != nil {
-			return err
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		results   []models.TenantEntitlementResult
+		semaphore = make(chan struct{}, max(concurrency, 1))
+	)
+	for _, value := range tenants {
+		entry := value.(map[string]any)
+		tenantName := helpers.GetString(entry, "name")
+		if !helpers.HasTenant(tenantName, ms.Action.ConfigTenants) {
+			continue
 		}
-		slog.Info(ms.Action.Name, "text", "Created tenant entitlement", "tenant", tenantName, "flowId", decodedResponse.FlowID)
 
-		time.Sleep(30 * time.Second)
+		wg.Go(func() {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result := models.TenantEntitlementResult{Tenant: tenantName, ApplicationID: applicationID, Status: models.TenantEntitlementEntitled}
+			flowID, created, err := ms.entitleTenant(requestURLs[tenantName], headers, entry, applicationIDs)
+			switch {
+			case err != nil:
+				slog.Warn(ms.Action.Name, "text", "Failed to create tenant entitlement", "tenant", tenantName, "application", applicationID, "error", err)
+				result.Status, result.Error = models.TenantEntitlementFailed, err.Error()
+			case !created:
+				result.Status = models.TenantEntitlementSkipped
+			default:
+				result.FlowID = flowID
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, result)
+		})
 	}
+	wg.Wait()
+	slices.SortFunc(results, func(a, b models.TenantEntitlementResult) int {
+		return strings.Compare(a.Tenant, b.Tenant)
+	})
 
-	return nil
+	return results, nil
 }
 
-func (ms *ManagementSvc) UpgradeTenantEntitlement(consortiumName string, tenantType constant.TenantType, newApplicationID string) error {
-	tenantParameters, err := ms.TenantSvc.GetEntitlementTenantParameters(consortiumName)
+// getEntitlementRequestURL builds the entitlement request URL of a tenant with the query escaped tenant parameters of its config
+func (ms *ManagementSvc) getEntitlementRequestURL(query string, consortiumName string, tenantName string) (string, error) {
+	tenantParameters, err := ms.TenantSvc.GetEntitlementTenantParameters(consortiumName, tenantName)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	return ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/entitlements?%s&tenantParameters=%s", query, url.QueryEscape(tenantParameters))), nil
+}
+
+// entitleTenant entitles a tenant to the applications it is not yet entitled to in a single request,
+// created is false when the tenant is already entitled to all of them
+func (ms *ManagementSvc) entitleTenant(requestURL string, headers map[string]string, entry map[string]any, applicationIDs []string) (flowID string, created bool, err error) {
+	tenantName := helpers.GetString(entry, "name")
+	existingEntitlements, err := ms.GetTenantEntitlements(tenantName, false)
+	if err != nil {
+		return "", false, err
 	}
 
+	var missingApplicationIDs []string
+	for _, applicationID := range applicationIDs {
+		if slices.ContainsFunc(existingEntitlements.Entitlements, func(e models.TenantEntitlementDTO) bool {
+			return e.ApplicationID == applicationID
+		}) {
+			slog.Info(ms.Action.Name, "text", "Tenant entitlement already exists, skipping", "tenant", tenantName, "application", applicationID)
+			continue
+		}
+		missingApplicationIDs = append(missingApplicationIDs, applicationID)
+	}
+	if len(missingApplicationIDs) == 0 {
+		return "", false, nil
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"tenantId":     helpers.GetString(entry, "id"),
+		"applications": missingApplicationIDs,
+	})
+	if err != nil {
+		return "", false, err
+	}
+
+	var decodedResponse models.TenantEntitlementResponse
+	if err := ms.HTTPClient.PostReturnStruct(requestURL, payload, headers, &decodedResponse); err != nil {
+		return "", false, err
+	}
+	slog.Info(ms.Action.Name, "text", "Created tenant entitlement", "tenant", tenantName, "applications", missingApplicationIDs, "flowId", decodedResponse.FlowID)
+
+	if err := ms.waitForEntitlementFlow(tenantName, decodedResponse.FlowID, headers); err != nil {
+		return decodedResponse.FlowID, false, err
+	}
+
+	return decodedResponse.FlowID, true, nil
+}
+
+// waitForEntitlementFlow polls the entitlement flow of a tenant until it finishes, fails or the timeout elapses,
+// module schema migrations of an entitlement can still be running after the entitlement request returns
+func (ms *ManagementSvc) waitForEntitlementFlow(tenantName string, flowID string, headers map[string]string) error {
+	if flowID == "" {
+		return nil
+	}
+
+	pollWait := helpers.DefaultDuration(ms.EntitlementPollWait, constant.TenantEntitlementPollWait)
+	timeout := helpers.DefaultDuration(ms.EntitlementFlowTimeout, constant.TenantEntitlementFlowTimeout)
+	deadline := time.Now().Add(timeout)
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/entitlement-flows/%s", flowID))
+	for {
+		var flow models.TenantEntitlementFlow
+		if err := ms.HTTPClient.GetReturnStruct(requestURL, headers, &flow); err != nil {
+			return err
+		}
+
+		status := models.TenantEntitlementFlowStatus(strings.ToLower(string(flow.Status)))
+		switch status {
+		case models.TenantEntitlementFlowFinished:
+			slog.Info(ms.Action.Name, "text", "Tenant entitlement flow finished", "tenant", tenantName, "flowId", flowID)
+			return nil
+		case models.TenantEntitlementFlowFailed, models.TenantEntitlementFlowCancelled, models.TenantEntitlementFlowCancellationFailed:
+			return apperrors.TenantEntitlementFlowFailed(tenantName, flowID, string(status))
+		}
+		if time.Now().Add(pollWait).After(deadline) {
+			return apperrors.TenantEntitlementFlowTimeout(tenantName, flowID, string(status), timeout)
+		}
+
+		slog.Info(ms.Action.Name, "text", "Waiting for tenant entitlement flow", "tenant", tenantName, "flowId", flowID, "status", status)
+		time.Sleep(helpers.AddJitter(pollWait, constant.PollJitterFraction))
+	}
+}
+
+func (ms *ManagementSvc) UpgradeTenantEntitlement(consortiumName string, tenantType constant.TenantType, newApplicationID string) error {
 	tenants, err := ms.GetTenants(consortiumName, tenantType)
 	if err != nil {
 		return nil
 	}
 
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/entitlements?async=false&tenantParameters=%s", tenantParameters))
 	headers, err := helpers.SecureApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
 		return nil
@@ -118,6 +253,11 @@ func (ms *ManagementSvc) UpgradeTenantEntitlement(consortiumName string, tenantT
 			continue
 		}
 
+		requestURL, err := ms.getEntitlementRequestURL("async=true", consortiumName, tenantName)
+		if err != nil {
+			return err
+		}
+
 		payload, err := json.Marshal(map[string]any{
 			"tenantId":     helpers.GetString(entry, "id"),
 			"applications": []string{newApplicationID},
@@ -131,23 +271,35 @@ func (ms *ManagementSvc) UpgradeTenantEntitlement(consortiumName string, tenantT
 			return err
 		}
 		slog.Info(ms.Action.Name, "text", "Upgraded tenant entitlement", "tenant", tenantName, "flowId", decodedResponse.FlowID)
+
+		if err := ms.waitForEntitlementFlow(tenantName, decodedResponse.FlowID, headers); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (ms *ManagementSvc) RemoveTenantEntitlements(consortiumName string, tenantType constant.TenantType, purgeSchemas bool) error {
+// RemoveTenantEntitlements removes the entitlements of the given applications, without ids
+// the configured application and its additional entitlements are removed, the number of tenants
+// whose entitlements were removed is returned
+func (ms *ManagementSvc) RemoveTenantEntitlements(consortiumName string, tenantType constant.TenantType, purgeSchemas bool, applicationIDs ...string) (int, error) {
+	if len(applicationIDs) == 0 {
+		applicationIDs = ms.Action.GetEntitlementApplicationIDs()
+	}
+
 	tenants, err := ms.GetTenants(consortiumName, tenantType)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	requestURL := ms.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/entitlements?purge=%t&ignoreErrors=false", purgeSchemas))
+	requestURL := ms.Action.GetRequestURL(ms.Action.GetGatewayPort(), fmt.Sprintf("/entitlements?purge=%t&ignoreErrors=false", purgeSchemas))
 	headers, err := helpers.SecureOkapiApplicationJSONHeaders(ms.Action.KeycloakMasterAccessToken)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	var removed int
 	for _, value := range tenants {
 		entry := value.(map[string]any)
 		tenantName := helpers.GetString(entry, "name")
@@ -158,18 +310,71 @@ func (ms *ManagementSvc) RemoveTenantEntitlements(consortiumName string, tenantT
 
 		payload, err := json.Marshal(map[string]any{
 			"tenantId":     tenantID,
-			"applications": []string{ms.Action.ConfigApplicationID},
+			"applications": applicationIDs,
 		})
 		if err != nil {
-			return err
+			return removed, err
 		}
 
 		var decodedResponse models.TenantEntitlementResponse
 		if err := ms.HTTPClient.DeleteWithPayloadReturnStruct(requestURL, payload, headers, &decodedResponse); err != nil {
-			return err
+			return removed, err
 		}
+		removed++
 		slog.Info(ms.Action.Name, "text", "Removed tenant entitlement", "tenant", tenantName, "flowId", decodedResponse.FlowID)
 	}
 
-	return nil
+	return removed, nil
+}
+
+// WaitForTenantEntitlements polls the entitlement status of every configured tenant until each of them
+// is entitled to the configured application and its additional entitlements or the timeout elapses
+func (ms *ManagementSvc) WaitForTenantEntitlements(timeout time.Duration) error {
+	pollWait := helpers.DefaultDuration(ms.EntitlementPollWait, constant.TenantEntitlementPollWait)
+	timeout = helpers.DefaultDuration(timeout, constant.TenantEntitlementWaitTimeout)
+	deadline := time.Now().Add(timeout)
+
+	pending := helpers.SortedMapKeys(ms.Action.ConfigTenants)
+	for {
+		var stillPending []string
+		for _, tenantName := range pending {
+			entitled, err := ms.isTenantEntitled(tenantName)
+			if err != nil {
+				return err
+			}
+			if !entitled {
+				stillPending = append(stillPending, tenantName)
+				continue
+			}
+			slog.Info(ms.Action.Name, "text", "Tenant is entitled", "tenant", tenantName, "applications", ms.Action.GetEntitlementApplicationIDs())
+		}
+
+		pending = stillPending
+		if len(pending) == 0 {
+			slog.Info(ms.Action.Name, "text", "All configured tenants are entitled")
+			return nil
+		}
+		if time.Now().Add(pollWait).After(deadline) {
+			return apperrors.TenantEntitlementsTimeout(pending, timeout)
+		}
+
+		slog.Warn(ms.Action.Name, "text", "Waiting for tenant entitlements", "pending", pending)
+		time.Sleep(helpers.AddJitter(pollWait, constant.PollJitterFraction))
+	}
+}
+
+func (ms *ManagementSvc) isTenantEntitled(tenantName string) (bool, error) {
+	response, err := ms.GetTenantEntitlements(tenantName, false)
+	if err != nil {
+		return false, err
+	}
+	for _, applicationID := range ms.Action.GetEntitlementApplicationIDs() {
+		if !slices.ContainsFunc(response.Entitlements, func(e models.TenantEntitlementDTO) bool {
+			return e.ApplicationID == applicationID
+		}) {
+			return false, nil
+		}
+	}
+
+	return true, nil
 }
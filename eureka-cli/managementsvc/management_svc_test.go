@@ -1,18 +1,27 @@
 package managementsvc_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/internal/testhelpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/managementsvc"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockTenantSvc is a mock for tenantsvc.TenantProcessor
@@ -20,8 +29,8 @@ type MockTenantSvc struct {
 	mock.Mock
 }
 
-func (m *MockTenantSvc) GetEntitlementTenantParameters(consortiumName string) (string, error) {
-	args := m.Called(consortiumName)
+func (m *MockTenantSvc) GetEntitlementTenantParameters(consortiumName string, tenantName string) (string, error) {
+	args := m.Called(consortiumName, tenantName)
 	return args.String(0), args.Error(1)
 }
 
@@ -328,7 +337,7 @@ func TestRemoveTenants_HeaderCreationError(t *testing.T) {
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
 	// Act
-	err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member))
+	_, err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member), true)
 
 	// Assert
 	assert.Error(t, err)
@@ -344,7 +353,7 @@ func TestCreateTenantEntitlement_HeaderCreationError(t *testing.T) {
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium").
+	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium", mock.Anything).
 		Return("params", nil)
 
 	// Act - GetTenants will fail with header creation error, but the function returns nil instead of error (BUG in actual code)
@@ -364,7 +373,7 @@ func TestRemoveTenantEntitlements_HeaderCreationError(t *testing.T) {
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
 	// Act
-	err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false)
+	_, err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false)
 
 	// Assert
 	assert.Error(t, err)
@@ -388,9 +397,8 @@ func TestCreateApplication_HeaderCreationError(t *testing.T) {
 			FolioModules:  []*models.ProxyModule{},
 			EurekaModules: []*models.ProxyModule{},
 		},
-		BackendModules:    map[string]models.BackendModule{},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		BackendModules:  map[string]models.BackendModule{},
+		FrontendModules: map[string]models.FrontendModule{},
 	}
 
 	// Act
@@ -609,6 +617,14 @@ func TestUpdateModuleDiscovery_Success(t *testing.T) {
 	moduleID := "mod-test-1.0.0"
 	sidecarURL := "http://custom-url:8080"
 
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/modules/discovery?query=")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
 	mockHTTP.On("PutReturnNoContent",
 		mock.MatchedBy(func(url string) bool {
 			return strings.Contains(url, "/modules/"+moduleID+"/discovery")
@@ -640,6 +656,14 @@ func TestUpdateModuleDiscovery_RestoreURL(t *testing.T) {
 	moduleID := "mod-test-1.0.0"
 	privatePort := 8080
 
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/modules/discovery?query=")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
 	mockHTTP.On("PutReturnNoContent",
 		mock.Anything,
 		mock.MatchedBy(func(payload []byte) bool {
@@ -659,157 +683,103 @@ func TestUpdateModuleDiscovery_RestoreURL(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestUpdateModuleDiscovery_HTTPError(t *testing.T) {
+func TestUpdateModuleDiscovery_AlreadyCorrect_Skipped(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
 	moduleID := "mod-test-1.0.0"
-	expectedError := errors.New("HTTP PUT failed")
+	sidecarURL := "http://mod-test-sc.eureka:8081"
 
-	mockHTTP.On("PutReturnNoContent",
-		mock.Anything,
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/modules/discovery?query=")
+		}),
 		mock.Anything,
 		mock.Anything).
-		Return(expectedError)
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.ModuleDiscoveryResponse)
+			target.Discovery = []models.ModuleDiscovery{{ID: moduleID, Name: "mod-test", Version: "1.0.0", Location: sidecarURL}}
+		}).
+		Return(nil)
 
 	// Act
-	err := svc.UpdateModuleDiscovery(moduleID, false, 8080, "http://test:8080")
+	err := svc.UpdateModuleDiscovery(moduleID, false, 8081, sidecarURL)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	mockHTTP.AssertExpectations(t)
+	assert.NoError(t, err)
+	mockHTTP.AssertNotCalled(t, "PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestCreateTenants_Success(t *testing.T) {
+func TestUpdateModuleDiscovery_Force_AlwaysUpdates(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigTenants = map[string]any{
-		"test-tenant": map[string]any{
-			"consortium":    "test-consortium",
-			"centralTenant": false,
-		},
-	}
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	action.Param.Force = true
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "query=name%3D%3D")
-		}),
-		mock.Anything,
-		mock.Anything).
-		Return(nil)
+	moduleID := "mod-test-1.0.0"
+	sidecarURL := "http://mod-test-sc.eureka:8081"
 
-	mockHTTP.On("PostReturnStruct",
-		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/tenants")
-		}),
-		mock.MatchedBy(func(payload []byte) bool {
-			var data map[string]string
-			_ = json.Unmarshal(payload, &data)
-			return data["name"] == "test-tenant" && strings.Contains(data["description"], "test-consortium-member")
-		}),
-		mock.Anything,
-		mock.AnythingOfType("*models.Tenant")).
-		Run(func(args mock.Arguments) {
-			tenant := args.Get(3).(*models.Tenant)
-			tenant.ID = "test-tenant-id"
-			tenant.Name = "test-tenant"
-			tenant.Description = "test-consortium-member"
-		}).
-		Return(nil)
+	mockHTTP.On("PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
-	err := svc.CreateTenants()
+	err := svc.UpdateModuleDiscovery(moduleID, false, 8081, sidecarURL)
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "GetReturnStruct", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestCreateTenants_CentralTenant(t *testing.T) {
+func TestUpdateModuleDiscovery_LookupError_StillUpdates(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigTenants = map[string]any{
-		"central-tenant": map[string]any{
-			"consortium":     "test-consortium",
-			"central-tenant": true,
-		},
-	}
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
-
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "query=name%3D%3D")
-		}),
-		mock.Anything,
-		mock.Anything).
-		Return(nil)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	mockHTTP.On("PostReturnStruct",
-		mock.Anything,
-		mock.MatchedBy(func(payload []byte) bool {
-			var data map[string]string
-			_ = json.Unmarshal(payload, &data)
-			return strings.Contains(data["description"], "central")
-		}),
-		mock.Anything,
-		mock.AnythingOfType("*models.Tenant")).
-		Run(func(args mock.Arguments) {
-			tenant := args.Get(3).(*models.Tenant)
-			tenant.ID = "central-tenant-id"
-			tenant.Name = "central-tenant"
-			tenant.Description = "test-consortium-central"
-		}).
-		Return(nil)
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("lookup failed"))
+	mockHTTP.On("PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
-	err := svc.CreateTenants()
+	err := svc.UpdateModuleDiscovery("mod-test-1.0.0", false, 8081, "http://custom-url:8081")
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestCreateTenants_HTTPError(t *testing.T) {
+func TestUpdateModuleDiscovery_HTTPError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigTenants = map[string]any{
-		"test-tenant": map[string]any{},
-	}
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	mockHTTP.On("GetRetryReturnStruct",
+	moduleID := "mod-test-1.0.0"
+	expectedError := errors.New("HTTP PUT failed")
+
+	mockHTTP.On("GetReturnStruct",
 		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "query=name%3D%3D")
+			return strings.Contains(url, "/modules/discovery?query=")
 		}),
 		mock.Anything,
 		mock.Anything).
 		Return(nil)
 
-	expectedError := errors.New("HTTP POST failed")
-	mockHTTP.On("PostReturnStruct",
-		mock.Anything,
+	mockHTTP.On("PutReturnNoContent",
 		mock.Anything,
 		mock.Anything,
-		mock.AnythingOfType("*models.Tenant")).
+		mock.Anything).
 		Return(expectedError)
 
 	// Act
-	err := svc.CreateTenants()
+	err := svc.UpdateModuleDiscovery(moduleID, false, 8080, "http://test:8080")
 
 	// Assert
 	assert.Error(t, err)
@@ -817,285 +787,281 @@ func TestCreateTenants_HTTPError(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestRemoveTenants_Success(t *testing.T) {
+func TestGetAllModuleDiscovery_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigTenants = map[string]any{
-		"test-tenant": map[string]any{},
-	}
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant", "description": "test-consortium-member"}], "totalRecords": 1}`
-
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/modules/discovery?offset=0&limit=10000")
+		}),
 		mock.Anything,
-		mock.Anything).
+		mock.AnythingOfType("*models.ModuleDiscoveryResponse")).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantsResponse)
-			_ = json.Unmarshal([]byte(responseBody), target)
+			target := args.Get(2).(*models.ModuleDiscoveryResponse)
+			target.Discovery = []models.ModuleDiscovery{
+				{ID: "mod-users-1.0.0", Name: "mod-users", Version: "1.0.0", Location: "http://mod-users-sc.eureka:8081"},
+				{ID: "mod-notes-2.0.0", Name: "mod-notes", Version: "2.0.0", Location: "http://mod-notes-sc.eureka:8081"},
+			}
+			target.TotalRecords = 2
 		}).
 		Return(nil)
 
-	mockHTTP.On("Delete",
-		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/tenants/tenant-123") && strings.Contains(url, "purgeKafkaTopics=true")
-		}),
-		mock.Anything).
-		Return(nil)
-
 	// Act
-	err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member))
+	result, err := svc.GetAllModuleDiscovery()
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "mod-users-1.0.0", result[0].ID)
+	assert.Equal(t, "http://mod-notes-sc.eureka:8081", result[1].Location)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestRemoveTenants_GetTenantsError(t *testing.T) {
+func TestGetAllModuleDiscovery_HTTPError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	expectedError := errors.New("failed to get tenants")
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
-		mock.Anything,
-		mock.Anything).
-		Return(expectedError)
+	expectedError := errors.New("HTTP request failed")
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
 
 	// Act
-	err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member))
+	result, err := svc.GetAllModuleDiscovery()
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	mockHTTP.AssertExpectations(t)
+	assert.ErrorIs(t, err, expectedError)
+	assert.Nil(t, result)
 }
 
-func TestCreateTenantEntitlement_Success(t *testing.T) {
-	// Arrange
-	mockHTTP := &testhelpers.MockHTTPClient{}
-	action := testhelpers.NewMockAction()
-	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigTenants = map[string]any{
-		"test-tenant": map[string]any{},
-	}
-	action.ConfigApplicationID = "app-123"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
-
-	tenantParam := "param1=value1"
-	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium").
-		Return(tenantParam, nil)
-
-	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+func mockExistingModuleDiscovery(mockHTTP *testhelpers.MockHTTPClient, discovery ...models.ModuleDiscovery) {
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/modules/discovery?offset=0")
+		}),
 		mock.Anything,
-		mock.Anything).
+		mock.AnythingOfType("*models.RawModuleDiscoveryResponse")).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantsResponse)
-			_ = json.Unmarshal([]byte(responseBody), target)
+			target := args.Get(2).(*models.RawModuleDiscoveryResponse)
+			for _, entry := range discovery {
+				rawEntry, _ := json.Marshal(entry)
+				target.Discovery = append(target.Discovery, rawEntry)
+			}
+			target.TotalRecords = len(discovery)
 		}).
 		Return(nil)
+}
 
-	mockHTTP.On("GetReturnStruct",
+func TestBatchUpdateModuleDiscovery_UpdatesChangedAndCreatesMissingEntries(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	unchanged := models.ModuleDiscovery{ID: "mod-orders-1.0.0", Name: "mod-orders", Version: "1.0.0", Location: "http://mod-orders-sc.eureka:8081"}
+	changed := models.ModuleDiscovery{ID: "mod-users-1.0.0", Name: "mod-users", Version: "1.0.0", Location: "http://host.docker.internal:9130"}
+	missing := models.ModuleDiscovery{ID: "mod-notes-2.0.0", Name: "mod-notes", Version: "2.0.0", Location: "http://mod-notes-sc.eureka:8081"}
+	mockExistingModuleDiscovery(mockHTTP, unchanged,
+		models.ModuleDiscovery{ID: "mod-users-1.0.0", Name: "mod-users", Version: "1.0.0", Location: "http://mod-users-sc.eureka:8081"})
+	mockHTTP.On("PutReturnNoContent",
 		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/entitlements?tenant=")
+			return strings.HasSuffix(url, "/modules/mod-users-1.0.0/discovery")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data models.ModuleDiscovery
+			_ = json.Unmarshal(payload, &data)
+			return data == changed
 		}),
-		mock.Anything,
 		mock.Anything).
-		Return(nil)
-
+		Return(nil).Once()
 	mockHTTP.On("PostReturnStruct",
 		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/entitlements") && strings.Contains(url, tenantParam)
+			return strings.HasSuffix(url, "/modules/discovery")
 		}),
 		mock.MatchedBy(func(payload []byte) bool {
-			var data map[string]any
+			var data models.ModuleDiscoveryRequest
 			_ = json.Unmarshal(payload, &data)
-			apps := data["applications"].([]any)
-			return data["tenantId"] == "tenant-123" && len(apps) == 1 && apps[0] == "app-123"
+			return assert.ObjectsAreEqual([]models.ModuleDiscovery{missing}, data.Discovery)
 		}),
-		mock.Anything,
-		mock.Anything).
-		Run(func(args mock.Arguments) {
-			target := args.Get(3).(*models.TenantEntitlementResponse)
-			target.FlowID = "flow-123"
-			target.TotalRecords = 1
-		}).
-		Return(nil)
+		mock.Anything, mock.Anything).
+		Return(nil).Once()
 
 	// Act
-	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+	err := svc.BatchUpdateModuleDiscovery([]models.ModuleDiscovery{unchanged, changed, missing})
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
-	mockTenantSvc.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 }
 
-func TestCreateTenantEntitlement_GetParametersError(t *testing.T) {
+func TestBatchUpdateModuleDiscovery_AllEntriesCurrent(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	action.KeycloakMasterAccessToken = "test-token"
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	expectedError := errors.New("failed to get parameters")
-	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium").
-		Return("", expectedError)
+	entry := models.ModuleDiscovery{ID: "mod-users-1.0.0", Name: "mod-users", Version: "1.0.0", Location: "http://mod-users-sc.eureka:8081"}
+	mockExistingModuleDiscovery(mockHTTP, entry)
 
 	// Act
-	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+	err := svc.BatchUpdateModuleDiscovery([]models.ModuleDiscovery{entry})
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	mockTenantSvc.AssertExpectations(t)
+	assert.NoError(t, err)
+	mockHTTP.AssertNotCalled(t, "PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestRemoveTenantEntitlements_Success(t *testing.T) {
+func TestBatchUpdateModuleDiscovery_UpdateError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigTenants = map[string]any{
-		"test-tenant": map[string]any{},
-	}
-	action.ConfigApplicationID = "app-123"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
-		mock.Anything,
-		mock.Anything).
-		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantsResponse)
-			_ = json.Unmarshal([]byte(responseBody), target)
-		}).
-		Return(nil)
-
-	mockHTTP.On("DeleteWithPayloadReturnStruct",
-		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/entitlements") && strings.Contains(url, "purge=true")
-		}),
-		mock.MatchedBy(func(payload []byte) bool {
-			var data map[string]any
-			_ = json.Unmarshal(payload, &data)
-			return data["tenantId"] == "tenant-123"
-		}),
-		mock.Anything,
-		mock.Anything).
-		Run(func(args mock.Arguments) {
-			target := args.Get(3).(*models.TenantEntitlementResponse)
-			target.FlowID = "flow-456"
-			target.TotalRecords = 1
-		}).
-		Return(nil)
+	mockExistingModuleDiscovery(mockHTTP, models.ModuleDiscovery{ID: "mod-users-1.0.0", Location: "http://mod-users-sc.eureka:8081"})
+	expectedError := errors.New("HTTP PUT failed")
+	mockHTTP.On("PutReturnNoContent", mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
 
 	// Act
-	err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), true)
+	err := svc.BatchUpdateModuleDiscovery([]models.ModuleDiscovery{{ID: "mod-users-1.0.0", Location: "http://host.docker.internal:9130"}})
 
 	// Assert
-	assert.NoError(t, err)
-	mockHTTP.AssertExpectations(t)
+	assert.ErrorIs(t, err, expectedError)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestRemoveTenantEntitlements_GetTenantsError(t *testing.T) {
+func TestBatchUpdateRawModuleDiscovery_KeepsUnknownFields(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	expectedError := errors.New("failed to get tenants")
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
-		mock.Anything,
-		mock.Anything).
-		Return(expectedError)
+	rawEntry := json.RawMessage(`{"id":"mod-users-1.0.0","name":"mod-users","version":"1.0.0","location":"http://mod-users-sc.eureka:8081","metadata":{"createdBy":"admin"}}`)
+	mockExistingModuleDiscovery(mockHTTP)
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/modules/discovery")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			return string(payload) == `{"discovery":[`+string(rawEntry)+`]}`
+		}),
+		mock.Anything, mock.Anything).
+		Return(nil).Once()
 
 	// Act
-	err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false)
+	err := svc.BatchUpdateRawModuleDiscovery([]json.RawMessage{rawEntry})
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestGetModuleDiscovery_NilResponse(t *testing.T) {
+func TestBatchUpdateRawModuleDiscovery_UpdatesChangedEntryWithUnknownFields(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	mockHTTP.On("GetReturnStruct",
-		mock.Anything,
-		mock.Anything,
+	rawEntry := json.RawMessage(`{"id":"mod-users-1.0.0","name":"mod-users","version":"1.0.0","location":"http://host.docker.internal:9130","metadata":{"createdBy":"admin"}}`)
+	mockExistingModuleDiscovery(mockHTTP,
+		models.ModuleDiscovery{ID: "mod-users-1.0.0", Name: "mod-users", Version: "1.0.0", Location: "http://mod-users-sc.eureka:8081"})
+	mockHTTP.On("PutReturnNoContent",
+		mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/modules/mod-users-1.0.0/discovery")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			return string(payload) == string(rawEntry)
+		}),
 		mock.Anything).
-		Return(nil)
+		Return(nil).Once()
 
 	// Act
-	result, err := svc.GetModuleDiscovery("mod-test")
+	err := svc.BatchUpdateRawModuleDiscovery([]json.RawMessage{rawEntry})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Empty(t, result.Discovery)
 	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestUpdateModuleDiscovery_EdgeModule(t *testing.T) {
+func TestRestoreModuleDiscovery_UpdatesExistingAndCreatesMissingEntries(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
-
-	moduleID := "edge-test-1.0.0"
-	privatePort := 8080
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
+	mockExistingModuleDiscovery(mockHTTP,
+		models.ModuleDiscovery{ID: "mod-users-19.0.0", Name: "mod-users", Version: "19.0.0", Location: "http://host.docker.internal:9130"})
 	mockHTTP.On("PutReturnNoContent",
-		mock.Anything,
+		mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/modules/mod-users-19.0.0/discovery")
+		}),
 		mock.MatchedBy(func(payload []byte) bool {
-			var data map[string]any
+			var data models.ModuleDiscovery
 			_ = json.Unmarshal(payload, &data)
-			// Edge modules don't have -sc suffix
-			expectedURL := "http://edge-test.eureka:8080"
-			return data["location"] == expectedURL
+			return data.Location == "http://mod-users-sc.eureka:8081"
 		}),
 		mock.Anything).
-		Return(nil)
+		Return(nil).Once()
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/modules/discovery")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data models.ModuleDiscoveryRequest
+			_ = json.Unmarshal(payload, &data)
+			return assert.ObjectsAreEqual([]models.ModuleDiscovery{
+				{ID: "edge-sip2-3.4.0", Name: "edge-sip2", Version: "3.4.0", Location: "http://edge-sip2.eureka:8081"},
+			}, data.Discovery)
+		}),
+		mock.Anything, mock.Anything).
+		Return(nil).Once()
 
 	// Act
-	err := svc.UpdateModuleDiscovery(moduleID, true, privatePort, "")
+	err := svc.RestoreModuleDiscovery([]string{"mod-users-19.0.0", "edge-sip2-3.4.0"}, 8081)
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 }
 
-func TestCreateTenants_NoConsortium(t *testing.T) {
+func TestRestoreModuleDiscovery_NoModules(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	svc := managementsvc.New(testhelpers.NewMockAction(), mockHTTP, &MockTenantSvc{})
+
+	// Act
+	err := svc.RestoreModuleDiscovery(nil, 8081)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTenants_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
 	action.ConfigTenants = map[string]any{
-		"standalone-tenant": map[string]any{},
+		"test-tenant": map[string]any{
+			"consortium":    "test-consortium",
+			"centralTenant": false,
+		},
 	}
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
@@ -1109,20 +1075,21 @@ func TestCreateTenants_NoConsortium(t *testing.T) {
 		Return(nil)
 
 	mockHTTP.On("PostReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/tenants")
+		}),
 		mock.MatchedBy(func(payload []byte) bool {
 			var data map[string]string
 			_ = json.Unmarshal(payload, &data)
-			// When no consortium, description should be "nop-default"
-			return data["description"] == "nop-default"
+			return data["name"] == "test-tenant" && strings.Contains(data["description"], "test-consortium-member")
 		}),
 		mock.Anything,
 		mock.AnythingOfType("*models.Tenant")).
 		Run(func(args mock.Arguments) {
 			tenant := args.Get(3).(*models.Tenant)
-			tenant.ID = "standalone-tenant-id"
-			tenant.Name = "standalone-tenant"
-			tenant.Description = "nop-default"
+			tenant.ID = "test-tenant-id"
+			tenant.Name = "test-tenant"
+			tenant.Description = "test-consortium-member"
 		}).
 		Return(nil)
 
@@ -1134,166 +1101,194 @@ func TestCreateTenants_NoConsortium(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestRemoveTenants_TenantNotInConfig(t *testing.T) {
+func TestCreateTenants_CentralTenant(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
 	action.ConfigTenants = map[string]any{
-		"other-tenant": map[string]any{},
+		"central-tenant": map[string]any{
+			"consortium":     "test-consortium",
+			"central-tenant": true,
+		},
 	}
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant", "description": "test-consortium-member"}], "totalRecords": 1}`
-
 	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "query=name%3D%3D")
+		}),
 		mock.Anything,
 		mock.Anything).
+		Return(nil)
+
+	mockHTTP.On("PostReturnStruct",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]string
+			_ = json.Unmarshal(payload, &data)
+			return strings.Contains(data["description"], "central")
+		}),
+		mock.Anything,
+		mock.AnythingOfType("*models.Tenant")).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantsResponse)
-			_ = json.Unmarshal([]byte(responseBody), target)
+			tenant := args.Get(3).(*models.Tenant)
+			tenant.ID = "central-tenant-id"
+			tenant.Name = "central-tenant"
+			tenant.Description = "test-consortium-central"
 		}).
 		Return(nil)
 
-	// Act - should not call Delete since "test-tenant" is not in ConfigTenants
-	err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member))
+	// Act
+	err := svc.CreateTenants()
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
-	// Verify Delete was NOT called
-	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 }
 
-func TestCreateTenantEntitlement_TenantNotInConfig(t *testing.T) {
+func TestCreateTenants_WithAttributes(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
 	action.ConfigTenants = map[string]any{
-		"other-tenant": map[string]any{},
+		"tenant1": map[string]any{
+			"attributes": map[string]any{"region": "eu", "tier": "gold"},
+		},
 	}
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	tenantParam := "param1=value1"
-	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium").
-		Return(tenantParam, nil)
-
-	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
-	mockHTTP.On("GetRetryReturnStruct",
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct",
 		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			attributes, ok := data["attributes"].(map[string]any)
+			return ok && data["description"] == "nop-default" && attributes["region"] == "eu" && attributes["tier"] == "gold"
+		}),
 		mock.Anything,
-		mock.Anything).
-		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantsResponse)
-			_ = json.Unmarshal([]byte(responseBody), target)
-		}).
+		mock.AnythingOfType("*models.Tenant")).
 		Return(nil)
 
-	// Act - should not call PostReturnNoContent since "test-tenant" is not in ConfigTenants
-	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+	// Act
+	err := svc.CreateTenants()
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
-	mockTenantSvc.AssertExpectations(t)
-	// Verify PostReturnStruct was NOT called
-	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestRemoveTenantEntitlements_TenantNotInConfig(t *testing.T) {
+func TestCreateTenants_WithTag_AddsTagAttribute(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
+	action.Param.Tag = "run-42"
+	configAttributes := map[string]any{"region": "eu"}
 	action.ConfigTenants = map[string]any{
-		"other-tenant": map[string]any{},
+		"tenant1": map[string]any{"attributes": configAttributes},
 	}
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
-	mockHTTP.On("GetRetryReturnStruct",
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct",
 		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			attributes, ok := data["attributes"].(map[string]any)
+			return ok && data["description"] == "nop-default" && attributes["region"] == "eu" && attributes[constant.TagAttribute] == "run-42"
+		}),
 		mock.Anything,
-		mock.Anything).
-		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantsResponse)
-			_ = json.Unmarshal([]byte(responseBody), target)
-		}).
+		mock.AnythingOfType("*models.Tenant")).
 		Return(nil)
 
-	// Act - should not call DeleteWithBody since "test-tenant" is not in ConfigTenants
-	err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false)
+	// Act
+	err := svc.CreateTenants()
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
-	// Verify DeleteWithPayloadReturnStruct was NOT called
-	mockHTTP.AssertNotCalled(t, "DeleteWithPayloadReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NotContains(t, configAttributes, constant.TagAttribute)
 }
 
-func TestGetApplications_DecodeError(t *testing.T) {
+func TestCreateTenants_WithoutAttributes_OmitsAttributes(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"tenant1": map[string]any{},
+	}
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	// Simulate error from GetReturnStruct (e.g., decode error)
-	expectedError := errors.New("decode error: invalid character")
-
-	mockHTTP.On("GetReturnStruct",
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct",
 		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			_, hasAttributes := data["attributes"]
+			return !hasAttributes
+		}),
 		mock.Anything,
-		mock.Anything).
-		Return(expectedError)
+		mock.AnythingOfType("*models.Tenant")).
+		Return(nil)
 
 	// Act
-	result, err := svc.GetApplications()
+	err := svc.CreateTenants()
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	assert.Empty(t, result.ApplicationDescriptors)
+	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestGetModuleDiscovery_DecodeError(t *testing.T) {
+func TestCreateTenants_HTTPError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	// Simulate error from GetReturnStruct (e.g., decode error)
-	expectedError := errors.New("decode error: invalid character")
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "query=name%3D%3D")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
 
-	mockHTTP.On("GetReturnStruct",
+	expectedError := errors.New("HTTP POST failed")
+	mockHTTP.On("PostReturnStruct",
 		mock.Anything,
 		mock.Anything,
-		mock.Anything).
+		mock.Anything,
+		mock.AnythingOfType("*models.Tenant")).
 		Return(expectedError)
 
 	// Act
-	result, err := svc.GetModuleDiscovery("mod-test")
+	err := svc.CreateTenants()
 
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
-	assert.Empty(t, result.Discovery)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestRemoveTenants_DeleteError(t *testing.T) {
+func TestCreateTenants_ConflictIgnored(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
+	action.Param.IgnoreExisting = true
 	action.KeycloakMasterAccessToken = "test-token"
 	action.ConfigTenants = map[string]any{
 		"test-tenant": map[string]any{},
@@ -1301,34 +1296,19 @@ func TestRemoveTenants_DeleteError(t *testing.T) {
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
-
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
-		mock.Anything,
-		mock.Anything).
-		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantsResponse)
-			_ = json.Unmarshal([]byte(responseBody), target)
-		}).
-		Return(nil)
-
-	expectedError := errors.New("delete failed")
-	mockHTTP.On("Delete",
-		mock.Anything,
-		mock.Anything).
-		Return(expectedError)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.AnythingOfType("*models.Tenant")).
+		Return(apperrors.RequestFailed(http.StatusConflict, http.MethodPost, "/tenants"))
 
 	// Act
-	err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member))
+	err := svc.CreateTenants()
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestCreateTenantEntitlement_PostError(t *testing.T) {
+func TestCreateTenants_ConflictNotIgnored(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
@@ -1336,51 +1316,60 @@ func TestCreateTenantEntitlement_PostError(t *testing.T) {
 	action.ConfigTenants = map[string]any{
 		"test-tenant": map[string]any{},
 	}
-	action.ConfigApplicationID = "app-123"
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium").
-		Return("params", nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.AnythingOfType("*models.Tenant")).
+		Return(apperrors.RequestFailed(http.StatusConflict, http.MethodPost, "/tenants"))
 
-	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
-		mock.Anything,
-		mock.Anything).
+	// Act
+	err := svc.CreateTenants()
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrHTTP409Conflict)
+}
+
+func TestRemoveTenants_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant", "description": "test-consortium-member"}], "totalRecords": 1}`
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.TenantsResponse)
 			_ = json.Unmarshal([]byte(responseBody), target)
 		}).
 		Return(nil)
 
-	mockHTTP.On("GetReturnStruct",
+	mockHTTP.On("Delete",
 		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/entitlements?tenant=")
+			return strings.Contains(url, "/tenants/tenant-123") && strings.HasSuffix(url, "?purgeKafkaTopics=true")
 		}),
-		mock.Anything,
 		mock.Anything).
 		Return(nil)
 
-	expectedError := errors.New("post failed")
-	mockHTTP.On("PostReturnStruct",
-		mock.Anything,
-		mock.Anything,
-		mock.Anything,
-		mock.Anything).
-		Return(expectedError)
-
 	// Act
-	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+	results, err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member), true)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TenantRemovalResult{{Tenant: "test-tenant", Status: models.TenantRemovalRemoved}}, results)
 	mockHTTP.AssertExpectations(t)
-	mockTenantSvc.AssertExpectations(t)
 }
 
-func TestRemoveTenantEntitlements_DeleteError(t *testing.T) {
+func TestRemoveTenants_WithoutPurgeKafkaTopics(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
@@ -1388,31 +1377,85 @@ func TestRemoveTenantEntitlements_DeleteError(t *testing.T) {
 	action.ConfigTenants = map[string]any{
 		"test-tenant": map[string]any{},
 	}
-	action.ConfigApplicationID = "app-123"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
 	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.Anything,
-		mock.Anything,
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/tenants/tenant-123?purgeKafkaTopics=false")
+		}),
 		mock.Anything).
+		Return(nil)
+
+	// Act
+	results, err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member), false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TenantRemovalResult{{Tenant: "test-tenant", Status: models.TenantRemovalRemoved}}, results)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestRemoveTenants_ContinuesAfterFailure(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"tenant-a": map[string]any{},
+		"tenant-b": map[string]any{},
+	}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	responseBody := `{"tenants": [{"id": "id-a", "name": "tenant-a"}, {"id": "id-b", "name": "tenant-b"}], "totalRecords": 2}`
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			target := args.Get(2).(*models.TenantsResponse)
 			_ = json.Unmarshal([]byte(responseBody), target)
 		}).
 		Return(nil)
+	mockHTTP.On("Delete", mock.MatchedBy(func(url string) bool { return strings.Contains(url, "/tenants/id-a") }), mock.Anything).
+		Return(apperrors.RequestFailed(500, "DELETE", "/tenants/id-a"))
+	mockHTTP.On("Delete", mock.MatchedBy(func(url string) bool { return strings.Contains(url, "/tenants/id-b") }), mock.Anything).
+		Return(nil)
 
-	expectedError := errors.New("delete failed")
-	mockHTTP.On("DeleteWithPayloadReturnStruct",
-		mock.Anything,
+	// Act
+	results, err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member), true)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "tenant-a", results[0].Tenant)
+	assert.Equal(t, models.TenantRemovalFailed, results[0].Status)
+	assert.Equal(t, 500, results[0].StatusCode)
+	assert.NotEmpty(t, results[0].Error)
+	assert.Equal(t, models.TenantRemovalResult{Tenant: "tenant-b", Status: models.TenantRemovalRemoved}, results[1])
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestRemoveTenants_GetTenantsError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	expectedError := errors.New("failed to get tenants")
+	mockHTTP.On("GetRetryReturnStruct",
 		mock.Anything,
 		mock.Anything,
 		mock.Anything).
 		Return(expectedError)
 
 	// Act
-	err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false)
+	_, err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member), true)
 
 	// Assert
 	assert.Error(t, err)
@@ -1420,201 +1463,1247 @@ func TestRemoveTenantEntitlements_DeleteError(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestCreateApplication_MinimalSuccess(t *testing.T) {
+func TestCreateTenantEntitlement_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigApplicationID = "test-app"
-	action.ConfigApplicationName = "Test Application"
-	action.ConfigApplicationVersion = "1.0.0"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	action.ConfigApplicationID = "app-123"
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	// Create minimal extract with one backend module
-	version := "1.0.0"
-	extract := &models.RegistryExtract{
-		Modules: &models.ProxyModulesByRegistry{
-			FolioModules: []*models.ProxyModule{
-				{
-					ID: "mod-test-1.0.0",
-					Metadata: models.ProxyModuleMetadata{
-						Name:        "mod-test",
-						Version:     &version,
-						SidecarName: "mod-test-sc",
-					},
-				},
-			},
-			EurekaModules: []*models.ProxyModule{},
-		},
-		BackendModules: map[string]models.BackendModule{
-			"mod-test": {
-				DeployModule: true,
-				PrivatePort:  8080,
-			},
-		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
-	}
+	tenantParam := "param1=value1"
+	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium", "test-tenant").
+		Return(tenantParam, nil)
 
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
 	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+
+	mockHTTP.On("GetReturnStruct",
 		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/applications/") && !strings.Contains(url, "?")
+			return strings.Contains(url, "/entitlements?tenant=")
 		}),
 		mock.Anything,
 		mock.Anything).
-		Once().
-		Return(apperrors.ErrHTTP404NotFound)
+		Return(nil)
 
 	mockHTTP.On("PostReturnStruct",
 		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/applications")
+			return strings.Contains(url, "/entitlements") && strings.Contains(url, "tenantParameters="+neturl.QueryEscape(tenantParam))
 		}),
 		mock.MatchedBy(func(payload []byte) bool {
 			var data map[string]any
 			_ = json.Unmarshal(payload, &data)
-			return data["id"] == "test-app" && data["name"] == "Test Application"
+			apps := data["applications"].([]any)
+			return data["tenantId"] == "tenant-123" && len(apps) == 1 && apps[0] == "app-123"
 		}),
 		mock.Anything,
-		mock.AnythingOfType("*models.ApplicationDescriptor")).
+		mock.Anything).
 		Run(func(args mock.Arguments) {
-			resp := args.Get(3).(*models.ApplicationDescriptor)
-			resp.ID = "test-app"
-			resp.Name = "Test Application"
-			resp.Version = "1.0.0"
+			target := args.Get(3).(*models.TenantEntitlementResponse)
+			target.FlowID = "flow-123"
+			target.TotalRecords = 1
 		}).
 		Return(nil)
 
-	mockHTTP.On("PostReturnStruct",
-		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/modules/discovery")
-		}),
-		mock.MatchedBy(func(payload []byte) bool {
-			var data map[string]any
-			_ = json.Unmarshal(payload, &data)
-			discovery := data["discovery"].([]any)
-			return len(discovery) == 1
-		}),
-		mock.Anything,
-		mock.AnythingOfType("*models.ModuleDiscoveryResponse")).
+	mockHTTP.On("GetReturnStruct", "http://localhost:8000/entitlement-flows/flow-123", mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
-			resp := args.Get(3).(*models.ModuleDiscoveryResponse)
-			resp.TotalRecords = 1
+			target := args.Get(2).(*models.TenantEntitlementFlow)
+			target.Status = models.TenantEntitlementFlowFinished
 		}).
 		Return(nil)
 
 	// Act
-	err := svc.CreateApplication(extract)
+	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
+	mockTenantSvc.AssertExpectations(t)
 }
 
-func TestCreateApplication_WithFrontendModule(t *testing.T) {
-	// Arrange
+// newEntitlementFlowTestSvc returns a service entitling "test-tenant" whose entitlement request starts flow "flow-123"
+func newEntitlementFlowTestSvc() (*managementsvc.ManagementSvc, *testhelpers.MockHTTPClient) {
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigApplicationID = "test-app"
-	action.ConfigApplicationName = "Test Application"
-	action.ConfigApplicationVersion = "1.0.0"
+	action.ConfigTenants = map[string]any{"test-tenant": map[string]any{}}
+	action.ConfigApplicationID = "app-123"
 	mockTenantSvc := &MockTenantSvc{}
+	mockTenantSvc.On("GetEntitlementTenantParameters", mock.Anything, mock.Anything).Return("loadReference=true", nil)
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc.EntitlementPollWait = time.Millisecond
 
-	version := "1.0.0"
-	extract := &models.RegistryExtract{
-		Modules: &models.ProxyModulesByRegistry{
-			FolioModules: []*models.ProxyModule{
-				{
-					ID: "folio-test-1.0.0",
-					Metadata: models.ProxyModuleMetadata{
-						Name:    "folio-test",
-						Version: &version,
-					},
-				},
-			},
-			EurekaModules: []*models.ProxyModule{},
-		},
-		BackendModules: map[string]models.BackendModule{},
-		FrontendModules: map[string]models.FrontendModule{
-			"folio-test": {
-				DeployModule: true,
-			},
-		},
-		ModuleDescriptors: map[string]any{},
-	}
-
-	mockHTTP.On("GetRetryReturnStruct",
-		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/applications/") && !strings.Contains(url, "?")
-		}),
-		mock.Anything,
-		mock.Anything).
-		Once().
-		Return(apperrors.ErrHTTP404NotFound)
-
-	mockHTTP.On("PostReturnStruct",
-		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/applications")
-		}),
-		mock.MatchedBy(func(payload []byte) bool {
-			var data map[string]any
-			_ = json.Unmarshal(payload, &data)
-			uiModules := data["uiModules"].([]any)
-			return len(uiModules) == 1
-		}),
-		mock.Anything,
-		mock.AnythingOfType("*models.ApplicationDescriptor")).
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
-			resp := args.Get(3).(*models.ApplicationDescriptor)
-			resp.ID = "test-app"
-			resp.Name = "Test Application"
-			resp.Version = "1.0.0"
+			target := args.Get(2).(*models.TenantsResponse)
+			target.Tenants = []models.Tenant{{ID: "tenant-123", Name: "test-tenant"}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetReturnStruct", mock.MatchedBy(func(url string) bool {
+		return strings.Contains(url, "/entitlements?tenant=")
+	}), mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(3).(*models.TenantEntitlementResponse).FlowID = "flow-123"
 		}).
 		Return(nil)
 
+	return svc, mockHTTP
+}
+
+func mockEntitlementFlowStatuses(mockHTTP *testhelpers.MockHTTPClient, statuses ...models.TenantEntitlementFlowStatus) {
+	for _, status := range statuses {
+		mockHTTP.On("GetReturnStruct", mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/entitlement-flows/flow-123")
+		}), mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*models.TenantEntitlementFlow).Status = status
+			}).
+			Return(nil).Once()
+	}
+}
+
+func TestCreateTenantEntitlement_WaitsForEntitlementFlow(t *testing.T) {
+	// Arrange
+	svc, mockHTTP := newEntitlementFlowTestSvc()
+	mockEntitlementFlowStatuses(mockHTTP, models.TenantEntitlementFlowQueued, models.TenantEntitlementFlowInProgress, "FINISHED")
+
 	// Act
-	err := svc.CreateApplication(extract)
+	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
 
 	// Assert
 	assert.NoError(t, err)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestCreateApplication_SkipsManagementModule(t *testing.T) {
+func TestCreateTenantEntitlement_EntitlementFlowFailed(t *testing.T) {
 	// Arrange
-	mockHTTP := &testhelpers.MockHTTPClient{}
-	action := testhelpers.NewMockAction()
-	action.KeycloakMasterAccessToken = "test-token"
-	action.ConfigApplicationID = "test-app"
-	action.ConfigApplicationName = "Test Application"
-	action.ConfigApplicationVersion = "1.0.0"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc, mockHTTP := newEntitlementFlowTestSvc()
+	mockEntitlementFlowStatuses(mockHTTP, models.TenantEntitlementFlowInProgress, models.TenantEntitlementFlowFailed)
 
-	version := "1.0.0"
-	extract := &models.RegistryExtract{
-		Modules: &models.ProxyModulesByRegistry{
-			FolioModules: []*models.ProxyModule{
-				{
-					ID: "mgr-applications-1.0.0",
+	// Act
+	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrDeploymentFailed)
+	assert.Contains(t, err.Error(), "entitlement flow flow-123 of tenant test-tenant ended with status failed")
+}
+
+func TestCreateTenantEntitlement_EntitlementFlowTimeout(t *testing.T) {
+	// Arrange
+	svc, mockHTTP := newEntitlementFlowTestSvc()
+	svc.EntitlementPollWait = 20 * time.Millisecond
+	svc.EntitlementFlowTimeout = 10 * time.Millisecond
+	mockEntitlementFlowStatuses(mockHTTP, models.TenantEntitlementFlowInProgress)
+
+	// Act
+	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrTimeout)
+	assert.Contains(t, err.Error(), "last status in_progress")
+}
+
+func TestEntitleTenants_EntitlementFlowFailed_RecordsFailure(t *testing.T) {
+	// Arrange
+	svc, mockHTTP := newEntitlementFlowTestSvc()
+	mockEntitlementFlowStatuses(mockHTTP, models.TenantEntitlementFlowCancelled)
+
+	// Act
+	results, err := svc.EntitleTenants("test-consortium", constant.Member, "app-123", 1)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, models.TenantEntitlementFailed, results[0].Status)
+	assert.Contains(t, results[0].Error, "ended with status cancelled")
+}
+
+func TestCreateTenantEntitlement_GetParametersError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{"test-tenant": map[string]any{}}
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			target.Tenants = []models.Tenant{{ID: "tenant-123", Name: "test-tenant"}}
+		}).
+		Return(nil)
+	expectedError := errors.New("failed to get parameters")
+	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium", "test-tenant").
+		Return("", expectedError)
+
+	// Act
+	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	mockTenantSvc.AssertExpectations(t)
+}
+
+func TestRemoveTenantEntitlements_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	action.ConfigApplicationID = "app-123"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+
+	mockHTTP.On("DeleteWithPayloadReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/entitlements") && strings.Contains(url, "purge=true")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			return data["tenantId"] == "tenant-123"
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(3).(*models.TenantEntitlementResponse)
+			target.FlowID = "flow-456"
+			target.TotalRecords = 1
+		}).
+		Return(nil)
+
+	// Act
+	removed, err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateTenantEntitlement_MultipleApplications_EntitlesMissingOnly(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	action.ConfigApplicationID = "app-123"
+	action.ConfigApplicationEntitlements = []string{"app-edge-1.0.0", "app-erm-2.0.0"}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium", "test-tenant").Return("loadReference=true", nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			target.Tenants = []models.Tenant{{ID: "tenant-123", Name: "test-tenant"}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			target.Entitlements = []models.TenantEntitlementDTO{{ApplicationID: "app-edge-1.0.0", TenantID: "tenant-123"}}
+		}).
+		Return(nil)
+	mockHTTP.On("PostReturnStruct",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data struct {
+				Applications []string `json:"applications"`
+			}
+			_ = json.Unmarshal(payload, &data)
+			return slices.Equal(data.Applications, []string{"app-123", "app-erm-2.0.0"})
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNumberOfCalls(t, "PostReturnStruct", 1)
+}
+
+func TestRemoveTenantEntitlements_MultipleApplications(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	action.ConfigApplicationID = "app-123"
+	action.ConfigApplicationEntitlements = []string{"app-edge-1.0.0"}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			target.Tenants = []models.Tenant{{ID: "tenant-123", Name: "test-tenant"}}
+		}).
+		Return(nil)
+	mockHTTP.On("DeleteWithPayloadReturnStruct",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data struct {
+				Applications []string `json:"applications"`
+			}
+			_ = json.Unmarshal(payload, &data)
+			return slices.Equal(data.Applications, []string{"app-123", "app-edge-1.0.0"})
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	_, err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), true)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestRemoveTenantEntitlements_GivenApplications(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	action.ConfigApplicationID = "app-123"
+	action.ConfigApplicationEntitlements = []string{"app-edge-1.0.0"}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			target.Tenants = []models.Tenant{{ID: "tenant-123", Name: "test-tenant"}}
+		}).
+		Return(nil)
+	mockHTTP.On("DeleteWithPayloadReturnStruct",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data struct {
+				Applications []string `json:"applications"`
+			}
+			_ = json.Unmarshal(payload, &data)
+			return slices.Equal(data.Applications, []string{"app-erm-usage-1.0.0"})
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	_, err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false, "app-erm-usage-1.0.0")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestRemoveTenantEntitlements_GetTenantsError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	expectedError := errors.New("failed to get tenants")
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	_, err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestGetModuleDiscovery_NilResponse(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	mockHTTP.On("GetReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	result, err := svc.GetModuleDiscovery("mod-test")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.Discovery)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestUpdateModuleDiscovery_EdgeModule(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	moduleID := "edge-test-1.0.0"
+	privatePort := 8080
+
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/modules/discovery?query=")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	mockHTTP.On("PutReturnNoContent",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			// Edge modules don't have -sc suffix
+			expectedURL := "http://edge-test.eureka:8080"
+			return data["location"] == expectedURL
+		}),
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	err := svc.UpdateModuleDiscovery(moduleID, true, privatePort, "")
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateTenants_NoConsortium(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"standalone-tenant": map[string]any{},
+	}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "query=name%3D%3D")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	mockHTTP.On("PostReturnStruct",
+		mock.Anything,
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]string
+			_ = json.Unmarshal(payload, &data)
+			// When no consortium, description should be "nop-default"
+			return data["description"] == "nop-default"
+		}),
+		mock.Anything,
+		mock.AnythingOfType("*models.Tenant")).
+		Run(func(args mock.Arguments) {
+			tenant := args.Get(3).(*models.Tenant)
+			tenant.ID = "standalone-tenant-id"
+			tenant.Name = "standalone-tenant"
+			tenant.Description = "nop-default"
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.CreateTenants()
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestRemoveTenants_TenantNotInConfig(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"other-tenant": map[string]any{},
+	}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant", "description": "test-consortium-member"}], "totalRecords": 1}`
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+
+	// Act - should not call Delete since "test-tenant" is not in ConfigTenants
+	results, err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member), true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	mockHTTP.AssertExpectations(t)
+	// Verify Delete was NOT called
+	mockHTTP.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestCreateTenantEntitlement_TenantNotInConfig(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"other-tenant": map[string]any{},
+	}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+
+	// Act - should not call PostReturnNoContent since "test-tenant" is not in ConfigTenants
+	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	mockTenantSvc.AssertExpectations(t)
+	// Verify PostReturnStruct was NOT called
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveTenantEntitlements_TenantNotInConfig(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"other-tenant": map[string]any{},
+	}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+
+	// Act - should not call DeleteWithBody since "test-tenant" is not in ConfigTenants
+	_, err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	// Verify DeleteWithPayloadReturnStruct was NOT called
+	mockHTTP.AssertNotCalled(t, "DeleteWithPayloadReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetApplications_DecodeError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	// Simulate error from GetReturnStruct (e.g., decode error)
+	expectedError := errors.New("decode error: invalid character")
+
+	mockHTTP.On("GetReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	result, err := svc.GetApplications()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	assert.Empty(t, result.ApplicationDescriptors)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestGetModuleDiscovery_DecodeError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	// Simulate error from GetReturnStruct (e.g., decode error)
+	expectedError := errors.New("decode error: invalid character")
+
+	mockHTTP.On("GetReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	result, err := svc.GetModuleDiscovery("mod-test")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	assert.Empty(t, result.Discovery)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestRemoveTenants_DeleteError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+
+	expectedError := errors.New("delete failed")
+	mockHTTP.On("Delete",
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	results, err := svc.RemoveTenants("test-consortium", constant.TenantType(constant.Member), true)
+
+	// Assert
+	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, models.TenantRemovalFailed, results[0].Status)
+	assert.Equal(t, expectedError.Error(), results[0].Error)
+	assert.Zero(t, results[0].StatusCode)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateTenantEntitlement_PostError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	action.ConfigApplicationID = "app-123"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium", mock.Anything).
+		Return("params", nil)
+
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/entitlements?tenant=")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Return(nil)
+
+	expectedError := errors.New("post failed")
+	mockHTTP.On("PostReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	err := svc.CreateTenantEntitlement("test-consortium", constant.TenantType(constant.Member))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	mockHTTP.AssertExpectations(t)
+	mockTenantSvc.AssertExpectations(t)
+}
+
+func TestRemoveTenantEntitlements_DeleteError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"test-tenant": map[string]any{},
+	}
+	action.ConfigApplicationID = "app-123"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			_ = json.Unmarshal([]byte(responseBody), target)
+		}).
+		Return(nil)
+
+	expectedError := errors.New("delete failed")
+	mockHTTP.On("DeleteWithPayloadReturnStruct",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	_, err := svc.RemoveTenantEntitlements("test-consortium", constant.TenantType(constant.Member), false)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateApplication_MinimalSuccess(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationName = "Test Application"
+	action.ConfigApplicationVersion = "1.0.0"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	// Create minimal extract with one backend module
+	version := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "mod-test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:        "mod-test",
+						Version:     &version,
+						SidecarName: "mod-test-sc",
+					},
+				},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules: map[string]models.BackendModule{
+			"mod-test": {
+				DeployModule: true,
+				PrivatePort:  8080,
+			},
+		},
+		FrontendModules: map[string]models.FrontendModule{},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications/") && !strings.Contains(url, "?")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Once().
+		Return(apperrors.ErrHTTP404NotFound)
+
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			return data["id"] == "test-app" && data["name"] == "Test Application"
+		}),
+		mock.Anything,
+		mock.AnythingOfType("*models.ApplicationDescriptor")).
+		Run(func(args mock.Arguments) {
+			resp := args.Get(3).(*models.ApplicationDescriptor)
+			resp.ID = "test-app"
+			resp.Name = "Test Application"
+			resp.Version = "1.0.0"
+		}).
+		Return(nil)
+
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/modules/discovery")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			discovery := data["discovery"].([]any)
+			return len(discovery) == 1
+		}),
+		mock.Anything,
+		mock.AnythingOfType("*models.ModuleDiscoveryResponse")).
+		Run(func(args mock.Arguments) {
+			resp := args.Get(3).(*models.ModuleDiscoveryResponse)
+			resp.TotalRecords = 1
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateApplication_SkipModuleDiscovery(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.Param.SkipModuleDiscovery = true
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationName = "Test Application"
+	action.ConfigApplicationVersion = "1.0.0"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	version := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "mod-test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:        "mod-test",
+						Version:     &version,
+						SidecarName: "mod-test-sc",
+					},
+				},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules: map[string]models.BackendModule{
+			"mod-test": {
+				DeployModule: true,
+				PrivatePort:  8080,
+			},
+		},
+		FrontendModules: map[string]models.FrontendModule{},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Once().
+		Return(apperrors.ErrHTTP404NotFound)
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			return len(data["modules"].([]any)) == 1
+		}),
+		mock.Anything,
+		mock.AnythingOfType("*models.ApplicationDescriptor")).
+		Return(nil)
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNumberOfCalls(t, "PostReturnStruct", 1)
+}
+
+func TestCreateApplication_DryRun(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.Param.DryRun = true
+	action.Param.Tag = "run-42"
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationName = "Test Application"
+	action.ConfigApplicationVersion = "1.0.0"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	var buf bytes.Buffer
+	svc.DryRunWriter = &buf
+
+	version := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "mod-test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:        "mod-test",
+						Version:     &version,
+						SidecarName: "mod-test-sc",
+					},
+				},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules: map[string]models.BackendModule{
+			"mod-test": {
+				DeployModule: true,
+				PrivatePort:  8080,
+			},
+		},
+		FrontendModules: map[string]models.FrontendModule{},
+	}
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "# POST /applications?check=true\n{\n  ")
+	assert.Contains(t, output, `"id": "test-app"`)
+	assert.Contains(t, output, `"version": "1.0.0"`)
+	assert.Contains(t, output, `"description": "Default [run-42]"`)
+	assert.Contains(t, output, "# POST /modules/discovery")
+	assert.Contains(t, output, `"location": "http://mod-test-sc.eureka:8080"`)
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateApplication_DryRunSkipModuleDiscovery(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.Param.DryRun = true
+	action.Param.SkipModuleDiscovery = true
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationName = "Test Application"
+	action.ConfigApplicationVersion = "1.0.0"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	var buf bytes.Buffer
+	svc.DryRunWriter = &buf
+
+	version := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "mod-test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:        "mod-test",
+						Version:     &version,
+						SidecarName: "mod-test-sc",
+					},
+				},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules: map[string]models.BackendModule{
+			"mod-test": {
+				DeployModule: true,
+				PrivatePort:  8080,
+			},
+		},
+		FrontendModules: map[string]models.FrontendModule{},
+	}
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "# POST /applications?check=true")
+	assert.NotContains(t, buf.String(), "/modules/discovery")
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateApplication_InvalidPlatform(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationPlatform = "unknown"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	// Act
+	err := svc.CreateApplication(&models.RegistryExtract{})
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateApplication_WithFrontendModule(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationName = "Test Application"
+	action.ConfigApplicationVersion = "1.0.0"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	version := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "folio-test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:    "folio-test",
+						Version: &version,
+					},
+				},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules: map[string]models.BackendModule{},
+		FrontendModules: map[string]models.FrontendModule{
+			"folio-test": {
+				DeployModule: true,
+			},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications/") && !strings.Contains(url, "?")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Once().
+		Return(apperrors.ErrHTTP404NotFound)
+
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			uiModules := data["uiModules"].([]any)
+			return len(uiModules) == 1
+		}),
+		mock.Anything,
+		mock.AnythingOfType("*models.ApplicationDescriptor")).
+		Run(func(args mock.Arguments) {
+			resp := args.Get(3).(*models.ApplicationDescriptor)
+			resp.ID = "test-app"
+			resp.Name = "Test Application"
+			resp.Version = "1.0.0"
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateApplication_SkipsManagementModule(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationName = "Test Application"
+	action.ConfigApplicationVersion = "1.0.0"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	version := "1.0.0"
+	uiVersion := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "mgr-applications-1.0.0",
 					Metadata: models.ProxyModuleMetadata{
 						Name:    "mgr-applications", // Should be skipped
 						Version: &version,
 					},
 				},
+				{
+					ID: "folio_test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:    "folio_test", // Keeps the application non-empty
+						Version: &uiVersion,
+					},
+				},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules: map[string]models.BackendModule{
+			"mgr-applications": {
+				DeployModule: true,
+				PrivatePort:  8080,
+			},
+		},
+		FrontendModules: map[string]models.FrontendModule{
+			"folio_test": {DeployModule: true, ModuleName: "folio_test"},
+		},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications/") && !strings.Contains(url, "?")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Once().
+		Return(apperrors.ErrHTTP404NotFound)
+
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			modules, ok := data["modules"].([]any)
+			// Should be nil or empty since mgr-applications is skipped
+			return !ok || len(modules) == 0
+		}),
+		mock.Anything,
+		mock.AnythingOfType("*models.ApplicationDescriptor")).
+		Run(func(args mock.Arguments) {
+			resp := args.Get(3).(*models.ApplicationDescriptor)
+			resp.ID = "test-app"
+			resp.Name = "Test Application"
+			resp.Version = "1.0.0"
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateApplication_SkipsExcludedModules(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationName = "Test Application"
+	action.ConfigApplicationVersion = "1.0.0"
+	action.ConfigApplicationExcludeModules = []string{"folio_n*"}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	version := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{ID: "folio_users-1.0.0", Metadata: models.ProxyModuleMetadata{Name: "folio_users", Version: &version}},
+				{ID: "folio_notes-1.0.0", Metadata: models.ProxyModuleMetadata{Name: "folio_notes", Version: &version}},
 			},
 			EurekaModules: []*models.ProxyModule{},
 		},
-		BackendModules: map[string]models.BackendModule{
-			"mgr-applications": {
-				DeployModule: true,
-				PrivatePort:  8080,
-			},
+		BackendModules: map[string]models.BackendModule{},
+		FrontendModules: map[string]models.FrontendModule{
+			"folio_users": {DeployModule: true, ModuleName: "folio_users"},
+			"folio_notes": {DeployModule: true, ModuleName: "folio_notes"},
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -1633,18 +2722,14 @@ func TestCreateApplication_SkipsManagementModule(t *testing.T) {
 		mock.MatchedBy(func(payload []byte) bool {
 			var data map[string]any
 			_ = json.Unmarshal(payload, &data)
-			modules, ok := data["modules"].([]any)
-			// Should be nil or empty since mgr-applications is skipped
-			return !ok || len(modules) == 0
+			uiModules, ok := data["uiModules"].([]any)
+			if !ok || len(uiModules) != 1 {
+				return false
+			}
+			return uiModules[0].(map[string]any)["name"] == "folio_users"
 		}),
 		mock.Anything,
 		mock.AnythingOfType("*models.ApplicationDescriptor")).
-		Run(func(args mock.Arguments) {
-			resp := args.Get(3).(*models.ApplicationDescriptor)
-			resp.ID = "test-app"
-			resp.Name = "Test Application"
-			resp.Version = "1.0.0"
-		}).
 		Return(nil)
 
 	// Act
@@ -1666,14 +2751,24 @@ func TestCreateApplication_HTTPError(t *testing.T) {
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
+	uiVersion := "1.0.0"
 	extract := &models.RegistryExtract{
 		Modules: &models.ProxyModulesByRegistry{
-			FolioModules:  []*models.ProxyModule{},
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "folio_test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:    "folio_test", // Keeps the application non-empty
+						Version: &uiVersion,
+					},
+				},
+			},
 			EurekaModules: []*models.ProxyModule{},
 		},
-		BackendModules:    map[string]models.BackendModule{},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		BackendModules: map[string]models.BackendModule{},
+		FrontendModules: map[string]models.FrontendModule{
+			"folio_test": {DeployModule: true, ModuleName: "folio_test"},
+		},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -1736,8 +2831,7 @@ func TestCreateApplication_WithModuleVersionOverride(t *testing.T) {
 				ModuleVersion: &overrideVersion, // Override version
 			},
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		FrontendModules: map[string]models.FrontendModule{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -1797,6 +2891,7 @@ func TestCreateApplication_WithModuleVersionOverride(t *testing.T) {
 
 func TestCreateApplication_WithFetchDescriptorsFromRemote(t *testing.T) {
 	// Arrange
+	t.Setenv("HOME", t.TempDir())
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
@@ -1828,8 +2923,7 @@ func TestCreateApplication_WithFetchDescriptorsFromRemote(t *testing.T) {
 				PrivatePort:  8080,
 			},
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		FrontendModules: map[string]models.FrontendModule{},
 	}
 
 	descriptorData := map[string]any{
@@ -1901,8 +2995,184 @@ func TestCreateApplication_WithFetchDescriptorsFromRemote(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
+func TestCreateApplication_URLOnlyPayload(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app-1.0.0"
+	action.ConfigApplicationName = "test-app"
+	action.ConfigApplicationVersion = "1.0.0"
+	action.ConfigApplicationPlatform = "base"
+	action.ConfigApplicationFetchDescriptors = false
+	action.ConfigRegistryURL = "https://folio-registry.dev.folio.org"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	backendVersion := "2.0.0"
+	frontendVersion := "3.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{ID: "mod-test-2.0.0", Metadata: models.ProxyModuleMetadata{Name: "mod-test", Version: &backendVersion, SidecarName: "mod-test-sc"}},
+				{ID: "folio_test-3.0.0", Metadata: models.ProxyModuleMetadata{Name: "folio_test", Version: &frontendVersion}},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules:  map[string]models.BackendModule{"mod-test": {DeployModule: true, PrivatePort: 8081}},
+		FrontendModules: map[string]models.FrontendModule{"folio_test": {DeployModule: true}},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications/") && !strings.Contains(url, "?")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Once().
+		Return(apperrors.ErrHTTP404NotFound)
+
+	var applicationPayload map[string]any
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications?check=true")
+		}),
+		mock.Anything,
+		mock.Anything,
+		mock.AnythingOfType("*models.ApplicationDescriptor")).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal(args.Get(1).([]byte), &applicationPayload)
+		}).
+		Return(nil)
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/modules/discovery")
+		}),
+		mock.Anything,
+		mock.Anything,
+		mock.AnythingOfType("*models.ModuleDiscoveryResponse")).
+		Return(nil)
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertNotCalled(t, "GetReturnStruct", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, "test-app-1.0.0", applicationPayload["id"])
+	assert.Equal(t, "test-app", applicationPayload["name"])
+	assert.Equal(t, "1.0.0", applicationPayload["version"])
+	assert.Equal(t, "base", applicationPayload["platform"])
+	assert.Nil(t, applicationPayload["moduleDescriptors"])
+	assert.Nil(t, applicationPayload["uiModuleDescriptors"])
+	assert.Equal(t, []any{map[string]any{
+		"id":      "mod-test-2.0.0",
+		"name":    "mod-test",
+		"version": "2.0.0",
+		"url":     "https://folio-registry.dev.folio.org/_/proxy/modules/mod-test-2.0.0",
+	}}, applicationPayload["modules"])
+	assert.Equal(t, []any{map[string]any{
+		"id":      "folio_test-3.0.0",
+		"name":    "folio_test",
+		"version": "3.0.0",
+		"url":     "https://folio-registry.dev.folio.org/_/proxy/modules/folio_test-3.0.0",
+	}}, applicationPayload["uiModules"])
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateApplication_WithDescriptorFileOverride(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	action.ConfigApplicationName = "Test Application"
+	action.ConfigApplicationVersion = "1.0.0"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	descriptorFile := filepath.Join(t.TempDir(), "ModuleDescriptor.json")
+	require.NoError(t, os.WriteFile(descriptorFile, []byte(`{"id":"mod-test-1.0.0","name":"Local Module"}`), 0600))
+
+	version := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "mod-test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:        "mod-test",
+						Version:     &version,
+						SidecarName: "mod-test-sc",
+					},
+				},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules: map[string]models.BackendModule{
+			"mod-test": {
+				DeployModule:   true,
+				PrivatePort:    8080,
+				DescriptorFile: descriptorFile,
+			},
+		},
+		FrontendModules: map[string]models.FrontendModule{},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications/") && !strings.Contains(url, "?")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Once().
+		Return(apperrors.ErrHTTP404NotFound)
+
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications")
+		}),
+		mock.MatchedBy(func(payload []byte) bool {
+			var data map[string]any
+			_ = json.Unmarshal(payload, &data)
+			descriptors, ok := data["moduleDescriptors"].([]any)
+			if !ok || len(descriptors) != 1 {
+				return false
+			}
+			descriptor := descriptors[0].(map[string]any)
+			return descriptor["name"] == "Local Module"
+		}),
+		mock.Anything,
+		mock.AnythingOfType("*models.ApplicationDescriptor")).
+		Run(func(args mock.Arguments) {
+			resp := args.Get(3).(*models.ApplicationDescriptor)
+			resp.ID = "test-app"
+		}).
+		Return(nil)
+
+	mockHTTP.On("PostReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/modules/discovery")
+		}),
+		mock.Anything,
+		mock.Anything,
+		mock.AnythingOfType("*models.ModuleDiscoveryResponse")).
+		Return(nil)
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.MatchedBy(func(url string) bool {
+		return strings.Contains(url, "/_/proxy/modules/")
+	}), mock.Anything, mock.Anything)
+}
+
 func TestCreateApplication_FetchDescriptorError(t *testing.T) {
 	// Arrange
+	t.Setenv("HOME", t.TempDir())
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
@@ -1933,8 +3203,7 @@ func TestCreateApplication_FetchDescriptorError(t *testing.T) {
 				PrivatePort:  8080,
 			},
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		FrontendModules: map[string]models.FrontendModule{},
 	}
 
 	expectedError := errors.New("failed to fetch descriptor")
@@ -1968,14 +3237,24 @@ func TestCreateApplication_WithDependencies(t *testing.T) {
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
+	uiVersion := "1.0.0"
 	extract := &models.RegistryExtract{
 		Modules: &models.ProxyModulesByRegistry{
-			FolioModules:  []*models.ProxyModule{},
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "folio_test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:    "folio_test", // Keeps the application non-empty
+						Version: &uiVersion,
+					},
+				},
+			},
 			EurekaModules: []*models.ProxyModule{},
 		},
-		BackendModules:    map[string]models.BackendModule{},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		BackendModules: map[string]models.BackendModule{},
+		FrontendModules: map[string]models.FrontendModule{
+			"folio_test": {DeployModule: true, ModuleName: "folio_test"},
+		},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2107,6 +3386,27 @@ func TestGetTenantType_MissingCentralTenantField(t *testing.T) {
 	assert.Equal(t, "test-consortium-member", result)
 }
 
+func TestGetConfigTenants_FiltersPartition(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.ConfigTenants = map[string]any{
+		"central": map[string]any{"consortium": "test-consortium", "central-tenant": true},
+		"member":  map[string]any{"consortium": "test-consortium"},
+		"diku":    map[string]any{},
+	}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	// Act
+	members := svc.GetConfigTenants("test-consortium", constant.Member)
+	all := svc.GetConfigTenants("", constant.All)
+
+	// Assert
+	assert.Equal(t, []any{map[string]any{"name": "member", "description": "test-consortium-member"}}, members)
+	assert.Len(t, all, 3)
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestCreateApplication_SkipsModuleNotInConfig(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
@@ -2119,6 +3419,7 @@ func TestCreateApplication_SkipsModuleNotInConfig(t *testing.T) {
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
 	version := "1.0.0"
+	uiVersion := "1.0.0"
 	extract := &models.RegistryExtract{
 		Modules: &models.ProxyModulesByRegistry{
 			FolioModules: []*models.ProxyModule{
@@ -2129,14 +3430,22 @@ func TestCreateApplication_SkipsModuleNotInConfig(t *testing.T) {
 						Version: &version,
 					},
 				},
+				{
+					ID: "folio_test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:    "folio_test", // Keeps the application non-empty
+						Version: &uiVersion,
+					},
+				},
 			},
 			EurekaModules: []*models.ProxyModule{},
 		},
 		BackendModules: map[string]models.BackendModule{
 			// Module not in config, should be skipped
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		FrontendModules: map[string]models.FrontendModule{
+			"folio_test": {DeployModule: true, ModuleName: "folio_test"},
+		},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2170,11 +3479,73 @@ func TestCreateApplication_SkipsModuleNotInConfig(t *testing.T) {
 		Return(nil)
 
 	// Act
-	err := svc.CreateApplication(extract)
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestCreateApplication_NoDeployableModules(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	version := "1.0.0"
+	extract := &models.RegistryExtract{
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{
+					ID: "mod-test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:    "mod-test",
+						Version: &version,
+					},
+				},
+			},
+			EurekaModules: []*models.ProxyModule{},
+		},
+		BackendModules:  map[string]models.BackendModule{},
+		FrontendModules: map[string]models.FrontendModule{},
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.Contains(url, "/applications/") && !strings.Contains(url, "?")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Once().
+		Return(apperrors.ErrHTTP404NotFound)
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "test-app")
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateApplication_NilRegistryModules(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "test-app"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	// Act
+	err := svc.CreateApplication(&models.RegistryExtract{})
 
 	// Assert
-	assert.NoError(t, err)
-	mockHTTP.AssertExpectations(t)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestCreateApplication_SkipsModuleWithDeployFalse(t *testing.T) {
@@ -2189,6 +3560,7 @@ func TestCreateApplication_SkipsModuleWithDeployFalse(t *testing.T) {
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
 	version := "1.0.0"
+	uiVersion := "1.0.0"
 	extract := &models.RegistryExtract{
 		Modules: &models.ProxyModulesByRegistry{
 			FolioModules: []*models.ProxyModule{
@@ -2199,6 +3571,13 @@ func TestCreateApplication_SkipsModuleWithDeployFalse(t *testing.T) {
 						Version: &version,
 					},
 				},
+				{
+					ID: "folio_test-1.0.0",
+					Metadata: models.ProxyModuleMetadata{
+						Name:    "folio_test", // Keeps the application non-empty
+						Version: &uiVersion,
+					},
+				},
 			},
 			EurekaModules: []*models.ProxyModule{},
 		},
@@ -2208,8 +3587,9 @@ func TestCreateApplication_SkipsModuleWithDeployFalse(t *testing.T) {
 				PrivatePort:  8080,
 			},
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		FrontendModules: map[string]models.FrontendModule{
+			"folio_test": {DeployModule: true, ModuleName: "folio_test"},
+		},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2282,8 +3662,7 @@ func TestCreateApplication_WithEurekaModules(t *testing.T) {
 				PrivatePort:  8081,
 			},
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		FrontendModules: map[string]models.FrontendModule{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2368,8 +3747,7 @@ func TestCreateApplication_DiscoveryPostError(t *testing.T) {
 				PrivatePort:  8080,
 			},
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		FrontendModules: map[string]models.FrontendModule{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2448,8 +3826,7 @@ func TestCreateApplication_WithModuleURLs(t *testing.T) {
 				PrivatePort:  8080,
 			},
 		},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		FrontendModules: map[string]models.FrontendModule{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2510,6 +3887,7 @@ func TestCreateApplication_WithModuleURLs(t *testing.T) {
 
 func TestCreateApplication_FrontendModuleWithFetchDescriptors(t *testing.T) {
 	// Arrange
+	t.Setenv("HOME", t.TempDir())
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
@@ -2540,7 +3918,6 @@ func TestCreateApplication_FrontendModuleWithFetchDescriptors(t *testing.T) {
 				DeployModule: true,
 			},
 		},
-		ModuleDescriptors: map[string]any{},
 	}
 
 	descriptorData := map[string]any{
@@ -2630,7 +4007,6 @@ func TestCreateApplication_FrontendModuleWithURL(t *testing.T) {
 				DeployModule: true,
 			},
 		},
-		ModuleDescriptors: map[string]any{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2709,7 +4085,6 @@ func TestCreateApplication_FrontendVersionOverride(t *testing.T) {
 				ModuleVersion: &overrideVersion, // Override version
 			},
 		},
-		ModuleDescriptors: map[string]any{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2798,7 +4173,6 @@ func TestCreateApplication_MixedBackendAndFrontend(t *testing.T) {
 				DeployModule: true,
 			},
 		},
-		ModuleDescriptors: map[string]any{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -2899,7 +4273,6 @@ func TestCreateApplication_BothModulesBackendVersionOverride(t *testing.T) {
 				// Frontend has no version override, but won't be used since backend takes priority
 			},
 		},
-		ModuleDescriptors: map[string]any{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -3000,7 +4373,6 @@ func TestCreateApplication_BothModulesFrontendVersionOverride(t *testing.T) {
 				ModuleVersion: &frontendOverrideVersion, // Frontend has version override
 			},
 		},
-		ModuleDescriptors: map[string]any{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -3103,7 +4475,6 @@ func TestCreateApplication_BothModulesBothVersionOverrides(t *testing.T) {
 				ModuleVersion: &frontendOverrideVersion, // Frontend also has version override
 			},
 		},
-		ModuleDescriptors: map[string]any{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -3149,349 +4520,884 @@ func TestCreateApplication_BothModulesBothVersionOverrides(t *testing.T) {
 			return strings.Contains(url, "/modules/discovery")
 		}),
 		mock.Anything,
-		mock.Anything,
-		mock.AnythingOfType("*models.ModuleDiscoveryResponse")).
+		mock.Anything,
+		mock.AnythingOfType("*models.ModuleDiscoveryResponse")).
+		Run(func(args mock.Arguments) {
+			resp := args.Get(3).(*models.ModuleDiscoveryResponse)
+			resp.TotalRecords = 1
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.CreateApplication(extract)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+func TestFetchModuleDescriptor_RemoteModule_Success(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "mod-test-1.0.0"
+	moduleDescriptorURL := "http://registry.local/_/proxy/modules/mod-test-1.0.0"
+	expectedDescriptor := map[string]any{
+		"id":      "mod-test-1.0.0",
+		"name":    "mod-test",
+		"version": "1.0.0",
+	}
+
+	mockHTTP.On("GetRetryReturnStruct",
+		moduleDescriptorURL,
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*any)
+			*target = expectedDescriptor
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.FetchModuleDescriptor(extract, moduleID, moduleDescriptorURL, "", false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDescriptor, extract.ModuleDescriptors.Get(moduleID))
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestFetchModuleDescriptor_RemoteModule_HTTPError(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "mod-test-1.0.0"
+	moduleDescriptorURL := "http://registry.local/_/proxy/modules/mod-test-1.0.0"
+	expectedError := errors.New("network error")
+
+	mockHTTP.On("GetRetryReturnStruct",
+		moduleDescriptorURL,
+		mock.Anything,
+		mock.Anything).
+		Return(expectedError)
+
+	// Act
+	err := svc.FetchModuleDescriptor(extract, moduleID, moduleDescriptorURL, "", false)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	assert.Zero(t, extract.ModuleDescriptors.Len())
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestFetchModuleDescriptor_RemoteModule_UsesDescriptorCache(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "mod-test-1.0.0"
+	cachedDescriptor := map[string]any{"id": moduleID, "name": "mod-test"}
+	cachePath, err := helpers.GetDescriptorCacheFilePath(moduleID)
+	require.NoError(t, err)
+	require.NoError(t, helpers.WriteJSONToFile(cachePath, cachedDescriptor))
+
+	// Act
+	err = svc.FetchModuleDescriptor(extract, moduleID, "http://registry.local/_/proxy/modules/mod-test-1.0.0", "", false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, cachedDescriptor, extract.ModuleDescriptors.Get(moduleID))
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFetchModuleDescriptor_RemoteModule_DescriptorCacheMiss(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "mod-test-1.0.0"
+	moduleDescriptorURL := "http://registry.local/_/proxy/modules/mod-test-1.0.0"
+	expectedDescriptor := map[string]any{"id": moduleID}
+
+	mockHTTP.On("GetRetryReturnStruct", moduleDescriptorURL, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*any)
+			*target = expectedDescriptor
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.FetchModuleDescriptor(extract, moduleID, moduleDescriptorURL, "", false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDescriptor, extract.ModuleDescriptors.Get(moduleID))
+	mockHTTP.AssertExpectations(t)
+	cachePath, err := helpers.GetDescriptorCacheFilePath(moduleID)
+	require.NoError(t, err)
+	var cachedDescriptor map[string]any
+	require.NoError(t, helpers.ReadJSONFromFile(cachePath, &cachedDescriptor))
+	assert.Equal(t, expectedDescriptor, cachedDescriptor)
+}
+
+func TestFetchModuleDescriptor_RemoteModule_NoCache(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.Param.NoCache = true
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "mod-test-1.0.0"
+	moduleDescriptorURL := "http://registry.local/_/proxy/modules/mod-test-1.0.0"
+	cachePath, err := helpers.GetDescriptorCacheFilePath(moduleID)
+	require.NoError(t, err)
+	require.NoError(t, helpers.WriteJSONToFile(cachePath, map[string]any{"id": moduleID, "name": "stale"}))
+	fetchedDescriptor := map[string]any{"id": moduleID, "name": "fetched"}
+
+	mockHTTP.On("GetRetryReturnStruct", moduleDescriptorURL, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*any)
+			*target = fetchedDescriptor
+		}).
+		Return(nil)
+
+	// Act
+	err = svc.FetchModuleDescriptor(extract, moduleID, moduleDescriptorURL, "", false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fetchedDescriptor, extract.ModuleDescriptors.Get(moduleID))
+	mockHTTP.AssertExpectations(t)
+	var cachedDescriptor map[string]any
+	require.NoError(t, helpers.ReadJSONFromFile(cachePath, &cachedDescriptor))
+	assert.Equal(t, "stale", cachedDescriptor["name"])
+}
+
+func TestFetchModuleDescriptor_LocalBackendModule_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "mod-test-1.0.0"
+	testFile := testhelpers.CreateTempJSONFile(t, map[string]any{
+		"id":      "mod-test-1.0.0",
+		"name":    "mod-test",
+		"version": "1.0.0",
+	})
+
+	// Act
+	err := svc.FetchModuleDescriptor(extract, moduleID, "", testFile, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, extract.ModuleDescriptors.Get(moduleID))
+	descriptor := extract.ModuleDescriptors.Get(moduleID).(map[string]any)
+	assert.Equal(t, "mod-test-1.0.0", descriptor["id"])
+	assert.Equal(t, "mod-test", descriptor["name"])
+}
+
+func TestFetchModuleDescriptor_LocalFrontendModule_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "folio-ui-test-1.0.0"
+	testFile := testhelpers.CreateTempJSONFile(t, map[string]any{
+		"id":      "folio-ui-test-1.0.0",
+		"name":    "folio-ui-test",
+		"version": "1.0.0",
+	})
+
+	// Act
+	err := svc.FetchModuleDescriptor(extract, moduleID, "", testFile, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, extract.ModuleDescriptors.Get(moduleID))
+	descriptor := extract.ModuleDescriptors.Get(moduleID).(map[string]any)
+	assert.Equal(t, "folio-ui-test-1.0.0", descriptor["id"])
+	assert.Equal(t, "folio-ui-test", descriptor["name"])
+}
+
+func TestFetchModuleDescriptor_LocalModule_FileNotFound(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "mod-test-1.0.0"
+
+	// Act
+	err := svc.FetchModuleDescriptor(extract, moduleID, "", "/nonexistent/path.json", true)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Zero(t, extract.ModuleDescriptors.Len())
+}
+
+func TestFetchModuleDescriptor_LocalModule_InvalidJSON(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	extract := &models.RegistryExtract{}
+	moduleID := "mod-test-1.0.0"
+	testFile := testhelpers.CreateTempFile(t, "invalid json content {")
+
+	// Act
+	err := svc.FetchModuleDescriptor(extract, moduleID, "", testFile, true)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Zero(t, extract.ModuleDescriptors.Len())
+}
+
+// ==================== GetTenantEntitlements Tests ====================
+
+func TestGetTenantEntitlements_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	tenantName := "test-tenant"
+	includeModules := true
+
+	expectedResponse := models.TenantEntitlementResponse{
+		TotalRecords: 2,
+		Entitlements: []models.TenantEntitlementDTO{
+			{
+				ApplicationID: "app-123",
+				TenantID:      "tenant-123",
+			},
+			{
+				ApplicationID: "app-456",
+				TenantID:      "tenant-123",
+			},
+		},
+	}
+
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return assert.Contains(t, url, "/entitlements") &&
+				assert.Contains(t, url, "tenant=test-tenant") &&
+				assert.Contains(t, url, "includeModules=true")
+		}),
+		mock.Anything,
+		mock.Anything).
 		Run(func(args mock.Arguments) {
-			resp := args.Get(3).(*models.ModuleDiscoveryResponse)
-			resp.TotalRecords = 1
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			*target = expectedResponse
 		}).
 		Return(nil)
 
 	// Act
-	err := svc.CreateApplication(extract)
+	result, err := svc.GetTenantEntitlements(tenantName, includeModules)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, 2, result.TotalRecords)
+	assert.Len(t, result.Entitlements, 2)
+	assert.Equal(t, "app-123", result.Entitlements[0].ApplicationID)
+	assert.Equal(t, "tenant-123", result.Entitlements[0].TenantID)
 	mockHTTP.AssertExpectations(t)
 }
-func TestFetchModuleDescriptor_RemoteModule_Success(t *testing.T) {
+
+func TestGetTenantEntitlements_WithoutModules(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	extract := &models.RegistryExtract{
-		ModuleDescriptors: make(map[string]any),
-	}
-	moduleID := "mod-test-1.0.0"
-	moduleDescriptorURL := "http://registry.local/_/proxy/modules/mod-test-1.0.0"
-	expectedDescriptor := map[string]any{
-		"id":      "mod-test-1.0.0",
-		"name":    "mod-test",
-		"version": "1.0.0",
+	tenantName := "test-tenant"
+	includeModules := false
+
+	expectedResponse := models.TenantEntitlementResponse{
+		TotalRecords: 1,
+		Entitlements: []models.TenantEntitlementDTO{
+			{
+				ApplicationID: "app-789",
+				TenantID:      "tenant-789",
+			},
+		},
 	}
 
-	mockHTTP.On("GetRetryReturnStruct",
-		moduleDescriptorURL,
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return assert.Contains(t, url, "/entitlements") &&
+				assert.Contains(t, url, "tenant=test-tenant") &&
+				assert.Contains(t, url, "includeModules=false")
+		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*any)
-			*target = expectedDescriptor
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			*target = expectedResponse
 		}).
 		Return(nil)
 
 	// Act
-	err := svc.FetchModuleDescriptor(extract, moduleID, moduleDescriptorURL, "", false)
+	result, err := svc.GetTenantEntitlements(tenantName, includeModules)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, expectedDescriptor, extract.ModuleDescriptors[moduleID])
+	assert.Equal(t, 1, result.TotalRecords)
+	assert.Len(t, result.Entitlements, 1)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestFetchModuleDescriptor_RemoteModule_HTTPError(t *testing.T) {
+func TestGetTenantEntitlements_HeaderError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "" // Invalid token
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	extract := &models.RegistryExtract{
-		ModuleDescriptors: make(map[string]any),
-	}
-	moduleID := "mod-test-1.0.0"
-	moduleDescriptorURL := "http://registry.local/_/proxy/modules/mod-test-1.0.0"
-	expectedError := errors.New("network error")
+	// Act
+	result, err := svc.GetTenantEntitlements("test-tenant", true)
 
-	mockHTTP.On("GetRetryReturnStruct",
-		moduleDescriptorURL,
-		mock.Anything,
-		mock.Anything).
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, models.TenantEntitlementResponse{}, result)
+}
+
+func TestGetTenantEntitlements_HTTPError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	expectedError := errors.New("network error")
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
 		Return(expectedError)
 
 	// Act
-	err := svc.FetchModuleDescriptor(extract, moduleID, moduleDescriptorURL, "", false)
+	result, err := svc.GetTenantEntitlements("test-tenant", true)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
-	assert.Empty(t, extract.ModuleDescriptors)
+	assert.Equal(t, models.TenantEntitlementResponse{}, result)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestFetchModuleDescriptor_LocalBackendModule_Success(t *testing.T) {
+func TestGetTenantEntitlements_EmptyResponse(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	extract := &models.RegistryExtract{
-		ModuleDescriptors: make(map[string]any),
+	expectedResponse := models.TenantEntitlementResponse{
+		TotalRecords: 0,
+		Entitlements: []models.TenantEntitlementDTO{},
 	}
-	moduleID := "mod-test-1.0.0"
-	testFile := testhelpers.CreateTempJSONFile(t, map[string]any{
-		"id":      "mod-test-1.0.0",
-		"name":    "mod-test",
-		"version": "1.0.0",
-	})
+
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			*target = expectedResponse
+		}).
+		Return(nil)
 
 	// Act
-	err := svc.FetchModuleDescriptor(extract, moduleID, "", testFile, true)
+	result, err := svc.GetTenantEntitlements("test-tenant", false)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotEmpty(t, extract.ModuleDescriptors[moduleID])
-	descriptor := extract.ModuleDescriptors[moduleID].(map[string]any)
-	assert.Equal(t, "mod-test-1.0.0", descriptor["id"])
-	assert.Equal(t, "mod-test", descriptor["name"])
+	assert.Equal(t, 0, result.TotalRecords)
+	assert.Empty(t, result.Entitlements)
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestFetchModuleDescriptor_LocalFrontendModule_Success(t *testing.T) {
+// ==================== WaitForTenantEntitlements Tests ====================
+
+func TestWaitForTenantEntitlements_AllEntitled(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "app-combined-1.0.0"
+	action.ConfigTenants = map[string]any{"diku": nil, "test": nil}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	extract := &models.RegistryExtract{
-		ModuleDescriptors: make(map[string]any),
-	}
-	moduleID := "folio-ui-test-1.0.0"
-	testFile := testhelpers.CreateTempJSONFile(t, map[string]any{
-		"id":      "folio-ui-test-1.0.0",
-		"name":    "folio-ui-test",
-		"version": "1.0.0",
-	})
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			*target = models.TenantEntitlementResponse{
+				Entitlements: []models.TenantEntitlementDTO{{ApplicationID: "app-combined-1.0.0"}},
+			}
+		}).
+		Return(nil)
 
 	// Act
-	err := svc.FetchModuleDescriptor(extract, moduleID, "", testFile, true)
+	err := svc.WaitForTenantEntitlements(time.Second)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotEmpty(t, extract.ModuleDescriptors[moduleID])
-	descriptor := extract.ModuleDescriptors[moduleID].(map[string]any)
-	assert.Equal(t, "folio-ui-test-1.0.0", descriptor["id"])
-	assert.Equal(t, "folio-ui-test", descriptor["name"])
+	mockHTTP.AssertNumberOfCalls(t, "GetReturnStruct", 2)
 }
 
-func TestFetchModuleDescriptor_LocalModule_FileNotFound(t *testing.T) {
+func TestWaitForTenantEntitlements_EntitledAfterPolling(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "app-combined-1.0.0"
+	action.ConfigTenants = map[string]any{"diku": nil}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+	svc.EntitlementPollWait = time.Millisecond
 
-	extract := &models.RegistryExtract{
-		ModuleDescriptors: make(map[string]any),
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			*target = models.TenantEntitlementResponse{
+				Entitlements: []models.TenantEntitlementDTO{{ApplicationID: "app-combined-1.0.0"}},
+			}
+		}).
+		Return(nil).Once()
+
+	// Act
+	err := svc.WaitForTenantEntitlements(time.Second)
+
+	// Assert
+	assert.NoError(t, err)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestWaitForTenantEntitlements_Timeout(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "app-combined-1.0.0"
+	action.ConfigTenants = map[string]any{"diku": nil}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+	svc.EntitlementPollWait = 10 * time.Millisecond
+
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			*target = models.TenantEntitlementResponse{
+				Entitlements: []models.TenantEntitlementDTO{{ApplicationID: "app-other-1.0.0"}},
+			}
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.WaitForTenantEntitlements(50 * time.Millisecond)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrTimeout)
+	assert.Contains(t, err.Error(), "diku")
+}
+
+func TestWaitForTenantEntitlements_PendingAdditionalEntitlement(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigApplicationID = "app-combined-1.0.0"
+	action.ConfigApplicationEntitlements = []string{"app-edge-1.0.0"}
+	action.ConfigTenants = map[string]any{"diku": nil}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+	svc.EntitlementPollWait = 10 * time.Millisecond
+
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			*target = models.TenantEntitlementResponse{
+				Entitlements: []models.TenantEntitlementDTO{{ApplicationID: "app-combined-1.0.0"}},
+			}
+		}).
+		Return(nil)
+
+	// Act
+	err := svc.WaitForTenantEntitlements(50 * time.Millisecond)
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrTimeout)
+	assert.Contains(t, err.Error(), "diku")
+}
+
+func TestWaitForTenantEntitlements_QueryError(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{"diku": nil}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+	expectedError := errors.New("connection refused")
+
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
+
+	// Act
+	err := svc.WaitForTenantEntitlements(time.Second)
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+}
+
+// ==================== GetApplicationDependencies Tests ====================
+
+func TestGetApplicationDependencies_Success(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	application := map[string]any{
+		"id":           "app-test-1.0.0",
+		"dependencies": []any{map[string]any{"name": "app-platform-minimal", "version": "^1.0.0"}},
+		"moduleDescriptors": []any{
+			map[string]any{
+				"id":       "mod-users-19.0.0",
+				"provides": []any{map[string]any{"id": "users", "version": "16.1"}},
+			},
+			map[string]any{
+				"id":       "mod-orders-13.0.0",
+				"requires": []any{map[string]any{"id": "users", "version": "16.0"}},
+				"optional": []any{map[string]any{"id": "finance", "version": "2.0"}},
+			},
+		},
 	}
-	moduleID := "mod-test-1.0.0"
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/applications/app-test-1.0.0?full=true")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*map[string]any)
+			*target = application
+		}).
+		Return(nil)
 
 	// Act
-	err := svc.FetchModuleDescriptor(extract, moduleID, "", "/nonexistent/path.json", true)
+	graph, err := svc.GetApplicationDependencies("app-test-1.0.0")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Empty(t, extract.ModuleDescriptors)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ApplicationDependency{{Name: "app-platform-minimal", Version: "^1.0.0"}}, graph.Dependencies)
+	require.Len(t, graph.Modules, 2)
+	assert.Equal(t, "mod-orders-13.0.0", graph.Modules[0].ModuleID)
+	assert.Equal(t, []models.InterfaceDependency{
+		{InterfaceID: "users", Version: "16.0", ProviderIDs: []string{"mod-users-19.0.0"}},
+		{InterfaceID: "finance", Version: "2.0", Optional: true},
+	}, graph.Modules[0].Requires)
+	assert.Empty(t, graph.Modules[0].Provides)
+	assert.Equal(t, "mod-users-19.0.0", graph.Modules[1].ModuleID)
+	assert.Empty(t, graph.Modules[1].Requires)
+	assert.Equal(t, []models.ProvidedInterface{{InterfaceID: "users", Version: "16.1"}}, graph.Modules[1].Provides)
+	mockHTTP.AssertExpectations(t)
 }
 
-func TestFetchModuleDescriptor_LocalModule_InvalidJSON(t *testing.T) {
+func TestGetApplicationDependencies_SingleDependencyObject(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	action.KeycloakMasterAccessToken = "test-token"
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*map[string]any)
+			*target = map[string]any{
+				"id":           "app-test-1.0.0",
+				"dependencies": map[string]any{"name": "app-combined", "version": "1.0.0"},
+			}
+		}).
+		Return(nil)
+
+	// Act
+	graph, err := svc.GetApplicationDependencies("app-test-1.0.0")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []models.ApplicationDependency{{Name: "app-combined", Version: "1.0.0"}}, graph.Dependencies)
+	assert.Empty(t, graph.Modules)
+}
+
+func TestGetApplicationDependencies_NotFound(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
+
+	// Act
+	graph, err := svc.GetApplicationDependencies("app-missing-1.0.0")
+
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.Nil(t, graph)
+}
+
+// ==================== GetRegistryDependencies Tests ====================
+
+func TestGetRegistryDependencies_FromRegistryDescriptors(t *testing.T) {
+	// Arrange
+	t.Setenv("HOME", t.TempDir())
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.ConfigApplicationID = "app-test-1.0.0"
+	action.ConfigRegistryURL = "https://folio-registry.dev.folio.org"
+	action.ConfigApplicationDependencies = map[string]any{"name": "app-platform-minimal", "version": "^1.0.0"}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	usersVersion := "19.0.0"
 	extract := &models.RegistryExtract{
-		ModuleDescriptors: make(map[string]any),
+		Modules: &models.ProxyModulesByRegistry{
+			FolioModules: []*models.ProxyModule{
+				{ID: "mod-users-18.0.0", Metadata: models.ProxyModuleMetadata{Name: "mod-users"}},
+				{ID: "mod-orders-13.0.0", Metadata: models.ProxyModuleMetadata{Name: "mod-orders"}},
+				{ID: "mod-notes-5.0.0", Metadata: models.ProxyModuleMetadata{Name: "mod-notes"}},
+			},
+			EurekaModules: []*models.ProxyModule{
+				{ID: "mgr-tenants-3.0.0", Metadata: models.ProxyModuleMetadata{Name: "mgr-tenants"}},
+			},
+		},
+		BackendModules: map[string]models.BackendModule{
+			"mod-users":   {DeployModule: true, ModuleVersion: &usersVersion},
+			"mod-orders":  {DeployModule: true},
+			"mod-notes":   {DeployModule: false},
+			"mgr-tenants": {DeployModule: true},
+		},
 	}
-	moduleID := "mod-test-1.0.0"
-	testFile := testhelpers.CreateTempFile(t, "invalid json content {")
+	descriptors := map[string]map[string]any{
+		"mod-users-19.0.0": {
+			"id":       "mod-users-19.0.0",
+			"provides": []any{map[string]any{"id": "users", "version": "16.1"}},
+		},
+		"mod-orders-13.0.0": {
+			"id":       "mod-orders-13.0.0",
+			"requires": []any{map[string]any{"id": "users", "version": "16.0"}},
+		},
+	}
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			requestURL := args.String(0)
+			target := args.Get(2).(*any)
+			*target = descriptors[requestURL[strings.LastIndex(requestURL, "/")+1:]]
+		}).
+		Return(nil)
 
 	// Act
-	err := svc.FetchModuleDescriptor(extract, moduleID, "", testFile, true)
+	graph, err := svc.GetRegistryDependencies(extract)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Empty(t, extract.ModuleDescriptors)
+	require.NoError(t, err)
+	assert.Equal(t, "app-test-1.0.0", graph.ApplicationID)
+	assert.Equal(t, []models.ApplicationDependency{{Name: "app-platform-minimal", Version: "^1.0.0"}}, graph.Dependencies)
+	require.Len(t, graph.Modules, 2)
+	assert.Equal(t, "mod-orders-13.0.0", graph.Modules[0].ModuleID)
+	assert.Equal(t, []string{"mod-users-19.0.0"}, graph.Modules[0].Requires[0].ProviderIDs)
+	assert.Equal(t, "mod-users-19.0.0", graph.Modules[1].ModuleID)
+	mockHTTP.AssertNumberOfCalls(t, "GetRetryReturnStruct", 2)
+	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct", mock.MatchedBy(func(url string) bool {
+		return strings.Contains(url, "mgr-tenants") || strings.Contains(url, "mod-notes")
+	}), mock.Anything, mock.Anything)
 }
 
-// ==================== GetTenantEntitlements Tests ====================
+// ==================== GetApplicationModules Tests ====================
 
-func TestGetTenantEntitlements_Success(t *testing.T) {
+func TestGetApplicationModules_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
-
-	tenantName := "test-tenant"
-	includeModules := true
-
-	expectedResponse := models.TenantEntitlementResponse{
-		TotalRecords: 2,
-		Entitlements: []models.TenantEntitlementDTO{
-			{
-				ApplicationID: "app-123",
-				TenantID:      "tenant-123",
-			},
-			{
-				ApplicationID: "app-456",
-				TenantID:      "tenant-123",
-			},
-		},
-	}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	mockHTTP.On("GetReturnStruct",
+	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(url string) bool {
-			return assert.Contains(t, url, "/entitlements") &&
-				assert.Contains(t, url, "tenant=test-tenant") &&
-				assert.Contains(t, url, "includeModules=true")
+			return strings.HasSuffix(url, "/applications/app-test-1.0.0")
 		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantEntitlementResponse)
-			*target = expectedResponse
+			target := args.Get(2).(*map[string]any)
+			*target = map[string]any{
+				"id": "app-test-1.0.0",
+				"modules": []any{
+					map[string]any{"id": "mod-users-19.0.0", "name": "mod-users", "version": "19.0.0"},
+					map[string]any{"id": "mod-orders-13.0.0", "name": "mod-orders", "version": "13.0.0"},
+				},
+				"uiModules": []any{
+					map[string]any{"id": "folio_users-10.0.0", "name": "folio_users", "version": "10.0.0"},
+				},
+			}
 		}).
 		Return(nil)
 
 	// Act
-	result, err := svc.GetTenantEntitlements(tenantName, includeModules)
+	modules, err := svc.GetApplicationModules("app-test-1.0.0")
 
 	// Assert
-	assert.NoError(t, err)
-	assert.Equal(t, 2, result.TotalRecords)
-	assert.Len(t, result.Entitlements, 2)
-	assert.Equal(t, "app-123", result.Entitlements[0].ApplicationID)
-	assert.Equal(t, "tenant-123", result.Entitlements[0].TenantID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ApplicationModule{
+		{ID: "mod-users-19.0.0", Name: "mod-users", Version: "19.0.0"},
+		{ID: "mod-orders-13.0.0", Name: "mod-orders", Version: "13.0.0"},
+	}, modules)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestGetTenantEntitlements_WithoutModules(t *testing.T) {
+func TestGetApplicationModules_NotFound(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	tenantName := "test-tenant"
-	includeModules := false
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
 
-	expectedResponse := models.TenantEntitlementResponse{
-		TotalRecords: 1,
-		Entitlements: []models.TenantEntitlementDTO{
-			{
-				ApplicationID: "app-789",
-				TenantID:      "tenant-789",
-			},
-		},
-	}
+	// Act
+	modules, err := svc.GetApplicationModules("app-missing-1.0.0")
 
-	mockHTTP.On("GetReturnStruct",
+	// Assert
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.Nil(t, modules)
+}
+
+func TestGetApplication_Full(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(url string) bool {
-			return assert.Contains(t, url, "/entitlements") &&
-				assert.Contains(t, url, "tenant=test-tenant") &&
-				assert.Contains(t, url, "includeModules=false")
+			return strings.HasSuffix(url, "/applications/app-test-1.0.0?full=true")
 		}),
 		mock.Anything,
 		mock.Anything).
 		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantEntitlementResponse)
-			*target = expectedResponse
+			target := args.Get(2).(*map[string]any)
+			*target = map[string]any{"id": "app-test-1.0.0", "name": "app-test", "version": "1.0.0"}
 		}).
 		Return(nil)
 
 	// Act
-	result, err := svc.GetTenantEntitlements(tenantName, includeModules)
+	app, err := svc.GetApplication("app-test-1.0.0")
 
 	// Assert
-	assert.NoError(t, err)
-	assert.Equal(t, 1, result.TotalRecords)
-	assert.Len(t, result.Entitlements, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "app-test", app["name"])
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestGetTenantEntitlements_HeaderError(t *testing.T) {
+func TestGetApplication_NotFound(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
-	action.KeycloakMasterAccessToken = "" // Invalid token
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	action.KeycloakMasterAccessToken = "test-token"
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
 
 	// Act
-	result, err := svc.GetTenantEntitlements("test-tenant", true)
+	app, err := svc.GetApplication("app-missing-1.0.0")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, models.TenantEntitlementResponse{}, result)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.Nil(t, app)
 }
 
-func TestGetTenantEntitlements_HTTPError(t *testing.T) {
+func TestGetApplicationDescriptor_Success(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	expectedError := errors.New("network error")
-	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
-		Return(expectedError)
+	mockHTTP.On("GetRetryReturnStruct",
+		mock.MatchedBy(func(url string) bool {
+			return strings.HasSuffix(url, "/applications/app-test-1.0.0")
+		}),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.ApplicationDescriptor)
+			*target = models.ApplicationDescriptor{
+				ID:      "app-test-1.0.0",
+				Modules: []models.ApplicationModule{{ID: "mod-users-19.0.0", Name: "mod-users", Version: "19.0.0"}},
+			}
+		}).
+		Return(nil)
 
 	// Act
-	result, err := svc.GetTenantEntitlements("test-tenant", true)
+	app, err := svc.GetApplicationDescriptor("app-test-1.0.0")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
-	assert.Equal(t, models.TenantEntitlementResponse{}, result)
+	require.NoError(t, err)
+	assert.Equal(t, "app-test-1.0.0", app.ID)
+	assert.Len(t, app.Modules, 1)
 	mockHTTP.AssertExpectations(t)
 }
 
-func TestGetTenantEntitlements_EmptyResponse(t *testing.T) {
+func TestGetApplicationDescriptor_NotFound(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
 	action := testhelpers.NewMockAction()
 	action.KeycloakMasterAccessToken = "test-token"
-	mockTenantSvc := &MockTenantSvc{}
-	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
-
-	expectedResponse := models.TenantEntitlementResponse{
-		TotalRecords: 0,
-		Entitlements: []models.TenantEntitlementDTO{},
-	}
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
 
-	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).
-		Run(func(args mock.Arguments) {
-			target := args.Get(2).(*models.TenantEntitlementResponse)
-			*target = expectedResponse
-		}).
-		Return(nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Return(apperrors.ErrHTTP404NotFound)
 
 	// Act
-	result, err := svc.GetTenantEntitlements("test-tenant", false)
+	app, err := svc.GetApplicationDescriptor("app-missing-1.0.0")
 
 	// Assert
-	assert.NoError(t, err)
-	assert.Equal(t, 0, result.TotalRecords)
-	assert.Empty(t, result.Entitlements)
-	mockHTTP.AssertExpectations(t)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.Nil(t, app)
 }
 
 // ==================== GetLatestApplication Tests ====================
@@ -3629,7 +5535,7 @@ func TestUpgradeTenantEntitlement_Success(t *testing.T) {
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	mockTenantSvc.On("GetEntitlementTenantParameters", "consortium1").Return("param1=value1", nil)
+	mockTenantSvc.On("GetEntitlementTenantParameters", "consortium1", mock.Anything).Return("param1=value1", nil)
 
 	mockHTTP.On("GetRetryReturnStruct",
 		mock.MatchedBy(func(url string) bool { return strings.Contains(url, "/tenants") }),
@@ -3643,7 +5549,7 @@ func TestUpgradeTenantEntitlement_Success(t *testing.T) {
 
 	mockHTTP.On("PutReturnStruct",
 		mock.MatchedBy(func(url string) bool {
-			return strings.Contains(url, "/entitlements") && strings.Contains(url, "async=false") && strings.Contains(url, "tenantParameters=param1")
+			return strings.Contains(url, "/entitlements") && strings.Contains(url, "async=true") && strings.Contains(url, "tenantParameters=param1%3Dvalue1")
 		}),
 		mock.Anything,
 		mock.Anything,
@@ -3654,6 +5560,8 @@ func TestUpgradeTenantEntitlement_Success(t *testing.T) {
 		}).
 		Return(nil)
 
+	mockEntitlementFlowStatuses(mockHTTP, models.TenantEntitlementFlowFinished)
+
 	// Act
 	err := svc.UpgradeTenantEntitlement("consortium1", constant.Member, "new-app-id")
 
@@ -3670,8 +5578,16 @@ func TestUpgradeTenantEntitlement_TenantParametersError(t *testing.T) {
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{"test-tenant": map[string]any{}}
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			target.Tenants = []models.Tenant{{ID: "tenant-123", Name: "test-tenant"}}
+		}).
+		Return(nil)
 	expectedError := errors.New("failed to get parameters")
-	mockTenantSvc.On("GetEntitlementTenantParameters", "consortium1").Return("", expectedError)
+	mockTenantSvc.On("GetEntitlementTenantParameters", "consortium1", "test-tenant").Return("", expectedError)
 
 	// Act
 	err := svc.UpgradeTenantEntitlement("consortium1", constant.Member, "new-app-id")
@@ -3679,7 +5595,7 @@ func TestUpgradeTenantEntitlement_TenantParametersError(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
-	mockHTTP.AssertNotCalled(t, "GetRetryReturnStruct")
+	mockHTTP.AssertNotCalled(t, "PutReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	mockTenantSvc.AssertExpectations(t)
 }
 
@@ -3692,7 +5608,7 @@ func TestUpgradeTenantEntitlement_HTTPError(t *testing.T) {
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	mockTenantSvc.On("GetEntitlementTenantParameters", "consortium1").Return("params", nil)
+	mockTenantSvc.On("GetEntitlementTenantParameters", "consortium1", mock.Anything).Return("params", nil)
 
 	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
@@ -3879,16 +5795,56 @@ func TestRemoveApplications_Success(t *testing.T) {
 		Return(nil)
 
 	// Act
-	err := svc.RemoveApplications("test-app", "ignore-app")
+	removed, err := svc.RemoveApplications("test-app", "ignore-app")
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
 	mockHTTP.AssertExpectations(t)
 	mockHTTP.AssertNotCalled(t, "Delete", mock.MatchedBy(func(url string) bool {
 		return strings.Contains(url, "/applications/ignore-app")
 	}), mock.Anything, mock.Anything)
 }
 
+func TestRemoveApplications_FilterByIDs(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	svc := managementsvc.New(action, mockHTTP, &MockTenantSvc{})
+
+	mockHTTP.On("GetReturnStruct",
+		mock.MatchedBy(func(url string) bool { return strings.Contains(url, "/applications") }),
+		mock.Anything,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.ApplicationsResponse)
+			target.ApplicationDescriptors = []map[string]any{
+				{"id": "app-a-1.0.0", "name": "app-a"},
+				{"id": "app-b-1.0.0", "name": "app-b"},
+				{"id": "app-shared-1.0.0", "name": "app-shared"},
+			}
+		}).
+		Return(nil)
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(url string) bool { return strings.HasSuffix(url, "/applications/app-a-1.0.0") }),
+		mock.Anything).
+		Return(nil)
+	mockHTTP.On("Delete",
+		mock.MatchedBy(func(url string) bool { return strings.HasSuffix(url, "/applications/app-b-1.0.0") }),
+		mock.Anything).
+		Return(nil)
+
+	// Act
+	removed, err := svc.RemoveApplications("", "", "app-a-1.0.0", "app-b-1.0.0")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	mockHTTP.AssertExpectations(t)
+	mockHTTP.AssertNumberOfCalls(t, "Delete", 2)
+}
+
 func TestRemoveApplications_GetApplicationsError(t *testing.T) {
 	// Arrange
 	mockHTTP := &testhelpers.MockHTTPClient{}
@@ -3902,7 +5858,7 @@ func TestRemoveApplications_GetApplicationsError(t *testing.T) {
 		Return(expectedError)
 
 	// Act
-	err := svc.RemoveApplications("test-app", "ignore-app")
+	_, err := svc.RemoveApplications("test-app", "ignore-app")
 
 	// Assert
 	assert.Error(t, err)
@@ -3927,7 +5883,7 @@ func TestRemoveApplications_HeaderError(t *testing.T) {
 
 	// Act - set token to empty after GetApplications succeeds
 	action.KeycloakMasterAccessToken = ""
-	err := svc.RemoveApplications("test-app", "ignore-app")
+	_, err := svc.RemoveApplications("test-app", "ignore-app")
 
 	// Assert
 	assert.Error(t, err)
@@ -3955,7 +5911,7 @@ func TestRemoveApplications_DeleteError(t *testing.T) {
 		Return(expectedError)
 
 	// Act
-	err := svc.RemoveApplications("test-app", "ignore-app")
+	_, err := svc.RemoveApplications("test-app", "ignore-app")
 
 	// Assert
 	assert.Error(t, err)
@@ -4115,9 +6071,8 @@ func TestCreateApplication_AlreadyExists_Skipped(t *testing.T) {
 			FolioModules:  []*models.ProxyModule{},
 			EurekaModules: []*models.ProxyModule{},
 		},
-		BackendModules:    map[string]models.BackendModule{},
-		FrontendModules:   map[string]models.FrontendModule{},
-		ModuleDescriptors: map[string]any{},
+		BackendModules:  map[string]models.BackendModule{},
+		FrontendModules: map[string]models.FrontendModule{},
 	}
 
 	mockHTTP.On("GetRetryReturnStruct",
@@ -4152,7 +6107,7 @@ func TestCreateTenantEntitlement_AlreadyEntitled_Skipped(t *testing.T) {
 	mockTenantSvc := &MockTenantSvc{}
 	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
 
-	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium").
+	mockTenantSvc.On("GetEntitlementTenantParameters", "test-consortium", mock.Anything).
 		Return("param1=value1", nil)
 
 	responseBody := `{"tenants": [{"id": "tenant-123", "name": "test-tenant"}], "totalRecords": 1}`
@@ -4189,3 +6144,132 @@ func TestCreateTenantEntitlement_AlreadyEntitled_Skipped(t *testing.T) {
 	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	mockTenantSvc.AssertExpectations(t)
 }
+
+func TestEntitleTenants_CollectsResultsPerTenant(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{
+		"tenant-a": map[string]any{},
+		"tenant-b": map[string]any{},
+		"tenant-c": map[string]any{},
+	}
+	action.ConfigApplicationID = "app-1.0.0"
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	mockTenantSvc.On("GetEntitlementTenantParameters", constant.NoneConsortium, mock.Anything).Return("loadReference=true", nil)
+	responseBody := `{"tenants": [{"id": "id-c", "name": "tenant-c"}, {"id": "id-a", "name": "tenant-a"}, {"id": "id-b", "name": "tenant-b"}, {"id": "id-x", "name": "other"}], "totalRecords": 4}`
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal([]byte(responseBody), args.Get(2).(*models.TenantsResponse))
+		}).
+		Return(nil)
+	mockHTTP.On("GetReturnStruct", mock.MatchedBy(func(url string) bool {
+		return strings.Contains(url, "/entitlements?tenant=tenant-b")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantEntitlementResponse)
+			target.Entitlements = []models.TenantEntitlementDTO{{ApplicationID: "app-2.0.0", TenantID: "id-b"}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetReturnStruct", mock.MatchedBy(func(url string) bool {
+		return strings.HasSuffix(url, "/entitlement-flows/flow-a")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(*models.TenantEntitlementFlow).Status = models.TenantEntitlementFlowFinished
+		}).
+		Return(nil)
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct", mock.Anything, mock.MatchedBy(func(payload []byte) bool {
+		return strings.Contains(string(payload), `"tenantId":"id-a"`) && strings.Contains(string(payload), `"applications":["app-2.0.0"]`)
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(3).(*models.TenantEntitlementResponse).FlowID = "flow-a"
+		}).
+		Return(nil)
+	mockHTTP.On("PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError)
+
+	// Act
+	results, err := svc.EntitleTenants(constant.NoneConsortium, constant.Default, "app-2.0.0", 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TenantEntitlementResult{
+		{Tenant: "tenant-a", ApplicationID: "app-2.0.0", Status: models.TenantEntitlementEntitled, FlowID: "flow-a"},
+		{Tenant: "tenant-b", ApplicationID: "app-2.0.0", Status: models.TenantEntitlementSkipped},
+		{Tenant: "tenant-c", ApplicationID: "app-2.0.0", Status: models.TenantEntitlementFailed, Error: assert.AnError.Error()},
+	}, results)
+	mockHTTP.AssertNumberOfCalls(t, "PostReturnStruct", 2)
+}
+
+func TestEntitleTenants_ConfiguredApplicationWithAdditionalEntitlements(t *testing.T) {
+	// Arrange
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	action := testhelpers.NewMockAction()
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{"tenant-a": map[string]any{}}
+	action.ConfigApplicationID = "app-1.0.0"
+	action.ConfigApplicationEntitlements = []string{"app-edge-1.0.0"}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	mockTenantSvc.On("GetEntitlementTenantParameters", constant.NoneConsortium, mock.Anything).Return("", nil)
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(*models.TenantsResponse).Tenants = []models.Tenant{{ID: "id-a", Name: "tenant-a"}}
+		}).
+		Return(nil)
+	mockHTTP.On("GetReturnStruct", mock.MatchedBy(func(url string) bool {
+		return strings.HasSuffix(url, "/entitlement-flows/flow-a")
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(*models.TenantEntitlementFlow).Status = models.TenantEntitlementFlowFinished
+		}).
+		Return(nil)
+	mockHTTP.On("GetReturnStruct", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockHTTP.On("PostReturnStruct", mock.Anything, mock.MatchedBy(func(payload []byte) bool {
+		return strings.Contains(string(payload), `"applications":["app-1.0.0","app-edge-1.0.0"]`)
+	}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(3).(*models.TenantEntitlementResponse).FlowID = "flow-a"
+		}).
+		Return(nil)
+
+	// Act
+	results, err := svc.EntitleTenants(constant.NoneConsortium, constant.Default, "app-1.0.0", 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TenantEntitlementResult{
+		{Tenant: "tenant-a", ApplicationID: "app-1.0.0", Status: models.TenantEntitlementEntitled, FlowID: "flow-a"},
+	}, results)
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestEntitleTenants_GetParametersError(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	mockHTTP := &testhelpers.MockHTTPClient{}
+	mockTenantSvc := &MockTenantSvc{}
+	svc := managementsvc.New(action, mockHTTP, mockTenantSvc)
+
+	action.KeycloakMasterAccessToken = "test-token"
+	action.ConfigTenants = map[string]any{"test-tenant": map[string]any{}}
+	mockHTTP.On("GetRetryReturnStruct", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*models.TenantsResponse)
+			target.Tenants = []models.Tenant{{ID: "tenant-123", Name: "test-tenant"}}
+		}).
+		Return(nil)
+	mockTenantSvc.On("GetEntitlementTenantParameters", "consortium", "test-tenant").Return("", assert.AnError)
+
+	// Act
+	results, err := svc.EntitleTenants("consortium", constant.Central, "app-2.0.0", 1)
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, results)
+	mockHTTP.AssertNotCalled(t, "PostReturnStruct", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
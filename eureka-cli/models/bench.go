@@ -0,0 +1,26 @@
+package models
+
+// ==================== Gateway Benchmark ====================
+
+// BenchSummary represents the outcome of a gateway benchmark, status codes are keyed by the
+// response status, successful responses are counted as 2xx and transport failures as error
+type BenchSummary struct {
+	Endpoint    string         `json:"endpoint"`
+	Tenant      string         `json:"tenant"`
+	Concurrency int            `json:"concurrency"`
+	DurationMs  int64          `json:"durationMs"`
+	Requests    int            `json:"requests"`
+	Errors      int            `json:"errors"`
+	ErrorRate   float64        `json:"errorRate"`
+	Throughput  float64        `json:"throughput"`
+	Latency     BenchLatency   `json:"latencyMs"`
+	StatusCodes map[string]int `json:"statusCodes,omitempty"`
+}
+
+// BenchLatency represents the latency percentiles of a gateway benchmark in milliseconds
+type BenchLatency struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "slices"
+
+// ==================== Deploy State ====================
+
+// DeployState records the deploy phases that have completed successfully
+type DeployState struct {
+	CompletedPhases []string `json:"completedPhases"`
+}
+
+// IsCompleted reports whether a phase has already completed
+func (s *DeployState) IsCompleted(phase string) bool {
+	return slices.Contains(s.CompletedPhases, phase)
+}
+
+// MarkCompleted records a phase as completed, marking it more than once has no effect
+func (s *DeployState) MarkCompleted(phase string) {
+	if s.IsCompleted(phase) {
+		return
+	}
+	s.CompletedPhases = append(s.CompletedPhases, phase)
+}
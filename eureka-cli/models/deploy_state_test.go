@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== DeployState Tests ====================
+
+func TestDeployState_MarkCompleted_Idempotent(t *testing.T) {
+	// Arrange
+	state := DeployState{}
+
+	// Act
+	state.MarkCompleted("system")
+	state.MarkCompleted("system")
+	state.MarkCompleted("tenants")
+
+	// Assert
+	assert.Equal(t, []string{"system", "tenants"}, state.CompletedPhases)
+	assert.True(t, state.IsCompleted("system"))
+	assert.True(t, state.IsCompleted("tenants"))
+	assert.False(t, state.IsCompleted("modules"))
+}
@@ -0,0 +1,17 @@
+package models
+
+// ==================== Kafka ====================
+
+// ConsumerGroupPartition represents a row of the kafka-consumer-groups.sh describe output,
+// offsets and lag are nil when the broker reports them as "-"
+type ConsumerGroupPartition struct {
+	Group         string `json:"group"`
+	Topic         string `json:"topic"`
+	Partition     int    `json:"partition"`
+	CurrentOffset *int64 `json:"currentOffset"`
+	LogEndOffset  *int64 `json:"logEndOffset"`
+	Lag           *int64 `json:"lag"`
+	ConsumerID    string `json:"consumerId,omitempty"`
+	Host          string `json:"host,omitempty"`
+	ClientID      string `json:"clientId,omitempty"`
+}
@@ -42,6 +42,18 @@ type KeycloakUserRoleRequest struct {
 	RoleIDs []string `json:"roleIds"`
 }
 
+// KeycloakUserRolesResponse represents the response containing the roles assigned to a user
+type KeycloakUserRolesResponse struct {
+	UserRoles    []KeycloakUserRole `json:"userRoles"`
+	TotalRecords int                `json:"totalRecords,omitempty"`
+}
+
+// KeycloakUserRole represents a single role assignment of a user
+type KeycloakUserRole struct {
+	UserID string `json:"userId"`
+	RoleID string `json:"roleId"`
+}
+
 // KeycloakUserCreateResponse represents the response when creating a new Keycloak user
 type KeycloakUserCreateResponse struct {
 	ID string `json:"id"`
@@ -107,6 +119,111 @@ type KeycloakCapabilitySet struct {
 	Action        string `json:"action,omitempty"`
 }
 
+// ==================== Capability Management ====================
+
+// KeycloakCapabilitiesResponse represents the response containing a list of capabilities
+type KeycloakCapabilitiesResponse struct {
+	Capabilities []KeycloakCapability `json:"capabilities"`
+	TotalCount   int                  `json:"totalRecords,omitempty"`
+}
+
+// KeycloakCapability represents a capability entity
+type KeycloakCapability struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	ApplicationID string `json:"applicationId,omitempty"`
+	Resource      string `json:"resource,omitempty"`
+	Action        string `json:"action,omitempty"`
+	Permission    string `json:"permission,omitempty"`
+	Type          string `json:"type,omitempty"`
+}
+
+// KeycloakRoleDetail represents a role together with its attached capability sets and capabilities
+type KeycloakRoleDetail struct {
+	Role           KeycloakRole            `json:"role"`
+	CapabilitySets []KeycloakCapabilitySet `json:"capabilitySets"`
+	Capabilities   []KeycloakCapability    `json:"capabilities"`
+}
+
+// KeycloakRealmReport represents a summary of a Keycloak realm state,
+// login counts are nil when the realm does not store events
+type KeycloakRealmReport struct {
+	Realm       string `json:"realm"`
+	Users       int    `json:"users"`
+	Clients     int    `json:"clients"`
+	Roles       int    `json:"roles"`
+	LoginEvents *int   `json:"loginEvents"`
+	LoginErrors *int   `json:"loginErrors"`
+}
+
+// KeycloakRoleMappings represents roles with their attached capability sets in the roles config shape
+type KeycloakRoleMappings struct {
+	Roles map[string]KeycloakRoleMapping `json:"roles" yaml:"roles"`
+}
+
+// KeycloakRoleMapping represents a single role entry of the roles config section
+type KeycloakRoleMapping struct {
+	Tenant         string   `json:"tenant" yaml:"tenant"`
+	CapabilitySets []string `json:"capability-sets" yaml:"capability-sets"`
+}
+
+// RoleCapabilitySetsAttachResult represents the outcome of attaching capability sets to a single role
+type RoleCapabilitySetsAttachResult struct {
+	RoleName   string
+	Tenant     string
+	Attached   int
+	Unresolved []string
+}
+
+// TenantCapabilitySetsAttachResult represents the outcome of attaching capability sets to the roles of a tenant,
+// unresolved capability set names are keyed by role name
+type TenantCapabilitySetsAttachResult struct {
+	Tenant         string              `json:"tenant"`
+	Roles          int                 `json:"roles"`
+	Attached       int                 `json:"attached"`
+	CapabilitySets int                 `json:"capabilitySets"`
+	Unresolved     map[string][]string `json:"unresolved,omitempty"`
+}
+
+// CapabilitySetsAttachReport aggregates the attach results of every processed tenant
+type CapabilitySetsAttachReport struct {
+	Tenants        int                                `json:"tenants"`
+	Roles          int                                `json:"roles"`
+	Attached       int                                `json:"attached"`
+	CapabilitySets int                                `json:"capabilitySets"`
+	Unresolved     int                                `json:"unresolved"`
+	Results        []TenantCapabilitySetsAttachResult `json:"results"`
+}
+
+// AddTenant adds the result of a tenant to the report totals
+func (r *CapabilitySetsAttachReport) AddTenant(result TenantCapabilitySetsAttachResult) {
+	r.Tenants++
+	r.Roles += result.Roles
+	r.Attached += result.Attached
+	r.CapabilitySets += result.CapabilitySets
+	for _, names := range result.Unresolved {
+		r.Unresolved += len(names)
+	}
+	r.Results = append(r.Results, result)
+}
+
+// UserLoginResult represents the outcome of logging in a configured user, the password is never recorded
+type UserLoginResult struct {
+	Username string `json:"username"`
+	Tenant   string `json:"tenant"`
+	Success  bool   `json:"success"`
+}
+
+// UnusedCapabilitySetsReport represents the capability sets of a tenant that are not attached to any role
+type UnusedCapabilitySetsReport struct {
+	Tenant         string                  `json:"tenant"`
+	Total          int                     `json:"total"`
+	Attached       int                     `json:"attached"`
+	Roles          int                     `json:"roles"`
+	CapabilitySets []KeycloakCapabilitySet `json:"capabilitySets"`
+}
+
 // ==================== Client Configuration ====================
 
 // KeycloakClientUpdateRequest represents the payload for updating a Keycloak client configuration
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== KeycloakCapabilitySetsResponse Tests ====================
+
+func TestKeycloakCapabilitySetsResponse_Decode(t *testing.T) {
+	// Arrange
+	rawResponse := []byte(`{
+	  "capabilitySets": [
+	    {
+	      "id": "0a1a7a3e-8f4b-4d6c-9a43-0c1e1a3b7d21",
+	      "name": "ui-users_view",
+	      "description": "Users: Can view user profile",
+	      "resource": "UI-Users",
+	      "action": "view",
+	      "applicationId": "app-platform-minimal-2.0.19",
+	      "moduleId": "folio_users-11.0.0",
+	      "type": "procedural",
+	      "permission": "ui-users.view",
+	      "capabilities": ["2f3e8b1c-6f5d-4c6e-8a2b-1d9c7e5f4a30"],
+	      "metadata": {
+	        "createdDate": "2025-01-14T10:00:00.000+00:00",
+	        "modifiedDate": "2025-01-14T10:00:00.000+00:00"
+	      }
+	    },
+	    {
+	      "id": "5c9d3e2f-1a7b-4b8e-b6c4-7d2e9f0a1b32",
+	      "name": "users_item_view",
+	      "description": "Users: Item view",
+	      "resource": "Users Item",
+	      "action": "view",
+	      "applicationId": "app-platform-minimal-2.0.19",
+	      "moduleId": "mod-users-19.4.0",
+	      "type": "data",
+	      "permission": "users.item.get",
+	      "capabilities": []
+	    }
+	  ],
+	  "totalRecords": 2
+	}`)
+
+	// Act
+	var response KeycloakCapabilitySetsResponse
+	err := json.Unmarshal(rawResponse, &response)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.TotalCount)
+	assert.Len(t, response.CapabilitySets, 2)
+	assert.Equal(t, KeycloakCapabilitySet{
+		ID:            "0a1a7a3e-8f4b-4d6c-9a43-0c1e1a3b7d21",
+		Name:          "ui-users_view",
+		Description:   "Users: Can view user profile",
+		ApplicationID: "app-platform-minimal-2.0.19",
+		Resource:      "UI-Users",
+		Action:        "view",
+	}, response.CapabilitySets[0])
+	assert.Equal(t, "users_item_view", response.CapabilitySets[1].Name)
+}
+
+func TestKeycloakCapabilitySetsResponse_DecodeEmpty(t *testing.T) {
+	// Arrange
+	rawResponse := []byte(`{"capabilitySets": [], "totalRecords": 0}`)
+
+	// Act
+	var response KeycloakCapabilitySetsResponse
+	err := json.Unmarshal(rawResponse, &response)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, response.CapabilitySets)
+	assert.Equal(t, 0, response.TotalCount)
+}
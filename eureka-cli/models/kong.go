@@ -19,3 +19,18 @@ type KongRoutesResponse struct {
 	Data []KongRoute `json:"data"`
 	Next string      `json:"next,omitempty"`
 }
+
+// KongService represents a Kong service, the gateway registers a service named by the module id for every module
+type KongService struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	Host string   `json:"host"`
+	Port int      `json:"port"`
+	Tags []string `json:"tags"`
+}
+
+// KongServicesResponse represents the response containing a list of Kong services
+type KongServicesResponse struct {
+	Data []KongService `json:"data"`
+	Next string        `json:"next,omitempty"`
+}
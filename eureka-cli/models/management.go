@@ -1,5 +1,13 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
+)
+
 // ==================== Tenant Management ====================
 
 // TenantCreateRequest represents the payload for creating a new tenant
@@ -20,6 +28,16 @@ type Tenant struct {
 	Description string `json:"description"`
 }
 
+// TenantSummary represents a tenant with its number of entitled applications and users,
+// counts are nil when they could not be read
+type TenantSummary struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Applications *int   `json:"applications"`
+	Users        *int   `json:"users,omitempty"`
+}
+
 // ==================== Tenant Entitlement Management ====================
 
 // TenantEntitlementRequest represents the payload for creating or removing tenant entitlements
@@ -41,6 +59,58 @@ type TenantEntitlementDTO struct {
 	TenantID      string `json:"tenantId"`
 }
 
+// TenantEntitlementFlowStatus is the execution status of an entitlement flow
+type TenantEntitlementFlowStatus string
+
+const (
+	TenantEntitlementFlowQueued             TenantEntitlementFlowStatus = "queued"
+	TenantEntitlementFlowInProgress         TenantEntitlementFlowStatus = "in_progress"
+	TenantEntitlementFlowFinished           TenantEntitlementFlowStatus = "finished"
+	TenantEntitlementFlowFailed             TenantEntitlementFlowStatus = "failed"
+	TenantEntitlementFlowCancelled          TenantEntitlementFlowStatus = "cancelled"
+	TenantEntitlementFlowCancellationFailed TenantEntitlementFlowStatus = "cancellation_failed"
+)
+
+// TenantEntitlementFlow represents the state of an entitlement flow
+type TenantEntitlementFlow struct {
+	ID     string                      `json:"id"`
+	Status TenantEntitlementFlowStatus `json:"status"`
+}
+
+// TenantEntitlementStatus is the outcome of entitling a tenant to an application
+type TenantEntitlementStatus string
+
+const (
+	TenantEntitlementEntitled TenantEntitlementStatus = "entitled"
+	TenantEntitlementSkipped  TenantEntitlementStatus = "skipped"
+	TenantEntitlementFailed   TenantEntitlementStatus = "failed"
+)
+
+// TenantEntitlementResult represents the outcome of entitling a single tenant to an application
+type TenantEntitlementResult struct {
+	Tenant        string                  `json:"tenant"`
+	ApplicationID string                  `json:"applicationId"`
+	Status        TenantEntitlementStatus `json:"status"`
+	FlowID        string                  `json:"flowId,omitempty"`
+	Error         string                  `json:"error,omitempty"`
+}
+
+// TenantRemovalStatus is the outcome of removing a tenant
+type TenantRemovalStatus string
+
+const (
+	TenantRemovalRemoved TenantRemovalStatus = "removed"
+	TenantRemovalFailed  TenantRemovalStatus = "failed"
+)
+
+// TenantRemovalResult represents the outcome of removing a single tenant, status code is set for failed HTTP requests
+type TenantRemovalResult struct {
+	Tenant     string              `json:"tenant"`
+	Status     TenantRemovalStatus `json:"status"`
+	StatusCode int                 `json:"statusCode,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
 // ==================== Application Management ====================
 
 // ApplicationCreateRequest represents the payload for creating a new application with modules and descriptors
@@ -67,12 +137,30 @@ type ApplicationModule struct {
 
 // ApplicationDescriptor represents an application descriptor response from the API
 type ApplicationDescriptor struct {
-	ID          string         `json:"id"`
-	Name        string         `json:"name"`
-	Version     string         `json:"version"`
-	Description string         `json:"description,omitempty"`
-	Platform    string         `json:"platform,omitempty"`
-	Metadata    map[string]any `json:"metadata,omitempty"`
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Version     string              `json:"version"`
+	Description string              `json:"description,omitempty"`
+	Platform    string              `json:"platform,omitempty"`
+	Metadata    map[string]any      `json:"metadata,omitempty"`
+	Modules     []ApplicationModule `json:"modules,omitempty"`
+	UIModules   []ApplicationModule `json:"uiModules,omitempty"`
+}
+
+// ApplicationDiff represents the module differences between two application descriptors
+type ApplicationDiff struct {
+	From    string                    `json:"from"`
+	To      string                    `json:"to"`
+	Added   []ApplicationModule       `json:"added"`
+	Removed []ApplicationModule       `json:"removed"`
+	Changed []ApplicationModuleChange `json:"changed"`
+}
+
+// ApplicationModuleChange represents a module present in both applications with different versions
+type ApplicationModuleChange struct {
+	Name        string `json:"name"`
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
 }
 
 // ApplicationsResponse represents the response containing a list of application descriptors
@@ -94,6 +182,144 @@ type ApplicationUpgradeRequest struct {
 	ShouldBuild                  bool
 }
 
+// ApplicationDependencyGraph represents an application with its application dependencies
+// and the interfaces each of its modules requires from the other modules
+type ApplicationDependencyGraph struct {
+	ApplicationID string                  `json:"applicationId"`
+	Dependencies  []ApplicationDependency `json:"dependencies"`
+	Modules       []ModuleDependencies    `json:"modules"`
+}
+
+// ApplicationDependency represents a dependency of an application on another application
+type ApplicationDependency struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ModuleDependencies represents the required, optional and provided interfaces of a single module
+type ModuleDependencies struct {
+	ModuleID string                `json:"moduleId"`
+	Requires []InterfaceDependency `json:"requires"`
+	Provides []ProvidedInterface   `json:"provides,omitempty"`
+}
+
+// ProvidedInterface represents an interface with its version provided by a module
+type ProvidedInterface struct {
+	InterfaceID string `json:"interfaceId"`
+	Version     string `json:"version"`
+}
+
+// InterfaceDependency represents a required interface and the modules of the application providing it,
+// an empty ProviderIDs means that the interface is not provided within the application
+type InterfaceDependency struct {
+	InterfaceID string   `json:"interfaceId"`
+	Version     string   `json:"version"`
+	Optional    bool     `json:"optional"`
+	ProviderIDs []string `json:"providerIds"`
+}
+
+// InterfaceIncompatibility represents a required or optional interface of a module that no module of the application
+// provides in a compatible version, an empty Provided means that the interface is not provided within the application
+type InterfaceIncompatibility struct {
+	ModuleID    string   `json:"moduleId"`
+	InterfaceID string   `json:"interfaceId"`
+	Required    string   `json:"required"`
+	Optional    bool     `json:"optional"`
+	Provided    []string `json:"provided"`
+}
+
+// Incompatibilities checks the interface versions the modules require against the versions provided within the application
+// in the same way as the gateway does on registration, a missing optional interface is not an incompatibility
+func (g *ApplicationDependencyGraph) Incompatibilities() []InterfaceIncompatibility {
+	providers := make(map[string][]string)
+	for _, module := range g.Modules {
+		for _, provided := range module.Provides {
+			providers[provided.InterfaceID] = append(providers[provided.InterfaceID], fmt.Sprintf("%s (%s)", module.ModuleID, provided.Version))
+		}
+	}
+
+	var incompatibilities []InterfaceIncompatibility
+	for _, module := range g.Modules {
+		for _, required := range module.Requires {
+			provided := providers[required.InterfaceID]
+			if len(provided) == 0 && required.Optional {
+				continue
+			}
+			if g.isProvidedInCompatibleVersion(required) {
+				continue
+			}
+			incompatibilities = append(incompatibilities, InterfaceIncompatibility{
+				ModuleID:    module.ModuleID,
+				InterfaceID: required.InterfaceID,
+				Required:    required.Version,
+				Optional:    required.Optional,
+				Provided:    provided,
+			})
+		}
+	}
+
+	return incompatibilities
+}
+
+func (g *ApplicationDependencyGraph) isProvidedInCompatibleVersion(required InterfaceDependency) bool {
+	for _, module := range g.Modules {
+		for _, provided := range module.Provides {
+			if provided.InterfaceID == required.InterfaceID && helpers.IsInterfaceVersionCompatible(required.Version, provided.Version) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// DeploymentLayers sorts the modules topologically by their required interfaces, each layer only
+// depends on the modules of the preceding layers, optional interfaces do not constrain the order.
+// Modules that can never be placed because they take part in or depend on a dependency cycle are returned separately
+func (g *ApplicationDependencyGraph) DeploymentLayers() (layers [][]string, cyclic []string) {
+	pending := make(map[string][]string, len(g.Modules))
+	for _, module := range g.Modules {
+		var providerIDs []string
+		for _, required := range module.Requires {
+			if required.Optional {
+				continue
+			}
+			for _, providerID := range required.ProviderIDs {
+				if providerID != module.ModuleID && !slices.Contains(providerIDs, providerID) {
+					providerIDs = append(providerIDs, providerID)
+				}
+			}
+		}
+		pending[module.ModuleID] = providerIDs
+	}
+
+	for len(pending) > 0 {
+		var layer []string
+		for moduleID, providerIDs := range pending {
+			if !slices.ContainsFunc(providerIDs, func(providerID string) bool {
+				_, isPending := pending[providerID]
+				return isPending
+			}) {
+				layer = append(layer, moduleID)
+			}
+		}
+		if len(layer) == 0 {
+			break
+		}
+		slices.Sort(layer)
+		for _, moduleID := range layer {
+			delete(pending, moduleID)
+		}
+		layers = append(layers, layer)
+	}
+	for moduleID := range pending {
+		cyclic = append(cyclic, moduleID)
+	}
+	slices.Sort(cyclic)
+
+	return layers, cyclic
+}
+
 // ModuleDiscoveryRequest represents the payload for registering module discovery information
 type ModuleDiscoveryRequest struct {
 	Discovery []ModuleDiscovery `json:"discovery"`
@@ -105,6 +331,12 @@ type ModuleDiscoveryResponse struct {
 	TotalRecords int               `json:"totalRecords"`
 }
 
+// RawModuleDiscoveryResponse represents the response containing a list of module discovery entries kept as they are returned
+type RawModuleDiscoveryResponse struct {
+	Discovery    []json.RawMessage `json:"discovery"`
+	TotalRecords int               `json:"totalRecords"`
+}
+
 // ModuleDiscovery represents discovery information for a deployed module
 type ModuleDiscovery struct {
 	ID       string `json:"id"`
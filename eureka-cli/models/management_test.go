@@ -0,0 +1,122 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== Incompatibilities Tests ====================
+
+func TestIncompatibilities_ReportsVersionMismatchAndMissing(t *testing.T) {
+	// Arrange
+	graph := &ApplicationDependencyGraph{
+		ApplicationID: "app-test-1.0.0",
+		Modules: []ModuleDependencies{
+			{ModuleID: "mod-users-19.0.0", Provides: []ProvidedInterface{{InterfaceID: "users", Version: "16.1"}}},
+			{ModuleID: "mod-notes-6.0.0", Provides: []ProvidedInterface{{InterfaceID: "notes", Version: "4.0"}}},
+			{ModuleID: "mod-orders-13.0.0", Requires: []InterfaceDependency{
+				{InterfaceID: "users", Version: "17.0"},
+				{InterfaceID: "notes", Version: "3.0 4.0"},
+				{InterfaceID: "finance", Version: "2.0"},
+				{InterfaceID: "audit", Version: "1.0", Optional: true},
+				{InterfaceID: "notes", Version: "5.0", Optional: true},
+			}},
+		},
+	}
+
+	// Act
+	incompatibilities := graph.Incompatibilities()
+
+	// Assert
+	assert.Equal(t, []InterfaceIncompatibility{
+		{ModuleID: "mod-orders-13.0.0", InterfaceID: "users", Required: "17.0", Provided: []string{"mod-users-19.0.0 (16.1)"}},
+		{ModuleID: "mod-orders-13.0.0", InterfaceID: "finance", Required: "2.0"},
+		{ModuleID: "mod-orders-13.0.0", InterfaceID: "notes", Required: "5.0", Optional: true, Provided: []string{"mod-notes-6.0.0 (4.0)"}},
+	}, incompatibilities)
+}
+
+func TestIncompatibilities_AnyCompatibleProviderSatisfies(t *testing.T) {
+	// Arrange
+	graph := &ApplicationDependencyGraph{
+		Modules: []ModuleDependencies{
+			{ModuleID: "mod-a-1.0.0", Provides: []ProvidedInterface{{InterfaceID: "timer", Version: "1.0"}}},
+			{ModuleID: "mod-b-1.0.0", Provides: []ProvidedInterface{{InterfaceID: "timer", Version: "1.2"}}},
+			{ModuleID: "mod-c-1.0.0", Requires: []InterfaceDependency{{InterfaceID: "timer", Version: "1.1"}}},
+		},
+	}
+
+	// Act
+	incompatibilities := graph.Incompatibilities()
+
+	// Assert
+	assert.Empty(t, incompatibilities)
+}
+
+// ==================== DeploymentLayers Tests ====================
+
+func TestDeploymentLayers_OrdersByRequiredInterfaces(t *testing.T) {
+	// Arrange
+	graph := ApplicationDependencyGraph{
+		Modules: []ModuleDependencies{
+			{ModuleID: "mod-orders-13.0.0", Requires: []InterfaceDependency{
+				{InterfaceID: "users", ProviderIDs: []string{"mod-users-19.0.0"}},
+				{InterfaceID: "finance", ProviderIDs: []string{"mod-finance-5.0.0"}},
+			}},
+			{ModuleID: "mod-finance-5.0.0", Requires: []InterfaceDependency{
+				{InterfaceID: "users", ProviderIDs: []string{"mod-users-19.0.0"}},
+			}},
+			{ModuleID: "mod-users-19.0.0", Requires: []InterfaceDependency{
+				{InterfaceID: "permissions", ProviderIDs: []string{"mod-users-19.0.0"}},
+				{InterfaceID: "login"},
+			}},
+			{ModuleID: "mod-notes-6.0.0"},
+		},
+	}
+
+	// Act
+	layers, cyclic := graph.DeploymentLayers()
+
+	// Assert
+	assert.Equal(t, [][]string{
+		{"mod-notes-6.0.0", "mod-users-19.0.0"},
+		{"mod-finance-5.0.0"},
+		{"mod-orders-13.0.0"},
+	}, layers)
+	assert.Empty(t, cyclic)
+}
+
+func TestDeploymentLayers_IgnoresOptionalInterfaces(t *testing.T) {
+	// Arrange
+	graph := ApplicationDependencyGraph{
+		Modules: []ModuleDependencies{
+			{ModuleID: "mod-a-1.0.0", Requires: []InterfaceDependency{{InterfaceID: "b", Optional: true, ProviderIDs: []string{"mod-b-1.0.0"}}}},
+			{ModuleID: "mod-b-1.0.0", Requires: []InterfaceDependency{{InterfaceID: "a", ProviderIDs: []string{"mod-a-1.0.0"}}}},
+		},
+	}
+
+	// Act
+	layers, cyclic := graph.DeploymentLayers()
+
+	// Assert
+	assert.Equal(t, [][]string{{"mod-a-1.0.0"}, {"mod-b-1.0.0"}}, layers)
+	assert.Empty(t, cyclic)
+}
+
+func TestDeploymentLayers_ReturnsCyclicModules(t *testing.T) {
+	// Arrange
+	graph := ApplicationDependencyGraph{
+		Modules: []ModuleDependencies{
+			{ModuleID: "mod-a-1.0.0", Requires: []InterfaceDependency{{InterfaceID: "b", ProviderIDs: []string{"mod-b-1.0.0"}}}},
+			{ModuleID: "mod-b-1.0.0", Requires: []InterfaceDependency{{InterfaceID: "a", ProviderIDs: []string{"mod-a-1.0.0"}}}},
+			{ModuleID: "mod-c-1.0.0", Requires: []InterfaceDependency{{InterfaceID: "a", ProviderIDs: []string{"mod-a-1.0.0"}}}},
+		},
+	}
+
+	// Act
+	layers, cyclic := graph.DeploymentLayers()
+
+	// Assert
+	assert.Empty(t, layers)
+	assert.Equal(t, []string{"mod-a-1.0.0", "mod-b-1.0.0", "mod-c-1.0.0"}, cyclic)
+}
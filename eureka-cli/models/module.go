@@ -1,6 +1,8 @@
 package models
 
 import (
+	"sync"
+
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
@@ -44,6 +46,7 @@ type BackendModule struct {
 	UseOkapiURL              bool
 	DisableSystemUser        bool
 	LocalDescriptorPath      string
+	DescriptorFile           string
 	ModuleName               string
 	ModuleVersion            *string
 	ModuleExposedServerPort  int
@@ -60,6 +63,17 @@ type BackendModule struct {
 	SidecarExposedPorts      *nat.PortSet
 	SidecarPortBindings      *nat.PortMap
 	PrivatePort              int
+	HealthPort               int
+}
+
+// GetHealthPort returns the host port of the module healthcheck, the configured health-port
+// (published on the same port number inside the container) or otherwise the exposed server port
+func (bm BackendModule) GetHealthPort() int {
+	if bm.HealthPort != 0 {
+		return bm.HealthPort
+	}
+
+	return bm.ModuleExposedServerPort
 }
 
 // BackendModuleProperties contains the properties needed to construct a BackendModule
@@ -70,16 +84,26 @@ type BackendModuleProperties struct {
 	UseOkapiURL         bool
 	DisableSystemUser   bool
 	LocalDescriptorPath string
+	DescriptorFile      string
 	Name                string
 	Version             *string
 	Port                *int
 	PrivatePort         *int
+	HealthPort          *int
 	Env                 map[string]any
 	SidecarEnv          map[string]any
 	Resources           map[string]any
 	Volumes             []string
 }
 
+func (p BackendModuleProperties) getHealthPort() int {
+	if p.HealthPort == nil {
+		return 0
+	}
+
+	return *p.HealthPort
+}
+
 // SidecarRequest contains all the information needed to deploy a sidecar container
 type SidecarRequest struct {
 	Client           *client.Client
@@ -92,7 +116,10 @@ type SidecarRequest struct {
 
 // NewBackendModuleWithSidecar creates a new BackendModule instance with sidecar configuration
 func NewBackendModuleWithSidecar(action *action.Action, p BackendModuleProperties) (*BackendModule, error) {
-	exposedPorts := helpers.CreateExposedPorts(*p.PrivatePort)
+	moduleResources, err := helpers.CreateResources(true, p.Resources)
+	if err != nil {
+		return nil, err
+	}
 	moduleServerPort := *p.Port
 
 	var moduleDebugPort, sidecarServerPort, sidecarDebugPort = 0, 0, 0
@@ -113,27 +140,33 @@ func NewBackendModuleWithSidecar(action *action.Action, p BackendModulePropertie
 		UseOkapiURL:              p.UseOkapiURL,
 		DisableSystemUser:        p.DisableSystemUser,
 		LocalDescriptorPath:      p.LocalDescriptorPath,
+		DescriptorFile:           p.DescriptorFile,
 		ModuleName:               p.Name,
 		ModuleVersion:            p.Version,
 		ModuleExposedServerPort:  moduleServerPort,
 		ModuleExposedDebugPort:   moduleDebugPort,
 		PrivatePort:              *p.PrivatePort,
-		ModuleExposedPorts:       exposedPorts,
-		ModulePortBindings:       helpers.CreatePortBindings(moduleServerPort, moduleDebugPort, *p.PrivatePort),
+		HealthPort:               p.getHealthPort(),
+		ModuleExposedPorts:       helpers.CreateExposedPorts(*p.PrivatePort, p.getHealthPort()),
+		ModulePortBindings:       helpers.CreatePortBindings(moduleServerPort, moduleDebugPort, *p.PrivatePort, p.getHealthPort()),
 		ModuleEnv:                p.Env,
 		SidecarEnv:               p.SidecarEnv,
-		ModuleResources:          *helpers.CreateResources(true, p.Resources),
+		ModuleResources:          *moduleResources,
 		ModuleVolumes:            p.Volumes,
 		DeploySidecar:            *p.DeploySidecar,
 		SidecarExposedServerPort: sidecarServerPort,
 		SidecarExposedDebugPort:  sidecarDebugPort,
-		SidecarExposedPorts:      exposedPorts,
-		SidecarPortBindings:      helpers.CreatePortBindings(sidecarServerPort, sidecarDebugPort, *p.PrivatePort),
+		SidecarExposedPorts:      helpers.CreateExposedPorts(*p.PrivatePort, 0),
+		SidecarPortBindings:      helpers.CreatePortBindings(sidecarServerPort, sidecarDebugPort, *p.PrivatePort, 0),
 	}, nil
 }
 
 // NewBackendModule creates a new BackendModule instance without sidecar configuration
 func NewBackendModule(action *action.Action, p BackendModuleProperties) (*BackendModule, error) {
+	moduleResources, err := helpers.CreateResources(true, p.Resources)
+	if err != nil {
+		return nil, err
+	}
 	serverPort := *p.Port
 	debugPort, err := action.GetPreReservedPort()
 	if err != nil {
@@ -146,16 +179,18 @@ func NewBackendModule(action *action.Action, p BackendModuleProperties) (*Backen
 		UseOkapiURL:             p.UseOkapiURL,
 		DisableSystemUser:       p.DisableSystemUser,
 		LocalDescriptorPath:     p.LocalDescriptorPath,
+		DescriptorFile:          p.DescriptorFile,
 		ModuleName:              p.Name,
 		ModuleVersion:           p.Version,
 		ModuleExposedServerPort: serverPort,
 		ModuleExposedDebugPort:  debugPort,
 		PrivatePort:             *p.PrivatePort,
-		ModuleExposedPorts:      helpers.CreateExposedPorts(*p.PrivatePort),
-		ModulePortBindings:      helpers.CreatePortBindings(serverPort, debugPort, *p.PrivatePort),
+		HealthPort:              p.getHealthPort(),
+		ModuleExposedPorts:      helpers.CreateExposedPorts(*p.PrivatePort, p.getHealthPort()),
+		ModulePortBindings:      helpers.CreatePortBindings(serverPort, debugPort, *p.PrivatePort, p.getHealthPort()),
 		ModuleEnv:               p.Env,
 		SidecarEnv:              p.SidecarEnv,
-		ModuleResources:         *helpers.CreateResources(true, p.Resources),
+		ModuleResources:         *moduleResources,
 		ModuleVolumes:           p.Volumes,
 		DeploySidecar:           false,
 		SidecarExposedPorts:     nil,
@@ -171,6 +206,7 @@ type FrontendModule struct {
 	ModuleVersion       *string
 	ModuleName          string
 	LocalDescriptorPath string
+	DescriptorFile      string
 }
 
 // ==================== Container ====================
@@ -213,5 +249,38 @@ type RegistryExtract struct {
 	Modules           *ProxyModulesByRegistry
 	BackendModules    map[string]BackendModule
 	FrontendModules   map[string]FrontendModule
-	ModuleDescriptors map[string]any
+	ModuleDescriptors ModuleDescriptors
+}
+
+// ModuleDescriptors is a concurrency-safe set of module descriptors keyed by module id, the zero value is ready to use
+type ModuleDescriptors struct {
+	mu          sync.RWMutex
+	descriptors map[string]any
+}
+
+// Get returns the descriptor of a module or nil when it has not been fetched
+func (md *ModuleDescriptors) Get(moduleID string) any {
+	md.mu.RLock()
+	defer md.mu.RUnlock()
+
+	return md.descriptors[moduleID]
+}
+
+// Set stores the descriptor of a module, replacing any previous one
+func (md *ModuleDescriptors) Set(moduleID string, descriptor any) {
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	if md.descriptors == nil {
+		md.descriptors = make(map[string]any)
+	}
+	md.descriptors[moduleID] = descriptor
+}
+
+// Len returns the number of stored descriptors
+func (md *ModuleDescriptors) Len() int {
+	md.mu.RLock()
+	defer md.mu.RUnlock()
+
+	return len(md.descriptors)
 }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
@@ -42,7 +44,7 @@ func TestNewBackendModuleWithSidecar_Success_WithDeployModule(t *testing.T) {
 			"JAVA_OPTIONS": "-Xmx512m",
 		},
 		Resources: map[string]any{
-			"memory": "1Gi",
+			"memory": "1g",
 		},
 		Volumes: []string{"/data:/data"},
 	}
@@ -415,3 +417,54 @@ func TestBackendModule_WithSidecarVsWithout_StructureDifference(t *testing.T) {
 	assert.Nil(t, withoutSidecar.SidecarExposedPorts)
 	assert.Nil(t, withoutSidecar.SidecarPortBindings)
 }
+
+// ==================== ModuleDescriptors Tests ====================
+
+func TestBackendModule_GetHealthPort(t *testing.T) {
+	// Arrange
+	defaultModule := BackendModule{ModuleExposedServerPort: 9130}
+	dedicatedModule := BackendModule{ModuleExposedServerPort: 9130, HealthPort: 9131}
+
+	// Act & Assert
+	assert.Equal(t, 9130, defaultModule.GetHealthPort())
+	assert.Equal(t, 9131, dedicatedModule.GetHealthPort())
+}
+
+func TestModuleDescriptors_ZeroValueGetSet(t *testing.T) {
+	// Arrange
+	var descriptors ModuleDescriptors
+
+	// Act
+	descriptors.Set("mod-users-19.2.3", map[string]any{"id": "mod-users-19.2.3"})
+
+	// Assert
+	assert.Equal(t, map[string]any{"id": "mod-users-19.2.3"}, descriptors.Get("mod-users-19.2.3"))
+	assert.Nil(t, descriptors.Get("mod-missing-1.0.0"))
+	assert.Equal(t, 1, descriptors.Len())
+}
+
+// Run with -race to verify that parallel descriptor fetches do not race
+func TestModuleDescriptors_ConcurrentAccess(t *testing.T) {
+	// Arrange
+	var (
+		descriptors ModuleDescriptors
+		wg          sync.WaitGroup
+	)
+
+	// Act
+	for i := range 50 {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			moduleID := fmt.Sprintf("mod-test-%d", idx)
+			descriptors.Set(moduleID, idx)
+			_ = descriptors.Get(moduleID)
+			_ = descriptors.Len()
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 50, descriptors.Len())
+	assert.Equal(t, 7, descriptors.Get("mod-test-7"))
+}
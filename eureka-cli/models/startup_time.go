@@ -0,0 +1,62 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// ==================== Module Startup Times ====================
+
+// ModuleStartupTime records how long a deployed module took to become healthy
+type ModuleStartupTime struct {
+	Module     string    `json:"module"`
+	Type       string    `json:"type"`
+	Healthy    bool      `json:"healthy"`
+	StartedAt  time.Time `json:"startedAt"`
+	EndedAt    time.Time `json:"endedAt"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// ModuleStartupTimes holds the startup times recorded by the deploys of the current environment
+type ModuleStartupTimes struct {
+	Modules []ModuleStartupTime `json:"modules"`
+}
+
+// Merge records the startup times of a deploy, replacing the previous times of the same modules
+func (s *ModuleStartupTimes) Merge(startupTimes []ModuleStartupTime) {
+	for _, startupTime := range startupTimes {
+		idx := slices.IndexFunc(s.Modules, func(existing ModuleStartupTime) bool {
+			return existing.Module == startupTime.Module
+		})
+		if idx == -1 {
+			s.Modules = append(s.Modules, startupTime)
+			continue
+		}
+		s.Modules[idx] = startupTime
+	}
+}
+
+// Slowest returns the startup times sorted from the slowest module, a non-positive limit returns all of them
+func (s *ModuleStartupTimes) Slowest(limit int) []ModuleStartupTime {
+	sorted := slices.Clone(s.Modules)
+	slices.SortStableFunc(sorted, func(a, b ModuleStartupTime) int {
+		if a.DurationMs != b.DurationMs {
+			if a.DurationMs > b.DurationMs {
+				return -1
+			}
+			return 1
+		}
+		if a.Module < b.Module {
+			return -1
+		}
+		if a.Module > b.Module {
+			return 1
+		}
+		return 0
+	})
+	if limit > 0 && limit < len(sorted) {
+		return sorted[:limit]
+	}
+
+	return sorted
+}
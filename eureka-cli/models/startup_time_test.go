@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== ModuleStartupTimes Tests ====================
+
+func TestModuleStartupTimes_Merge_ReplacesSameModule(t *testing.T) {
+	// Arrange
+	startupTimes := ModuleStartupTimes{Modules: []ModuleStartupTime{
+		{Module: "mod-users", DurationMs: 1000},
+		{Module: "mod-orders", DurationMs: 2000},
+	}}
+
+	// Act
+	startupTimes.Merge([]ModuleStartupTime{{Module: "mod-users", DurationMs: 3000}, {Module: "mgr-tenants", DurationMs: 500}})
+
+	// Assert
+	assert.Len(t, startupTimes.Modules, 3)
+	assert.Equal(t, int64(3000), startupTimes.Modules[0].DurationMs)
+	assert.Equal(t, "mgr-tenants", startupTimes.Modules[2].Module)
+}
+
+func TestModuleStartupTimes_Slowest(t *testing.T) {
+	// Arrange
+	startupTimes := ModuleStartupTimes{Modules: []ModuleStartupTime{
+		{Module: "mod-users", DurationMs: 1000},
+		{Module: "mod-orders", DurationMs: 2000},
+		{Module: "mod-notes", DurationMs: 1000},
+	}}
+
+	// Act
+	all := startupTimes.Slowest(0)
+	top := startupTimes.Slowest(1)
+
+	// Assert
+	assert.Equal(t, []string{"mod-orders", "mod-notes", "mod-users"}, []string{all[0].Module, all[1].Module, all[2].Module})
+	assert.Len(t, top, 1)
+	assert.Equal(t, "mod-orders", top[0].Module)
+	assert.Equal(t, "mod-users", startupTimes.Modules[0].Module)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -38,16 +39,24 @@ func (mp *ModuleProps) ReadBackendModules(isManagement bool, verbose bool) (map[
 		slog.Info(mp.Action.Name, "text", "No backend modules were read")
 		return modules, nil
 	}
+	if err := mp.reserveConfiguredPorts(); err != nil {
+		return nil, err
+	}
 
-	for name, value := range mp.Action.ConfigBackendModules {
+	assignedPorts := make(map[string]int)
+	for _, name := range helpers.SortedMapKeys(mp.Action.ConfigBackendModules) {
 		if isManagement && !mp.isManagementModule(name) || !isManagement && mp.isManagementModule(name) {
 			continue
 		}
 
+		value := mp.Action.ConfigBackendModules[name]
 		p, err := mp.createBackendProperties(name, value)
 		if err != nil {
 			return nil, err
 		}
+		if mp.getConfiguredPort(value) == nil && p.Port != nil && *p.Port != 0 {
+			assignedPorts[name] = *p.Port
+		}
 
 		backendModule, err := mp.createBackendModule(p)
 		if err != nil {
@@ -62,10 +71,44 @@ func (mp *ModuleProps) ReadBackendModules(isManagement bool, verbose bool) (map[
 			}
 		}
 	}
+	if len(assignedPorts) > 0 {
+		slog.Info(mp.Action.Name, "text", "Assigned module ports from range",
+			"start", mp.Action.ConfigApplicationPortStart, "end", mp.Action.ConfigApplicationPortEnd, "ports", assignedPorts)
+	}
 
 	return modules, nil
 }
 
+// reserveConfiguredPorts marks the explicitly configured module ports as reserved,
+// so that ports auto-assigned from the range never collide with them
+func (mp *ModuleProps) reserveConfiguredPorts() error {
+	portOwners := make(map[int]string)
+	for _, name := range helpers.SortedMapKeys(mp.Action.ConfigBackendModules) {
+		port := mp.getConfiguredPort(mp.Action.ConfigBackendModules[name])
+		if port == nil {
+			continue
+		}
+		if owner, exists := portOwners[*port]; exists {
+			return errors.DuplicateModulePort(*port, name, owner)
+		}
+		portOwners[*port] = name
+		if !slices.Contains(mp.Action.ReservedPorts, *port) {
+			mp.Action.ReservedPorts = append(mp.Action.ReservedPorts, *port)
+		}
+	}
+
+	return nil
+}
+
+func (mp *ModuleProps) getConfiguredPort(value any) *int {
+	entry, ok := value.(map[string]any)
+	if !ok || !helpers.GetBoolOrDefault(entry, field.ModuleDeployModuleEntry, true) {
+		return nil
+	}
+
+	return helpers.GetIntPtr(entry, field.ModulePortEntry)
+}
+
 func (mp *ModuleProps) createBackendProperties(name string, value any) (models.BackendModuleProperties, error) {
 	if value == nil {
 		return mp.createDefaultBackendProperties(name)
@@ -151,9 +194,13 @@ func (mp *ModuleProps) createConfigurableBackendProperties(value any, name strin
 	p.DisableSystemUser = helpers.GetBool(entry, field.ModuleDisableSystemUserEntry)
 	p.UseOkapiURL = helpers.GetBool(entry, field.ModuleUseOkapiURLEntry)
 	p.LocalDescriptorPath = helpers.GetString(entry, field.ModuleLocalDescriptorPathEntry)
-	if p.LocalDescriptorPath != "" {
-		if _, err := os.Stat(p.LocalDescriptorPath); os.IsNotExist(err) {
-			return models.BackendModuleProperties{}, errors.LocalDescriptorNotFound(p.LocalDescriptorPath, name)
+	p.DescriptorFile = helpers.GetString(entry, field.ModuleDescriptorFileEntry)
+	for _, descriptorPath := range []string{p.LocalDescriptorPath, p.DescriptorFile} {
+		if descriptorPath == "" {
+			continue
+		}
+		if _, err := os.Stat(descriptorPath); os.IsNotExist(err) {
+			return models.BackendModuleProperties{}, errors.LocalDescriptorNotFound(descriptorPath, name)
 		}
 	}
 
@@ -164,9 +211,16 @@ func (mp *ModuleProps) createConfigurableBackendProperties(value any, name strin
 	}
 
 	p.PrivatePort = mp.getPrivatePort(entry)
+	p.HealthPort = helpers.GetIntPtr(entry, field.ModuleHealthPortEntry)
 	p.Env = helpers.GetMap(entry, field.ModuleEnvEntry)
 	p.SidecarEnv = helpers.GetMap(entry, field.ModuleSidecarEnvEntry)
 	p.Resources = helpers.GetMap(entry, field.ModuleResourceEntry)
+	if err := helpers.ValidateResources(name, p.Resources); err != nil {
+		return models.BackendModuleProperties{}, err
+	}
+	if err := validateHealthyChecks(name, entry); err != nil {
+		return models.BackendModuleProperties{}, err
+	}
 	p.Volumes, err = mp.getVolumes(entry)
 	if err != nil {
 		return models.BackendModuleProperties{}, err
@@ -175,6 +229,20 @@ func (mp *ModuleProps) createConfigurableBackendProperties(value any, name strin
 	return p, nil
 }
 
+// validateHealthyChecks rejects a healthy-checks entry that is not a positive integer, an omitted entry
+// keeps the default of a single healthy response
+func validateHealthyChecks(name string, entry map[string]any) error {
+	rawValue, exists := entry[field.ModuleHealthyChecksEntry]
+	if !exists || rawValue == nil {
+		return nil
+	}
+	if value, ok := rawValue.(int); !ok || value < 1 {
+		return errors.InvalidHealthyChecks(name, rawValue)
+	}
+
+	return nil
+}
+
 func (mp *ModuleProps) getDeploySidecar(entry map[string]any) *bool {
 	if boolPtr := helpers.GetBoolPtr(entry, field.ModuleDeploySidecarEntry); boolPtr != nil {
 		return boolPtr
@@ -300,6 +368,7 @@ func (mp *ModuleProps) ReadFrontendModules(verbose bool) (map[string]models.Fron
 				deployModule        = true
 				version             *string
 				localDescriptorPath = ""
+				descriptorFile      = ""
 			)
 			if value != nil {
 				entry, ok := value.(map[string]any)
@@ -313,9 +382,13 @@ func (mp *ModuleProps) ReadFrontendModules(verbose bool) (map[string]models.Fron
 				}
 
 				localDescriptorPath = helpers.GetString(entry, field.ModuleLocalDescriptorPathEntry)
-				if localDescriptorPath != "" {
-					if _, err := os.Stat(localDescriptorPath); os.IsNotExist(err) {
-						return nil, errors.LocalDescriptorNotFound(localDescriptorPath, name)
+				descriptorFile = helpers.GetString(entry, field.ModuleDescriptorFileEntry)
+				for _, descriptorPath := range []string{localDescriptorPath, descriptorFile} {
+					if descriptorPath == "" {
+						continue
+					}
+					if _, err := os.Stat(descriptorPath); os.IsNotExist(err) {
+						return nil, errors.LocalDescriptorNotFound(descriptorPath, name)
 					}
 				}
 			}
@@ -325,6 +398,7 @@ func (mp *ModuleProps) ReadFrontendModules(verbose bool) (map[string]models.Fron
 				ModuleName:          name,
 				ModuleVersion:       version,
 				LocalDescriptorPath: localDescriptorPath,
+				DescriptorFile:      descriptorFile,
 			}
 			if verbose {
 				if version == nil {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/moduleprops"
@@ -55,6 +56,64 @@ func TestReadBackendModules_PortExhaustion(t *testing.T) {
 	})
 }
 
+func TestReadBackendModules_PortAssignment(t *testing.T) {
+	t.Run("TestReadBackendModules_PortAssignment_SkipsConfiguredPorts", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{
+			Name:                       "test-action",
+			Param:                      &action.Param{},
+			ReservedPorts:              []int{},
+			ConfigApplicationPortStart: 38000,
+			ConfigApplicationPortEnd:   38100,
+			ConfigBackendModules: map[string]any{
+				"mod-inventory": nil,
+				"mod-users": map[string]any{
+					field.ModulePortEntry: 38000,
+				},
+			},
+		}
+		mp := moduleprops.New(act)
+
+		// Act
+		result, err := mp.ReadBackendModules(false, false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 38000, result["mod-users"].ModuleExposedServerPort)
+		assert.NotEqual(t, 38000, result["mod-inventory"].ModuleExposedServerPort)
+		assert.Contains(t, act.ReservedPorts, 38000)
+	})
+
+	t.Run("TestReadBackendModules_PortAssignment_DuplicateConfiguredPorts", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{
+			Name:                       "test-action",
+			Param:                      &action.Param{},
+			ReservedPorts:              []int{},
+			ConfigApplicationPortStart: 38000,
+			ConfigApplicationPortEnd:   38100,
+			ConfigBackendModules: map[string]any{
+				"mod-inventory": map[string]any{
+					field.ModulePortEntry: 38050,
+				},
+				"mod-users": map[string]any{
+					field.ModulePortEntry: 38050,
+				},
+			},
+		}
+		mp := moduleprops.New(act)
+
+		// Act
+		result, err := mp.ReadBackendModules(false, false)
+
+		// Assert
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "mod-inventory")
+		assert.Contains(t, err.Error(), "mod-users")
+		assert.Nil(t, result)
+	})
+}
+
 // ==================== ReadBackendModulesFromConfig Tests ====================
 
 func TestReadBackendModules_EmptyConfig(t *testing.T) {
@@ -213,6 +272,36 @@ func TestReadBackendModules_ConfigurableProperties(t *testing.T) {
 		assert.Equal(t, 9000, module.ModuleExposedServerPort)
 	})
 
+	t.Run("TestReadBackendModules_ConfigurableProperties_WithHealthPort", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{
+			Name:                       "test-action",
+			Param:                      &action.Param{},
+			ReservedPorts:              []int{},
+			ConfigApplicationPortStart: 8000,
+			ConfigApplicationPortEnd:   9000,
+			ConfigBackendModules: map[string]any{
+				"mod-inventory": map[string]any{
+					field.ModulePortEntry:       9130,
+					field.ModuleHealthPortEntry: 9131,
+				},
+				"mod-users": map[string]any{
+					field.ModulePortEntry: 9140,
+				},
+			},
+		}
+		mp := moduleprops.New(act)
+
+		// Act
+		result, err := mp.ReadBackendModules(false, false)
+
+		// Assert
+		assert.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, 9131, result["mod-inventory"].GetHealthPort())
+		assert.Equal(t, 9140, result["mod-users"].GetHealthPort())
+	})
+
 	t.Run("TestReadBackendModules_ConfigurableProperties_WithPrivatePort", func(t *testing.T) {
 		// Arrange
 		act := &action.Action{
@@ -518,6 +607,59 @@ func TestReadBackendModules_LocalDescriptor(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "local descriptor")
 	})
+
+	t.Run("TestReadBackendModules_LocalDescriptor_ValidDescriptorFile", func(t *testing.T) {
+		// Arrange
+		tmpFile := filepath.Join(t.TempDir(), "descriptor.json")
+		err := os.WriteFile(tmpFile, []byte(`{}`), 0600)
+		require.NoError(t, err)
+
+		act := &action.Action{
+			Name:                       "test-action",
+			Param:                      &action.Param{},
+			ReservedPorts:              []int{},
+			ConfigApplicationPortStart: 8000,
+			ConfigApplicationPortEnd:   9000,
+			ConfigBackendModules: map[string]any{
+				"mod-inventory": map[string]any{
+					field.ModuleDescriptorFileEntry: tmpFile,
+				},
+			},
+		}
+		mp := moduleprops.New(act)
+
+		// Act
+		result, err := mp.ReadBackendModules(false, false)
+
+		// Assert
+		assert.NoError(t, err)
+		require.Len(t, result, 1)
+		module := result["mod-inventory"]
+		assert.Equal(t, tmpFile, module.DescriptorFile)
+		assert.Empty(t, module.LocalDescriptorPath)
+	})
+
+	t.Run("TestReadBackendModules_LocalDescriptor_InvalidDescriptorFile", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{
+			Name:  "test-action",
+			Param: &action.Param{},
+			ConfigBackendModules: map[string]any{
+				"mod-inventory": map[string]any{
+					field.ModuleDescriptorFileEntry: "/nonexistent/path/descriptor.json",
+				},
+			},
+		}
+		mp := moduleprops.New(act)
+
+		// Act
+		result, err := mp.ReadBackendModules(false, false)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "local descriptor")
+	})
 }
 
 func TestReadBackendModules_Volumes(t *testing.T) {
@@ -825,6 +967,50 @@ func TestReadBackendModules_Volumes(t *testing.T) {
 	})
 }
 
+func TestReadBackendModules_HealthyChecks(t *testing.T) {
+	newAction := func(value any) *action.Action {
+		return &action.Action{
+			Name:                       "test-action",
+			Param:                      &action.Param{},
+			ReservedPorts:              []int{},
+			ConfigApplicationPortStart: 8000,
+			ConfigApplicationPortEnd:   9000,
+			ConfigBackendModules: map[string]any{
+				"mod-inventory": map[string]any{
+					field.ModuleHealthyChecksEntry: value,
+				},
+			},
+		}
+	}
+
+	t.Run("TestReadBackendModules_HealthyChecks_PositiveValue", func(t *testing.T) {
+		// Arrange
+		mp := moduleprops.New(newAction(3))
+
+		// Act
+		result, err := mp.ReadBackendModules(false, false)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("TestReadBackendModules_HealthyChecks_InvalidValues", func(t *testing.T) {
+		for _, value := range []any{0, -1, "two"} {
+			// Arrange
+			mp := moduleprops.New(newAction(value))
+
+			// Act
+			result, err := mp.ReadBackendModules(false, false)
+
+			// Assert
+			assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+			assert.Contains(t, err.Error(), "mod-inventory")
+			assert.Nil(t, result)
+		}
+	})
+}
+
 func TestReadBackendModules_EdgeModules(t *testing.T) {
 	t.Run("TestReadBackendModules_EdgeModules_EdgeModuleNoSidecar", func(t *testing.T) {
 		// Arrange
@@ -45,6 +45,7 @@ type ModuleSvc struct {
 	ModuleEnv           moduleenv.ModuleEnvProcessor
 	ReadinessMaxRetries int
 	ReadinessWait       time.Duration
+	ReadinessMinWait    time.Duration
 }
 
 func New(action *action.Action,
@@ -67,6 +67,10 @@ func (ms *ModuleSvc) DeployCustomSidecar(client *client.Client, pair *ModulePair
 	if err != nil {
 		return err
 	}
+	sidecarResources, err := helpers.CreateResources(false, ms.Action.ConfigSidecarModuleResources)
+	if err != nil {
+		return err
+	}
 
 	return ms.DeployModule(client, &models.Container{
 		Name: pair.Module.Metadata.SidecarName,
@@ -79,7 +83,7 @@ func (ms *ModuleSvc) DeployCustomSidecar(client *client.Client, pair *ModulePair
 		HostConfig: &container.HostConfig{
 			PortBindings:  *pair.BackendModule.SidecarPortBindings,
 			RestartPolicy: *helpers.GetRestartPolicy(),
-			Resources:     *helpers.CreateResources(false, ms.Action.ConfigSidecarModuleResources),
+			Resources:     *sidecarResources,
 		},
 		NetworkConfig: helpers.GetModuleNetworkConfig(),
 		Platform:      helpers.GetPlatform(),
@@ -96,7 +100,7 @@ func (ms *ModuleSvc) CheckModuleAndSidecarReadiness(pair *ModulePair) error {
 	if pair.ModuleURL != "" {
 		go ms.CheckModuleReadinessByURL(&interceptModuleWG, errCh, pair.ModuleName, pair.ModuleURL)
 	} else {
-		go ms.CheckModuleReadiness(&interceptModuleWG, errCh, pair.ModuleName, pair.BackendModule.ModuleExposedServerPort)
+		go ms.CheckModuleReadiness(&interceptModuleWG, errCh, pair.ModuleName, pair.BackendModule.GetHealthPort())
 	}
 	if pair.SidecarURL != "" {
 		go ms.CheckModuleReadinessByURL(&interceptModuleWG, errCh, helpers.GetSidecarName(pair.ModuleName), pair.SidecarURL)
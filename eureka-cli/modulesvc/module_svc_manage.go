@@ -26,7 +26,7 @@ type ModuleManager interface {
 	GetDeployedModules(client *client.Client, filters filters.Args) ([]container.Summary, error)
 	GetModule(client *client.Client, moduleName string) ([]container.Summary, error)
 	PullModule(client *client.Client, imageName string) error
-	DeployModules(client *client.Client, containers *models.Containers, sidecarImage string, sidecarResources *container.Resources) (map[string]int, int, error)
+	DeployModules(client *client.Client, containers *models.Containers, sidecarImage string, sidecarResources *container.Resources) (map[string]models.BackendModule, int, error)
 	DeployModule(client *client.Client, container *models.Container) error
 	UndeployModuleByNamePattern(client *client.Client, pattern string) error
 }
@@ -103,8 +103,9 @@ func (ms *ModuleSvc) PullModule(client *client.Client, imageName string) error {
 	return nil
 }
 
-func (ms *ModuleSvc) DeployModules(client *client.Client, containers *models.Containers, sidecarImage string, sidecarResources *container.Resources) (map[string]int, int, error) {
-	newlyDeployed := make(map[string]int)
+// DeployModules returns the newly deployed backend modules keyed by name and the number of matched modules
+func (ms *ModuleSvc) DeployModules(client *client.Client, containers *models.Containers, sidecarImage string, sidecarResources *container.Resources) (map[string]models.BackendModule, int, error) {
+	newlyDeployed := make(map[string]models.BackendModule)
 	totalMatched := 0
 
 	var sidecarWG sync.WaitGroup
@@ -163,7 +164,7 @@ func (ms *ModuleSvc) DeployModules(client *client.Client, containers *models.Con
 			}); err != nil {
 				return nil, 0, err
 			}
-			newlyDeployed[module.Metadata.Name] = backendModule.ModuleExposedServerPort
+			newlyDeployed[module.Metadata.Name] = backendModule
 
 			if backendModule.DeploySidecar && sidecarImage != "" {
 				sidecarWG.Add(1)
@@ -195,9 +196,22 @@ func (ms *ModuleSvc) shouldSkipModule(module *models.ProxyModule, managementOnly
 	return (managementOnly && !isManagementModule) || (!managementOnly && isManagementModule)
 }
 
+// shouldDeployModule reports whether a configured module is deployed, application modules
+// must also pass the include and exclude lists that filter their registration
 func (ms *ModuleSvc) shouldDeployModule(module *models.ProxyModule, backendModules map[string]models.BackendModule) bool {
 	backendModule, exists := backendModules[module.Metadata.Name]
-	return exists && backendModule.DeployModule
+	if !exists || !backendModule.DeployModule {
+		return false
+	}
+	if strings.Contains(module.Metadata.Name, constant.ManagementModulePattern) {
+		return true
+	}
+	if registrable, reason := ms.Action.IsModuleRegistrable(module.Metadata.Name); !registrable {
+		slog.Info(ms.Action.Name, "text", "Skipping module deployment", "module", module.Metadata.Name, "reason", reason)
+		return false
+	}
+
+	return true
 }
 
 func (ms *ModuleSvc) deploySidecarAsync(wg *sync.WaitGroup, errCh chan<- error, r *models.SidecarRequest) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/folio-org/eureka-setup/eureka-cli/errors"
+	"github.com/folio-org/eureka-setup/eureka-cli/field"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 )
 
@@ -20,28 +21,41 @@ type ModuleReadinessChecker interface {
 
 func (ms *ModuleSvc) CheckModuleReadiness(wg *sync.WaitGroup, errCh chan<- error, moduleName string, port int) {
 	requestURL := ms.Action.GetRequestURL(strconv.Itoa(port), "/admin/health")
-	ms.checkReadiness(wg, errCh, moduleName, requestURL)
+	ms.checkReadiness(wg, errCh, moduleName, requestURL, ms.getHealthcheckMaxAttempts(), ms.getHealthyChecks(moduleName), ms.getHealthcheckMinInterval(), ms.getHealthcheckInterval())
 }
 
 func (ms *ModuleSvc) CheckModuleReadinessByURL(wg *sync.WaitGroup, errCh chan<- error, moduleName string, baseURL string) {
 	requestURL := strings.TrimRight(baseURL, "/") + "/admin/health"
-	ms.checkReadiness(wg, errCh, moduleName, requestURL)
+	ms.checkReadiness(wg, errCh, moduleName, requestURL, ms.getHealthcheckMaxAttempts(), ms.getHealthyChecks(moduleName), ms.getHealthcheckMinInterval(), ms.getHealthcheckInterval())
 }
 
-func (ms *ModuleSvc) checkReadiness(wg *sync.WaitGroup, errCh chan<- error, moduleName string, requestURL string) {
+// checkReadiness declares a module ready after healthyChecks consecutive healthy responses, an unhealthy response
+// in between resets the count so that a module which reports UP briefly before crashing is not taken as ready
+func (ms *ModuleSvc) checkReadiness(wg *sync.WaitGroup, errCh chan<- error, moduleName string, requestURL string, maxRetries int, healthyChecks int, initialWait time.Duration, maxWait time.Duration) {
 	defer wg.Done()
 
-	slog.Info(ms.Action.Name, "text", "Preparing module readiness check", "module", moduleName, "url", requestURL)
-	maxRetries := helpers.DefaultInt(ms.ReadinessMaxRetries, constant.ModuleReadinessMaxRetries)
-	waitDuration := helpers.DefaultDuration(ms.ReadinessWait, constant.ModuleReadinessWait)
+	slog.Info(ms.Action.Name, "text", "Preparing module readiness check", "module", moduleName, "url", requestURL, "maxAttempts", maxRetries, "healthyChecks", healthyChecks, "initialInterval", initialWait, "interval", maxWait)
+	var healthyCount int
 	for retryCount := range maxRetries {
 		statusCode, _ := ms.HTTPClient.Ping(requestURL)
 		if statusCode == http.StatusOK {
-			slog.Info(ms.Action.Name, "text", "Module is ready", "module", moduleName)
-			return
+			healthyCount++
+			if healthyCount >= healthyChecks {
+				slog.Info(ms.Action.Name, "text", "Module is ready", "module", moduleName)
+				return
+			}
+
+			slog.Info(ms.Action.Name, "text", "Module is healthy, awaiting consecutive healthchecks", "module", moduleName, "healthy", healthyCount, "required", healthyChecks, "wait", initialWait)
+			time.Sleep(initialWait)
+			continue
+		}
+		if healthyCount > 0 {
+			slog.Warn(ms.Action.Name, "text", "Module became unhealthy after a healthy response", "module", moduleName, "healthy", healthyCount)
+			healthyCount = 0
 		}
 
-		slog.Warn(ms.Action.Name, "text", "Module is unready", "module", moduleName, "count", retryCount, "max", maxRetries)
+		waitDuration := helpers.ExponentialBackoff(initialWait, maxWait, retryCount)
+		slog.Warn(ms.Action.Name, "text", "Module is unready", "module", moduleName, "count", retryCount, "max", maxRetries, "wait", waitDuration)
 		time.Sleep(waitDuration)
 	}
 
@@ -50,3 +64,38 @@ func (ms *ModuleSvc) checkReadiness(wg *sync.WaitGroup, errCh chan<- error, modu
 	default:
 	}
 }
+
+func (ms *ModuleSvc) getHealthcheckMaxAttempts() int {
+	if ms.Action.Param != nil && ms.Action.Param.HealthcheckMaxAttempts > 0 {
+		return ms.Action.Param.HealthcheckMaxAttempts
+	}
+
+	return helpers.DefaultInt(ms.ReadinessMaxRetries, constant.ModuleReadinessMaxRetries)
+}
+
+// getHealthyChecks returns the number of consecutive healthy responses required by a module,
+// read from healthy-checks of its backend-modules entry
+func (ms *ModuleSvc) getHealthyChecks(moduleName string) int {
+	entry, ok := ms.Action.ConfigBackendModules[moduleName].(map[string]any)
+	if !ok {
+		return constant.ModuleReadinessHealthyChecks
+	}
+
+	return max(helpers.GetInt(entry, field.ModuleHealthyChecksEntry), constant.ModuleReadinessHealthyChecks)
+}
+
+func (ms *ModuleSvc) getHealthcheckMinInterval() time.Duration {
+	if ms.Action.Param != nil && ms.Action.Param.HealthcheckMinInterval > 0 {
+		return ms.Action.Param.HealthcheckMinInterval
+	}
+
+	return helpers.DefaultDuration(ms.ReadinessMinWait, constant.ModuleReadinessMinWait)
+}
+
+func (ms *ModuleSvc) getHealthcheckInterval() time.Duration {
+	if ms.Action.Param != nil && ms.Action.Param.HealthcheckInterval > 0 {
+		return ms.Action.Param.HealthcheckInterval
+	}
+
+	return helpers.DefaultDuration(ms.ReadinessWait, constant.ModuleReadinessWait)
+}
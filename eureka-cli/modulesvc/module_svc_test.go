@@ -159,6 +159,25 @@ func TestGetBackendModule_ChecksEurekaModules(t *testing.T) {
 	assert.Equal(t, "mod-eureka", foundModule.Metadata.Name)
 }
 
+func TestShouldDeployModule_AppliesApplicationModuleFilter(t *testing.T) {
+	// Arrange
+	action := testhelpers.NewMockAction()
+	action.ConfigApplicationExcludeModules = []string{"mod-search*", "mgr-*"}
+	svc := &ModuleSvc{Action: action}
+	backendModules := map[string]models.BackendModule{
+		"mod-users":     {DeployModule: true},
+		"mod-search":    {DeployModule: true},
+		"mgr-tenants":   {DeployModule: true},
+		"mod-inventory": {DeployModule: false},
+	}
+
+	// Act & Assert
+	assert.True(t, svc.shouldDeployModule(&models.ProxyModule{Metadata: models.ProxyModuleMetadata{Name: "mod-users"}}, backendModules))
+	assert.False(t, svc.shouldDeployModule(&models.ProxyModule{Metadata: models.ProxyModuleMetadata{Name: "mod-search"}}, backendModules))
+	assert.True(t, svc.shouldDeployModule(&models.ProxyModule{Metadata: models.ProxyModuleMetadata{Name: "mgr-tenants"}}, backendModules))
+	assert.False(t, svc.shouldDeployModule(&models.ProxyModule{Metadata: models.ProxyModuleMetadata{Name: "mod-inventory"}}, backendModules))
+}
+
 func TestGetModuleImageVersion_UseBackendModuleVersion(t *testing.T) {
 	// Arrange
 	action := testhelpers.NewMockAction()
@@ -710,6 +729,122 @@ func TestCheckModuleReadiness_HTTPError(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
+func TestCheckModuleReadiness_HealthcheckParamsOverrideDefaults(t *testing.T) {
+	// Arrange
+	mockHTTP := new(testhelpers.MockHTTPClient)
+	action := testhelpers.NewMockAction()
+	action.Param.HealthcheckMaxAttempts = 2
+	action.Param.HealthcheckInterval = 1 * time.Millisecond
+	svc := New(action, mockHTTP, nil, nil, nil)
+	svc.ReadinessMaxRetries = 5
+	svc.ReadinessWait = 1 * time.Hour
+
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusServiceUnavailable, nil)
+
+	wg := &sync.WaitGroup{}
+	errCh := make(chan error, 1)
+	wg.Add(1)
+
+	// Act
+	go svc.CheckModuleReadiness(wg, errCh, "test-module", 8080)
+	wg.Wait()
+	close(errCh)
+
+	// Assert
+	assert.Error(t, <-errCh)
+	mockHTTP.AssertNumberOfCalls(t, "Ping", 2)
+}
+
+func TestCheckModuleReadiness_BackoffDetectsLateHealthyModule(t *testing.T) {
+	// Arrange
+	mockHTTP := new(testhelpers.MockHTTPClient)
+	action := testhelpers.NewMockAction()
+	action.Param.HealthcheckMinInterval = 1 * time.Millisecond
+	action.Param.HealthcheckInterval = 4 * time.Millisecond
+	action.Param.HealthcheckMaxAttempts = 5
+	svc := New(action, mockHTTP, nil, nil, nil)
+
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusServiceUnavailable, nil).Times(3)
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusOK, nil).Once()
+
+	wg := &sync.WaitGroup{}
+	errCh := make(chan error, 1)
+	wg.Add(1)
+
+	// Act
+	go svc.CheckModuleReadiness(wg, errCh, "test-module", 8080)
+	wg.Wait()
+	close(errCh)
+
+	// Assert
+	assert.NoError(t, <-errCh)
+	mockHTTP.AssertNumberOfCalls(t, "Ping", 4)
+}
+
+func TestCheckModuleReadiness_HealthyChecksRejectFlappingModule(t *testing.T) {
+	// Arrange
+	mockHTTP := new(testhelpers.MockHTTPClient)
+	action := testhelpers.NewMockAction()
+	action.ConfigBackendModules = map[string]any{
+		"test-module": map[string]any{"healthy-checks": 2},
+	}
+	action.Param.HealthcheckMinInterval = 1 * time.Millisecond
+	action.Param.HealthcheckInterval = 1 * time.Millisecond
+	action.Param.HealthcheckMaxAttempts = 4
+	svc := New(action, mockHTTP, nil, nil, nil)
+
+	// UP, DOWN, UP, DOWN: never two healthy responses in a row
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusOK, nil).Once()
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusServiceUnavailable, nil).Once()
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusOK, nil).Once()
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusServiceUnavailable, nil).Once()
+
+	wg := &sync.WaitGroup{}
+	errCh := make(chan error, 1)
+	wg.Add(1)
+
+	// Act
+	go svc.CheckModuleReadiness(wg, errCh, "test-module", 8080)
+	wg.Wait()
+	close(errCh)
+
+	// Assert
+	err := <-errCh
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "test-module")
+	mockHTTP.AssertNumberOfCalls(t, "Ping", 4)
+}
+
+func TestCheckModuleReadiness_HealthyChecksAcceptStableModule(t *testing.T) {
+	// Arrange
+	mockHTTP := new(testhelpers.MockHTTPClient)
+	action := testhelpers.NewMockAction()
+	action.ConfigBackendModules = map[string]any{
+		"test-module": map[string]any{"healthy-checks": 2},
+	}
+	action.Param.HealthcheckMinInterval = 1 * time.Millisecond
+	action.Param.HealthcheckInterval = 1 * time.Millisecond
+	action.Param.HealthcheckMaxAttempts = 5
+	svc := New(action, mockHTTP, nil, nil, nil)
+
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusOK, nil).Once()
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusServiceUnavailable, nil).Once()
+	mockHTTP.On("Ping", mock.Anything, mock.Anything).Return(http.StatusOK, nil).Twice()
+
+	wg := &sync.WaitGroup{}
+	errCh := make(chan error, 1)
+	wg.Add(1)
+
+	// Act
+	go svc.CheckModuleReadiness(wg, errCh, "test-module", 8080)
+	wg.Wait()
+	close(errCh)
+
+	// Assert
+	assert.NoError(t, <-errCh)
+	mockHTTP.AssertNumberOfCalls(t, "Ping", 4)
+}
+
 func TestCheckModuleReadiness_NilResponse(t *testing.T) {
 	// Arrange
 	mockHTTP := new(testhelpers.MockHTTPClient)
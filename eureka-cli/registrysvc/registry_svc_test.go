@@ -661,13 +661,9 @@ func TestResolveModuleMetadata_ModuleWithoutVersion(t *testing.T) {
 	svc.ResolveModuleMetadata(modules)
 
 	// Assert
-	// When module ID doesn't have a proper version, the regex parsing behavior
-	// extracts what it can - "mod" as name and "-users" as the "version"
-	// This tests the actual behavior of GetModuleNameFromID and GetOptionalModuleVersion
-	assert.Equal(t, "mod", modules.FolioModules[0].Metadata.Name)
-	assert.NotNil(t, modules.FolioModules[0].Metadata.Version)
-	assert.Equal(t, "-users", *modules.FolioModules[0].Metadata.Version)
-	assert.Equal(t, "mod-sc", modules.FolioModules[0].Metadata.SidecarName)
+	assert.Equal(t, "mod-users", modules.FolioModules[0].Metadata.Name)
+	assert.Nil(t, modules.FolioModules[0].Metadata.Version)
+	assert.Equal(t, "mod-users-sc", modules.FolioModules[0].Metadata.SidecarName)
 }
 
 // Tests for getSidecarName
@@ -5,9 +5,7 @@ import (
 	"log/slog"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
-	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/folio-org/eureka-setup/eureka-cli/errors"
-	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/httpclient"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
 )
@@ -30,8 +28,8 @@ func New(action *action.Action, httpClient httpclient.HTTPClientRunner) *SearchS
 }
 
 func (ss *SearchSvc) ReindexInventoryRecords(tenantName string) error {
-	requestURL := ss.Action.GetRequestURL(constant.KongPort, "/search/index/inventory/reindex")
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ss.Action.KeycloakAccessToken)
+	requestURL := ss.Action.GetRequestURL(ss.Action.GetGatewayPort(), "/search/index/inventory/reindex")
+	headers, err := ss.Action.BuildTenantHeaders(tenantName, ss.Action.KeycloakAccessToken)
 	if err != nil {
 		return err
 	}
@@ -83,8 +81,8 @@ func (ss *SearchSvc) ReindexInstanceRecords(tenantName string) error {
 		return err
 	}
 
-	requestURL := ss.Action.GetRequestURL(constant.KongPort, "/search/index/instance-records/reindex/full")
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, ss.Action.KeycloakAccessToken)
+	requestURL := ss.Action.GetRequestURL(ss.Action.GetGatewayPort(), "/search/index/instance-records/reindex/full")
+	headers, err := ss.Action.BuildTenantHeaders(tenantName, ss.Action.KeycloakAccessToken)
 	if err != nil {
 		return err
 	}
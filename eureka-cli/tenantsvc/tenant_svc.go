@@ -3,6 +3,8 @@ package tenantsvc
 import (
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
 	"github.com/folio-org/eureka-setup/eureka-cli/consortiumsvc"
@@ -14,10 +16,13 @@ import (
 
 // TenantProcessor defines the interface for tenant-related operations
 type TenantProcessor interface {
-	GetEntitlementTenantParameters(consortiumName string) (string, error)
+	GetEntitlementTenantParameters(consortiumName string, tenantName string) (string, error)
 	SetConfigTenantParams(tenantName string) error
 }
 
+// reservedEntitlementParameters are set by the CLI and cannot be overridden by the extra entitlement parameters
+var reservedEntitlementParameters = []string{"loadReference", "loadSample", "centralTenantId"}
+
 // TenantSvc provides functionality for managing tenant configurations and parameters
 type TenantSvc struct {
 	Action        *action.Action
@@ -29,17 +34,39 @@ func New(action *action.Action, consortiumSvc consortiumsvc.ConsortiumProcessor)
 	return &TenantSvc{Action: action, ConsortiumSvc: consortiumSvc}
 }
 
-func (ts *TenantSvc) GetEntitlementTenantParameters(consortiumName string) (string, error) {
+// GetEntitlementTenantParameters builds the tenant parameters of an entitlement from the tenant config, reference
+// and sample data are loaded unless disabled and the extra entitlement parameters are appended in key order,
+// keys or values containing the , or = separators are rejected
+func (ts *TenantSvc) GetEntitlementTenantParameters(consortiumName string, tenantName string) (string, error) {
+	configTenant := helpers.GetMap(ts.Action.ConfigTenants, tenantName)
+	parameters := []string{
+		fmt.Sprintf("loadReference=%t", helpers.GetBoolOrDefault(configTenant, field.TenantsLoadReferenceEntry, true)),
+		fmt.Sprintf("loadSample=%t", helpers.GetBoolOrDefault(configTenant, field.TenantsLoadSampleEntry, true)),
+	}
+
+	extraParameters := helpers.GetMap(configTenant, field.TenantsEntitlementParametersEntry)
+	for _, name := range helpers.SortedMapKeys(extraParameters) {
+		if slices.Contains(reservedEntitlementParameters, name) {
+			continue
+		}
+		value := fmt.Sprintf("%v", extraParameters[name])
+		if strings.ContainsAny(name, ",=") || strings.ContainsAny(value, ",=") {
+			return "", errors.InvalidEntitlementParameter(tenantName, name, value)
+		}
+		parameters = append(parameters, fmt.Sprintf("%s=%s", name, value))
+	}
+
 	if consortiumName == constant.NoneConsortium {
-		return "loadReference=true,loadSample=true", nil
+		return strings.Join(parameters, ","), nil
 	}
 
 	centralTenant := ts.ConsortiumSvc.GetConsortiumCentralTenant(consortiumName)
 	if centralTenant == "" {
 		return "", errors.ConsortiumMissingCentralTenant(consortiumName)
 	}
+	parameters = append(parameters, fmt.Sprintf("centralTenantId=%s", centralTenant))
 
-	return fmt.Sprintf("loadReference=true,loadSample=true,centralTenantId=%s", centralTenant), nil
+	return strings.Join(parameters, ","), nil
 }
 
 func (ts *TenantSvc) SetConfigTenantParams(tenantName string) error {
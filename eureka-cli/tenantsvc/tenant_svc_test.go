@@ -90,7 +90,7 @@ func TestGetEntitlementTenantParameters_NoneConsortium(t *testing.T) {
 		svc := tenantsvc.New(act, mockConsortiumSvc)
 
 		// Act
-		result, err := svc.GetEntitlementTenantParameters(constant.NoneConsortium)
+		result, err := svc.GetEntitlementTenantParameters(constant.NoneConsortium, "diku")
 
 		// Assert
 		assert.NoError(t, err)
@@ -98,6 +98,63 @@ func TestGetEntitlementTenantParameters_NoneConsortium(t *testing.T) {
 	})
 }
 
+func TestGetEntitlementTenantParameters_ConfiguredParameters(t *testing.T) {
+	t.Run("TestGetEntitlementTenantParameters_ConfiguredParameters_Success", func(t *testing.T) {
+		// Arrange
+		act := &action.Action{
+			Name: "test-action",
+			ConfigTenants: map[string]any{
+				"diku": map[string]any{
+					"load-sample": false,
+					"entitlement-parameters": map[string]any{
+						"runReindex": true,
+						"loadSample": true,
+						"batchSize":  100,
+					},
+				},
+			},
+		}
+		mockConsortiumSvc := new(MockConsortiumSvc)
+		svc := tenantsvc.New(act, mockConsortiumSvc)
+
+		// Act
+		result, err := svc.GetEntitlementTenantParameters(constant.NoneConsortium, "diku")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "loadReference=true,loadSample=false,batchSize=100,runReindex=true", result)
+	})
+}
+
+func TestGetEntitlementTenantParameters_RejectsSeparators(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]any
+	}{
+		{name: "comma in value", parameters: map[string]any{"topics": "a,b"}},
+		{name: "equals in value", parameters: map[string]any{"filter": "name=x"}},
+		{name: "equals in key", parameters: map[string]any{"a=b": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			act := &action.Action{
+				Name:          "test-action",
+				ConfigTenants: map[string]any{"diku": map[string]any{"entitlement-parameters": tt.parameters}},
+			}
+			svc := tenantsvc.New(act, new(MockConsortiumSvc))
+
+			// Act
+			result, err := svc.GetEntitlementTenantParameters(constant.NoneConsortium, "diku")
+
+			// Assert
+			assert.ErrorIs(t, err, errors.ErrInvalidInput)
+			assert.Empty(t, result)
+		})
+	}
+}
+
 func TestGetEntitlementTenantParameters_WithCentralTenant(t *testing.T) {
 	t.Run("TestGetEntitlementTenantParameters_WithCentralTenant_Success", func(t *testing.T) {
 		// Arrange
@@ -110,7 +167,7 @@ func TestGetEntitlementTenantParameters_WithCentralTenant(t *testing.T) {
 		mockConsortiumSvc.On("GetConsortiumCentralTenant", consortiumName).Return(centralTenant)
 
 		// Act
-		result, err := svc.GetEntitlementTenantParameters(consortiumName)
+		result, err := svc.GetEntitlementTenantParameters(consortiumName, "diku")
 
 		// Assert
 		assert.NoError(t, err)
@@ -130,7 +187,7 @@ func TestGetEntitlementTenantParameters_NoCentralTenant(t *testing.T) {
 		mockConsortiumSvc.On("GetConsortiumCentralTenant", consortiumName).Return("")
 
 		// Act
-		result, err := svc.GetEntitlementTenantParameters(consortiumName)
+		result, err := svc.GetEntitlementTenantParameters(consortiumName, "diku")
 
 		// Assert
 		assert.Error(t, err)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/docker/docker/client"
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/execsvc"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/managementsvc"
@@ -65,14 +66,17 @@ func (um *UpgradeModuleSvc) prepareModuleAndSidecarPairNetwork(pair *modulesvc.M
 	}
 
 	pair.BackendModule, pair.Module = um.ModuleSvc.GetBackendModule(pair.Containers, pair.ModuleName)
+	if pair.BackendModule == nil || pair.Module == nil {
+		return apperrors.ModuleNotConfigured(pair.ModuleName)
+	}
 	pair.BackendModule.ModuleVersion = &pair.ModuleVersion
 	pair.BackendModule.ModuleExposedServerPort = ports[0]
 	pair.BackendModule.ModuleExposedDebugPort = ports[1]
 	pair.BackendModule.SidecarExposedServerPort = ports[2]
 	pair.BackendModule.SidecarExposedDebugPort = ports[3]
 
-	pair.BackendModule.ModulePortBindings = helpers.CreatePortBindings(ports[0], ports[1], pair.BackendModule.PrivatePort)
-	pair.BackendModule.SidecarPortBindings = helpers.CreatePortBindings(ports[2], ports[3], pair.BackendModule.PrivatePort)
+	pair.BackendModule.ModulePortBindings = helpers.CreatePortBindings(ports[0], ports[1], pair.BackendModule.PrivatePort, pair.BackendModule.HealthPort)
+	pair.BackendModule.SidecarPortBindings = helpers.CreatePortBindings(ports[2], ports[3], pair.BackendModule.PrivatePort, 0)
 
 	pair.Module.Metadata.Version = pair.BackendModule.ModuleVersion
 
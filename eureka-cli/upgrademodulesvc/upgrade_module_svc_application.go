@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/constant"
+	apperrors "github.com/folio-org/eureka-setup/eureka-cli/errors"
 	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 )
 
@@ -15,6 +16,7 @@ type UpgradeModuleApplicationBuilder interface {
 	UpdateBackendModules(moduleName, newModuleVersion string, shouldBuild bool, modules []any) ([]map[string]any, []map[string]string, string, error)
 	UpdateFrontendModules(shouldBuild bool, modules []any) (newFrontendModules []map[string]any)
 	UpdateBackendModuleDescriptors(moduleName, oldModuleID string, newModuleDescriptor map[string]any, moduleDescriptors []any) []any
+	AddBackendModule(moduleName, moduleVersion string, shouldBuild bool, modules []any) ([]map[string]any, []map[string]string, error)
 }
 
 func (um *UpgradeModuleSvc) UpdateBackendModules(moduleName, newModuleVersion string, shouldBuild bool, modules []any) ([]map[string]any, []map[string]string, string, error) {
@@ -30,35 +32,12 @@ func (um *UpgradeModuleSvc) UpdateBackendModules(moduleName, newModuleVersion st
 		entry := value.(map[string]any)
 		if helpers.GetString(entry, "name") == moduleName {
 			oldModuleID = helpers.GetString(entry, "id")
-			moduleID := fmt.Sprintf("%s-%s", moduleName, newModuleVersion)
-			if shouldBuild {
-				entry = map[string]any{
-					"id":      moduleID,
-					"name":    moduleName,
-					"version": newModuleVersion,
-				}
-			} else {
-				moduleDescriptorURL := um.Action.GetModuleURL(moduleID)
-				entry = map[string]any{
-					"id":      moduleID,
-					"name":    moduleName,
-					"version": newModuleVersion,
-					"url":     moduleDescriptorURL,
-				}
-			}
-
-			privatePort, err := strconv.Atoi(constant.PrivateServerPort)
+			newEntry, discoveryModule, err := um.newModuleEntry(moduleName, newModuleVersion, shouldBuild)
 			if err != nil {
 				return nil, nil, "", err
 			}
-			sidecarURL := helpers.GetSidecarURL(moduleName, privatePort)
-
-			newDiscoveryModules = append(newDiscoveryModules, map[string]string{
-				"id":       moduleID,
-				"name":     moduleName,
-				"version":  newModuleVersion,
-				"location": sidecarURL,
-			})
+			entry = newEntry
+			newDiscoveryModules = append(newDiscoveryModules, discoveryModule)
 		} else {
 			entry = um.getDefaultModuleEntry(shouldBuild, entry)
 		}
@@ -71,6 +50,53 @@ func (um *UpgradeModuleSvc) UpdateBackendModules(moduleName, newModuleVersion st
 	return newBackendModules, newDiscoveryModules, oldModuleID, nil
 }
 
+func (um *UpgradeModuleSvc) AddBackendModule(moduleName, moduleVersion string, shouldBuild bool, modules []any) ([]map[string]any, []map[string]string, error) {
+	var newBackendModules []map[string]any
+	for _, value := range modules {
+		entry := value.(map[string]any)
+		if helpers.GetString(entry, "name") == moduleName {
+			return nil, nil, apperrors.ModuleAlreadyInApplication(helpers.GetString(entry, "id"))
+		}
+		newBackendModules = append(newBackendModules, um.getDefaultModuleEntry(shouldBuild, entry))
+	}
+
+	newEntry, discoveryModule, err := um.newModuleEntry(moduleName, moduleVersion, shouldBuild)
+	if err != nil {
+		return nil, nil, err
+	}
+	if slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		fmt.Println("added backend module entry =", newEntry)
+	}
+	newBackendModules = append(newBackendModules, newEntry)
+
+	return newBackendModules, []map[string]string{discoveryModule}, nil
+}
+
+func (um *UpgradeModuleSvc) newModuleEntry(moduleName, moduleVersion string, shouldBuild bool) (map[string]any, map[string]string, error) {
+	moduleID := fmt.Sprintf("%s-%s", moduleName, moduleVersion)
+	entry := map[string]any{
+		"id":      moduleID,
+		"name":    moduleName,
+		"version": moduleVersion,
+	}
+	if !shouldBuild {
+		entry["url"] = um.Action.GetModuleURL(moduleID)
+	}
+
+	privatePort, err := strconv.Atoi(constant.PrivateServerPort)
+	if err != nil {
+		return nil, nil, err
+	}
+	sidecarURL := helpers.GetSidecarURL(moduleName, privatePort)
+
+	return entry, map[string]string{
+		"id":       moduleID,
+		"name":     moduleName,
+		"version":  moduleVersion,
+		"location": sidecarURL,
+	}, nil
+}
+
 func (um *UpgradeModuleSvc) UpdateFrontendModules(shouldBuild bool, modules []any) (newFrontendModules []map[string]any) {
 	if slog.Default().Enabled(context.Background(), slog.LevelDebug) {
 		fmt.Printf("\nDUMPING frontend module entries\n")
@@ -4,9 +4,7 @@ import (
 	"fmt"
 
 	"github.com/folio-org/eureka-setup/eureka-cli/action"
-	"github.com/folio-org/eureka-setup/eureka-cli/constant"
 	"github.com/folio-org/eureka-setup/eureka-cli/errors"
-	"github.com/folio-org/eureka-setup/eureka-cli/helpers"
 	"github.com/folio-org/eureka-setup/eureka-cli/httpclient"
 	"github.com/folio-org/eureka-setup/eureka-cli/models"
 )
@@ -28,8 +26,8 @@ func New(action *action.Action, httpClient httpclient.HTTPClientRunner) *UserSvc
 }
 
 func (us *UserSvc) Get(tenantName string, username string) (*models.User, error) {
-	requestURL := us.Action.GetRequestURL(constant.KongPort, fmt.Sprintf("/users?query=username==%s&limit=1", username))
-	headers, err := helpers.SecureOkapiTenantApplicationJSONHeaders(tenantName, us.Action.KeycloakAccessToken)
+	requestURL := us.Action.GetRequestURL(us.Action.GetGatewayPort(), fmt.Sprintf("/users?query=username==%s&limit=1", username))
+	headers, err := us.Action.BuildTenantHeaders(tenantName, us.Action.KeycloakAccessToken)
 	if err != nil {
 		return nil, err
 	}